@@ -4,7 +4,25 @@ CREATE DATABASE bike_rent_express;
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
 CREATE TYPE user_role AS ENUM ('ADMIN', 'USER');
-CREATE TYPE vehicle_status AS ENUM ('AVAILABLE', 'NOT_AVAILABLE');
+CREATE TYPE vehicle_status AS ENUM ('AVAILABLE', 'NOT_AVAILABLE', 'IN_MAINTENANCE');
+CREATE TYPE transaction_status AS ENUM ('ACTIVE', 'OVERDUE', 'RETURNED', 'PICKED_UP');
+CREATE TYPE damage_severity AS ENUM ('MINOR', 'MODERATE', 'SEVERE');
+CREATE TYPE dispute_status AS ENUM ('OPEN', 'RESOLVED');
+CREATE TYPE dispute_resolution AS ENUM ('UPHOLD', 'PARTIAL_REFUND', 'FULL_REFUND');
+CREATE TYPE dispatch_task_type AS ENUM ('DELIVERY', 'PICKUP');
+CREATE TYPE dispatch_task_status AS ENUM ('PENDING', 'ASSIGNED', 'ACCEPTED', 'IN_PROGRESS', 'COMPLETED');
+CREATE TYPE shift_exception_type AS ENUM ('SHIFT', 'LEAVE');
+CREATE TYPE maintenance_status AS ENUM ('SCHEDULED', 'IN_PROGRESS', 'COMPLETED', 'CANCELLED');
+CREATE TYPE vehicle_document_type AS ENUM ('REGISTRATION', 'TAX', 'INSURANCE');
+
+-- tabel rental_tier
+CREATE TABLE rental_tier(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	name VARCHAR(255) NOT NULL UNIQUE,
+	max_active_rentals INTEGER NOT NULL CHECK (max_active_rentals > 0),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
 
 -- tabel user
 CREATE TABLE users(
@@ -14,11 +32,13 @@ CREATE TABLE users(
 	password VARCHAR(255) NOT NULL,
 	address VARCHAR(255) NULL,
 	role user_role NOT NULL,
-	can_rent BOOLEAN NULL,
+	tier_id uuid NULL REFERENCES rental_tier(id) ON DELETE SET NULL,
+	max_active_rentals INTEGER NULL CHECK (max_active_rentals > 0),
 	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	telp VARCHAR(255) NOT NULL,
-	deleted_at DATE NULL
+	deleted_at DATE NULL,
+	purged_at TIMESTAMP NULL
 );
 
 -- tabel balance
@@ -30,6 +50,18 @@ CREATE TABLE balance(
 	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- tabel branch
+CREATE TABLE branch(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	address VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX branch_name_idx ON branch(name) WHERE deleted_at IS NULL;
+
 -- tabel employee
 CREATE TABLE employee(
 	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
@@ -37,23 +69,113 @@ CREATE TABLE employee(
 	telp VARCHAR(255) NOT NULL,
 	username VARCHAR(255) NOT NULL,
 	password VARCHAR(255) NOT NULL,
+	role VARCHAR(50) NOT NULL DEFAULT 'EMPLOYEE' CHECK (role IN ('EMPLOYEE', 'BRANCH_MANAGER')),
+	branch_id uuid NULL REFERENCES branch(id),
 	deleted_at DATE NULL,
+	purged_at TIMESTAMP NULL,
 	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE INDEX employee_branch_id_idx ON employee(branch_id);
+
+-- tabel shift_template
+CREATE TABLE shift_template(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	start_time TIME NOT NULL,
+	end_time TIME NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (end_time > start_time)
+);
+
+CREATE INDEX shift_template_employee_id_idx ON shift_template(employee_id);
+
+-- tabel shift_exception
+CREATE TABLE shift_exception(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	date DATE NOT NULL,
+	type shift_exception_type NOT NULL,
+	start_time TIME NULL,
+	end_time TIME NULL,
+	note TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (end_time > start_time)
+);
+
+CREATE INDEX shift_exception_employee_id_date_idx ON shift_exception(employee_id, date);
+
+-- tabel one_way_fee
+CREATE TABLE one_way_fee(
+	from_branch_id uuid NOT NULL REFERENCES branch(id),
+	to_branch_id uuid NOT NULL REFERENCES branch(id),
+	fee INTEGER NOT NULL CHECK (fee >= 0),
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (from_branch_id, to_branch_id),
+	CHECK (from_branch_id <> to_branch_id)
+);
+
+-- tabel vehicle_model
+CREATE TABLE vehicle_model(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	brand VARCHAR(255) NOT NULL,
+	model VARCHAR(255) NOT NULL,
+	transmission VARCHAR(255) NOT NULL,
+	engine_cc INTEGER NOT NULL CHECK (engine_cc > 0),
+	default_price INTEGER NOT NULL CHECK (default_price > 0),
+	default_hourly_price INTEGER NOT NULL DEFAULT 0 CHECK (default_hourly_price >= 0),
+	specs JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX vehicle_model_brand_model_idx ON vehicle_model(LOWER(brand), LOWER(model)) WHERE deleted_at IS NULL;
+
 -- tabel motor_vehicle
+-- a unit of a vehicle model takes its name, type and prices from the model, the price
+-- and hourly_price of the unit are only set when they override the model's
 CREATE TABLE motor_vehicle(
 	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
-	name VARCHAR(255) NOT NULL,
-	type VARCHAR(255) NOT NULL,
-	price INTEGER NOT NULL,
+	name VARCHAR(255) NULL,
+	type VARCHAR(255) NULL,
+	price INTEGER NULL,
+	hourly_price INTEGER NULL,
 	plat VARCHAR(255) NOT NULL,
 	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	production_year VARCHAR(255) NOT NULL,
 	status vehicle_status NOT NULL,
-	deleted_at DATE NULL
+	deleted_at DATE NULL,
+	purged_at TIMESTAMP NULL,
+	home_branch_id uuid NULL REFERENCES branch(id),
+	current_branch_id uuid NULL REFERENCES branch(id),
+	vehicle_model_id uuid NULL REFERENCES vehicle_model(id),
+	odometer INTEGER NOT NULL DEFAULT 0 CHECK (odometer >= 0),
+	fuel_level INTEGER NULL CHECK (fuel_level BETWEEN 0 AND 100),
+	daily_km_limit INTEGER NULL CHECK (daily_km_limit > 0),
+	overage_per_km INTEGER NOT NULL DEFAULT 0 CHECK (overage_per_km >= 0),
+	CHECK (vehicle_model_id IS NOT NULL OR (name IS NOT NULL AND type IS NOT NULL AND price IS NOT NULL))
+);
+
+CREATE INDEX motor_vehicle_current_branch_id_idx ON motor_vehicle(current_branch_id);
+CREATE INDEX motor_vehicle_vehicle_model_id_status_idx ON motor_vehicle(vehicle_model_id, status);
+-- plates are stored as BB 2423 KG, two vehicles that are not deleted can not share one whatever its case or spacing
+CREATE UNIQUE INDEX motor_vehicle_plat_key_idx ON motor_vehicle((UPPER(REGEXP_REPLACE(plat, '\s', '', 'g')))) WHERE deleted_at IS NULL;
+
+-- tabel booking
+CREATE TABLE booking(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	user_id uuid NOT NULL REFERENCES users(id),
+	employee_id uuid NULL REFERENCES employee(id),
+	start_date TIMESTAMPTZ NOT NULL,
+	end_date TIMESTAMPTZ NOT NULL,
+	total_price INTEGER NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 -- tabel transaction
@@ -61,14 +183,80 @@ CREATE TABLE transaction(
 	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
 	user_id uuid NOT NULL REFERENCES users(id),
 	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
-	start_date DATE NOT NULL,
-	end_date DATE NOT NULL,
+	start_date TIMESTAMPTZ NOT NULL,
+	end_date TIMESTAMPTZ NOT NULL,
 	price INTEGER NOT NULL,
 	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-	employee_id uuid NOT NULL REFERENCES employee(id)
+	employee_id uuid NULL REFERENCES employee(id),
+	status transaction_status NOT NULL DEFAULT 'ACTIVE',
+	late_fee INTEGER NOT NULL DEFAULT 0,
+	booking_id uuid NULL REFERENCES booking(id),
+	pickup_branch_id uuid NULL REFERENCES branch(id),
+	return_branch_id uuid NULL REFERENCES branch(id),
+	one_way_fee INTEGER NOT NULL DEFAULT 0,
+	km_limit INTEGER NULL,
+	overage_per_km INTEGER NOT NULL DEFAULT 0
 );
 
+CREATE INDEX transaction_status_end_date_idx ON transaction(status, end_date);
+CREATE INDEX transaction_user_id_status_idx ON transaction(user_id, status);
+CREATE INDEX transaction_booking_id_idx ON transaction(booking_id);
+
+-- tabel dispatch_task
+CREATE TABLE dispatch_task(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	transaction_id uuid NOT NULL REFERENCES transaction(id),
+	type dispatch_task_type NOT NULL,
+	branch_id uuid NULL REFERENCES branch(id),
+	employee_id uuid NULL REFERENCES employee(id),
+	status dispatch_task_status NOT NULL DEFAULT 'PENDING',
+	scheduled_at TIMESTAMPTZ NOT NULL,
+	accepted_at TIMESTAMP NULL,
+	started_at TIMESTAMP NULL,
+	completed_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (transaction_id, type)
+);
+
+CREATE INDEX dispatch_task_employee_id_status_idx ON dispatch_task(employee_id, status);
+
+-- tabel motor_pickup
+CREATE TABLE motor_pickup(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	transaction_id uuid NOT NULL UNIQUE REFERENCES transaction(id),
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	pickup_date TIMESTAMPTZ NOT NULL,
+	odometer INTEGER NOT NULL CHECK (odometer >= 0),
+	fuel_level INTEGER NOT NULL CHECK (fuel_level BETWEEN 0 AND 100),
+	damage_notes TEXT NOT NULL DEFAULT '',
+	identity_verified BOOLEAN NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- tabel motor_pickup_photo
+CREATE TABLE motor_pickup_photo(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_pickup_id uuid NOT NULL REFERENCES motor_pickup(id),
+	url TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- tabel damage_catalog
+CREATE TABLE damage_catalog(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	part VARCHAR(255) NOT NULL,
+	severity damage_severity NOT NULL,
+	repair_cost INTEGER NOT NULL CHECK (repair_cost >= 0),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX damage_catalog_part_severity_idx ON damage_catalog(part, severity) WHERE deleted_at IS NULL;
+
 -- tabel motor_return
 CREATE TABLE motor_return(
 	id uuid DEFAULT uuid_generate_V4() PRIMARY KEY,
@@ -77,7 +265,204 @@ CREATE TABLE motor_return(
 	extra_charge INTEGER NOT NULL,
 	condition_motor VARCHAR(255) NOT NULL,
 	description VARCHAR(255) NOT NULL,
+	odometer INTEGER NOT NULL DEFAULT 0,
+	fuel_level INTEGER NOT NULL DEFAULT 0,
+	override_reason VARCHAR(255) NULL,
+	voided_at TIMESTAMP NULL,
+	void_reason VARCHAR(255) NULL,
+	branch_id uuid NULL REFERENCES branch(id),
+	employee_id uuid NULL REFERENCES employee(id),
+	overage_km INTEGER NOT NULL DEFAULT 0,
+	overage_charge INTEGER NOT NULL DEFAULT 0,
 	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE INDEX motor_return_employee_id_idx ON motor_return(employee_id);
+
+-- tabel motor_return_damage
+CREATE TABLE motor_return_damage(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NOT NULL REFERENCES motor_return(id),
+	damage_catalog_id uuid NOT NULL REFERENCES damage_catalog(id),
+	part VARCHAR(255) NOT NULL,
+	severity damage_severity NOT NULL,
+	quantity INTEGER NOT NULL CHECK (quantity > 0),
+	repair_cost INTEGER NOT NULL
+);
+
+CREATE INDEX motor_return_damage_motor_return_id_idx ON motor_return_damage(motor_return_id);
+
+-- tabel motor_return_revision
+CREATE TABLE motor_return_revision(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NOT NULL REFERENCES motor_return(id),
+	action VARCHAR(10) NOT NULL CHECK (action IN ('AMEND', 'VOID')),
+	extra_charge INTEGER NOT NULL,
+	condition_motor VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	adjustment INTEGER NOT NULL,
+	reason VARCHAR(255) NOT NULL,
+	actor VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX motor_return_revision_motor_return_id_idx ON motor_return_revision(motor_return_id);
+
+
+-- tabel dispute
+CREATE TABLE dispute(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NOT NULL UNIQUE REFERENCES motor_return(id),
+	user_id uuid NOT NULL REFERENCES users(id),
+	status dispute_status NOT NULL DEFAULT 'OPEN',
+	reason TEXT NOT NULL,
+	resolution dispute_resolution NULL,
+	refund_amount INTEGER NOT NULL DEFAULT 0,
+	resolution_note TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX dispute_user_id_idx ON dispute(user_id);
+
+-- tabel dispute_message
+CREATE TABLE dispute_message(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	dispute_id uuid NOT NULL REFERENCES dispute(id),
+	author_role VARCHAR(50) NOT NULL,
+	author VARCHAR(255) NOT NULL,
+	message TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX dispute_message_dispute_id_idx ON dispute_message(dispute_id);
+
+-- tabel dispute_event
+CREATE TABLE dispute_event(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	dispute_id uuid NOT NULL REFERENCES dispute(id),
+	from_status dispute_status NULL,
+	to_status dispute_status NOT NULL,
+	actor_role VARCHAR(50) NOT NULL,
+	actor VARCHAR(255) NOT NULL,
+	note TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX dispute_event_dispute_id_idx ON dispute_event(dispute_id);
+
+-- tabel balance_ledger
+CREATE TABLE balance_ledger(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	user_id uuid NOT NULL REFERENCES users(id),
+	amount INTEGER NOT NULL,
+	kind VARCHAR(50) NOT NULL,
+	reference_id uuid NULL,
+	description VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX balance_ledger_user_id_idx ON balance_ledger(user_id);
+
+-- tabel vehicle_document
+-- a renewed document is added as a new row, the one expiring last of each type is in force
+CREATE TABLE vehicle_document(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	type vehicle_document_type NOT NULL,
+	number VARCHAR(255) NOT NULL,
+	issued_at DATE NULL,
+	expires_at DATE NOT NULL,
+	notes TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	CHECK (issued_at IS NULL OR expires_at > issued_at)
+);
+
+CREATE INDEX vehicle_document_motor_vehicle_id_type_idx ON vehicle_document(motor_vehicle_id, type);
+CREATE INDEX vehicle_document_expires_at_idx ON vehicle_document(expires_at);
+
+-- tabel attachment
+CREATE TABLE attachment(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NULL REFERENCES motor_return(id),
+	motor_pickup_id uuid NULL REFERENCES motor_pickup(id),
+	motor_vehicle_id uuid NULL REFERENCES motor_vehicle(id),
+	dispute_id uuid NULL REFERENCES dispute(id),
+	vehicle_model_id uuid NULL REFERENCES vehicle_model(id),
+	vehicle_document_id uuid NULL REFERENCES vehicle_document(id),
+	file_name VARCHAR(255) NOT NULL,
+	content_type VARCHAR(255) NOT NULL,
+	size_bytes BIGINT NOT NULL,
+	storage_key VARCHAR(255) NOT NULL UNIQUE,
+	thumbnail_key VARCHAR(255) NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT attachment_owner_check CHECK (num_nonnulls(motor_return_id, motor_pickup_id, motor_vehicle_id, dispute_id, vehicle_model_id, vehicle_document_id) = 1)
+);
+
+CREATE INDEX attachment_motor_return_id_idx ON attachment(motor_return_id);
+CREATE INDEX attachment_motor_pickup_id_idx ON attachment(motor_pickup_id);
+CREATE INDEX attachment_motor_vehicle_id_idx ON attachment(motor_vehicle_id);
+CREATE INDEX attachment_dispute_id_idx ON attachment(dispute_id);
+CREATE INDEX attachment_vehicle_model_id_idx ON attachment(vehicle_model_id);
+CREATE INDEX attachment_vehicle_document_id_idx ON attachment(vehicle_document_id);
+
+-- tabel maintenance_schedule
+-- a service that is due every interval_km ridden or interval_days passed since the last one
+CREATE TABLE maintenance_schedule(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	description VARCHAR(255) NOT NULL,
+	interval_km INTEGER NULL CHECK (interval_km > 0),
+	interval_days INTEGER NULL CHECK (interval_days > 0),
+	last_service_km INTEGER NOT NULL DEFAULT 0 CHECK (last_service_km >= 0),
+	last_service_date DATE NOT NULL DEFAULT CURRENT_DATE,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	CHECK (interval_km IS NOT NULL OR interval_days IS NOT NULL)
+);
+
+CREATE INDEX maintenance_schedule_motor_vehicle_id_idx ON maintenance_schedule(motor_vehicle_id);
+
+-- tabel maintenance
+-- a maintenance ticket keeps the vehicle from being rented from start_date to end_date while
+-- it is SCHEDULED or IN_PROGRESS, an open ended one until it is completed or cancelled
+CREATE TABLE maintenance(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	maintenance_schedule_id uuid NULL REFERENCES maintenance_schedule(id),
+	motor_return_id uuid NULL REFERENCES motor_return(id),
+	status maintenance_status NOT NULL DEFAULT 'SCHEDULED',
+	description VARCHAR(255) NOT NULL,
+	start_date TIMESTAMPTZ NOT NULL,
+	end_date TIMESTAMPTZ NULL,
+	odometer INTEGER NULL CHECK (odometer >= 0),
+	cost INTEGER NOT NULL DEFAULT 0 CHECK (cost >= 0),
+	notes TEXT NOT NULL DEFAULT '',
+	completed_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (end_date IS NULL OR end_date > start_date)
+);
+
+CREATE INDEX maintenance_motor_vehicle_id_status_idx ON maintenance(motor_vehicle_id, status);
+
+-- tabel motor_vehicle_event
+-- the timeline of a vehicle, every change of its status, own prices or plate with who made it and why,
+-- the events of a purged vehicle are kept without the vehicle and its plates
+CREATE TABLE motor_vehicle_event(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NULL REFERENCES motor_vehicle(id),
+	field VARCHAR(20) NOT NULL CHECK (field IN ('STATUS', 'PRICE', 'HOURLY_PRICE', 'PLAT')),
+	old_value VARCHAR(255) NULL,
+	new_value VARCHAR(255) NULL,
+	actor VARCHAR(255) NOT NULL,
+	reason VARCHAR(255) NOT NULL,
+	transaction_id uuid NULL REFERENCES transaction(id),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX motor_vehicle_event_motor_vehicle_id_created_at_idx ON motor_vehicle_event(motor_vehicle_id, created_at);
@@ -8,7 +8,7 @@ INSERT INTO balance(amount, user_id) VALUES (0, 'c11ac713-19be-4d73-874e-e748ea6
 
 -- insert motor_vechile
 INSERT INTO motor_vehicle(name, type, price, plat, production_year, status)
-VALUES('Honda ct 125', 'KOPLING', 150000, 'BB2423KG', '2019', 'AVAILABLE');
+VALUES('Honda ct 125', 'KOPLING', 150000, 'BB 2423 KG', '2019', 'AVAILABLE');
 
 --insert employee
 INSERT INTO employee(name, telp, username, password) 
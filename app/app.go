@@ -3,7 +3,26 @@ package app
 import (
 	"bike-rent-express/config"
 	"bike-rent-express/model/dto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/pkg/event"
+	"bike-rent-express/pkg/scheduler"
+	"bike-rent-express/pkg/storage"
 	"bike-rent-express/router"
+	"bike-rent-express/src/dispatch"
+	"bike-rent-express/src/dispatch/dispatchRepository"
+	"bike-rent-express/src/dispatch/dispatchUsecase"
+	"bike-rent-express/src/overdue"
+	"bike-rent-express/src/overdue/overdueRepository"
+	"bike-rent-express/src/overdue/overdueUsecase"
+	"bike-rent-express/src/shift/shiftRepository"
+	"bike-rent-express/src/shift/shiftUsecase"
+	"bike-rent-express/src/trash"
+	"bike-rent-express/src/trash/trashRepository"
+	"bike-rent-express/src/trash/trashUsecase"
+	"bike-rent-express/src/vehicleDocument"
+	"bike-rent-express/src/vehicleDocument/vehicleDocumentRepository"
+	"bike-rent-express/src/vehicleDocument/vehicleDocumentUsecase"
+	"context"
 	"database/sql"
 	"errors"
 	"flag"
@@ -51,7 +70,7 @@ func initEnv() (dto.ConfigData, error) {
 		return dto.ConfigData{}, err
 	}
 
-	maxConn, err := strconv.Atoi(dbMaxIdle)
+	maxConn, err := strconv.Atoi(dbMaxConn)
 	if err != nil {
 		return dto.ConfigData{}, err
 	}
@@ -72,6 +91,137 @@ func initEnv() (dto.ConfigData, error) {
 	configData.DbConfig.MaxLifeTime = dbMaxLifeTime
 	configData.DbConfig.LogMode = logMode
 
+	overdueInterval := os.Getenv("OVERDUE_TICK_INTERVAL")
+	if overdueInterval == "" {
+		overdueInterval = "1h"
+	}
+
+	lateFeePercent := 100
+	if envLateFeePercent := os.Getenv("LATE_FEE_PERCENT"); envLateFeePercent != "" {
+		lateFeePercent, err = strconv.Atoi(envLateFeePercent)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	documentExpiryInterval := os.Getenv("DOCUMENT_EXPIRY_TICK_INTERVAL")
+	if documentExpiryInterval == "" {
+		documentExpiryInterval = "24h"
+	}
+
+	documentExpiryNoticeDays := 30
+	if envDocumentExpiryNoticeDays := os.Getenv("DOCUMENT_EXPIRY_NOTICE_DAYS"); envDocumentExpiryNoticeDays != "" {
+		documentExpiryNoticeDays, err = strconv.Atoi(envDocumentExpiryNoticeDays)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	configData.WorkerConfig.OverdueInterval = overdueInterval
+	configData.WorkerConfig.LateFeePercent = lateFeePercent
+	configData.WorkerConfig.DocumentExpiryInterval = documentExpiryInterval
+	configData.WorkerConfig.DocumentExpiryNoticeDays = documentExpiryNoticeDays
+
+	maxActiveRentals := 3
+	if envMaxActiveRentals := os.Getenv("MAX_ACTIVE_RENTALS"); envMaxActiveRentals != "" {
+		maxActiveRentals, err = strconv.Atoi(envMaxActiveRentals)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	configData.RentalConfig.MaxActiveRentals = maxActiveRentals
+
+	storageDriver := os.Getenv("STORAGE_DRIVER")
+	if storageDriver == "" {
+		storageDriver = "local"
+	}
+
+	storageLocalDir := os.Getenv("STORAGE_LOCAL_DIR")
+	if storageLocalDir == "" {
+		storageLocalDir = "uploads"
+	}
+
+	storageSigningKey := os.Getenv("STORAGE_SIGNING_KEY")
+	if storageSigningKey == "" {
+		storageSigningKey = os.Getenv("JWT_SECRET_KEY")
+	}
+
+	var maxUploadSize int64 = 10 << 20
+	if envMaxUploadSize := os.Getenv("MAX_UPLOAD_SIZE"); envMaxUploadSize != "" {
+		maxUploadSize, err = strconv.ParseInt(envMaxUploadSize, 10, 64)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	signedURLExpiry := 15 * time.Minute
+	if envSignedURLExpiry := os.Getenv("SIGNED_URL_EXPIRY"); envSignedURLExpiry != "" {
+		signedURLExpiry, err = time.ParseDuration(envSignedURLExpiry)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	configData.StorageConfig.Driver = storageDriver
+	configData.StorageConfig.LocalDir = storageLocalDir
+	configData.StorageConfig.SigningKey = storageSigningKey
+	configData.StorageConfig.Endpoint = os.Getenv("S3_ENDPOINT")
+	configData.StorageConfig.Region = os.Getenv("S3_REGION")
+	configData.StorageConfig.Bucket = os.Getenv("S3_BUCKET")
+	configData.StorageConfig.AccessKey = os.Getenv("S3_ACCESS_KEY")
+	configData.StorageConfig.SecretKey = os.Getenv("S3_SECRET_KEY")
+	configData.StorageConfig.MaxUploadSize = maxUploadSize
+	configData.StorageConfig.SignedURLExpiry = signedURLExpiry
+
+	disputeWindowDays := 7
+	if envDisputeWindowDays := os.Getenv("DISPUTE_WINDOW_DAYS"); envDisputeWindowDays != "" {
+		disputeWindowDays, err = strconv.Atoi(envDisputeWindowDays)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	configData.DisputeConfig.WindowDays = disputeWindowDays
+
+	dispatchStrategy := os.Getenv("DISPATCH_STRATEGY")
+	if dispatchStrategy == "" {
+		dispatchStrategy = "least-loaded"
+	}
+
+	dispatchInterval := os.Getenv("DISPATCH_TICK_INTERVAL")
+	if dispatchInterval == "" {
+		dispatchInterval = "15m"
+	}
+
+	configData.DispatchConfig.Strategy = dispatchStrategy
+	configData.DispatchConfig.TickInterval = dispatchInterval
+
+	// a return recording damage of this severity or worse opens a maintenance ticket, empty turns it off
+	autoTicketSeverity := os.Getenv("MAINTENANCE_AUTO_SEVERITY")
+	if autoTicketSeverity != "" && autoTicketSeverity != "MINOR" && autoTicketSeverity != "MODERATE" && autoTicketSeverity != "SEVERE" {
+		return dto.ConfigData{}, fmt.Errorf("MAINTENANCE_AUTO_SEVERITY must be MINOR, MODERATE or SEVERE, got %q", autoTicketSeverity)
+	}
+
+	configData.MaintenanceConfig.AutoTicketSeverity = autoTicketSeverity
+
+	// deleted vehicles, users and employees can be restored for this many days before they are purged
+	purgeRetentionDays := 90
+	if envPurgeRetentionDays := os.Getenv("PURGE_RETENTION_DAYS"); envPurgeRetentionDays != "" {
+		purgeRetentionDays, err = strconv.Atoi(envPurgeRetentionDays)
+		if err != nil {
+			return dto.ConfigData{}, err
+		}
+	}
+
+	purgeInterval := os.Getenv("PURGE_TICK_INTERVAL")
+	if purgeInterval == "" {
+		purgeInterval = "24h"
+	}
+
+	configData.TrashConfig.RetentionDays = purgeRetentionDays
+	configData.TrashConfig.PurgeInterval = purgeInterval
+
 	return configData, nil
 }
 
@@ -87,7 +237,6 @@ func RunService() {
 		log.Error().Msg(err.Error())
 		return
 	}
-	log.Info().Msg(fmt.Sprintf("config data %v", configData))
 
 	conn, err := config.ConnectDB(configData, log.Logger)
 	if err != nil {
@@ -135,7 +284,18 @@ func RunService() {
 	// gin recovery for handle panic
 	r.Use(gin.Recovery())
 
-	initializeDomainModule(r, conn)
+	if err := initializeDomainModule(r, conn, configData); err != nil {
+		log.Error().Msg("RunService.initializeDomainModule.err : " + err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := initializeWorker(ctx, conn, configData); err != nil {
+		log.Error().Msg("RunService.initializeWorker.err : " + err.Error())
+		return
+	}
 
 	version := "0.0.1"
 	log.Info().Msg(fmt.Sprintf("Service Running version %s", version))
@@ -146,9 +306,101 @@ func RunService() {
 	}
 }
 
-func initializeDomainModule(r *gin.Engine, db *sql.DB) {
+func initializeDomainModule(r *gin.Engine, db *sql.DB, configData dto.ConfigData) error {
 	apiGroup := r.Group("/api")
 	v1Group := apiGroup.Group("/v1")
 
-	router.InitRoute(v1Group, db)
+	blobStore, err := storage.New(storage.Config{
+		Driver:     configData.StorageConfig.Driver,
+		LocalDir:   configData.StorageConfig.LocalDir,
+		SigningKey: configData.StorageConfig.SigningKey,
+		FileURL:    v1Group.BasePath() + "/attachments/file",
+		Endpoint:   configData.StorageConfig.Endpoint,
+		Region:     configData.StorageConfig.Region,
+		Bucket:     configData.StorageConfig.Bucket,
+		AccessKey:  configData.StorageConfig.AccessKey,
+		SecretKey:  configData.StorageConfig.SecretKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	dispatchStrategy, err := dispatchUsecase.NewStrategy(configData.DispatchConfig.Strategy)
+	if err != nil {
+		return err
+	}
+
+	router.InitRoute(v1Group, db, configData, blobStore, dispatchStrategy)
+	return nil
+}
+
+func initializeWorker(ctx context.Context, db *sql.DB, configData dto.ConfigData) error {
+	overdueInterval, err := time.ParseDuration(configData.WorkerConfig.OverdueInterval)
+	if err != nil {
+		return err
+	}
+
+	documentExpiryInterval, err := time.ParseDuration(configData.WorkerConfig.DocumentExpiryInterval)
+	if err != nil {
+		return err
+	}
+
+	purgeInterval, err := time.ParseDuration(configData.TrashConfig.PurgeInterval)
+	if err != nil {
+		return err
+	}
+
+	dispatchInterval, err := time.ParseDuration(configData.DispatchConfig.TickInterval)
+	if err != nil {
+		return err
+	}
+
+	dispatchStrategy, err := dispatchUsecase.NewStrategy(configData.DispatchConfig.Strategy)
+	if err != nil {
+		return err
+	}
+
+	notify := func(e event.Event) error {
+		log.Info().Interface("payload", e.Payload).Msg("notification " + e.Name)
+		return nil
+	}
+
+	bus := event.NewBus()
+	bus.Subscribe(event.TransactionOverdue, notify)
+	bus.Subscribe(event.VehicleDocumentsExpiring, notify)
+
+	overdueRepo := overdueRepository.NewOverdueRepository(db)
+	overdueUC := overdueUsecase.NewOverdueUsecase(overdueRepo, bus, clock.New(), configData.WorkerConfig.LateFeePercent)
+
+	scheduler.New(overdue.JobName, overdueInterval, func() error {
+		_, err := overdueUC.ProcessOverdue()
+		return err
+	}).Start(ctx)
+
+	vehicleDocumentRepo := vehicleDocumentRepository.NewVehicleDocumentRepository(db)
+	expiryUC := vehicleDocumentUsecase.NewExpiryUsecase(vehicleDocumentRepo, bus, clock.New(), configData.WorkerConfig.DocumentExpiryNoticeDays)
+
+	scheduler.New(vehicleDocument.ExpiryJobName, documentExpiryInterval, func() error {
+		_, err := expiryUC.ProcessExpiring()
+		return err
+	}).Start(ctx)
+
+	trashRepo := trashRepository.NewTrashRepository(db)
+	purgeUC := trashUsecase.NewPurgeUsecase(trashRepo, clock.New(), configData.TrashConfig.RetentionDays)
+
+	scheduler.New(trash.PurgeJobName, purgeInterval, func() error {
+		_, err := purgeUC.ProcessPurge()
+		return err
+	}).Start(ctx)
+
+	shiftRepo := shiftRepository.NewShiftRepository(db)
+	dispatchRepo := dispatchRepository.NewDispatchRepository(db)
+	dispatchUC := dispatchUsecase.NewDispatchUsecase(dispatchRepo, shiftUsecase.NewShiftUsecase(shiftRepo), dispatchStrategy)
+
+	scheduler.New(dispatch.AssignJobName, dispatchInterval, func() error {
+		_, err := dispatchUC.AssignPending("")
+		return err
+	}).Start(ctx)
+
+	return nil
 }
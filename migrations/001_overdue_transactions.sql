@@ -0,0 +1,10 @@
+-- transaction status and late fee for the overdue rental worker
+CREATE TYPE transaction_status AS ENUM ('ACTIVE', 'OVERDUE', 'RETURNED');
+
+ALTER TABLE transaction
+	ADD COLUMN status transaction_status NOT NULL DEFAULT 'ACTIVE',
+	ADD COLUMN late_fee INTEGER NOT NULL DEFAULT 0;
+
+UPDATE transaction SET status = 'RETURNED' WHERE id IN (SELECT transaction_id FROM motor_return);
+
+CREATE INDEX transaction_status_end_date_idx ON transaction(status, end_date);
@@ -0,0 +1,15 @@
+-- active rental limit per customer, can_rent is now derived from the rental eligibility check
+CREATE TABLE rental_tier(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	name VARCHAR(255) NOT NULL UNIQUE,
+	max_active_rentals INTEGER NOT NULL CHECK (max_active_rentals > 0),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE users
+	ADD COLUMN tier_id uuid NULL REFERENCES rental_tier(id) ON DELETE SET NULL,
+	ADD COLUMN max_active_rentals INTEGER NULL CHECK (max_active_rentals > 0),
+	DROP COLUMN can_rent;
+
+CREATE INDEX transaction_user_id_status_idx ON transaction(user_id, status);
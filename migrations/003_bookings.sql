@@ -0,0 +1,15 @@
+-- booking groups several transactions rented under one checkout
+CREATE TABLE booking(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	user_id uuid NOT NULL REFERENCES users(id),
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	start_date DATE NOT NULL,
+	end_date DATE NOT NULL,
+	total_price INTEGER NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE transaction ADD COLUMN booking_id uuid NULL REFERENCES booking(id);
+
+CREATE INDEX transaction_booking_id_idx ON transaction(booking_id);
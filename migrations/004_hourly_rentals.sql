@@ -0,0 +1,11 @@
+-- vehicles may be rented per hour, rentals keep the exact pickup and return time
+ALTER TABLE motor_vehicle ADD COLUMN hourly_price INTEGER NOT NULL DEFAULT 0;
+
+-- existing whole-day rentals start and end at midnight local time
+ALTER TABLE transaction
+	ALTER COLUMN start_date TYPE TIMESTAMPTZ USING start_date::timestamp AT TIME ZONE 'Asia/Jakarta',
+	ALTER COLUMN end_date TYPE TIMESTAMPTZ USING end_date::timestamp AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE booking
+	ALTER COLUMN start_date TYPE TIMESTAMPTZ USING start_date::timestamp AT TIME ZONE 'Asia/Jakarta',
+	ALTER COLUMN end_date TYPE TIMESTAMPTZ USING end_date::timestamp AT TIME ZONE 'Asia/Jakarta';
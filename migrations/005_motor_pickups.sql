@@ -0,0 +1,27 @@
+-- employees record the handover of a rented vehicle before the customer rides off
+ALTER TYPE transaction_status ADD VALUE 'PICKED_UP';
+
+CREATE TABLE motor_pickup(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	transaction_id uuid NOT NULL UNIQUE REFERENCES transaction(id),
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	pickup_date TIMESTAMPTZ NOT NULL,
+	odometer INTEGER NOT NULL CHECK (odometer >= 0),
+	fuel_level INTEGER NOT NULL CHECK (fuel_level BETWEEN 0 AND 100),
+	damage_notes TEXT NOT NULL DEFAULT '',
+	identity_verified BOOLEAN NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE motor_pickup_photo(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_pickup_id uuid NOT NULL REFERENCES motor_pickup(id),
+	url TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- readings taken at return are compared with the ones taken at pickup
+ALTER TABLE motor_return
+	ADD COLUMN odometer INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN fuel_level INTEGER NOT NULL DEFAULT 0;
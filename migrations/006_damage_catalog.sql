@@ -0,0 +1,29 @@
+-- damage catalogue managed by admins, returns charge the selected entries
+CREATE TYPE damage_severity AS ENUM ('MINOR', 'MODERATE', 'SEVERE');
+
+CREATE TABLE damage_catalog(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	part VARCHAR(255) NOT NULL,
+	severity damage_severity NOT NULL,
+	repair_cost INTEGER NOT NULL CHECK (repair_cost >= 0),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX damage_catalog_part_severity_idx ON damage_catalog(part, severity) WHERE deleted_at IS NULL;
+
+-- the charged extra charge may be overridden by the employee, the reason is kept
+ALTER TABLE motor_return ADD COLUMN override_reason VARCHAR(255) NULL;
+
+CREATE TABLE motor_return_damage(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NOT NULL REFERENCES motor_return(id),
+	damage_catalog_id uuid NOT NULL REFERENCES damage_catalog(id),
+	part VARCHAR(255) NOT NULL,
+	severity damage_severity NOT NULL,
+	quantity INTEGER NOT NULL CHECK (quantity > 0),
+	repair_cost INTEGER NOT NULL
+);
+
+CREATE INDEX motor_return_damage_motor_return_id_idx ON motor_return_damage(motor_return_id);
@@ -0,0 +1,18 @@
+-- evidence files for returns, pickups and vehicles, the files themselves live in the blob store
+CREATE TABLE attachment(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NULL REFERENCES motor_return(id),
+	motor_pickup_id uuid NULL REFERENCES motor_pickup(id),
+	motor_vehicle_id uuid NULL REFERENCES motor_vehicle(id),
+	file_name VARCHAR(255) NOT NULL,
+	content_type VARCHAR(255) NOT NULL,
+	size_bytes BIGINT NOT NULL,
+	storage_key VARCHAR(255) NOT NULL UNIQUE,
+	thumbnail_key VARCHAR(255) NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (num_nonnulls(motor_return_id, motor_pickup_id, motor_vehicle_id) = 1)
+);
+
+CREATE INDEX attachment_motor_return_id_idx ON attachment(motor_return_id);
+CREATE INDEX attachment_motor_pickup_id_idx ON attachment(motor_pickup_id);
+CREATE INDEX attachment_motor_vehicle_id_idx ON attachment(motor_vehicle_id);
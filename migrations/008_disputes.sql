@@ -0,0 +1,62 @@
+-- customers contest the extra charge of a motor return, admins resolve with an optional refund
+CREATE TYPE dispute_status AS ENUM ('OPEN', 'RESOLVED');
+CREATE TYPE dispute_resolution AS ENUM ('UPHOLD', 'PARTIAL_REFUND', 'FULL_REFUND');
+
+CREATE TABLE dispute(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NOT NULL UNIQUE REFERENCES motor_return(id),
+	user_id uuid NOT NULL REFERENCES users(id),
+	status dispute_status NOT NULL DEFAULT 'OPEN',
+	reason TEXT NOT NULL,
+	resolution dispute_resolution NULL,
+	refund_amount INTEGER NOT NULL DEFAULT 0,
+	resolution_note TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX dispute_user_id_idx ON dispute(user_id);
+
+CREATE TABLE dispute_message(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	dispute_id uuid NOT NULL REFERENCES dispute(id),
+	author_role VARCHAR(50) NOT NULL,
+	author VARCHAR(255) NOT NULL,
+	message TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX dispute_message_dispute_id_idx ON dispute_message(dispute_id);
+
+-- every status change of a dispute
+CREATE TABLE dispute_event(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	dispute_id uuid NOT NULL REFERENCES dispute(id),
+	from_status dispute_status NULL,
+	to_status dispute_status NOT NULL,
+	actor_role VARCHAR(50) NOT NULL,
+	actor VARCHAR(255) NOT NULL,
+	note TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX dispute_event_dispute_id_idx ON dispute_event(dispute_id);
+
+-- balance movements that are not a plain top up or charge, e.g. dispute refunds
+CREATE TABLE balance_ledger(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	user_id uuid NOT NULL REFERENCES users(id),
+	amount INTEGER NOT NULL,
+	kind VARCHAR(50) NOT NULL,
+	reference_id uuid NULL,
+	description VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX balance_ledger_user_id_idx ON balance_ledger(user_id);
+
+-- disputes collect evidence as attachments too
+ALTER TABLE attachment ADD COLUMN dispute_id uuid NULL REFERENCES dispute(id);
+ALTER TABLE attachment DROP CONSTRAINT attachment_check;
+ALTER TABLE attachment ADD CONSTRAINT attachment_owner_check CHECK (num_nonnulls(motor_return_id, motor_pickup_id, motor_vehicle_id, dispute_id) = 1);
+CREATE INDEX attachment_dispute_id_idx ON attachment(dispute_id);
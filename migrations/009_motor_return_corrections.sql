@@ -0,0 +1,18 @@
+-- admins amend or void a settled motor return, the replaced values are kept as revisions
+ALTER TABLE motor_return ADD COLUMN voided_at TIMESTAMP NULL;
+ALTER TABLE motor_return ADD COLUMN void_reason VARCHAR(255) NULL;
+
+CREATE TABLE motor_return_revision(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_return_id uuid NOT NULL REFERENCES motor_return(id),
+	action VARCHAR(10) NOT NULL CHECK (action IN ('AMEND', 'VOID')),
+	extra_charge INTEGER NOT NULL,
+	condition_motor VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	adjustment INTEGER NOT NULL,
+	reason VARCHAR(255) NOT NULL,
+	actor VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX motor_return_revision_motor_return_id_idx ON motor_return_revision(motor_return_id);
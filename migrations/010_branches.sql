@@ -0,0 +1,31 @@
+-- vehicles belong to a home branch and stand at the branch they were last returned to,
+-- returning at another branch than the pickup one costs the one-way fee of the route
+CREATE TABLE branch(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	address VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX branch_name_idx ON branch(name) WHERE deleted_at IS NULL;
+
+CREATE TABLE one_way_fee(
+	from_branch_id uuid NOT NULL REFERENCES branch(id),
+	to_branch_id uuid NOT NULL REFERENCES branch(id),
+	fee INTEGER NOT NULL CHECK (fee >= 0),
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (from_branch_id, to_branch_id),
+	CHECK (from_branch_id <> to_branch_id)
+);
+
+ALTER TABLE motor_vehicle ADD COLUMN home_branch_id uuid NULL REFERENCES branch(id);
+ALTER TABLE motor_vehicle ADD COLUMN current_branch_id uuid NULL REFERENCES branch(id);
+CREATE INDEX motor_vehicle_current_branch_id_idx ON motor_vehicle(current_branch_id);
+
+ALTER TABLE transaction ADD COLUMN pickup_branch_id uuid NULL REFERENCES branch(id);
+ALTER TABLE transaction ADD COLUMN return_branch_id uuid NULL REFERENCES branch(id);
+ALTER TABLE transaction ADD COLUMN one_way_fee INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE motor_return ADD COLUMN branch_id uuid NULL REFERENCES branch(id);
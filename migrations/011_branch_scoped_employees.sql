@@ -0,0 +1,4 @@
+-- employees work at one branch, a branch manager only sees the data of that branch
+ALTER TABLE employee ADD COLUMN role VARCHAR(50) NOT NULL DEFAULT 'EMPLOYEE' CHECK (role IN ('EMPLOYEE', 'BRANCH_MANAGER'));
+ALTER TABLE employee ADD COLUMN branch_id uuid NULL REFERENCES branch(id);
+CREATE INDEX employee_branch_id_idx ON employee(branch_id);
@@ -0,0 +1,24 @@
+-- every rental gets a delivery and a pickup task dispatched to an employee of the branch,
+-- the customer no longer picks the employee of the transaction
+CREATE TYPE dispatch_task_type AS ENUM ('DELIVERY', 'PICKUP');
+CREATE TYPE dispatch_task_status AS ENUM ('PENDING', 'ASSIGNED', 'ACCEPTED', 'IN_PROGRESS', 'COMPLETED');
+
+CREATE TABLE dispatch_task(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	transaction_id uuid NOT NULL REFERENCES transaction(id),
+	type dispatch_task_type NOT NULL,
+	branch_id uuid NULL REFERENCES branch(id),
+	employee_id uuid NULL REFERENCES employee(id),
+	status dispatch_task_status NOT NULL DEFAULT 'PENDING',
+	scheduled_at TIMESTAMPTZ NOT NULL,
+	accepted_at TIMESTAMP NULL,
+	started_at TIMESTAMP NULL,
+	completed_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (transaction_id, type)
+);
+
+CREATE INDEX dispatch_task_employee_id_status_idx ON dispatch_task(employee_id, status);
+
+ALTER TABLE transaction ALTER COLUMN employee_id DROP NOT NULL;
@@ -0,0 +1,30 @@
+-- weekly shifts plus one-off extra shifts and leave, dispatch and bookings only take
+-- employees who are on shift when the motor is due
+CREATE TYPE shift_exception_type AS ENUM ('SHIFT', 'LEAVE');
+
+CREATE TABLE shift_template(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	start_time TIME NOT NULL,
+	end_time TIME NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (end_time > start_time)
+);
+
+CREATE INDEX shift_template_employee_id_idx ON shift_template(employee_id);
+
+CREATE TABLE shift_exception(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	employee_id uuid NOT NULL REFERENCES employee(id),
+	date DATE NOT NULL,
+	type shift_exception_type NOT NULL,
+	start_time TIME NULL,
+	end_time TIME NULL,
+	note TEXT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (end_time > start_time)
+);
+
+CREATE INDEX shift_exception_employee_id_date_idx ON shift_exception(employee_id, date);
@@ -0,0 +1,5 @@
+-- motor returns remember the employee who took the motor in, so the employee report can
+-- count them, returns recorded before this have no employee
+ALTER TABLE motor_return ADD COLUMN employee_id uuid NULL REFERENCES employee(id);
+
+CREATE INDEX motor_return_employee_id_idx ON motor_return(employee_id);
@@ -0,0 +1,33 @@
+-- motor vehicles become units of a vehicle model, the unit keeps its plate and production
+-- year and only overrides the model's prices when its own price or hourly_price is set.
+-- Existing vehicles keep their own name, type and prices until they are given a model.
+CREATE TABLE vehicle_model(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	brand VARCHAR(255) NOT NULL,
+	model VARCHAR(255) NOT NULL,
+	transmission VARCHAR(255) NOT NULL,
+	engine_cc INTEGER NOT NULL CHECK (engine_cc > 0),
+	default_price INTEGER NOT NULL CHECK (default_price > 0),
+	default_hourly_price INTEGER NOT NULL DEFAULT 0 CHECK (default_hourly_price >= 0),
+	specs JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX vehicle_model_brand_model_idx ON vehicle_model(LOWER(brand), LOWER(model)) WHERE deleted_at IS NULL;
+
+ALTER TABLE motor_vehicle ADD COLUMN vehicle_model_id uuid NULL REFERENCES vehicle_model(id);
+ALTER TABLE motor_vehicle ALTER COLUMN name DROP NOT NULL;
+ALTER TABLE motor_vehicle ALTER COLUMN type DROP NOT NULL;
+ALTER TABLE motor_vehicle ALTER COLUMN price DROP NOT NULL;
+ALTER TABLE motor_vehicle ALTER COLUMN hourly_price DROP NOT NULL;
+ALTER TABLE motor_vehicle ALTER COLUMN hourly_price DROP DEFAULT;
+ALTER TABLE motor_vehicle ADD CONSTRAINT motor_vehicle_model_check CHECK (vehicle_model_id IS NOT NULL OR (name IS NOT NULL AND type IS NOT NULL AND price IS NOT NULL));
+CREATE INDEX motor_vehicle_vehicle_model_id_status_idx ON motor_vehicle(vehicle_model_id, status);
+
+-- model photos are attachments of the model
+ALTER TABLE attachment ADD COLUMN vehicle_model_id uuid NULL REFERENCES vehicle_model(id);
+ALTER TABLE attachment DROP CONSTRAINT attachment_owner_check;
+ALTER TABLE attachment ADD CONSTRAINT attachment_owner_check CHECK (num_nonnulls(motor_return_id, motor_pickup_id, motor_vehicle_id, dispute_id, vehicle_model_id) = 1);
+CREATE INDEX attachment_vehicle_model_id_idx ON attachment(vehicle_model_id);
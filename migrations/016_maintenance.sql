@@ -0,0 +1,42 @@
+-- vehicles in the workshop get their own status instead of looking rented. Maintenance
+-- tickets block rentals over their window and schedules tell when the next service is due.
+-- ALTER TYPE ... ADD VALUE can not run inside a transaction block before PostgreSQL 12.
+ALTER TYPE vehicle_status ADD VALUE 'IN_MAINTENANCE';
+
+CREATE TYPE maintenance_status AS ENUM ('SCHEDULED', 'IN_PROGRESS', 'COMPLETED', 'CANCELLED');
+
+CREATE TABLE maintenance_schedule(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	description VARCHAR(255) NOT NULL,
+	interval_km INTEGER NULL CHECK (interval_km > 0),
+	interval_days INTEGER NULL CHECK (interval_days > 0),
+	last_service_km INTEGER NOT NULL DEFAULT 0 CHECK (last_service_km >= 0),
+	last_service_date DATE NOT NULL DEFAULT CURRENT_DATE,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	CHECK (interval_km IS NOT NULL OR interval_days IS NOT NULL)
+);
+
+CREATE INDEX maintenance_schedule_motor_vehicle_id_idx ON maintenance_schedule(motor_vehicle_id);
+
+CREATE TABLE maintenance(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	maintenance_schedule_id uuid NULL REFERENCES maintenance_schedule(id),
+	motor_return_id uuid NULL REFERENCES motor_return(id),
+	status maintenance_status NOT NULL DEFAULT 'SCHEDULED',
+	description VARCHAR(255) NOT NULL,
+	start_date TIMESTAMPTZ NOT NULL,
+	end_date TIMESTAMPTZ NULL,
+	odometer INTEGER NULL CHECK (odometer >= 0),
+	cost INTEGER NOT NULL DEFAULT 0 CHECK (cost >= 0),
+	notes TEXT NOT NULL DEFAULT '',
+	completed_at TIMESTAMP NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CHECK (end_date IS NULL OR end_date > start_date)
+);
+
+CREATE INDEX maintenance_motor_vehicle_id_status_idx ON maintenance(motor_vehicle_id, status);
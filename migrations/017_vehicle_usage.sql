@@ -0,0 +1,23 @@
+-- vehicles carry their latest odometer and fuel readings, rentals snapshot the mileage
+-- allowance of the vehicle and returns record the kilometres driven over it
+ALTER TABLE motor_vehicle ADD COLUMN odometer INTEGER NOT NULL DEFAULT 0 CHECK (odometer >= 0);
+ALTER TABLE motor_vehicle ADD COLUMN fuel_level INTEGER NULL CHECK (fuel_level BETWEEN 0 AND 100);
+ALTER TABLE motor_vehicle ADD COLUMN daily_km_limit INTEGER NULL CHECK (daily_km_limit > 0);
+ALTER TABLE motor_vehicle ADD COLUMN overage_per_km INTEGER NOT NULL DEFAULT 0 CHECK (overage_per_km >= 0);
+
+ALTER TABLE transaction ADD COLUMN km_limit INTEGER NULL;
+ALTER TABLE transaction ADD COLUMN overage_per_km INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE motor_return ADD COLUMN overage_km INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE motor_return ADD COLUMN overage_charge INTEGER NOT NULL DEFAULT 0;
+
+-- start from the highest reading already taken for each vehicle
+UPDATE motor_vehicle mv SET odometer = r.odometer
+FROM (
+	SELECT t.motor_vehicle_id, MAX(GREATEST(mp.odometer, COALESCE(mr.odometer, 0))) AS odometer
+	FROM transaction t
+	JOIN motor_pickup mp ON mp.transaction_id = t.id
+	LEFT JOIN motor_return mr ON mr.transaction_id = t.id
+	GROUP BY t.motor_vehicle_id
+) r
+WHERE r.motor_vehicle_id = mv.id;
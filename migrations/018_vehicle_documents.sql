@@ -0,0 +1,26 @@
+-- registration, tax and insurance papers of each vehicle with their expiry, a vehicle whose
+-- papers run out before the end of a rental can not be rented
+CREATE TYPE vehicle_document_type AS ENUM ('REGISTRATION', 'TAX', 'INSURANCE');
+
+CREATE TABLE vehicle_document(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	type vehicle_document_type NOT NULL,
+	number VARCHAR(255) NOT NULL,
+	issued_at DATE NULL,
+	expires_at DATE NOT NULL,
+	notes TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP NULL,
+	CHECK (issued_at IS NULL OR expires_at > issued_at)
+);
+
+CREATE INDEX vehicle_document_motor_vehicle_id_type_idx ON vehicle_document(motor_vehicle_id, type);
+CREATE INDEX vehicle_document_expires_at_idx ON vehicle_document(expires_at);
+
+-- scans of the papers are attachments of the document
+ALTER TABLE attachment ADD COLUMN vehicle_document_id uuid NULL REFERENCES vehicle_document(id);
+ALTER TABLE attachment DROP CONSTRAINT attachment_owner_check;
+ALTER TABLE attachment ADD CONSTRAINT attachment_owner_check CHECK (num_nonnulls(motor_return_id, motor_pickup_id, motor_vehicle_id, dispute_id, vehicle_model_id, vehicle_document_id) = 1);
+CREATE INDEX attachment_vehicle_document_id_idx ON attachment(vehicle_document_id);
@@ -0,0 +1,8 @@
+-- plates are stored as region, number and suffix separated by a space, such as BB 2423 KG,
+-- and a plate is unique among the vehicles that are not deleted whatever its case or spacing
+UPDATE motor_vehicle
+SET plat = RTRIM(REGEXP_REPLACE(UPPER(REGEXP_REPLACE(plat, '\s', '', 'g')), '^([A-Z]{1,2})([1-9][0-9]{0,3})([A-Z]{0,3})$', '\1 \2 \3'))
+WHERE UPPER(REGEXP_REPLACE(plat, '\s', '', 'g')) ~ '^[A-Z]{1,2}[1-9][0-9]{0,3}[A-Z]{0,3}$';
+
+-- fails while two vehicles that are not deleted share a plate, delete or correct one of them first
+CREATE UNIQUE INDEX motor_vehicle_plat_key_idx ON motor_vehicle((UPPER(REGEXP_REPLACE(plat, '\s', '', 'g')))) WHERE deleted_at IS NULL;
@@ -0,0 +1,15 @@
+-- every change of the status, own prices or plate of a vehicle is kept with who made it and why,
+-- rentals and returns point at their transaction
+CREATE TABLE motor_vehicle_event(
+	id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	motor_vehicle_id uuid NOT NULL REFERENCES motor_vehicle(id),
+	field VARCHAR(20) NOT NULL CHECK (field IN ('STATUS', 'PRICE', 'HOURLY_PRICE', 'PLAT')),
+	old_value VARCHAR(255) NULL,
+	new_value VARCHAR(255) NULL,
+	actor VARCHAR(255) NOT NULL,
+	reason VARCHAR(255) NOT NULL,
+	transaction_id uuid NULL REFERENCES transaction(id),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX motor_vehicle_event_motor_vehicle_id_created_at_idx ON motor_vehicle_event(motor_vehicle_id, created_at);
@@ -0,0 +1,5 @@
+-- deleted vehicles, users and employees can be restored until they are purged, a purged record
+-- that is still referenced by rentals or other records is kept with its personal data wiped
+ALTER TABLE users ADD COLUMN purged_at TIMESTAMP NULL;
+ALTER TABLE employee ADD COLUMN purged_at TIMESTAMP NULL;
+ALTER TABLE motor_vehicle ADD COLUMN purged_at TIMESTAMP NULL;
@@ -0,0 +1,2 @@
+-- the timeline of a purged vehicle is kept, its events lose the vehicle and the plates they held
+ALTER TABLE motor_vehicle_event ALTER COLUMN motor_vehicle_id DROP NOT NULL;
@@ -0,0 +1,2 @@
+-- bookings are dispatched like single rentals, the customer no longer picks their employee
+ALTER TABLE booking ALTER COLUMN employee_id DROP NOT NULL;
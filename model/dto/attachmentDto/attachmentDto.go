@@ -0,0 +1,33 @@
+package attachmentDto
+
+import "io"
+
+type (
+	Attachment struct {
+		ID           string `json:"id"`
+		OwnerType    string `json:"owner_type"`
+		OwnerID      string `json:"owner_id"`
+		FileName     string `json:"file_name"`
+		ContentType  string `json:"content_type"`
+		Size         int64  `json:"size"`
+		StorageKey   string `json:"-"`
+		ThumbnailKey string `json:"-"`
+		URL          string `json:"url"`
+		ThumbnailURL string `json:"thumbnail_url,omitempty"`
+		CreatedAt    string `json:"created_at"`
+	}
+
+	UploadAttachmentRequest struct {
+		OwnerType string
+		OwnerID   string
+		FileName  string
+		Size      int64
+		File      io.ReadSeeker
+	}
+
+	SignedFileRequest struct {
+		Key       string `form:"key"`
+		Expires   string `form:"expires"`
+		Signature string `form:"signature"`
+	}
+)
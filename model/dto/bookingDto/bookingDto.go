@@ -0,0 +1,47 @@
+package bookingDto
+
+import "bike-rent-express/model/dto/transactionDto"
+
+type (
+	Booking struct {
+		ID           string                       `json:"id"`
+		UserID       string                       `json:"user_id"`
+		EmployeeId   string                       `json:"employee_id"`
+		StartDate    string                       `json:"start_date"`
+		EndDate      string                       `json:"end_date"`
+		TotalPrice   int                          `json:"total_price"`
+		Transactions []transactionDto.Transaction `json:"transactions"`
+		CreatedAt    string                       `json:"created_at"`
+		UpdatedAt    string                       `json:"updated_at"`
+	}
+
+	// CreateBookingRequest rents the motor vehicles given by id and one available unit for
+	// every entry of VehicleModelIds, a model is listed again to rent more of its units.
+	// Every vehicle is picked up where it stands unless PickupBranchID is given. The employees
+	// handing the motors over and taking them back are dispatched, not chosen by the customer.
+	CreateBookingRequest struct {
+		ID              string   `json:"id"`
+		UserID          string   `json:"user_id" validate:"required"`
+		MotorVehicleIds []string `json:"motor_vehicle_ids" validate:"required_without=VehicleModelIds,unique,dive,required"`
+		VehicleModelIds []string `json:"vehicle_model_ids" validate:"required_without=MotorVehicleIds,dive,required,uuid"`
+		StartDate       string   `json:"start_date" validate:"required,format-datetime"`
+		EndDate         string   `json:"end_date" validate:"required,format-datetime"`
+		PickupBranchID  string   `json:"pickup_branch_id,omitempty" validate:"omitempty,uuid"`
+		ReturnBranchID  string   `json:"return_branch_id,omitempty" validate:"omitempty,uuid"`
+		Actor           string   `json:"-"`
+	}
+
+	BookingQuote struct {
+		Hours      int                `json:"hours"`
+		Items      []BookingQuoteItem `json:"items"`
+		TotalPrice int                `json:"total_price"`
+	}
+
+	// BookingQuoteItem of a vehicle model is priced at the unit that would be assigned now
+	BookingQuoteItem struct {
+		MotorVehicleId string `json:"motor_vehicle_id,omitempty"`
+		VehicleModelId string `json:"vehicle_model_id,omitempty"`
+		Price          int    `json:"price"`
+		OneWayFee      int    `json:"one_way_fee,omitempty"`
+	}
+)
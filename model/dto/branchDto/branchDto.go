@@ -0,0 +1,31 @@
+package branchDto
+
+type (
+	Branch struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Address   string `json:"address"`
+		CreatedAt string `json:"created_at"`
+		UpdatedAt string `json:"updated_at"`
+	}
+
+	BranchRequest struct {
+		Name    string `json:"name" validate:"required"`
+		Address string `json:"address" validate:"required"`
+	}
+
+	// OneWayFee is charged on top of the rental price when the vehicle is returned at
+	// ToBranchID after being picked up at FromBranchID
+	OneWayFee struct {
+		FromBranchID string `json:"from_branch_id"`
+		ToBranchID   string `json:"to_branch_id"`
+		Fee          int    `json:"fee"`
+		UpdatedAt    string `json:"updated_at"`
+	}
+
+	OneWayFeeRequest struct {
+		FromBranchID string `json:"from_branch_id" validate:"required,uuid,nefield=ToBranchID"`
+		ToBranchID   string `json:"to_branch_id" validate:"required,uuid"`
+		Fee          int    `json:"fee" validate:"min=0"`
+	}
+)
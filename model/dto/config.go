@@ -1,8 +1,17 @@
 package dto
 
+import "time"
+
 type ConfigData struct {
-	DbConfig  dbConfig
-	AppConfig appConfig
+	DbConfig          dbConfig
+	AppConfig         appConfig
+	WorkerConfig      workerConfig
+	RentalConfig      rentalConfig
+	StorageConfig     storageConfig
+	DisputeConfig     disputeConfig
+	DispatchConfig    dispatchConfig
+	MaintenanceConfig maintenanceConfig
+	TrashConfig       trashConfig
 }
 
 type dbConfig struct {
@@ -20,3 +29,45 @@ type dbConfig struct {
 type appConfig struct {
 	Port string
 }
+
+type workerConfig struct {
+	OverdueInterval          string
+	LateFeePercent           int
+	DocumentExpiryInterval   string
+	DocumentExpiryNoticeDays int
+}
+
+type rentalConfig struct {
+	MaxActiveRentals int
+}
+
+type storageConfig struct {
+	Driver          string
+	LocalDir        string
+	SigningKey      string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKey       string
+	SecretKey       string
+	MaxUploadSize   int64
+	SignedURLExpiry time.Duration
+}
+
+type disputeConfig struct {
+	WindowDays int
+}
+
+type dispatchConfig struct {
+	Strategy     string
+	TickInterval string
+}
+
+type maintenanceConfig struct {
+	AutoTicketSeverity string
+}
+
+type trashConfig struct {
+	RetentionDays int
+	PurgeInterval string
+}
@@ -0,0 +1,18 @@
+package damageCatalogDto
+
+type (
+	DamageCatalog struct {
+		ID         string `json:"id"`
+		Part       string `json:"part"`
+		Severity   string `json:"severity"`
+		RepairCost int    `json:"repair_cost"`
+		CreatedAt  string `json:"created_at"`
+		UpdatedAt  string `json:"updated_at"`
+	}
+
+	DamageCatalogRequest struct {
+		Part       string `json:"part" validate:"required"`
+		Severity   string `json:"severity" validate:"required,oneof=MINOR MODERATE SEVERE"`
+		RepairCost int    `json:"repair_cost" validate:"min=0"`
+	}
+)
@@ -0,0 +1,38 @@
+package dispatchDto
+
+const (
+	TaskDelivery = "DELIVERY"
+	TaskPickup   = "PICKUP"
+
+	StatusPending    = "PENDING"
+	StatusAssigned   = "ASSIGNED"
+	StatusAccepted   = "ACCEPTED"
+	StatusInProgress = "IN_PROGRESS"
+	StatusCompleted  = "COMPLETED"
+)
+
+type (
+	// Task is a delivery of the vehicle to the customer at the start of a rental or
+	// a pickup from the customer at its end, a task nobody could take stays PENDING.
+	Task struct {
+		ID            string `json:"id"`
+		TransactionID string `json:"transaction_id"`
+		Type          string `json:"type"`
+		BranchID      string `json:"branch_id,omitempty"`
+		EmployeeID    string `json:"employee_id,omitempty"`
+		Status        string `json:"status"`
+		ScheduledAt   string `json:"scheduled_at"`
+		AcceptedAt    string `json:"accepted_at,omitempty"`
+		StartedAt     string `json:"started_at,omitempty"`
+		CompletedAt   string `json:"completed_at,omitempty"`
+		CreatedAt     string `json:"created_at"`
+		UpdatedAt     string `json:"updated_at"`
+	}
+
+	// Candidate is an active employee of the task's branch with the number of tasks
+	// still open on their queue.
+	Candidate struct {
+		EmployeeID string
+		OpenTasks  int
+	}
+)
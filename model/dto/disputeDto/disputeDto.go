@@ -0,0 +1,68 @@
+package disputeDto
+
+import "time"
+
+type (
+	Dispute struct {
+		ID             string           `json:"id"`
+		MotorReturnID  string           `json:"motor_return_id"`
+		UserID         string           `json:"user_id"`
+		Status         string           `json:"status"`
+		Reason         string           `json:"reason"`
+		Resolution     string           `json:"resolution,omitempty"`
+		RefundAmount   int              `json:"refund_amount"`
+		ResolutionNote string           `json:"resolution_note,omitempty"`
+		Messages       []DisputeMessage `json:"messages,omitempty"`
+		Events         []DisputeEvent   `json:"events,omitempty"`
+		CreatedAt      string           `json:"created_at"`
+		UpdatedAt      string           `json:"updated_at"`
+	}
+
+	DisputeMessage struct {
+		ID         string `json:"id"`
+		AuthorRole string `json:"author_role"`
+		Author     string `json:"author"`
+		Message    string `json:"message"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	DisputeEvent struct {
+		FromStatus string `json:"from_status,omitempty"`
+		ToStatus   string `json:"to_status"`
+		ActorRole  string `json:"actor_role"`
+		Actor      string `json:"actor"`
+		Note       string `json:"note,omitempty"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	// DisputedReturn is the part of a motor return needed to decide whether it can be disputed.
+	DisputedReturn struct {
+		MotorReturnID string
+		UserID        string
+		ExtraCharge   int
+		ReturnedAt    time.Time
+	}
+
+	CreateDisputeRequest struct {
+		MotorReturnID string `json:"motor_return_id" validate:"required"`
+		Reason        string `json:"reason" validate:"required"`
+		UserID        string `json:"-"`
+		Actor         string `json:"-"`
+	}
+
+	DisputeMessageRequest struct {
+		Message    string `json:"message" validate:"required"`
+		DisputeID  string `json:"-"`
+		UserID     string `json:"-"`
+		AuthorRole string `json:"-"`
+		Author     string `json:"-"`
+	}
+
+	ResolveDisputeRequest struct {
+		Resolution   string `json:"resolution" validate:"required,oneof=UPHOLD PARTIAL_REFUND FULL_REFUND"`
+		RefundAmount int    `json:"refund_amount" validate:"required_if=Resolution PARTIAL_REFUND,min=0"`
+		Note         string `json:"note" validate:"required"`
+		DisputeID    string `json:"-"`
+		Actor        string `json:"-"`
+	}
+)
@@ -1,20 +1,30 @@
 package employeeDto
 
 type (
+	// Employee works at BranchID, a BRANCH_MANAGER manages the data of that branch
 	Employee struct {
 		ID        string `json:"id"`
 		Name      string `json:"name"`
 		Telp      string `json:"telp"`
 		Username  string `json:"username"`
 		Password  string `json:"-"`
+		Role      string `json:"role,omitempty"`
+		BranchID  string `json:"branch_id,omitempty"`
 		CreatedAt string `json:"created_at"`
 		UpdatedAt string `json:"updated_at"`
 	}
 
 	UpdateEmployeeRequest struct {
-		ID   string `json:"id"`
-		Name string `json:"name" validate:"required"`
-		Telp string `json:"telp" validate:"required"`
+		ID       string `json:"id"`
+		Name     string `json:"name" validate:"required"`
+		Telp     string `json:"telp" validate:"required"`
+		BranchID string `json:"-"`
+	}
+
+	AssignEmployeeRequest struct {
+		ID       string `json:"-"`
+		Role     string `json:"role" validate:"required,oneof=EMPLOYEE BRANCH_MANAGER"`
+		BranchID string `json:"branch_id" validate:"required_if=Role BRANCH_MANAGER,omitempty,uuid"`
 	}
 
 	CreateEmployeeRequest struct {
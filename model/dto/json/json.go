@@ -14,6 +14,13 @@ type (
 		Data    interface{} `json:"data,omitempty"`
 	}
 
+	jsonPagedResponse struct {
+		Code    string      `json:"responseCode"`
+		Message string      `json:"responseMessage,omitempty"`
+		Data    interface{} `json:"data"`
+		Paging  interface{} `json:"paging"`
+	}
+
 	jsonErrorResponse struct {
 		Code    string `json:"responseCode"`
 		Message string `json:"responseMessage"`
@@ -40,6 +47,16 @@ func NewResponseSuccess(c *gin.Context, result interface{}, message, serviceCode
 	})
 }
 
+// NewResponseSuccessPaged carries the paging of a list next to its data, even when the page is empty.
+func NewResponseSuccessPaged(c *gin.Context, result interface{}, paging interface{}, message, serviceCode, responseCode string) {
+	c.JSON(http.StatusOK, jsonPagedResponse{
+		Code:    "200" + serviceCode + responseCode,
+		Message: message,
+		Data:    result,
+		Paging:  paging,
+	})
+}
+
 func NewResponseCreated(c *gin.Context, result interface{}, message, serviceCode, responseCode string) {
 	c.JSON(http.StatusCreated, jsonResponse{
 		Code:    "201" + serviceCode + responseCode,
@@ -0,0 +1,66 @@
+package maintenanceDto
+
+type (
+	// Maintenance is a ticket for work on a motor vehicle and, once completed, its service
+	// record. MotorReturnID is set on the tickets opened by a return that recorded damage.
+	Maintenance struct {
+		ID                    string  `json:"id"`
+		MotorVehicleID        string  `json:"motor_vehicle_id"`
+		MaintenanceScheduleID string  `json:"maintenance_schedule_id,omitempty"`
+		MotorReturnID         string  `json:"motor_return_id,omitempty"`
+		Status                string  `json:"status"`
+		Description           string  `json:"description"`
+		StartDate             string  `json:"start_date"`
+		EndDate               *string `json:"end_date"`
+		Odometer              *int    `json:"odometer,omitempty"`
+		Cost                  int     `json:"cost"`
+		Notes                 string  `json:"notes"`
+		CompletedAt           *string `json:"completed_at,omitempty"`
+		CreatedAt             string  `json:"created_at"`
+		UpdatedAt             string  `json:"updated_at"`
+	}
+
+	CreateMaintenanceRequest struct {
+		MotorVehicleID        string `json:"motor_vehicle_id" validate:"required,uuid"`
+		MaintenanceScheduleID string `json:"maintenance_schedule_id" validate:"omitempty,uuid"`
+		Description           string `json:"description" validate:"required"`
+		StartDate             string `json:"start_date" validate:"required,format-datetime"`
+		EndDate               string `json:"end_date" validate:"required,format-datetime"`
+	}
+
+	CompleteMaintenanceRequest struct {
+		Odometer *int   `json:"odometer" validate:"required,min=0"`
+		Cost     int    `json:"cost" validate:"min=0"`
+		Notes    string `json:"notes"`
+	}
+
+	// MaintenanceSchedule is a service due every IntervalKm ridden or IntervalDays passed
+	// since the last one, whichever comes first. CurrentOdometer is the highest reading
+	// taken of the motor vehicle at a pickup, return or maintenance.
+	MaintenanceSchedule struct {
+		ID              string `json:"id"`
+		MotorVehicleID  string `json:"motor_vehicle_id"`
+		Description     string `json:"description"`
+		IntervalKm      int    `json:"interval_km,omitempty"`
+		IntervalDays    int    `json:"interval_days,omitempty"`
+		LastServiceKm   int    `json:"last_service_km"`
+		LastServiceDate string `json:"last_service_date"`
+		NextServiceKm   int    `json:"next_service_km,omitempty"`
+		NextServiceDate string `json:"next_service_date,omitempty"`
+		CurrentOdometer int    `json:"current_odometer"`
+		Due             bool   `json:"due"`
+		CreatedAt       string `json:"created_at"`
+		UpdatedAt       string `json:"updated_at"`
+	}
+
+	// MaintenanceScheduleRequest needs at least one of the intervals, LastServiceDate is
+	// today when left empty
+	MaintenanceScheduleRequest struct {
+		MotorVehicleID  string `json:"motor_vehicle_id" validate:"required,uuid"`
+		Description     string `json:"description" validate:"required"`
+		IntervalKm      int    `json:"interval_km" validate:"required_without=IntervalDays,min=0"`
+		IntervalDays    int    `json:"interval_days" validate:"required_without=IntervalKm,min=0"`
+		LastServiceKm   int    `json:"last_service_km" validate:"min=0"`
+		LastServiceDate string `json:"last_service_date" validate:"omitempty,format-date"`
+	}
+)
@@ -0,0 +1,30 @@
+package motorPickupDto
+
+type (
+	MotorPickup struct {
+		ID               string   `json:"id"`
+		TransactionID    string   `json:"transaction_id"`
+		EmployeeId       string   `json:"employee_id"`
+		PickupDate       string   `json:"pickup_date"`
+		Odometer         int      `json:"odometer"`
+		FuelLevel        int      `json:"fuel_level"`
+		DamageNotes      string   `json:"damage_notes"`
+		Photos           []string `json:"photos"`
+		IdentityVerified bool     `json:"identity_verified"`
+		CreatedAt        string   `json:"created_at"`
+		UpdatedAt        string   `json:"updated_at"`
+	}
+
+	CreateMotorPickupRequest struct {
+		ID               string   `json:"id"`
+		TransactionID    string   `json:"transaction_id" validate:"required"`
+		EmployeeId       string   `json:"-"`
+		BranchID         string   `json:"-"`
+		Actor            string   `json:"-"`
+		Odometer         int      `json:"odometer" validate:"min=0"`
+		FuelLevel        int      `json:"fuel_level" validate:"min=0,max=100"`
+		DamageNotes      string   `json:"damage_notes"`
+		Photos           []string `json:"photos" validate:"dive,url"`
+		IdentityVerified bool     `json:"identity_verified"`
+	}
+)
@@ -4,32 +4,123 @@ import "bike-rent-express/model/dto"
 
 type (
 	MotorReturn struct {
-		ID             string `json:"id"`
-		TrasactionID   string `json:"transaction_id"`
-		ReturnDate     string `json:"return_date"`
-		ExtraCharge    int    `json:"extra_charge"`
-		ConditionMotor string `json:"condition_motor"`
-		Descrption     string `json:"description"`
-		CreatedAt      string `json:"created_at"`
-		UpdatedAt      string `json:"updatad_at"`
+		ID             string              `json:"id"`
+		TrasactionID   string              `json:"transaction_id"`
+		ReturnDate     string              `json:"return_date"`
+		ExtraCharge    int                 `json:"extra_charge"`
+		ConditionMotor string              `json:"condition_motor"`
+		Descrption     string              `json:"description"`
+		Odometer       int                 `json:"odometer"`
+		FuelLevel      int                 `json:"fuel_level"`
+		BranchID       string              `json:"branch_id,omitempty"`
+		OverrideReason string              `json:"override_reason"`
+		VoidedAt       string              `json:"voided_at,omitempty"`
+		VoidReason     string              `json:"void_reason,omitempty"`
+		OverageKm      int                 `json:"overage_km,omitempty"`
+		OverageCharge  int                 `json:"overage_charge,omitempty"`
+		Damages        []MotorReturnDamage `json:"damages"`
+		CreatedAt      string              `json:"created_at"`
+		UpdatedAt      string              `json:"updatad_at"`
 	}
 
+	// CreateMotorReturnRequest computes ExtraCharge from the selected damages unless
+	// ExtraChargeOverride is given together with the reason for it. BranchID is where the
+	// vehicle came back, the return branch of the rental when left empty. Distance driven over
+	// the mileage allowance of the rental is charged as OverageCharge
 	CreateMotorReturnRequest struct {
-		ID             string `json:"id"`
-		TransactionID  string `json:"transaction_id" validate:"required"`
-		ExtraCharge    int    `json:"extra_charge" validate:"required"`
-		ConditionMotor string `json:"condition_motor" validate:"required"`
-		Description    string `json:"description" validate:"required"`
+		ID                  string              `json:"id"`
+		TransactionID       string              `json:"transaction_id" validate:"required"`
+		Damages             []DamageItemRequest `json:"damages,omitempty" validate:"unique=DamageCatalogID,dive"`
+		ExtraChargeOverride *int                `json:"extra_charge_override,omitempty" validate:"omitempty,min=0"`
+		OverrideReason      string              `json:"override_reason,omitempty" validate:"required_with=ExtraChargeOverride"`
+		ExtraCharge         int                 `json:"extra_charge"`
+		ConditionMotor      string              `json:"condition_motor" validate:"required"`
+		Description         string              `json:"description" validate:"required"`
+		Odometer            int                 `json:"odometer" validate:"min=0"`
+		FuelLevel           int                 `json:"fuel_level" validate:"min=0,max=100"`
+		BranchID            string              `json:"branch_id,omitempty" validate:"omitempty,uuid"`
+		Distance            int                 `json:"distance,omitempty"`
+		OverageKm           int                 `json:"overage_km,omitempty"`
+		OverageCharge       int                 `json:"overage_charge,omitempty"`
+		MaintenanceID       string              `json:"maintenance_id,omitempty"`
+		EmployeeId          string              `json:"-"`
+		Actor               string              `json:"-"`
+	}
+
+	DamageItemRequest struct {
+		DamageCatalogID string `json:"damage_catalog_id" validate:"required,uuid"`
+		Quantity        int    `json:"quantity" validate:"min=1"`
+	}
+
+	// MotorReturnDamage keeps the catalogue entry as it was priced at return time
+	MotorReturnDamage struct {
+		DamageCatalogID string `json:"damage_catalog_id"`
+		Part            string `json:"part"`
+		Severity        string `json:"severity"`
+		Quantity        int    `json:"quantity"`
+		RepairCost      int    `json:"repair_cost"`
+		Subtotal        int    `json:"subtotal"`
 	}
 
 	MotorReturnResponse struct {
-		ID             string       `json:"id"`
-		ReturnDate     string       `json:"return_date"`
-		ExtraCharge    int          `json:"extra_charge"`
-		ConditionMotor string       `json:"condition_motor"`
-		Descrption     string       `json:"description"`
-		Customer       dto.GetUsers `json:"customer"`
-		CreatedAt      string       `json:"created_at"`
-		UpdatedAt      string       `json:"updatad_at"`
+		ID             string              `json:"id"`
+		ReturnDate     string              `json:"return_date"`
+		ExtraCharge    int                 `json:"extra_charge"`
+		ConditionMotor string              `json:"condition_motor"`
+		Descrption     string              `json:"description"`
+		Odometer       int                 `json:"odometer"`
+		FuelLevel      int                 `json:"fuel_level"`
+		BranchID       string              `json:"branch_id,omitempty"`
+		Damages        []MotorReturnDamage `json:"damages"`
+		DamageCharge   int                 `json:"damage_charge"`
+		OverageKm      int                 `json:"overage_km,omitempty"`
+		OverageCharge  int                 `json:"overage_charge,omitempty"`
+		OverrideReason string              `json:"override_reason,omitempty"`
+		VoidedAt       string              `json:"voided_at,omitempty"`
+		VoidReason     string              `json:"void_reason,omitempty"`
+		Pickup         *PickupComparison   `json:"pickup,omitempty"`
+		Customer       dto.GetUsers        `json:"customer"`
+		CreatedAt      string              `json:"created_at"`
+		UpdatedAt      string              `json:"updatad_at"`
+	}
+
+	// PickupComparison sets the readings taken at return against the ones taken at pickup
+	PickupComparison struct {
+		MotorPickupID     string `json:"motor_pickup_id"`
+		PickupOdometer    int    `json:"pickup_odometer"`
+		PickupFuelLevel   int    `json:"pickup_fuel_level"`
+		PickupDamageNotes string `json:"pickup_damage_notes"`
+		Distance          int    `json:"distance"`
+		FuelUsed          int    `json:"fuel_used"`
+	}
+
+	// AmendMotorReturnRequest corrects a settled return, ConditionMotor and Description
+	// keep their recorded values when left empty
+	AmendMotorReturnRequest struct {
+		ExtraCharge    *int   `json:"extra_charge" validate:"required,min=0"`
+		ConditionMotor string `json:"condition_motor"`
+		Description    string `json:"description"`
+		Reason         string `json:"reason" validate:"required"`
+		ID             string `json:"-"`
+		Actor          string `json:"-"`
+	}
+
+	VoidMotorReturnRequest struct {
+		Reason string `json:"reason" validate:"required"`
+		ID     string `json:"-"`
+		Actor  string `json:"-"`
+	}
+
+	// MotorReturnRevision keeps the values a motor return had before it was amended or voided
+	MotorReturnRevision struct {
+		ID             string `json:"id"`
+		Action         string `json:"action"`
+		ExtraCharge    int    `json:"extra_charge"`
+		ConditionMotor string `json:"condition_motor"`
+		Description    string `json:"description"`
+		Adjustment     int    `json:"adjustment"`
+		Reason         string `json:"reason"`
+		Actor          string `json:"actor"`
+		CreatedAt      string `json:"created_at"`
 	}
 )
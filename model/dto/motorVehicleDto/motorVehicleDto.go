@@ -1,33 +1,127 @@
 package motorVehicleDto
 
 type (
+	// MotorVehicle stands at CurrentBranchID, the branch it was last returned to, which
+	// starts at its home branch. A unit of a vehicle model shows the name, type and prices
+	// of the model unless PriceOverride or HourlyPriceOverride are set on the unit. Odometer and
+	// FuelLevel are the latest readings, a DailyKmLimit of zero lets the vehicle drive unlimited.
 	MotorVehicle struct {
-		Id             string `json:"id,omitempty"`
-		Name           string `json:"name,omitempty"`
-		Type           string `json:"type,omitempty"`
-		Price          int    `json:"price,omitempty"`
-		Plat           string `json:"plat,omitempty"`
-		CreatedAt      string `json:"created_at"`
-		UpdatedAt      string `json:"updated_at"`
-		ProductionYear string `json:"production_year,omitempty"`
-		Status         string `json:"status,omitempty"`
+		Id                  string `json:"id,omitempty"`
+		Name                string `json:"name,omitempty"`
+		Type                string `json:"type,omitempty"`
+		Price               int    `json:"price,omitempty"`
+		HourlyPrice         int    `json:"hourly_price,omitempty"`
+		Plat                string `json:"plat,omitempty"`
+		CreatedAt           string `json:"created_at"`
+		UpdatedAt           string `json:"updated_at"`
+		ProductionYear      string `json:"production_year,omitempty"`
+		Status              string `json:"status,omitempty"`
+		HomeBranchID        string `json:"home_branch_id,omitempty"`
+		CurrentBranchID     string `json:"current_branch_id,omitempty"`
+		VehicleModelID      string `json:"vehicle_model_id,omitempty"`
+		PriceOverride       int    `json:"price_override,omitempty"`
+		HourlyPriceOverride int    `json:"hourly_price_override,omitempty"`
+		Odometer            int    `json:"odometer,omitempty"`
+		FuelLevel           *int   `json:"fuel_level,omitempty"`
+		DailyKmLimit        int    `json:"daily_km_limit,omitempty"`
+		OveragePerKm        int    `json:"overage_per_km,omitempty"`
 	}
 
+	// CreateMotorVehicle takes the name, type and price from the vehicle model when it is
+	// given, a price set anyway overrides the model's
 	CreateMotorVehicle struct {
-		Name           string `json:"name" validate:"required"`
-		Type           string `json:"type" validate:"required"`
-		Price          int    `json:"price" validate:"required"`
-		Plat           string `json:"plat" validate:"required"`
+		VehicleModelID string `json:"vehicle_model_id" validate:"omitempty,uuid"`
+		Name           string `json:"name" validate:"required_without=VehicleModelID"`
+		Type           string `json:"type" validate:"required_without=VehicleModelID"`
+		Price          int    `json:"price" validate:"required_without=VehicleModelID,min=0"`
+		HourlyPrice    int    `json:"hourly_price" validate:"min=0"`
+		Plat           string `json:"plat" validate:"required,format-plate"`
 		ProductionYear string `json:"production_year" validate:"required"`
 		Status         string `json:"status" validate:"required,status-valid"`
+		HomeBranchID   string `json:"home_branch_id" validate:"omitempty,uuid"`
+		Odometer       int    `json:"odometer" validate:"min=0"`
+		DailyKmLimit   int    `json:"daily_km_limit" validate:"min=0"`
+		OveragePerKm   int    `json:"overage_per_km" validate:"min=0"`
 	}
 
+	// UpdateMotorVehicle with UseModelPrice drops the price overrides of a unit of a vehicle model,
+	// an HourlyPrice of 0 clears the hourly price and leaves the vehicle rented per day
 	UpdateMotorVehicle struct {
-		Name           string `json:"name" validate:"required"`
-		Type           string `json:"type" validate:"required"`
-		Price          int    `json:"price" validate:"required"`
-		Plat           string `json:"plat" validate:"required"`
+		VehicleModelID string `json:"vehicle_model_id" validate:"omitempty,uuid"`
+		Name           string `json:"name" validate:"required_without=VehicleModelID"`
+		Type           string `json:"type" validate:"required_without=VehicleModelID"`
+		Price          int    `json:"price" validate:"min=0"`
+		HourlyPrice    *int   `json:"hourly_price" validate:"omitempty,min=0"`
+		UseModelPrice  bool   `json:"use_model_price"`
+		Plat           string `json:"plat" validate:"required,format-plate"`
 		ProductionYear string `json:"production_year" validate:"required"`
 		Status         string `json:"status" validate:"required,status-valid"`
+		HomeBranchID   string `json:"home_branch_id" validate:"omitempty,uuid"`
+		DailyKmLimit   *int   `json:"daily_km_limit" validate:"omitempty,min=0"`
+		OveragePerKm   *int   `json:"overage_per_km" validate:"omitempty,min=0"`
+		Reason         string `json:"reason" validate:"max=255"`
+		Actor          string `json:"-"`
+	}
+
+	// MotorVehicleUsage sums the distance driven over the rentals of a vehicle, the newest first
+	MotorVehicleUsage struct {
+		MotorVehicleID string        `json:"motor_vehicle_id"`
+		Odometer       int           `json:"odometer"`
+		FuelLevel      *int          `json:"fuel_level"`
+		TotalDistance  int           `json:"total_distance"`
+		Rentals        []RentalUsage `json:"rentals"`
+	}
+
+	// RentalUsage has no return readings while the rental is still out
+	RentalUsage struct {
+		TransactionID   string  `json:"transaction_id"`
+		PickupDate      string  `json:"pickup_date"`
+		ReturnDate      *string `json:"return_date"`
+		PickupOdometer  int     `json:"pickup_odometer"`
+		ReturnOdometer  *int    `json:"return_odometer"`
+		PickupFuelLevel int     `json:"pickup_fuel_level"`
+		ReturnFuelLevel *int    `json:"return_fuel_level"`
+		Distance        int     `json:"distance"`
+		KmLimit         *int    `json:"km_limit"`
+		OverageKm       int     `json:"overage_km"`
+		OverageCharge   int     `json:"overage_charge"`
+	}
+
+	// MotorVehicleHistory is the timeline of a vehicle, the oldest change first
+	MotorVehicleHistory struct {
+		MotorVehicleID string              `json:"motor_vehicle_id"`
+		Plat           string              `json:"plat"`
+		Status         string              `json:"status"`
+		Events         []MotorVehicleEvent `json:"events"`
+	}
+
+	// MotorVehicleEvent is a change of the status, own price, own hourly price or plate of a
+	// vehicle, a price without a value follows the vehicle model
+	MotorVehicleEvent struct {
+		ID            string  `json:"id"`
+		Field         string  `json:"field"`
+		OldValue      *string `json:"old_value"`
+		NewValue      *string `json:"new_value"`
+		Actor         string  `json:"actor"`
+		Reason        string  `json:"reason"`
+		TransactionID string  `json:"transaction_id,omitempty"`
+		CreatedAt     string  `json:"created_at"`
+	}
+
+	// MotorVehicleImport tells how many rows of a fleet file were read and imported, nothing
+	// is imported on a dry run or while any row has errors
+	MotorVehicleImport struct {
+		DryRun   bool          `json:"dry_run"`
+		Rows     int           `json:"rows"`
+		Imported int           `json:"imported"`
+		Errors   []ImportError `json:"errors,omitempty"`
+	}
+
+	// ImportError is a problem with a column of a row, Row counts the lines of the file with
+	// the header as line 1
+	ImportError struct {
+		Row     int    `json:"row"`
+		Column  string `json:"column"`
+		Message string `json:"message"`
 	}
 )
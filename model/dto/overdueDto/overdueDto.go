@@ -0,0 +1,9 @@
+package overdueDto
+
+type OverdueTransaction struct {
+	ID             string `json:"id"`
+	UserID         string `json:"user_id"`
+	MotorVehicleId string `json:"motor_vehicle_id"`
+	EndDate        string `json:"end_date"`
+	LateFee        int    `json:"late_fee"`
+}
@@ -0,0 +1,23 @@
+package rentalTierDto
+
+type (
+	RentalTier struct {
+		ID               string `json:"id"`
+		Name             string `json:"name"`
+		MaxActiveRentals int    `json:"max_active_rentals"`
+		CreatedAt        string `json:"created_at"`
+		UpdatedAt        string `json:"updated_at"`
+	}
+
+	RentalTierRequest struct {
+		Name             string `json:"name" validate:"required"`
+		MaxActiveRentals int    `json:"max_active_rentals" validate:"required,min=1"`
+	}
+
+	// UserRentalLimitRequest assigns a tier and/or a personal limit to a customer, a nil value clears it
+	UserRentalLimitRequest struct {
+		UserID           string  `json:"-"`
+		TierID           *string `json:"tier_id" validate:"omitempty,uuid"`
+		MaxActiveRentals *int    `json:"max_active_rentals" validate:"omitempty,min=1"`
+	}
+)
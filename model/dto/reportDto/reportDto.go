@@ -0,0 +1,37 @@
+package reportDto
+
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+type (
+	// EmployeeReportRequest covers the dates from and to inclusive, grouped by Period,
+	// a month when it is left empty.
+	EmployeeReportRequest struct {
+		From       string `form:"from" validate:"required,format-date"`
+		To         string `form:"to" validate:"required,format-date"`
+		Period     string `form:"period" validate:"omitempty,oneof=day week month"`
+		EmployeeID string `form:"employee_id" validate:"omitempty,uuid"`
+		BranchID   string `form:"branch_id" validate:"omitempty,uuid"`
+		Format     string `form:"format" validate:"omitempty,oneof=json csv"`
+	}
+
+	// EmployeeReport is what an employee did in the period starting on PeriodStart. Disputes
+	// lost are refunds granted on returns they processed and the handover time is how many
+	// minutes after the rental start they handed the motor over, nil without handovers.
+	EmployeeReport struct {
+		EmployeeID         string   `json:"employee_id"`
+		EmployeeName       string   `json:"employee_name"`
+		PeriodStart        string   `json:"period_start"`
+		RentalsHandled     int      `json:"rentals_handled"`
+		ReturnsProcessed   int      `json:"returns_processed"`
+		ExtraCharges       int      `json:"extra_charges"`
+		DisputesLost       int      `json:"disputes_lost"`
+		AvgHandoverMinutes *float64 `json:"avg_handover_minutes"`
+	}
+)
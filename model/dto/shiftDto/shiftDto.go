@@ -0,0 +1,62 @@
+package shiftDto
+
+const (
+	ExceptionShift = "SHIFT"
+	ExceptionLeave = "LEAVE"
+)
+
+type (
+	// ShiftTemplate is a weekly recurring shift, Weekday 0 is Sunday and the times are
+	// HH:MM in local time with the end after the start.
+	ShiftTemplate struct {
+		ID         string `json:"id"`
+		EmployeeID string `json:"employee_id"`
+		Weekday    int    `json:"weekday"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
+		CreatedAt  string `json:"created_at"`
+		UpdatedAt  string `json:"updated_at"`
+	}
+
+	ShiftTemplateRequest struct {
+		ID         string `json:"-"`
+		EmployeeID string `json:"employee_id" validate:"required,uuid"`
+		Weekday    *int   `json:"weekday" validate:"required,min=0,max=6"`
+		StartTime  string `json:"start_time" validate:"required,format-time"`
+		EndTime    string `json:"end_time" validate:"required,format-time"`
+	}
+
+	// ShiftException is a one-off extra shift or a leave on a single date, a leave
+	// without times covers the whole day.
+	ShiftException struct {
+		ID         string `json:"id"`
+		EmployeeID string `json:"employee_id"`
+		Date       string `json:"date"`
+		Type       string `json:"type"`
+		StartTime  string `json:"start_time,omitempty"`
+		EndTime    string `json:"end_time,omitempty"`
+		Note       string `json:"note,omitempty"`
+		CreatedAt  string `json:"created_at"`
+	}
+
+	ShiftExceptionRequest struct {
+		EmployeeID string `json:"employee_id" validate:"required,uuid"`
+		Date       string `json:"date" validate:"required,format-date"`
+		Type       string `json:"type" validate:"required,oneof=SHIFT LEAVE"`
+		StartTime  string `json:"start_time" validate:"required_if=Type SHIFT,required_with=EndTime,omitempty,format-time"`
+		EndTime    string `json:"end_time" validate:"required_if=Type SHIFT,required_with=StartTime,omitempty,format-time"`
+		Note       string `json:"note"`
+	}
+
+	Window struct {
+		StartTime string `json:"start_time"`
+		EndTime   string `json:"end_time"`
+	}
+
+	// CalendarDay is what an employee works on a date once leave is taken out of their shifts.
+	CalendarDay struct {
+		Date   string   `json:"date"`
+		OnDuty []Window `json:"on_duty"`
+		Leave  []Window `json:"leave,omitempty"`
+	}
+)
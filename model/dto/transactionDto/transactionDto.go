@@ -14,27 +14,42 @@ type Transaction struct {
 	StartDate      string `json:"start_date"`
 	EndDate        string `json:"end_date"`
 	Price          int    `json:"price"`
+	Status         string `json:"status"`
+	LateFee        int    `json:"late_fee"`
+	PickupBranchID string `json:"pickup_branch_id,omitempty"`
+	ReturnBranchID string `json:"return_branch_id,omitempty"`
+	OneWayFee      int    `json:"one_way_fee,omitempty"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
 }
 
+// AddTransactionRequest picks the vehicle up at the branch it stands at unless
+// PickupBranchID says otherwise, and returns it there unless ReturnBranchID is given.
+// The employee is not chosen by the customer, dispatch assigns the delivery to one.
 type AddTransactionRequest struct {
 	ID             string `json:"id"`
 	UserID         string `json:"user_id" validate:"required"`
 	MotorVehicleId string `json:"motor_vehicle_id" validate:"required"`
-	EmployeeId     string `json:"employee_id" validate:"required"`
-	StartDate      string `json:"start_date" validate:"required,format-date"`
-	EndDate        string `json:"end_date" validate:"required,format-date"`
+	StartDate      string `json:"start_date" validate:"required,format-datetime"`
+	EndDate        string `json:"end_date" validate:"required,format-datetime"`
+	PickupBranchID string `json:"pickup_branch_id,omitempty" validate:"omitempty,uuid"`
+	ReturnBranchID string `json:"return_branch_id,omitempty" validate:"omitempty,uuid"`
+	Actor          string `json:"-"`
 }
 
 type ResponseTransaction struct {
-	ID           string                       `json:"id"`
-	StartDate    string                       `json:"start_date"`
-	EndDate      string                       `json:"end_date"`
-	Price        int                          `json:"price"`
-	MotorVehicle motorVehicleDto.MotorVehicle `json:"motor_vehicle"`
-	Employee     employeeDto.Employee         `json:"employee"`
-	Customer     dto.GetUsers                 `json:"customer"`
-	CreatedAt    string                       `json:"created_at"`
-	UpdatedAt    string                       `json:"updated_at"`
+	ID             string                       `json:"id"`
+	StartDate      string                       `json:"start_date"`
+	EndDate        string                       `json:"end_date"`
+	Price          int                          `json:"price"`
+	Status         string                       `json:"status"`
+	LateFee        int                          `json:"late_fee"`
+	PickupBranchID string                       `json:"pickup_branch_id,omitempty"`
+	ReturnBranchID string                       `json:"return_branch_id,omitempty"`
+	OneWayFee      int                          `json:"one_way_fee,omitempty"`
+	MotorVehicle   motorVehicleDto.MotorVehicle `json:"motor_vehicle"`
+	Employee       employeeDto.Employee         `json:"employee"`
+	Customer       dto.GetUsers                 `json:"customer"`
+	CreatedAt      string                       `json:"created_at"`
+	UpdatedAt      string                       `json:"updated_at"`
 }
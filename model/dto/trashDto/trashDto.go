@@ -0,0 +1,22 @@
+package trashDto
+
+type (
+	// DeletedRecord is a deleted vehicle, user or employee that can still be restored, Label is
+	// the plate of a vehicle or the username of a user or employee
+	DeletedRecord struct {
+		Kind          string `json:"kind"`
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		Label         string `json:"label"`
+		DeletedAt     string `json:"deleted_at"`
+		PurgeableFrom string `json:"purgeable_from"`
+	}
+
+	// PurgeResult tells whether the record is DELETED or, still being referenced by rentals or
+	// other records, ANONYMIZED
+	PurgeResult struct {
+		Kind    string `json:"kind"`
+		ID      string `json:"id"`
+		Outcome string `json:"outcome"`
+	}
+)
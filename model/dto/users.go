@@ -31,7 +31,7 @@ type (
 		Password   string `json:"password"`
 		Address    string `json:"address" validate:"required"`
 		Role       string `json:"role"`
-		CanRent    bool   `json:"can_rent" validate:"required"`
+		CanRent    bool   `json:"can_rent"`
 		Updated_at string
 		Telp       string `json:"telp" validate:"required"`
 	}
@@ -52,6 +52,23 @@ type (
 		NewPassword string `json:"new_password" validate:"required"`
 	}
 
+	// CloseAccount is sent by a user closing their own account, the password confirms it
+	CloseAccount struct {
+		ID       string
+		Username string `json:"-"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	RentalEligibility struct {
+		UserID           string   `json:"user_id"`
+		CanRent          bool     `json:"can_rent"`
+		ActiveRentals    int      `json:"active_rentals"`
+		MaxActiveRentals int      `json:"max_active_rentals"`
+		OverdueRentals   int      `json:"overdue_rentals"`
+		UnpaidCharges    int      `json:"unpaid_charges"`
+		Reasons          []string `json:"reasons,omitempty"`
+	}
+
 	Balance struct {
 		ID        string `json:"id"`
 		Amount    int    `json:"amount"`
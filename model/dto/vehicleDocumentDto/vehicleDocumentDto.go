@@ -0,0 +1,29 @@
+package vehicleDocumentDto
+
+type (
+	// VehicleDocument is a registration, tax or insurance paper of a motor vehicle, InForce is
+	// set on the one of its type that expires last, DaysLeft goes negative once it expired
+	VehicleDocument struct {
+		ID             string  `json:"id"`
+		MotorVehicleID string  `json:"motor_vehicle_id"`
+		Plat           string  `json:"plat"`
+		Type           string  `json:"type"`
+		Number         string  `json:"number"`
+		IssuedAt       *string `json:"issued_at"`
+		ExpiresAt      string  `json:"expires_at"`
+		Notes          string  `json:"notes"`
+		DaysLeft       int     `json:"days_left"`
+		InForce        bool    `json:"in_force"`
+		CreatedAt      string  `json:"created_at"`
+		UpdatedAt      string  `json:"updated_at"`
+	}
+
+	VehicleDocumentRequest struct {
+		MotorVehicleID string  `json:"motor_vehicle_id" validate:"required,uuid"`
+		Type           string  `json:"type" validate:"required,oneof=REGISTRATION TAX INSURANCE"`
+		Number         string  `json:"number" validate:"required"`
+		IssuedAt       *string `json:"issued_at" validate:"omitempty,format-date"`
+		ExpiresAt      string  `json:"expires_at" validate:"required,format-date"`
+		Notes          string  `json:"notes"`
+	}
+)
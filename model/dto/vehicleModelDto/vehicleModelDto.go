@@ -0,0 +1,29 @@
+package vehicleModelDto
+
+type (
+	// VehicleModel is what the motor vehicle units of the model share, AvailableUnits
+	// counts the units that can be rented right now
+	VehicleModel struct {
+		ID                 string            `json:"id"`
+		Brand              string            `json:"brand"`
+		Model              string            `json:"model"`
+		Transmission       string            `json:"transmission"`
+		EngineCC           int               `json:"engine_cc"`
+		DefaultPrice       int               `json:"default_price"`
+		DefaultHourlyPrice int               `json:"default_hourly_price"`
+		Specs              map[string]string `json:"specs"`
+		AvailableUnits     int               `json:"available_units"`
+		CreatedAt          string            `json:"created_at"`
+		UpdatedAt          string            `json:"updated_at"`
+	}
+
+	VehicleModelRequest struct {
+		Brand              string            `json:"brand" validate:"required"`
+		Model              string            `json:"model" validate:"required"`
+		Transmission       string            `json:"transmission" validate:"required"`
+		EngineCC           int               `json:"engine_cc" validate:"required,min=1"`
+		DefaultPrice       int               `json:"default_price" validate:"required,min=1"`
+		DefaultHourlyPrice int               `json:"default_hourly_price" validate:"min=0"`
+		Specs              map[string]string `json:"specs"`
+	}
+)
@@ -8,6 +8,7 @@ type (
 		Username string `json:"username"`
 		ID       string `json:"id"`
 		Roles    string `json:"role"`
+		BranchID string `json:"branch_id,omitempty"`
 	}
 
 	LoginRequest struct {
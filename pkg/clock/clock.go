@@ -0,0 +1,18 @@
+package clock
+
+import "time"
+
+// Clock abstracts time.Now so background jobs can be tested against a fixed time.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func New() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,59 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TransactionOverdue       = "transaction.overdue"
+	VehicleDocumentsExpiring = "vehicle_document.expiring"
+)
+
+type (
+	Event struct {
+		Name       string      `json:"name"`
+		Payload    interface{} `json:"payload"`
+		OccurredAt time.Time   `json:"occurred_at"`
+	}
+
+	Handler func(e Event) error
+
+	Publisher interface {
+		Publish(e Event) error
+	}
+)
+
+// Bus is an in-process publisher, notification senders subscribe to the event names they care about.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish delivers the event to every subscriber, a failing subscriber is logged and does not stop the others.
+func (b *Bus) Publish(e Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[e.Name]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(e); err != nil {
+			log.Error().Msg("event.Publish." + e.Name + ".err : " + err.Error())
+		}
+	}
+
+	return nil
+}
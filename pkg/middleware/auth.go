@@ -17,6 +17,12 @@ var (
 )
 
 func GenerateTokenJwt(username string, roles string) (string, error) {
+	return GenerateBranchTokenJwt(username, roles, "")
+}
+
+// GenerateBranchTokenJwt issues a token scoped to branchID, the caller only sees the data of
+// that branch. Tokens without a branch see everything.
+func GenerateBranchTokenJwt(username string, roles string, branchID string) (string, error) {
 	loginExpDuration := time.Now().Add(24 * time.Hour).Unix()
 	claims := model.JWTClaim{
 		StandardClaims: jwt.StandardClaims{
@@ -25,6 +31,7 @@ func GenerateTokenJwt(username string, roles string) (string, error) {
 		},
 		Username: username,
 		Roles:    roles,
+		BranchID: branchID,
 	}
 
 	token := jwt.NewWithClaims(
@@ -84,6 +91,11 @@ func JWTAuth(roles ...string) gin.HandlerFunc {
 			return
 		}
 
+		// handlers that act on behalf of the caller read these back
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Roles)
+		c.Set("branch_id", claims.BranchID)
+
 		c.Next()
 	}
 }
@@ -0,0 +1,201 @@
+package queryspec
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/utils"
+	"encoding/base64"
+	encjson "encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type (
+	// Schema is what a list endpoint accepts. Filters and sort fields are looked up by their
+	// query parameter, so nothing from the request is ever written into the SQL itself.
+	Schema struct {
+		Filters     []Filter
+		Sorts       map[string]string
+		DefaultSort string
+		IDColumn    string
+	}
+
+	// Filter adds Condition when all of its Params are given, each ? in Condition takes the
+	// value of the param at the same position. Validate is a validator tag for the values.
+	Filter struct {
+		Params    []string
+		Condition string
+		Validate  string
+	}
+
+	// Spec is a parsed list request, build the SQL from it with Where, OrderBy and Limit.
+	Spec struct {
+		schema     Schema
+		conditions []string
+		values     []interface{}
+		sortColumn string
+		desc       bool
+		limit      int
+		after      *Key
+	}
+
+	// Page tells the client how to ask for the rows after this page.
+	Page struct {
+		Limit      int    `json:"limit"`
+		HasMore    bool   `json:"has_more"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+
+	// Key is the sort value and id of a row, the cursor of the next page is made of the last one.
+	Key struct {
+		Value string `json:"v"`
+		ID    string `json:"id"`
+	}
+)
+
+// Parse reads the filters, sort=field or sort=-field for descending, limit and cursor from
+// the query string and returns the fields it rejected the way utils.Validated does.
+func Parse(values url.Values, schema Schema) (Spec, []json.ValidationField) {
+	var errors []json.ValidationField
+	spec := Spec{schema: schema, limit: defaultLimit}
+
+	for _, filter := range schema.Filters {
+		var args []interface{}
+		for _, param := range filter.Params {
+			value := values.Get(param)
+			if value == "" {
+				continue
+			}
+			if filter.Validate != "" {
+				if err := utils.ValidatedVar(param, value, filter.Validate); err != nil {
+					errors = append(errors, err...)
+					continue
+				}
+			}
+			args = append(args, value)
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+		if len(args) != len(filter.Params) {
+			errors = append(errors, json.ValidationField{FieldName: strings.Join(filter.Params, ","), Message: "field is required together with the related field"})
+			continue
+		}
+		spec.conditions = append(spec.conditions, filter.Condition)
+		spec.values = append(spec.values, args...)
+	}
+
+	sort := values.Get("sort")
+	if sort == "" {
+		sort = schema.DefaultSort
+	}
+	spec.desc = strings.HasPrefix(sort, "-")
+	column, ok := schema.Sorts[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		errors = append(errors, json.ValidationField{FieldName: "sort", Message: "field is not one of the allowed values"})
+	}
+	spec.sortColumn = column
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxLimit {
+			errors = append(errors, json.ValidationField{FieldName: "limit", Message: "field must be between 1 and " + strconv.Itoa(maxLimit)})
+		}
+		spec.limit = n
+	}
+
+	if token := values.Get("cursor"); token != "" {
+		after, err := decodeCursor(token)
+		if err != nil {
+			errors = append(errors, json.ValidationField{FieldName: "cursor", Message: "field is not valid"})
+		}
+		spec.after = after
+	}
+
+	return spec, errors
+}
+
+// Where returns the conditions of the spec joined with AND, numbering its placeholders after
+// the args the caller already has, and those args with the spec's values appended.
+func (s Spec) Where(args []interface{}) (string, []interface{}) {
+	conditions := append([]string{}, s.conditions...)
+	values := append([]interface{}{}, s.values...)
+
+	if s.after != nil {
+		operator := ">"
+		if s.desc {
+			operator = "<"
+		}
+		conditions = append(conditions, "("+s.sortColumn+", "+s.schema.IDColumn+"::text) "+operator+" (?, ?)")
+		values = append(values, s.after.Value, s.after.ID)
+	}
+
+	if len(conditions) == 0 {
+		return "TRUE", args
+	}
+
+	where := strings.Join(conditions, " AND ")
+	for _, value := range values {
+		args = append(args, value)
+		where = strings.Replace(where, "?", "$"+strconv.Itoa(len(args)), 1)
+	}
+
+	return where, args
+}
+
+// OrderBy sorts on the chosen field and breaks ties on the id so the cursor is stable.
+func (s Spec) OrderBy() string {
+	direction := "ASC"
+	if s.desc {
+		direction = "DESC"
+	}
+
+	return s.sortColumn + " " + direction + ", " + s.schema.IDColumn + "::text " + direction
+}
+
+// Limit asks for one row more than the page holds, Paginate uses it to tell whether there is more.
+func (s Spec) Limit() string {
+	return strconv.Itoa(s.limit + 1)
+}
+
+// SortColumn is the sort field as text, select it to build the Key of each row.
+func (s Spec) SortColumn() string {
+	return "COALESCE((" + s.sortColumn + ")::text, '')"
+}
+
+// Paginate returns how many of the fetched rows belong to the page and the page info.
+func (s Spec) Paginate(keys []Key) (int, Page) {
+	page := Page{Limit: s.limit}
+	if len(keys) <= s.limit {
+		return len(keys), page
+	}
+
+	page.HasMore = true
+	page.NextCursor = encodeCursor(keys[s.limit-1])
+	return s.limit, page
+}
+
+func encodeCursor(key Key) string {
+	b, _ := encjson.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(token string) (*Key, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var after Key
+	if err := encjson.Unmarshal(b, &after); err != nil {
+		return nil, err
+	}
+
+	return &after, nil
+}
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Job func() error
+
+// Scheduler runs a job periodically on every replica. A job that must not run on two
+// replicas at once takes TryLock in its own transaction and skips the run when another
+// replica holds it, the lock goes with that transaction so no connection is kept aside
+// between runs.
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+func New(name string, interval time.Duration, job Job) *Scheduler {
+	return &Scheduler{
+		name:     name,
+		interval: interval,
+		job:      job,
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.job(); err != nil {
+			log.Error().Msg("scheduler." + s.name + ".err : " + err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TryLock takes the advisory lock of the job name until tx ends, it reports false while
+// another replica runs the job.
+func TryLock(tx *sql.Tx, name string) (bool, error) {
+	var locked bool
+	query := "SELECT pg_try_advisory_xact_lock($1);"
+	err := tx.QueryRow(query, lockKey(name)).Scan(&locked)
+	return locked, err
+}
+
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,96 @@
+// Package sheet reads and writes tables of strings as CSV files or XLSX workbooks, the first
+// row holds the column names.
+package sheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// FormatOf picks the format from the extension of a file name.
+func FormatOf(filename string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), ".")) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// ContentType is the media type a file of the format is served with.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// Read returns the rows of the file, only the first sheet of a workbook is read. Rows may be
+// shorter than the header when their last cells are empty.
+func Read(format string, r io.Reader) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case FormatXLSX:
+		workbook, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer workbook.Close()
+
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil
+		}
+		return workbook.GetRows(sheets[0])
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// Write writes the rows to w in the format.
+func Write(format string, w io.Writer, rows [][]string) error {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case FormatXLSX:
+		workbook := excelize.NewFile()
+		defer workbook.Close()
+
+		sheetName := workbook.GetSheetName(0)
+		for i, row := range rows {
+			cells := make([]interface{}, len(row))
+			for j, value := range row {
+				cells[j] = value
+			}
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+			if err := workbook.SetSheetRow(sheetName, cell, &cells); err != nil {
+				return err
+			}
+		}
+		return workbook.Write(w)
+	}
+	return ErrUnsupportedFormat
+}
@@ -0,0 +1,81 @@
+//go:build integration
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestS3Store points at the MinIO stand-in and makes sure its bucket exists.
+func newTestS3Store(t *testing.T) *s3Store {
+	endpoint := os.Getenv("TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("TEST_S3_ENDPOINT is not set")
+	}
+
+	store := NewS3Store(endpoint, os.Getenv("TEST_S3_REGION"), "bike-rent-express-test", os.Getenv("TEST_S3_ACCESS_KEY"), os.Getenv("TEST_S3_SECRET_KEY")).(*s3Store)
+
+	request, err := http.NewRequest(http.MethodPut, store.endpoint.String()+"/"+store.bucket, nil)
+	require.NoError(t, err)
+	store.sign(request)
+
+	response, err := store.client.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Contains(t, []int{http.StatusOK, http.StatusConflict}, response.StatusCode)
+
+	return store
+}
+
+func TestS3Store_PutGetDelete(t *testing.T) {
+	store := newTestS3Store(t)
+	content := []byte("odometer photo")
+	key := "motor-return/3f0c4c1e-0000-4000-8000-000000000001/photo one.jpg"
+
+	require.NoError(t, store.Put(key, bytes.NewReader(content), int64(len(content)), "image/jpeg"))
+
+	file, err := store.Get(key)
+	require.NoError(t, err)
+	stored, err := io.ReadAll(file)
+	file.Close()
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+
+	require.NoError(t, store.Delete(key))
+
+	_, err = store.Get(key)
+	require.Equal(t, ErrNotFound, err)
+	require.NoError(t, store.Delete(key))
+}
+
+func TestS3Store_SignedURL(t *testing.T) {
+	store := newTestS3Store(t)
+	content := []byte("%PDF-1.4 rental agreement")
+	key := "motor-vehicle/3f0c4c1e-0000-4000-8000-000000000002/agreement.pdf"
+
+	require.NoError(t, store.Put(key, bytes.NewReader(content), int64(len(content)), "application/pdf"))
+	defer store.Delete(key)
+
+	signedURL, err := store.SignedURL(key, time.Minute)
+	require.NoError(t, err)
+
+	response, err := http.Get(signedURL)
+	require.NoError(t, err)
+	downloaded, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, content, downloaded)
+
+	response, err = http.Get(signedURL + "x")
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when a key has no stored blob.
+var ErrNotFound = errors.New("blob not found")
+
+// ErrInvalidSignature is returned when a signed URL is tampered with or expired.
+var ErrInvalidSignature = errors.New("invalid or expired signature")
+
+// BlobStore keeps uploaded files outside the database, keys are slash separated paths.
+type BlobStore interface {
+	Put(key string, data io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	SignedURL(key string, expiry time.Duration) (string, error)
+}
+
+// SignatureVerifier is implemented by stores whose signed URLs are served by this service.
+type SignatureVerifier interface {
+	Verify(key, expires, signature string) error
+}
+
+type Config struct {
+	Driver     string
+	LocalDir   string
+	SigningKey string
+	FileURL    string
+	Endpoint   string
+	Region     string
+	Bucket     string
+	AccessKey  string
+	SecretKey  string
+}
+
+// New builds the store selected by the driver, "local" or "s3".
+func New(config Config) (BlobStore, error) {
+	switch config.Driver {
+	case "", "local":
+		if config.SigningKey == "" {
+			return nil, errors.New("storage signing key is not set")
+		}
+		return NewLocalStore(config.LocalDir, config.SigningKey, config.FileURL), nil
+	case "s3":
+		if config.Endpoint == "" || config.Bucket == "" || config.AccessKey == "" || config.SecretKey == "" {
+			return nil, errors.New("S3 storage config is not set")
+		}
+		return NewS3Store(config.Endpoint, config.Region, config.Bucket, config.AccessKey, config.SecretKey), nil
+	}
+
+	return nil, errors.New("unknown storage driver " + config.Driver)
+}
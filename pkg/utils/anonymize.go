@@ -0,0 +1,7 @@
+package utils
+
+// AnonymizeUser wipes the name, username, password and contact details of the user $1 and marks
+// the account deleted and purged, so it can neither log in nor be restored. The rentals, wallet
+// and ledger of the user are kept. The address is blanked rather than set to NULL, the user reads
+// scan it into a string.
+const AnonymizeUser = "UPDATE users SET name = 'Deleted user', username = 'deleted-' || id, password = '', telp = '', address = '', deleted_at = COALESCE(deleted_at, CURRENT_DATE), purged_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"bike-rent-express/model/dto"
+	"database/sql"
+)
+
+const (
+	ReasonRentalLimitReached = "active rental limit reached"
+	ReasonOverdueRentals     = "has overdue rentals"
+	ReasonUnpaidCharges      = "has unpaid charges"
+)
+
+// RowQuerier is satisfied by both *sql.DB and *sql.Tx.
+type RowQuerier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// EvaluateRentalEligibility fills CanRent and Reasons from the counters loaded by the repository.
+func EvaluateRentalEligibility(eligibility dto.RentalEligibility) dto.RentalEligibility {
+	eligibility.Reasons = nil
+
+	if eligibility.ActiveRentals >= eligibility.MaxActiveRentals {
+		eligibility.Reasons = append(eligibility.Reasons, ReasonRentalLimitReached)
+	}
+	if eligibility.OverdueRentals > 0 {
+		eligibility.Reasons = append(eligibility.Reasons, ReasonOverdueRentals)
+	}
+	if eligibility.UnpaidCharges > 0 {
+		eligibility.Reasons = append(eligibility.Reasons, ReasonUnpaidCharges)
+	}
+
+	eligibility.CanRent = len(eligibility.Reasons) == 0
+	return eligibility
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "fmt"
+
+// VehicleStatusEvent records in the history of the motor vehicle $1 that the status is changed
+// to status by the actor $2 for the reason $3, on behalf of the transaction $4 unless it is
+// empty. It is run just before the update of the status, with the same condition on mv, and
+// records nothing when the vehicle already has the status.
+func VehicleStatusEvent(status, condition string) string {
+	if condition == "" {
+		condition = "TRUE"
+	}
+	return fmt.Sprintf("INSERT INTO motor_vehicle_event(motor_vehicle_id, field, old_value, new_value, actor, reason, transaction_id) SELECT mv.id, 'STATUS', mv.status::text, '%[1]s', $2, $3, NULLIF($4, '')::uuid FROM motor_vehicle mv WHERE mv.id = $1 AND mv.status::text <> '%[1]s' AND (%[2]s);", status, condition)
+}
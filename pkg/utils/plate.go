@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Plate is an Indonesian license plate such as BB 2423 KG, the region code of the registration
+// office, a number of up to four digits and a suffix of up to three letters.
+type Plate struct {
+	Region string
+	Number string
+	Suffix string
+}
+
+var platePattern = regexp.MustCompile(`^([A-Z]{1,2})([1-9][0-9]{0,3})([A-Z]{0,3})$`)
+
+// ParsePlate reads a plate written in any case and with any spacing.
+func ParsePlate(plat string) (Plate, error) {
+	parts := platePattern.FindStringSubmatch(PlateKey(plat))
+	if parts == nil {
+		return Plate{}, errors.New("not an Indonesian license plate")
+	}
+
+	return Plate{Region: parts[1], Number: parts[2], Suffix: parts[3]}, nil
+}
+
+// String writes the plate the way it is stored, the parts in upper case separated by a space.
+func (p Plate) String() string {
+	return strings.TrimSpace(p.Region + " " + p.Number + " " + p.Suffix)
+}
+
+// NormalizePlate returns the stored form of a plate, one that can not be parsed is only trimmed.
+func NormalizePlate(plat string) string {
+	plate, err := ParsePlate(plat)
+	if err != nil {
+		return strings.TrimSpace(plat)
+	}
+	return plate.String()
+}
+
+// PlateKey is what makes two plates the same one, the plate in upper case without spaces. The
+// unique index on motor_vehicle uses the same expression.
+func PlateKey(plat string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(plat), ""))
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// RentalPeriod parses an RFC3339 rental span and returns its length in started hours.
+func RentalPeriod(start, end string) (time.Time, time.Time, int, error) {
+	startDate, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return startDate, startDate, 0, err
+	}
+
+	endDate, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return startDate, endDate, 0, err
+	}
+
+	if !endDate.After(startDate) {
+		return startDate, endDate, 0, errors.New("end date must be after the start date")
+	}
+
+	hours := int(math.Ceil(endDate.Sub(startDate).Hours()))
+	return startDate, endDate, hours, nil
+}
+
+// RentalPrice charges whole days at the daily price and any remaining hours at the cheaper
+// of the hourly or daily price, unless charging the whole span hourly is cheaper still.
+// A vehicle without an hourly price is only rented per started day.
+func RentalPrice(hours, dailyPrice, hourlyPrice int) int {
+	if hourlyPrice <= 0 {
+		return (hours + 23) / 24 * dailyPrice
+	}
+
+	price := hours / 24 * dailyPrice
+	if remaining := hours % 24; remaining > 0 {
+		price += min(remaining*hourlyPrice, dailyPrice)
+	}
+
+	return min(price, hours*hourlyPrice)
+}
+
+// UnitPrices selects the daily and hourly price of the motor vehicle mv from UnitModelJoin,
+// a unit of a vehicle model pays the prices of the model unless it overrides them.
+const (
+	UnitPrices    = "COALESCE(mv.price, vm.default_price), COALESCE(mv.hourly_price, vm.default_hourly_price, 0)"
+	UnitModelJoin = "motor_vehicle mv LEFT JOIN vehicle_model vm ON vm.id = mv.vehicle_model_id"
+)
+
+// NoMaintenance holds when the motor vehicle mv has no maintenance scheduled or in progress
+// that overlaps the rental from the start to the end parameter, an open ended maintenance
+// overlaps every rental ending after it starts.
+func NoMaintenance(start, end string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM maintenance m WHERE m.motor_vehicle_id = mv.id AND m.status IN ('SCHEDULED', 'IN_PROGRESS') AND m.start_date < %s AND (m.end_date IS NULL OR m.end_date > %s))", end, start)
+}
+
+// KmAllowance is the mileage allowance of a rental of the motor vehicle mv from the start to
+// the end parameter, the daily limit for every started day. It is NULL when mv drives unlimited.
+func KmAllowance(start, end string) string {
+	return fmt.Sprintf("mv.daily_km_limit * CEIL(EXTRACT(EPOCH FROM (%s::timestamptz - %s::timestamptz)) / 86400)::int", end, start)
+}
+
+// DocumentsValid holds when none of the papers in force of the motor vehicle mv, the one
+// expiring last of each type, runs out before the day the end parameter falls on.
+func DocumentsValid(end string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM vehicle_document d WHERE d.motor_vehicle_id = mv.id AND d.deleted_at IS NULL GROUP BY d.type HAVING MAX(d.expires_at) < (%s)::timestamptz::date)", end)
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// SniffContentType detects the content type from the first bytes of the file, the extension
+// and the header sent by the client are not trusted.
+func SniffContentType(file io.ReadSeeker) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
+
+// maxThumbnailPixels refuses to decode images that would take too much memory, a small
+// compressed file can still declare huge dimensions.
+const maxThumbnailPixels = 50_000_000
+
+// Thumbnail scales the image down to fit a maxSize square and encodes it as JPEG.
+func Thumbnail(file io.ReadSeeker, maxSize int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Width*config.Height > maxThumbnailPixels {
+		return nil, errors.New("image dimensions are too large")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	source, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			thumbnail.Set(x, y, averageColor(source, bounds,
+				bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height,
+				bounds.Min.X+(x+1)*bounds.Dx()/width, bounds.Min.Y+(y+1)*bounds.Dy()/height))
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, thumbnail, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// averageColor box filters the source pixels that shrink into one thumbnail pixel.
+func averageColor(source image.Image, bounds image.Rectangle, x0, y0, x1, y1 int) color.Color {
+	x1, y1 = max(x1, x0+1), max(y1, y0+1)
+	x1, y1 = min(x1, bounds.Max.X), min(y1, bounds.Max.Y)
+
+	var r, g, b, a, count uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pr, pg, pb, pa := source.At(x, y).RGBA()
+			r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+			count++
+		}
+	}
+
+	// JPEG has no alpha channel, transparent pixels are laid over white
+	white := 0xffff - a/count
+	return color.RGBA64{R: uint16(r/count + white), G: uint16(g/count + white), B: uint16(b/count + white), A: 0xffff}
+}
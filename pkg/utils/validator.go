@@ -2,7 +2,7 @@ package utils
 
 import (
 	"bike-rent-express/model/dto/json"
-	"regexp"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -12,13 +12,7 @@ var validate *validator.Validate
 func Validated(s any) []json.ValidationField {
 	var errors []json.ValidationField
 
-	if validate == nil {
-		validate = validator.New(validator.WithRequiredStructEnabled())
-		validate.RegisterValidation("format-date", validateDateFormat)
-		validate.RegisterValidation("status-valid", validateStatus)
-	}
-
-	err := validate.Struct(s)
+	err := getValidate().Struct(s)
 
 	if err != nil {
 		for _, err := range err.(validator.ValidationErrors) {
@@ -32,14 +26,57 @@ func Validated(s any) []json.ValidationField {
 	return errors
 }
 
+// ValidatedVar checks a single value against the validation tag, for query parameters
+// that are not bound to a struct, and reports the failures under the name.
+func ValidatedVar(name string, value string, tag string) []json.ValidationField {
+	var errors []json.ValidationField
+
+	err := getValidate().Var(value, tag)
+
+	if err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errors = append(errors, json.ValidationField{FieldName: name, Message: getErrorMesssage(err.Tag())})
+		}
+	}
+	return errors
+}
+
+func getValidate() *validator.Validate {
+	if validate == nil {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterValidation("format-datetime", validateDateTimeFormat)
+		validate.RegisterValidation("status-valid", validateStatus)
+		validate.RegisterValidation("format-date", validateDateFormat)
+		validate.RegisterValidation("format-time", validateTimeFormat)
+		validate.RegisterValidation("format-plate", validatePlateFormat)
+	}
+
+	return validate
+}
+
 func getErrorMesssage(tag string) string {
 	messages := map[string]string{
-		"required":     "field is required",
-		"email":        "email is not valid",
-		"string":       "field is not string",
-		"number":       "field is not number",
-		"format-date":  "wrong date format",
-		"status-valid": "AVAILABLE or NOT_AVAILABLE status only",
+		"required":         "field is required",
+		"email":            "email is not valid",
+		"string":           "field is not string",
+		"number":           "field is not number",
+		"min":              "field is below the minimum value",
+		"max":              "field is above the maximum value",
+		"url":              "field is not a valid url",
+		"oneof":            "field is not one of the allowed values",
+		"required_with":    "field is required together with the related field",
+		"required_if":      "field is required for the selected option",
+		"nefield":          "field must differ from the related field",
+		"uuid":             "field is not a valid uuid",
+		"unique":           "field must not contain duplicates",
+		"format-datetime":  "wrong date format, use RFC3339 such as 2024-08-13T09:00:00+07:00",
+		"status-valid":     "AVAILABLE, NOT_AVAILABLE or IN_MAINTENANCE status only",
+		"format-date":      "wrong date format, use YYYY-MM-DD such as 2024-08-13",
+		"format-time":      "wrong time format, use HH:MM such as 09:00",
+		"format-plate":     "not an Indonesian license plate, use the region, number and suffix such as BB 2423 KG",
+		"len":              "field does not have the required length",
+		"boolean":          "field is not true or false",
+		"required_without": "field is required when the related field is empty",
 	}
 
 	for key, val := range messages {
@@ -51,15 +88,29 @@ func getErrorMesssage(tag string) string {
 	return ""
 }
 
+func validateDateTimeFormat(fl validator.FieldLevel) bool {
+	_, err := time.Parse(time.RFC3339, fl.Field().String())
+	return err == nil
+}
+
 func validateDateFormat(fl validator.FieldLevel) bool {
-	date := fl.Field().String()
-	dateFormat := regexp.MustCompile(`\d{1,2}-\d{1,2}-\d{4}`)
-	return dateFormat.MatchString(date)
+	_, err := time.Parse("2006-01-02", fl.Field().String())
+	return err == nil
+}
+
+func validateTimeFormat(fl validator.FieldLevel) bool {
+	_, err := time.Parse("15:04", fl.Field().String())
+	return err == nil
+}
+
+func validatePlateFormat(fl validator.FieldLevel) bool {
+	_, err := ParsePlate(fl.Field().String())
+	return err == nil
 }
 
 func validateStatus(fl validator.FieldLevel) bool {
 	status := fl.Field().String()
-	if status == "AVAILABLE" || status == "NOT_AVAILABLE" {
+	if status == "AVAILABLE" || status == "NOT_AVAILABLE" || status == "IN_MAINTENANCE" {
 		return true
 	}
 	return false
@@ -1,29 +1,74 @@
 package router
 
 import (
+	"bike-rent-express/model/dto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/pkg/storage"
 	"bike-rent-express/src/Users/usersDelivery"
 	"bike-rent-express/src/Users/usersRepository"
 	"bike-rent-express/src/Users/usersUsecase"
+	"bike-rent-express/src/attachment/attachmentDelivery"
+	"bike-rent-express/src/attachment/attachmentRepository"
+	"bike-rent-express/src/attachment/attachmentUsecase"
+	"bike-rent-express/src/booking/bookingDelivery"
+	"bike-rent-express/src/booking/bookingRepository"
+	"bike-rent-express/src/booking/bookingUsecase"
+	"bike-rent-express/src/branch/branchDelivery"
+	"bike-rent-express/src/branch/branchRepository"
+	"bike-rent-express/src/branch/branchUsecase"
+	"bike-rent-express/src/damageCatalog/damageCatalogDelivery"
+	"bike-rent-express/src/damageCatalog/damageCatalogRepository"
+	"bike-rent-express/src/damageCatalog/damageCatalogUsecase"
+	"bike-rent-express/src/dispatch"
+	"bike-rent-express/src/dispatch/dispatchDelivery"
+	"bike-rent-express/src/dispatch/dispatchRepository"
+	"bike-rent-express/src/dispatch/dispatchUsecase"
+	"bike-rent-express/src/dispute/disputeDelivery"
+	"bike-rent-express/src/dispute/disputeRepository"
+	"bike-rent-express/src/dispute/disputeUsecase"
 	"bike-rent-express/src/employee/employeeDelivery"
 	"bike-rent-express/src/employee/employeeRepository"
 	"bike-rent-express/src/employee/employeeUsecase"
+	"bike-rent-express/src/maintenance/maintenanceDelivery"
+	"bike-rent-express/src/maintenance/maintenanceRepository"
+	"bike-rent-express/src/maintenance/maintenanceUsecase"
+	"bike-rent-express/src/motorPickup/motorPickupDelivery"
+	"bike-rent-express/src/motorPickup/motorPickupRepository"
+	"bike-rent-express/src/motorPickup/motorPickupUsecase"
 	"bike-rent-express/src/motorReturn/motorReturnDelivery"
 	"bike-rent-express/src/motorReturn/motorReturnRepository"
 	"bike-rent-express/src/motorReturn/motorReturnUsecase"
 	"bike-rent-express/src/motorVehicle/motorVehicleDelivery"
 	"bike-rent-express/src/motorVehicle/motorVehicleRepository"
 	"bike-rent-express/src/motorVehicle/motorVehicleUsecase"
+	"bike-rent-express/src/rentalTier/rentalTierDelivery"
+	"bike-rent-express/src/rentalTier/rentalTierRepository"
+	"bike-rent-express/src/rentalTier/rentalTierUsecase"
+	"bike-rent-express/src/report/reportDelivery"
+	"bike-rent-express/src/report/reportRepository"
+	"bike-rent-express/src/report/reportUsecase"
+	"bike-rent-express/src/shift/shiftDelivery"
+	"bike-rent-express/src/shift/shiftRepository"
+	"bike-rent-express/src/shift/shiftUsecase"
 	"bike-rent-express/src/transaction/transactionDelivery"
 	"bike-rent-express/src/transaction/transactionRepository"
 	"bike-rent-express/src/transaction/transactionUsecase"
-
+	"bike-rent-express/src/trash/trashDelivery"
+	"bike-rent-express/src/trash/trashRepository"
+	"bike-rent-express/src/trash/trashUsecase"
+	"bike-rent-express/src/vehicleDocument/vehicleDocumentDelivery"
+	"bike-rent-express/src/vehicleDocument/vehicleDocumentRepository"
+	"bike-rent-express/src/vehicleDocument/vehicleDocumentUsecase"
+	"bike-rent-express/src/vehicleModel/vehicleModelDelivery"
+	"bike-rent-express/src/vehicleModel/vehicleModelRepository"
+	"bike-rent-express/src/vehicleModel/vehicleModelUsecase"
 	"database/sql"
 
 	"github.com/gin-gonic/gin"
 )
 
-func InitRoute(v1Group *gin.RouterGroup, db *sql.DB) {
-	usersRepo := usersRepository.NewUsersRepository(db)
+func InitRoute(v1Group *gin.RouterGroup, db *sql.DB, configData dto.ConfigData, blobStore storage.BlobStore, dispatchStrategy dispatch.Strategy) {
+	usersRepo := usersRepository.NewUsersRepository(db, configData.RentalConfig.MaxActiveRentals)
 	usersUC := usersUsecase.NewUsersUsecase(usersRepo)
 	usersDelivery.NewUsersDelivery(v1Group, usersUC)
 
@@ -35,11 +80,67 @@ func InitRoute(v1Group *gin.RouterGroup, db *sql.DB) {
 	employeeUC := employeeUsecase.NewEmployeeUsecase(employeeRepository)
 	employeeDelivery.NewEmployeeDelivery(v1Group, employeeUC)
 
-	transactionRepository := transactionRepository.NewTransactionRepository(db)
-	transactionUC := transactionUsecase.NewTransactionRepository(transactionRepository, usersRepo, employeeRepository, motorVehicleRepo)
+	shiftRepository := shiftRepository.NewShiftRepository(db)
+	shiftUC := shiftUsecase.NewShiftUsecase(shiftRepository)
+	shiftDelivery.NewShiftDelivery(v1Group, shiftUC)
+
+	dispatchRepository := dispatchRepository.NewDispatchRepository(db)
+	dispatchUC := dispatchUsecase.NewDispatchUsecase(dispatchRepository, shiftUC, dispatchStrategy)
+	dispatchDelivery.NewDispatchDelivery(v1Group, dispatchUC)
+
+	transactionRepository := transactionRepository.NewTransactionRepository(db, configData.RentalConfig.MaxActiveRentals)
+	transactionUC := transactionUsecase.NewTransactionRepository(transactionRepository, usersRepo, employeeRepository, motorVehicleRepo, dispatchUC)
 	transactionDelivery.NewTransactionDelivery(v1Group, transactionUC)
 
-	motorReturnRepository := motorReturnRepository.NewMotorRepository(db)
-	motorReturnUC := motorReturnUsecase.NewMotorReturnUseCase(motorReturnRepository, transactionRepository, usersRepo)
+	bookingRepository := bookingRepository.NewBookingRepository(db, configData.RentalConfig.MaxActiveRentals)
+	bookingUC := bookingUsecase.NewBookingUsecase(bookingRepository, dispatchUC)
+	bookingDelivery.NewBookingDelivery(v1Group, bookingUC)
+
+	motorPickupRepository := motorPickupRepository.NewMotorPickupRepository(db)
+	motorPickupUC := motorPickupUsecase.NewMotorPickupUsecase(motorPickupRepository)
+	motorPickupDelivery.NewMotorPickupDelivery(v1Group, motorPickupUC)
+
+	motorReturnRepository := motorReturnRepository.NewMotorRepository(db, configData.MaintenanceConfig.AutoTicketSeverity)
+	motorReturnUC := motorReturnUsecase.NewMotorReturnUseCase(motorReturnRepository, transactionRepository, usersRepo, motorPickupRepository)
 	motorReturnDelivery.NewMotorReturnDelivey(v1Group, motorReturnUC)
+
+	rentalTierRepository := rentalTierRepository.NewRentalTierRepository(db)
+	rentalTierUC := rentalTierUsecase.NewRentalTierUsecase(rentalTierRepository)
+	rentalTierDelivery.NewRentalTierDelivery(v1Group, rentalTierUC)
+
+	damageCatalogRepository := damageCatalogRepository.NewDamageCatalogRepository(db)
+	damageCatalogUC := damageCatalogUsecase.NewDamageCatalogUsecase(damageCatalogRepository)
+	damageCatalogDelivery.NewDamageCatalogDelivery(v1Group, damageCatalogUC)
+
+	attachmentRepository := attachmentRepository.NewAttachmentRepository(db)
+	attachmentUC := attachmentUsecase.NewAttachmentUsecase(attachmentRepository, blobStore, configData.StorageConfig.MaxUploadSize, configData.StorageConfig.SignedURLExpiry)
+	attachmentDelivery.NewAttachmentDelivery(v1Group, attachmentUC, configData.StorageConfig.MaxUploadSize)
+
+	disputeRepository := disputeRepository.NewDisputeRepository(db)
+	disputeUC := disputeUsecase.NewDisputeUsecase(disputeRepository, clock.New(), configData.DisputeConfig.WindowDays)
+	disputeDelivery.NewDisputeDelivery(v1Group, disputeUC)
+
+	branchRepository := branchRepository.NewBranchRepository(db)
+	branchUC := branchUsecase.NewBranchUsecase(branchRepository)
+	branchDelivery.NewBranchDelivery(v1Group, branchUC)
+
+	reportRepository := reportRepository.NewReportRepository(db)
+	reportUC := reportUsecase.NewReportUsecase(reportRepository)
+	reportDelivery.NewReportDelivery(v1Group, reportUC)
+
+	vehicleModelRepository := vehicleModelRepository.NewVehicleModelRepository(db)
+	vehicleModelUC := vehicleModelUsecase.NewVehicleModelUsecase(vehicleModelRepository)
+	vehicleModelDelivery.NewVehicleModelDelivery(v1Group, vehicleModelUC)
+
+	maintenanceRepository := maintenanceRepository.NewMaintenanceRepository(db)
+	maintenanceUC := maintenanceUsecase.NewMaintenanceUsecase(maintenanceRepository)
+	maintenanceDelivery.NewMaintenanceDelivery(v1Group, maintenanceUC)
+
+	vehicleDocumentRepository := vehicleDocumentRepository.NewVehicleDocumentRepository(db)
+	vehicleDocumentUC := vehicleDocumentUsecase.NewVehicleDocumentUsecase(vehicleDocumentRepository)
+	vehicleDocumentDelivery.NewVehicleDocumentDelivery(v1Group, vehicleDocumentUC)
+
+	trashRepository := trashRepository.NewTrashRepository(db)
+	trashUC := trashUsecase.NewTrashUsecase(trashRepository, clock.New(), configData.TrashConfig.RetentionDays)
+	trashDelivery.NewTrashDelivery(v1Group, trashUC)
 }
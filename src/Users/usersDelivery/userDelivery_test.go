@@ -3,6 +3,7 @@ package usersDelivery
 import (
 	"bike-rent-express/model"
 	"bike-rent-express/model/dto"
+	"bike-rent-express/pkg/middleware"
 	"bytes"
 	"database/sql"
 	"encoding/json"
@@ -47,6 +48,21 @@ func (m *mockUserUC) GetByID(id string) (dto.GetUsers, error) {
 	return args.Get(0).(dto.GetUsers), args.Error(1)
 }
 
+func (m *mockUserUC) GetRentalEligibility(id string) (dto.RentalEligibility, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.RentalEligibility), args.Error(1)
+}
+
+func (m *mockUserUC) DeactivateUsers(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockUserUC) CloseAccount(closeAccountRequest dto.CloseAccount) error {
+	args := m.Called(closeAccountRequest)
+	return args.Error(0)
+}
+
 func (m *mockUserUC) GetAllUsers() ([]dto.GetUsers, error) {
 	args := m.Called()
 	return args.Get(0).([]dto.GetUsers), args.Error(1)
@@ -155,7 +171,7 @@ func (suite *UsersDeliveryTestSuite) TestUpdateUsers_Success() {
 	w := httptest.NewRecorder()
 	json, _ := json.Marshal(user)
 	req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/"+user.ID, bytes.NewBuffer(json))
-	req.Header.Add("Authorization", accessToken)
+	req.Header.Add("Authorization", suite.adminToken())
 
 	suite.router.ServeHTTP(w, req)
 	assert.Equal(suite.T(), 200, w.Code)
@@ -654,6 +670,89 @@ func (suite *UsersDeliveryTestSuite) TestChangePassword_Failed() {
 	assert.Equal(suite.T(), expectResponse, w.Body.String())
 }
 
+func (suite *UsersDeliveryTestSuite) adminToken() string {
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	return "Bearer " + token
+}
+
+func (suite *UsersDeliveryTestSuite) TestGetRentalEligibility_Success() {
+	expectResponse := `{"responseCode":"2000902","responseMessage":"Success get rental eligibility","data":{"user_id":"omosiof32131","can_rent":false,"active_rentals":3,"max_active_rentals":3,"overdue_rentals":0,"unpaid_charges":0,"reasons":["active rental limit reached"]}}`
+	eligibility := dto.RentalEligibility{
+		UserID:           expectUsers.Uuid,
+		ActiveRentals:    3,
+		MaxActiveRentals: 3,
+		Reasons:          []string{"active rental limit reached"},
+	}
+	suite.mockUserUC.On("GetRentalEligibility", expectUsers.Uuid).Return(eligibility, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/"+expectUsers.Uuid+"/rental-eligibility", nil)
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *UsersDeliveryTestSuite) TestGetRentalEligibility_FailedDataNotFound() {
+	expectResponse := `{"responseCode":"2000901","responseMessage":"User not found"}`
+	suite.mockUserUC.On("GetRentalEligibility", expectUsers.Uuid).Return(dto.RentalEligibility{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/"+expectUsers.Uuid+"/rental-eligibility", nil)
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *UsersDeliveryTestSuite) TestCloseAccount_Success() {
+	expectResponse := `{"responseCode":"2001002","responseMessage":"Account closed"}`
+	suite.mockUserUC.On("CloseAccount", dto.CloseAccount{ID: expectUsers.Uuid, Username: "test", Password: "test"}).Return(nil)
+
+	token, err := middleware.GenerateTokenJwt("test", "USER")
+	suite.Require().Nil(err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/"+expectUsers.Uuid+"/close", bytes.NewBufferString(`{"password":"test"}`))
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *UsersDeliveryTestSuite) TestCloseAccount_FailedWalletNotEmpty() {
+	expectResponse := `{"responseCode":"4001004","responseMessage":"the wallet must be empty before closing the account"}`
+	suite.mockUserUC.On("CloseAccount", dto.CloseAccount{ID: expectUsers.Uuid, Username: "test", Password: "test"}).Return(errors.New("4"))
+
+	token, err := middleware.GenerateTokenJwt("test", "USER")
+	suite.Require().Nil(err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/"+expectUsers.Uuid+"/close", bytes.NewBufferString(`{"password":"test"}`))
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *UsersDeliveryTestSuite) TestDeactivateUsers_Success() {
+	expectResponse := `{"responseCode":"2001102","responseMessage":"Account deactivated"}`
+	suite.mockUserUC.On("DeactivateUsers", expectUsers.Uuid).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/"+expectUsers.Uuid+"/deactivate", nil)
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
 func TestUsersDelivery(t *testing.T) {
 	suite.Run(t, new(UsersDeliveryTestSuite))
 }
@@ -31,6 +31,9 @@ func NewUsersDelivery(v1Group *gin.RouterGroup, usersUC Users.UsersUsecase) {
 		usersGroup.PUT("/:id/change-password", middleware.JWTAuth("ADMIN", "USER"), handler.ChangePassword)
 		usersGroup.PUT("/:id/top-up", middleware.JWTAuth("USER"), handler.TopUp)
 		usersGroup.GET("/:id/balance", middleware.JWTAuth("USER"), handler.GetBalance)
+		usersGroup.GET("/:id/rental-eligibility", middleware.JWTAuth("ADMIN", "USER"), handler.GetRentalEligibility)
+		usersGroup.PUT("/:id/close", middleware.JWTAuth("USER"), handler.CloseAccount)
+		usersGroup.PUT("/:id/deactivate", middleware.JWTAuth("ADMIN"), handler.DeactivateUsers)
 
 		usersGroup.POST("/register", handler.RegisterUsers)
 		usersGroup.POST("/login", handler.LoginUsers)
@@ -209,3 +212,68 @@ func (c *usersDelivery) GetBalance(ctx *gin.Context) {
 	json.NewResponseSuccess(ctx, balance, "Success get balance", "08", "02")
 
 }
+
+func (c *usersDelivery) GetRentalEligibility(ctx *gin.Context) {
+	id := ctx.Param("id")
+	eligibility, err := c.usersUC.GetRentalEligibility(id)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "User not found", "09", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "09", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, eligibility, "Success get rental eligibility", "09", "02")
+}
+
+func (c *usersDelivery) CloseAccount(ctx *gin.Context) {
+	var closeAccountRequest dto.CloseAccount
+
+	ctx.ShouldBindJSON(&closeAccountRequest)
+	if err := utils.Validated(closeAccountRequest); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "10", "01")
+		return
+	}
+
+	closeAccountRequest.ID = ctx.Param("id")
+	closeAccountRequest.Username = ctx.GetString("username")
+	err := c.usersUC.CloseAccount(closeAccountRequest)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "User not found", "10", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "password does not match", "10", "02")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "return every rental before closing the account", "10", "03")
+			return
+		}
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(ctx, nil, "the wallet must be empty before closing the account", "10", "04")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "10", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Account closed", "10", "02")
+}
+
+func (c *usersDelivery) DeactivateUsers(ctx *gin.Context) {
+	err := c.usersUC.DeactivateUsers(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "User not found", "11", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "11", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Account deactivated", "11", "02")
+}
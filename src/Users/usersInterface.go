@@ -8,6 +8,7 @@ import (
 type UsersRepository interface {
 	RegisterUsers(newUsers dto.RegisterUsers) error
 	GetByID(id string) (dto.GetUsers, error)
+	GetByIDIncludingDeleted(id string) (dto.GetUsers, error)
 	GetAll() ([]dto.GetUsers, error)
 	UpdateUsers(usersItem dto.Users) error
 	GetByUsername(username string) (dto.Users, error)
@@ -15,6 +16,9 @@ type UsersRepository interface {
 	UpdatePassword(changePasswordRequest dto.ChangePassword) error
 	UsernameIsReady(username string) (bool, error)
 	GetBalance(id string) (dto.Balance, error)
+	GetRentalEligibility(id string) (dto.RentalEligibility, error)
+	DeactivateUsers(id string) error
+	CloseAccount(id string) error
 }
 
 type UsersUsecase interface {
@@ -26,4 +30,7 @@ type UsersUsecase interface {
 	TopUp(topUpRequest dto.TopUpRequest) error
 	ChangePassword(changePasswordRequest dto.ChangePassword) error
 	GetBalanceCustomer(id string) (dto.Balance, error)
+	GetRentalEligibility(id string) (dto.RentalEligibility, error)
+	DeactivateUsers(id string) error
+	CloseAccount(closeAccountRequest dto.CloseAccount) error
 }
@@ -2,20 +2,92 @@ package usersRepository
 
 import (
 	"bike-rent-express/model/dto"
+	"bike-rent-express/pkg/utils"
 	"bike-rent-express/src/Users"
 	"database/sql"
+	"errors"
+	"strconv"
 )
 
 type usersRepository struct {
-	db *sql.DB
+	db               *sql.DB
+	maxActiveRentals int
 }
 
-func NewUsersRepository(db *sql.DB) Users.UsersRepository {
-	return &usersRepository{db}
+func NewUsersRepository(db *sql.DB, maxActiveRentals int) Users.UsersRepository {
+	return &usersRepository{db, maxActiveRentals}
+}
+
+// the rental eligibility counters of a row of users, the list, the profile and the rental
+// checks all read them from here
+const (
+	activeRentalsColumn  = "(SELECT COUNT(*) FROM transaction t WHERE t.user_id = users.id AND t.status <> 'RETURNED')"
+	overdueRentalsColumn = "(SELECT COUNT(*) FROM transaction t WHERE t.user_id = users.id AND t.status = 'OVERDUE')"
+	// late fees are settled together with the extra charge when the vehicle is returned, until
+	// then they are owed
+	unpaidChargesColumn = "(SELECT COALESCE(SUM(t.late_fee), 0) FROM transaction t WHERE t.user_id = users.id AND t.status <> 'RETURNED')"
+)
+
+// maxActiveRentalsColumn falls back to the tier of the user and then to defaultMaxActiveRentals,
+// a literal or a query parameter
+func maxActiveRentalsColumn(defaultMaxActiveRentals string) string {
+	return "COALESCE(users.max_active_rentals, (SELECT rt.max_active_rentals FROM rental_tier rt WHERE rt.id = users.tier_id), " + defaultMaxActiveRentals + ")"
+}
+
+// canRentColumn derives the can_rent flag with the same rules as LoadRentalEligibility
+func canRentColumn(defaultMaxActiveRentals int) string {
+	return "(users.role = 'USER' AND " + activeRentalsColumn + " < " + maxActiveRentalsColumn(strconv.Itoa(defaultMaxActiveRentals)) + " AND " + overdueRentalsColumn + " = 0 AND " + unpaidChargesColumn + " = 0) AS can_rent"
+}
+
+// LoadRentalEligibility reads the rental counters of a customer and evaluates them, rentals and
+// bookings check them in their own transaction.
+func LoadRentalEligibility(q utils.RowQuerier, userId string, defaultMaxActiveRentals int) (dto.RentalEligibility, error) {
+	var eligibility dto.RentalEligibility
+	query := "SELECT users.id, " + activeRentalsColumn + ", " + maxActiveRentalsColumn("$2") + ", " + overdueRentalsColumn + ", " + unpaidChargesColumn + " FROM users WHERE users.id = $1 AND users.role = 'USER';"
+
+	err := q.QueryRow(query, userId, defaultMaxActiveRentals).Scan(&eligibility.UserID, &eligibility.ActiveRentals, &eligibility.MaxActiveRentals, &eligibility.OverdueRentals, &eligibility.UnpaidCharges)
+	if err != nil {
+		return eligibility, err
+	}
+
+	return utils.EvaluateRentalEligibility(eligibility), nil
+}
+
+// rentalEligibilityErrors are the errors of the customers who may not rent, keyed by the
+// eligibility reason
+var rentalEligibilityErrors = map[string]error{
+	utils.ReasonRentalLimitReached: errors.New("3"),
+	utils.ReasonOverdueRentals:     errors.New("4"),
+	utils.ReasonUnpaidCharges:      errors.New("5"),
+}
+
+// CheckRentalEligibility loads the rental eligibility of a customer about to rent, a customer
+// who may not rent gets the error of the first reason.
+func CheckRentalEligibility(q utils.RowQuerier, userId string, defaultMaxActiveRentals int) (dto.RentalEligibility, error) {
+	eligibility, err := LoadRentalEligibility(q, userId, defaultMaxActiveRentals)
+	if err != nil {
+		return eligibility, err
+	}
+
+	if !eligibility.CanRent {
+		return eligibility, rentalEligibilityErrors[eligibility.Reasons[0]]
+	}
+
+	return eligibility, nil
 }
 
 func (r *usersRepository) GetByID(uuid string) (dto.GetUsers, error) {
-	query := `SELECT id, name, username, password,address, role, can_rent,created_at, updated_at,telp FROM users WHERE id = $1`
+	return r.getByID(uuid, "deleted_at IS NULL")
+}
+
+// GetByIDIncludingDeleted also finds deactivated and closed users, the rentals and returns they
+// made are shown with them.
+func (r *usersRepository) GetByIDIncludingDeleted(uuid string) (dto.GetUsers, error) {
+	return r.getByID(uuid, "TRUE")
+}
+
+func (r *usersRepository) getByID(uuid string, condition string) (dto.GetUsers, error) {
+	query := `SELECT id, name, username, password,address, role, ` + canRentColumn(r.maxActiveRentals) + `,created_at, updated_at,telp FROM users WHERE id = $1 AND ` + condition
 	var usersItem dto.GetUsers
 	if err := r.db.QueryRow(query, uuid).Scan(
 		&usersItem.Uuid,
@@ -36,7 +108,7 @@ func (r *usersRepository) GetByID(uuid string) (dto.GetUsers, error) {
 }
 
 func (r *usersRepository) GetAll() ([]dto.GetUsers, error) {
-	query := `SELECT id, name, username, address, role, can_rent, created_at, updated_at, telp FROM users WHERE role = 'USER'`
+	query := `SELECT id, name, username, address, role, ` + canRentColumn(r.maxActiveRentals) + `, created_at, updated_at, telp FROM users WHERE role = 'USER' AND deleted_at IS NULL`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -74,15 +146,13 @@ func (r *usersRepository) UpdateUsers(usersUpdate dto.Users) error {
         UPDATE users
         SET name = $1, 
             address = $2, 
-            can_rent = $3, 
-            telp = $4,
+            telp = $3,
 			updated_at = CURRENT_TIMESTAMP
-        WHERE id = $5
+        WHERE id = $4 AND deleted_at IS NULL
     `
 	result, err := r.db.Exec(query,
 		usersUpdate.Name,
 		usersUpdate.Address,
-		usersUpdate.CanRent,
 		usersUpdate.Telp,
 		usersUpdate.ID,
 	)
@@ -104,16 +174,13 @@ func (c *usersRepository) RegisterUsers(newUsers dto.RegisterUsers) error {
 		return err
 	}
 
-	canRent := newUsers.Role == "USER"
-
-	query := `INSERT INTO users (name, username, password, address, role, can_rent, telp) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id;`
+	query := `INSERT INTO users (name, username, password, address, role, telp) VALUES ($1,$2,$3,$4,$5,$6) RETURNING id;`
 	if err := tx.QueryRow(query,
 		newUsers.Name,
 		newUsers.Username,
 		newUsers.Password,
 		newUsers.Address,
 		newUsers.Role,
-		canRent,
 		newUsers.Telp).Scan(&newUsers.ID); err != nil {
 		tx.Rollback()
 		return err
@@ -134,7 +201,7 @@ func (c *usersRepository) RegisterUsers(newUsers dto.RegisterUsers) error {
 
 func (c *usersRepository) GetByUsername(username string) (dto.Users, error) {
 	var user dto.Users
-	query := `SELECT id, name, username, password, address, role, can_rent,updated_at,telp FROM users WHERE username = $1`
+	query := `SELECT id, name, username, password, address, role, ` + canRentColumn(c.maxActiveRentals) + `,updated_at,telp FROM users WHERE username = $1 AND deleted_at IS NULL`
 	if err := c.db.QueryRow(query, username).Scan(&user.ID, &user.Name, &user.Username, &user.Password, &user.Address, &user.Role, &user.CanRent, &user.Updated_at, &user.Telp); err != nil {
 		return user, err
 	}
@@ -163,8 +230,61 @@ func (c *usersRepository) UsernameIsReady(username string) (bool, error) {
 
 func (c *usersRepository) GetBalance(id string) (dto.Balance, error) {
 	var balance dto.Balance
-	query := "SELECT id, amount, created_at, updated_at FROM balance WHERE user_id = $1"
+	// the wallet of a deactivated or closed account can no longer be used
+	query := "SELECT b.id, b.amount, b.created_at, b.updated_at FROM balance b JOIN users u ON u.id = b.user_id WHERE b.user_id = $1 AND u.deleted_at IS NULL"
 
 	err := c.db.QueryRow(query, id).Scan(&balance.ID, &balance.Amount, &balance.CreatedAt, &balance.UpdatedAt)
 	return balance, err
 }
+
+func (c *usersRepository) GetRentalEligibility(id string) (dto.RentalEligibility, error) {
+	return LoadRentalEligibility(c.db, id, c.maxActiveRentals)
+}
+
+// DeactivateUsers soft deletes the user, who can no longer log in and can be restored by an
+// admin until the record is purged.
+func (c *usersRepository) DeactivateUsers(id string) error {
+	query := "UPDATE users SET deleted_at = CURRENT_DATE, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND role = 'USER' AND deleted_at IS NULL;"
+	result, err := c.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CloseAccount anonymizes the user, it fails with "3" while a rental of the user is not returned
+// and with "4" while the wallet is not empty.
+func (c *usersRepository) CloseAccount(id string) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var activeRentals bool
+	var amount int
+	query := `SELECT EXISTS (SELECT 1 FROM transaction t WHERE t.user_id = users.id AND t.status <> 'RETURNED'), COALESCE((SELECT b.amount FROM balance b WHERE b.user_id = users.id), 0)
+		FROM users WHERE id = $1 AND role = 'USER' AND deleted_at IS NULL FOR UPDATE;`
+	if err := tx.QueryRow(query, id).Scan(&activeRentals, &amount); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if activeRentals {
+		tx.Rollback()
+		return errors.New("3")
+	}
+	if amount != 0 {
+		tx.Rollback()
+		return errors.New("4")
+	}
+
+	if _, err := tx.Exec(utils.AnonymizeUser, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -2,7 +2,9 @@ package usersRepository
 
 import (
 	"bike-rent-express/model/dto"
+	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -29,7 +31,7 @@ func TestGetById_Success(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM users WHERE id = \\$1"
 	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectUsers.Uuid, expectUsers.Name, expectUsers.Username, expectUsers.Password, expectUsers.Address, expectUsers.Role, expectUsers.Can_rent, expectUsers.Created_at, expectUsers.Updated_at, expectUsers.Telp)
@@ -47,7 +49,7 @@ func TestGetById_Failed(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM users WHERE id = \\$1"
 
@@ -65,7 +67,7 @@ func TestGetAll_Success(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM users WHERE role = 'USER'"
 	values := [][]driver.Value{
@@ -109,7 +111,7 @@ func TestGetAll_Failed(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM users WHERE role = 'USER'"
 	expectAllUser := []dto.GetUsers{
@@ -147,10 +149,10 @@ func TestUpdateUsers_Success(t *testing.T) {
 		Telp:    expectUsers.Telp,
 		ID:      expectUsers.Uuid,
 	}
-	userRepositroy := NewUsersRepository(dbMock)
+	userRepositroy := NewUsersRepository(dbMock, 3)
 
 	query := "UPDATE users"
-	mock.ExpectExec(query).WithArgs(expectUsers.Name, expectUsers.Address, expectUsers.Can_rent, expectUsers.Telp, expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs(expectUsers.Name, expectUsers.Address, expectUsers.Telp, expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = userRepositroy.UpdateUsers(userItem)
 	assert.Nil(t, err)
@@ -170,10 +172,10 @@ func TestUpdateUsers_Failed(t *testing.T) {
 		Telp:    expectUsers.Telp,
 		ID:      expectUsers.Uuid,
 	}
-	userRepositroy := NewUsersRepository(dbMock)
+	userRepositroy := NewUsersRepository(dbMock, 3)
 
 	query := "UPDATE usersw"
-	mock.ExpectExec(query).WithArgs(expectUsers.Name, expectUsers.Address, expectUsers.Can_rent, expectUsers.Telp, expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(expectUsers.Name, expectUsers.Address, expectUsers.Telp, expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err = userRepositroy.UpdateUsers(userItem)
 	assert.NotNil(t, err)
@@ -194,10 +196,10 @@ func TestUpdateUsers_FailedAffectedRow0(t *testing.T) {
 		Telp:    expectUsers.Telp,
 		ID:      expectUsers.Uuid,
 	}
-	userRepositroy := NewUsersRepository(dbMock)
+	userRepositroy := NewUsersRepository(dbMock, 3)
 
 	query := "UPDATE users"
-	mock.ExpectExec(query).WithArgs(expectUsers.Name, expectUsers.Address, expectUsers.Can_rent, expectUsers.Telp, expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectExec(query).WithArgs(expectUsers.Name, expectUsers.Address, expectUsers.Telp, expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(1, 0))
 
 	err = userRepositroy.UpdateUsers(userItem)
 	assert.NotNil(t, err)
@@ -220,7 +222,7 @@ func TestRegisterUser_Success(t *testing.T) {
 		Telp:     expectUsers.Role,
 	}
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	mock.ExpectBegin()
 	query := "INSERT INTO users(.+) RETURNING .+"
@@ -251,7 +253,7 @@ func TestRegisterUser_FailedInsertUser(t *testing.T) {
 		Telp:     expectUsers.Role,
 	}
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	mock.ExpectBegin()
 	query := "INSERT INTO users(.+) RETURNING .+"
@@ -282,7 +284,7 @@ func TestRegisterUser_FailedInsertBalance(t *testing.T) {
 		Telp:     expectUsers.Role,
 	}
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	mock.ExpectBegin()
 	query := "INSERT INTO users(.+) RETURNING .+"
@@ -315,7 +317,7 @@ func TestGetByUsername_Success(t *testing.T) {
 		Telp:       expectUsers.Telp,
 	}
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM users WHERE .+"
 	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(user.ID, user.Name, user.Username, user.Password, user.Address, user.Role, user.CanRent, user.Updated_at, user.Telp)
@@ -343,7 +345,7 @@ func TestGetByUsername_Failed(t *testing.T) {
 		Telp:       expectUsers.Telp,
 	}
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM userss WHERE .+"
 	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(user.ID, user.Name, user.Username, user.Password, user.Address, user.Role, user.CanRent, user.Updated_at, user.Telp)
@@ -361,7 +363,7 @@ func TestUpdateBalance_Success(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 	topUpRequest := dto.TopUpRequest{
 		Amount: 0,
 		UserID: expectUsers.Uuid,
@@ -381,7 +383,7 @@ func TestUpdateBalance_Failed(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 	topUpRequest := dto.TopUpRequest{
 		Amount: 0,
 		UserID: expectUsers.Uuid,
@@ -401,7 +403,7 @@ func TestUpdatePassword_Success(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 	changePassword := dto.ChangePassword{
 		ID:          expectUsers.Uuid,
 		OldPassword: expectUsers.Password,
@@ -422,7 +424,7 @@ func TestUpdatePassword_Failed(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 	changePassword := dto.ChangePassword{
 		ID:          expectUsers.Uuid,
 		OldPassword: expectUsers.Password,
@@ -443,7 +445,7 @@ func TestUsernameIsReady_Success(t *testing.T) {
 	}
 	defer dbMock.Close()
 
-	userRepository := NewUsersRepository(dbMock)
+	userRepository := NewUsersRepository(dbMock, 3)
 	expectUsernameIsReady := true
 	query := "SELECT COUNT(.+) FROM users WHERE .+"
 	row := sqlmock.NewRows([]string{".+"}).AddRow(0)
@@ -453,3 +455,226 @@ func TestUsernameIsReady_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectUsernameIsReady, actualUsernameIsReady)
 }
+
+func TestGetRentalEligibility_Success(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+	expectEligibility := dto.RentalEligibility{
+		UserID:           expectUsers.Uuid,
+		CanRent:          false,
+		ActiveRentals:    3,
+		MaxActiveRentals: 3,
+		OverdueRentals:   1,
+		UnpaidCharges:    0,
+		Reasons:          []string{"active rental limit reached", "has overdue rentals"},
+	}
+
+	query := "SELECT (.+) FROM users WHERE users.id = \\$1 AND users.role = 'USER';"
+	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+"}).AddRow(expectUsers.Uuid, 3, 3, 1, 0)
+	mock.ExpectQuery(query).WithArgs(expectUsers.Uuid, 3).WillReturnRows(row)
+
+	actualEligibility, err := userRepository.GetRentalEligibility(expectUsers.Uuid)
+	assert.Nil(t, err)
+	assert.Equal(t, expectEligibility, actualEligibility)
+}
+
+func TestGetRentalEligibility_Failed(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	query := "SELECT (.+) FROM users WHERE users.id = \\$1 AND users.role = 'USER';"
+	mock.ExpectQuery(query).WithArgs(expectUsers.Uuid, 3).WillReturnError(sql.ErrNoRows)
+
+	_, err = userRepository.GetRentalEligibility(expectUsers.Uuid)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestCheckRentalEligibility_Success(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	query := "SELECT (.+) FROM users WHERE users.id = \\$1 AND users.role = 'USER';"
+	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+"}).AddRow(expectUsers.Uuid, 1, 3, 0, 0)
+	mock.ExpectQuery(query).WithArgs(expectUsers.Uuid, 3).WillReturnRows(row)
+
+	eligibility, err := CheckRentalEligibility(dbMock, expectUsers.Uuid, 3)
+	assert.Nil(t, err)
+	assert.True(t, eligibility.CanRent)
+	assert.Equal(t, 1, eligibility.ActiveRentals)
+}
+
+func TestCheckRentalEligibility_FailedOverdueRentals(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	query := "SELECT (.+) FROM users WHERE users.id = \\$1 AND users.role = 'USER';"
+	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+"}).AddRow(expectUsers.Uuid, 1, 3, 1, 0)
+	mock.ExpectQuery(query).WithArgs(expectUsers.Uuid, 3).WillReturnRows(row)
+
+	_, err = CheckRentalEligibility(dbMock, expectUsers.Uuid, 3)
+	assert.Equal(t, errors.New("4"), err)
+}
+
+func TestGetByUsername_IgnoresDeleted(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	query := "SELECT (.+) FROM users WHERE username = \\$1 AND deleted_at IS NULL"
+	mock.ExpectQuery(query).WithArgs(expectUsers.Username).WillReturnError(sql.ErrNoRows)
+
+	_, err = userRepository.GetByUsername(expectUsers.Username)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestDeactivateUsers_Success(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	query := "UPDATE users SET deleted_at = CURRENT_DATE, (.+) WHERE id = \\$1 AND role = 'USER' AND deleted_at IS NULL;"
+	mock.ExpectExec(query).WithArgs(expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = userRepository.DeactivateUsers(expectUsers.Uuid)
+	assert.Nil(t, err)
+}
+
+func TestDeactivateUsers_FailedNotFound(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	query := "UPDATE users SET deleted_at = CURRENT_DATE, (.+) WHERE id = \\$1 (.+);"
+	mock.ExpectExec(query).WithArgs(expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = userRepository.DeactivateUsers(expectUsers.Uuid)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestCloseAccount_Success(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM users WHERE id = \\$1 AND role = 'USER' AND deleted_at IS NULL FOR UPDATE;").WithArgs(expectUsers.Uuid).WillReturnRows(sqlmock.NewRows([]string{"active_rentals", "amount"}).AddRow(false, 0))
+	mock.ExpectExec("UPDATE users SET name = 'Deleted user', username = 'deleted-' \\|\\| id, password = '', telp = '', address = '', (.+) WHERE id = \\$1;").WithArgs(expectUsers.Uuid).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = userRepository.CloseAccount(expectUsers.Uuid)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCloseAccount_FailedActiveRentals(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM users WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs(expectUsers.Uuid).WillReturnRows(sqlmock.NewRows([]string{"active_rentals", "amount"}).AddRow(true, 0))
+	mock.ExpectRollback()
+
+	err = userRepository.CloseAccount(expectUsers.Uuid)
+	assert.Equal(t, "3", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCloseAccount_FailedWalletNotEmpty(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT EXISTS (.+) FROM users WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs(expectUsers.Uuid).WillReturnRows(sqlmock.NewRows([]string{"active_rentals", "amount"}).AddRow(false, 15000))
+	mock.ExpectRollback()
+
+	err = userRepository.CloseAccount(expectUsers.Uuid)
+	assert.Equal(t, "4", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByIDIncludingDeleted_Success(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	query := "SELECT (.+) FROM users WHERE id = \\$1 AND TRUE"
+	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectUsers.Uuid, expectUsers.Name, expectUsers.Username, expectUsers.Password, expectUsers.Address, expectUsers.Role, expectUsers.Can_rent, expectUsers.Created_at, expectUsers.Updated_at, expectUsers.Telp)
+	mock.ExpectQuery(query).WithArgs(expectUsers.Uuid).WillReturnRows(row)
+
+	actualUsers, err := userRepository.GetByIDIncludingDeleted(expectUsers.Uuid)
+	assert.Nil(t, err)
+	assert.Equal(t, expectUsers, actualUsers)
+}
+
+func TestGetByIDIncludingDeleted_SuccessAnonymized(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	userRepository := NewUsersRepository(dbMock, 3)
+
+	// a closed account still reads back, the rentals and returns of the customer list it
+	anonymized := dto.GetUsers{
+		Uuid:       expectUsers.Uuid,
+		Name:       "Deleted user",
+		Username:   "deleted-" + expectUsers.Uuid,
+		Role:       "USER",
+		Created_at: "0000",
+		Updated_at: "0000",
+	}
+	query := "SELECT (.+) FROM users WHERE id = \\$1 AND TRUE"
+	row := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(anonymized.Uuid, anonymized.Name, anonymized.Username, "", "", anonymized.Role, false, anonymized.Created_at, anonymized.Updated_at, "")
+	mock.ExpectQuery(query).WithArgs(expectUsers.Uuid).WillReturnRows(row)
+
+	actualUsers, err := userRepository.GetByIDIncludingDeleted(expectUsers.Uuid)
+	assert.Nil(t, err)
+	assert.Equal(t, anonymized, actualUsers)
+}
@@ -40,6 +40,11 @@ func (m *mockUserRepository) GetByID(id string) (dto.GetUsers, error) {
 	return args.Get(0).(dto.GetUsers), args.Error(1)
 }
 
+func (m *mockUserRepository) GetByIDIncludingDeleted(id string) (dto.GetUsers, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.GetUsers), args.Error(1)
+}
+
 func (m *mockUserRepository) GetAll() ([]dto.GetUsers, error) {
 	args := m.Called()
 	return args.Get(0).([]dto.GetUsers), args.Error(1)
@@ -50,6 +55,21 @@ func (m *mockUserRepository) UpdateUsers(usersItem dto.Users) error {
 	return args.Error(0)
 }
 
+func (m *mockUserRepository) GetRentalEligibility(id string) (dto.RentalEligibility, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.RentalEligibility), args.Error(1)
+}
+
+func (m *mockUserRepository) DeactivateUsers(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) CloseAccount(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *mockUserRepository) GetByUsername(username string) (dto.Users, error) {
 	args := m.Called(username)
 	return args.Get(0).(dto.Users), args.Error(1)
@@ -348,6 +368,75 @@ func (suite *UserUCTestSuite) TestChagePassword_Failed() {
 	assert.Error(suite.T(), err)
 }
 
+func (suite *UserUCTestSuite) TestGetRentalEligibility_Success() {
+	eligibility := dto.RentalEligibility{UserID: expectUsers.Uuid, CanRent: true, MaxActiveRentals: 3}
+
+	suite.mockUserRepository.On("GetRentalEligibility", expectUsers.Uuid).Return(eligibility, nil)
+	actualEligibility, err := suite.userUC.GetRentalEligibility(expectUsers.Uuid)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), eligibility, actualEligibility)
+}
+
+func (suite *UserUCTestSuite) TestGetRentalEligibility_FailedNoRows() {
+	suite.mockUserRepository.On("GetRentalEligibility", expectUsers.Uuid).Return(dto.RentalEligibility{}, sql.ErrNoRows)
+	_, err := suite.userUC.GetRentalEligibility(expectUsers.Uuid)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *UserUCTestSuite) TestGetRentalEligibility_Failed() {
+	suite.mockUserRepository.On("GetRentalEligibility", expectUsers.Uuid).Return(dto.RentalEligibility{}, errors.New("error"))
+	_, err := suite.userUC.GetRentalEligibility(expectUsers.Uuid)
+	assert.Equal(suite.T(), "error", err.Error())
+}
+
+func (suite *UserUCTestSuite) TestDeactivateUsers_Success() {
+	suite.mockUserRepository.On("DeactivateUsers", expectUsers.Uuid).Return(nil)
+	err := suite.userUC.DeactivateUsers(expectUsers.Uuid)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *UserUCTestSuite) TestDeactivateUsers_FailedNoRows() {
+	suite.mockUserRepository.On("DeactivateUsers", expectUsers.Uuid).Return(sql.ErrNoRows)
+	err := suite.userUC.DeactivateUsers(expectUsers.Uuid)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *UserUCTestSuite) TestCloseAccount_Success() {
+	closeAccount := dto.CloseAccount{ID: expectUsers.Uuid, Username: expectUsers.Username, Password: "test"}
+
+	suite.mockUserRepository.On("GetByID", expectUsers.Uuid).Return(expectUsers, nil)
+	suite.mockUserRepository.On("CloseAccount", expectUsers.Uuid).Return(nil)
+	err := suite.userUC.CloseAccount(closeAccount)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *UserUCTestSuite) TestCloseAccount_FailedAnotherUser() {
+	closeAccount := dto.CloseAccount{ID: expectUsers.Uuid, Username: "other", Password: "test"}
+
+	suite.mockUserRepository.On("GetByID", expectUsers.Uuid).Return(expectUsers, nil)
+	err := suite.userUC.CloseAccount(closeAccount)
+	assert.Equal(suite.T(), "1", err.Error())
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "CloseAccount", mock.Anything)
+}
+
+func (suite *UserUCTestSuite) TestCloseAccount_FailedPasswordNotMatch() {
+	closeAccount := dto.CloseAccount{ID: expectUsers.Uuid, Username: expectUsers.Username, Password: "wrong"}
+
+	suite.mockUserRepository.On("GetByID", expectUsers.Uuid).Return(expectUsers, nil)
+	err := suite.userUC.CloseAccount(closeAccount)
+	assert.Equal(suite.T(), "2", err.Error())
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "CloseAccount", mock.Anything)
+}
+
+func (suite *UserUCTestSuite) TestCloseAccount_FailedActiveRentals() {
+	closeAccount := dto.CloseAccount{ID: expectUsers.Uuid, Username: expectUsers.Username, Password: "test"}
+
+	suite.mockUserRepository.On("GetByID", expectUsers.Uuid).Return(expectUsers, nil)
+	suite.mockUserRepository.On("CloseAccount", expectUsers.Uuid).Return(errors.New("3"))
+	err := suite.userUC.CloseAccount(closeAccount)
+	assert.Equal(suite.T(), "3", err.Error())
+}
+
 func TestUserUCTestSuite(t *testing.T) {
 	suite.Run(t, new(UserUCTestSuite))
 }
@@ -135,3 +135,50 @@ func (c *usersUC) GetBalanceCustomer(id string) (dto.Balance, error) {
 	}
 	return balance, err
 }
+
+func (c *usersUC) GetRentalEligibility(id string) (dto.RentalEligibility, error) {
+	eligibility, err := c.usersRepo.GetRentalEligibility(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
+			return eligibility, errors.New("1")
+		}
+		return eligibility, err
+	}
+	return eligibility, nil
+}
+
+func (c *usersUC) DeactivateUsers(id string) error {
+	err := c.usersRepo.DeactivateUsers(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
+			return errors.New("1")
+		}
+		return err
+	}
+	return nil
+}
+
+// CloseAccount lets a user close their own account once the password is confirmed, the account
+// of another user is not found.
+func (c *usersUC) CloseAccount(closeAccountRequest dto.CloseAccount) error {
+	user, err := c.usersRepo.GetByID(closeAccountRequest.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
+			return errors.New("1")
+		}
+		return err
+	}
+	if user.Username != closeAccountRequest.Username {
+		return errors.New("1")
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(closeAccountRequest.Password))
+	if err != nil {
+		return errors.New("2")
+	}
+
+	err = c.usersRepo.CloseAccount(closeAccountRequest.ID)
+	if err == sql.ErrNoRows {
+		return errors.New("1")
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package attachmentDelivery
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/src/attachment"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead leaves room for the form boundaries and headers around the file.
+const multipartOverhead = 1 << 20
+
+type attachmentDelivery struct {
+	attachmentUC  attachment.AttachmentUsecase
+	maxUploadSize int64
+}
+
+func NewAttachmentDelivery(v1Group *gin.RouterGroup, attachmentUC attachment.AttachmentUsecase, maxUploadSize int64) {
+	handler := attachmentDelivery{attachmentUC, maxUploadSize}
+
+	attachmentGroup := v1Group.Group("/attachments")
+	{
+		attachmentGroup.GET("/file", handler.GetSignedFile)
+		attachmentGroup.POST("/:owner-type/:owner-id", middleware.JWTAuth("EMPLOYEE", "ADMIN", "BRANCH_MANAGER", "USER"), handler.customerOwnerOnly, handler.UploadAttachment)
+		attachmentGroup.GET("/:owner-type/:owner-id", middleware.JWTAuth("EMPLOYEE", "ADMIN", "BRANCH_MANAGER", "USER"), handler.customerOwnerOnly, handler.GetAttachmentsByOwner)
+		attachmentGroup.DELETE("/:owner-type/:owner-id/:attachment-id", middleware.JWTAuth("ADMIN"), handler.DeleteAttachment)
+	}
+}
+
+// customerOwnerOnly limits customers to the evidence of their own disputes and to looking
+// at the photos of vehicle models.
+func (a *attachmentDelivery) customerOwnerOnly(c *gin.Context) {
+	if c.GetString("role") != "USER" {
+		c.Next()
+		return
+	}
+
+	if c.Request.Method == http.MethodGet && c.Param("owner-type") == "vehicle-model" {
+		c.Next()
+		return
+	}
+
+	if c.Param("owner-type") != "dispute" {
+		json.NewResponseForbidden(c, "Forbidden", "03", "03")
+		c.Abort()
+		return
+	}
+
+	owned, err := a.attachmentUC.IsDisputeOwner(c.Param("owner-id"), c.GetString("username"))
+	if err != nil {
+		json.NewResponseError(c, err.Error(), "03", "03")
+		c.Abort()
+		return
+	}
+
+	if !owned {
+		json.NewResponseForbidden(c, "Forbidden", "03", "03")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+func (a *attachmentDelivery) UploadAttachment(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.maxUploadSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			json.NewResponseBadRequest(c, nil, "File size exceeds the limit", "01", "02")
+			return
+		}
+		json.NewResponseBadRequest(c, []json.ValidationField{{FieldName: "file", Message: "field is required"}}, "Bad Request", "01", "01")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+	defer file.Close()
+
+	uploaded, err := a.attachmentUC.UploadAttachment(attachmentDto.UploadAttachmentRequest{
+		OwnerType: c.Param("owner-type"),
+		OwnerID:   c.Param("owner-id"),
+		FileName:  fileHeader.Filename,
+		Size:      fileHeader.Size,
+		File:      file,
+	})
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "File size exceeds the limit", "01", "02")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "File type is not allowed", "01", "03")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "01", "04")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(c, uploaded, "Attachment uploaded", "01", "01")
+}
+
+func (a *attachmentDelivery) GetAttachmentsByOwner(c *gin.Context) {
+	attachments, err := a.attachmentUC.GetAttachmentsByOwner(c.Param("owner-type"), c.Param("owner-id"))
+	if err != nil {
+		json.NewResponseError(c, err.Error(), "02", "01")
+		return
+	}
+
+	if len(attachments) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, attachments, "Success get attachments", "02", "02")
+}
+
+func (a *attachmentDelivery) DeleteAttachment(c *gin.Context) {
+	err := a.attachmentUC.DeleteAttachment(c.Param("owner-type"), c.Param("owner-id"), c.Param("attachment-id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(c, nil, "Data not found", "03", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, nil, "Attachment deleted", "03", "02")
+}
+
+// GetSignedFile is not behind JWTAuth, the signature in the URL grants the access.
+func (a *attachmentDelivery) GetSignedFile(c *gin.Context) {
+	var request attachmentDto.SignedFileRequest
+	c.ShouldBindQuery(&request)
+
+	file, contentType, err := a.attachmentUC.OpenSignedFile(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseForbidden(c, "Invalid or expired signature", "04", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "04", "02")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "04", "01")
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, file, map[string]string{"Cache-Control": "private, max-age=60"})
+}
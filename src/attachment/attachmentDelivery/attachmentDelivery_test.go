@@ -0,0 +1,241 @@
+package attachmentDelivery
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectAttachment = attachmentDto.Attachment{
+	ID:          "1",
+	OwnerType:   "motor-return",
+	OwnerID:     "2",
+	FileName:    "surat.pdf",
+	ContentType: "application/pdf",
+	Size:        20,
+	URL:         "/api/v1/attachments/file?key=a.pdf",
+	CreatedAt:   "0000",
+}
+
+type mockAttachmentUC struct {
+	mock.Mock
+}
+
+func (m *mockAttachmentUC) UploadAttachment(request attachmentDto.UploadAttachmentRequest) (attachmentDto.Attachment, error) {
+	args := m.Called(request)
+	return args.Get(0).(attachmentDto.Attachment), args.Error(1)
+}
+
+func (m *mockAttachmentUC) GetAttachmentsByOwner(ownerType, ownerID string) ([]attachmentDto.Attachment, error) {
+	args := m.Called(ownerType, ownerID)
+	return args.Get(0).([]attachmentDto.Attachment), args.Error(1)
+}
+
+func (m *mockAttachmentUC) IsDisputeOwner(disputeID, username string) (bool, error) {
+	args := m.Called(disputeID, username)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAttachmentUC) DeleteAttachment(ownerType, ownerID, id string) error {
+	args := m.Called(ownerType, ownerID, id)
+	return args.Error(0)
+}
+
+func (m *mockAttachmentUC) OpenSignedFile(request attachmentDto.SignedFileRequest) (io.ReadCloser, string, error) {
+	args := m.Called(request)
+	file, _ := args.Get(0).(io.ReadCloser)
+	return file, args.String(1), args.Error(2)
+}
+
+func multipartBody(field, fileName string, content []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, fileName)
+	part.Write(content)
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+type AttachmentDeliveryTestSuite struct {
+	suite.Suite
+	mockAttachmentUC *mockAttachmentUC
+	router           *gin.Engine
+	accessToken      string
+}
+
+func (suite *AttachmentDeliveryTestSuite) SetupTest() {
+	suite.mockAttachmentUC = new(mockAttachmentUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewAttachmentDelivery(v1, suite.mockAttachmentUC, 1024)
+
+	token, err := middleware.GenerateTokenJwt("employee", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.accessToken = "Bearer " + token
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestUploadAttachment_Success() {
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Attachment uploaded","data":{"id":"1","owner_type":"motor-return","owner_id":"2","file_name":"surat.pdf","content_type":"application/pdf","size":20,"url":"/api/v1/attachments/file?key=a.pdf","created_at":"0000"}}`
+	suite.mockAttachmentUC.On("UploadAttachment", mock.MatchedBy(func(request attachmentDto.UploadAttachmentRequest) bool {
+		return request.OwnerType == "motor-return" && request.OwnerID == "2" && request.FileName == "surat.pdf" && request.Size == 20
+	})).Return(expectAttachment, nil)
+
+	body, contentType := multipartBody("file", "surat.pdf", []byte("%PDF-1.4 surat jalan"))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/attachments/motor-return/2", body)
+	req.Header.Add("Authorization", suite.accessToken)
+	req.Header.Add("Content-Type", contentType)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestUploadAttachment_FailedNoFile() {
+	expectResponse := `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"file","message":"field is required"}]}`
+
+	body, contentType := multipartBody("photo", "surat.pdf", []byte("%PDF-1.4 surat jalan"))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/attachments/motor-return/2", body)
+	req.Header.Add("Authorization", suite.accessToken)
+	req.Header.Add("Content-Type", contentType)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestUploadAttachment_FailedBodyTooLarge() {
+	expectResponse := `{"responseCode":"4000102","responseMessage":"File size exceeds the limit"}`
+
+	body, contentType := multipartBody("file", "besar.pdf", bytes.Repeat([]byte("a"), 2<<20))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/attachments/motor-return/2", body)
+	req.Header.Add("Authorization", suite.accessToken)
+	req.Header.Add("Content-Type", contentType)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestUploadAttachment_FailedContentType() {
+	expectResponse := `{"responseCode":"4000103","responseMessage":"File type is not allowed"}`
+	suite.mockAttachmentUC.On("UploadAttachment", mock.Anything).Return(attachmentDto.Attachment{}, errors.New("2"))
+
+	body, contentType := multipartBody("file", "ban.jpg", []byte("<html></html>"))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/attachments/motor-return/2", body)
+	req.Header.Add("Authorization", suite.accessToken)
+	req.Header.Add("Content-Type", contentType)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestGetAttachmentsByOwner_Empty() {
+	expectResponse := `{"responseCode":"2000201","responseMessage":"Data empty"}`
+	suite.mockAttachmentUC.On("GetAttachmentsByOwner", "motor-vehicle", "2").Return([]attachmentDto.Attachment(nil), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/attachments/motor-vehicle/2", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestVehicleModelPhotos_CustomerCanOnlyLook() {
+	token, err := middleware.GenerateTokenJwt("customer", "USER")
+	suite.Require().Nil(err)
+	suite.mockAttachmentUC.On("GetAttachmentsByOwner", "vehicle-model", "2").Return([]attachmentDto.Attachment{expectAttachment}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/attachments/vehicle-model/2", nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+
+	body, contentType := multipartBody("file", "foto.jpg", []byte("foto"))
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/attachments/vehicle-model/2", body)
+	req.Header.Add("Authorization", "Bearer "+token)
+	req.Header.Add("Content-Type", contentType)
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+	suite.mockAttachmentUC.AssertNotCalled(suite.T(), "UploadAttachment", mock.Anything)
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestDisputeEvidence_CustomerOwnDisputeOnly() {
+	token, err := middleware.GenerateTokenJwt("customer", "USER")
+	suite.Require().Nil(err)
+	suite.mockAttachmentUC.On("IsDisputeOwner", "2", "customer").Return(true, nil)
+	suite.mockAttachmentUC.On("IsDisputeOwner", "3", "customer").Return(false, nil)
+	suite.mockAttachmentUC.On("GetAttachmentsByOwner", "dispute", "2").Return([]attachmentDto.Attachment{expectAttachment}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/attachments/dispute/2", nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+
+	// another customer's dispute
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/attachments/dispute/3", nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+	suite.mockAttachmentUC.AssertNotCalled(suite.T(), "GetAttachmentsByOwner", "dispute", "3")
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestDeleteAttachment_FailedForbidden() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/attachments/motor-return/2/1", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestGetSignedFile_Success() {
+	request := attachmentDto.SignedFileRequest{Key: "motor-return/2/a.pdf", Expires: "1", Signature: "abc"}
+	suite.mockAttachmentUC.On("OpenSignedFile", request).Return(io.NopCloser(strings.NewReader("%PDF-1.4")), "application/pdf", nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/attachments/file?key=motor-return%2F2%2Fa.pdf&expires=1&signature=abc", nil)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), "%PDF-1.4", w.Body.String())
+}
+
+func (suite *AttachmentDeliveryTestSuite) TestGetSignedFile_FailedSignature() {
+	expectResponse := `{"responseCode":"4030401","responseMessage":"Invalid or expired signature"}`
+	suite.mockAttachmentUC.On("OpenSignedFile", mock.Anything).Return(nil, "", errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/attachments/file?key=a.pdf&expires=1&signature=abc", nil)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestAttachmentDelivery(t *testing.T) {
+	suite.Run(t, new(AttachmentDeliveryTestSuite))
+}
@@ -0,0 +1,25 @@
+package attachment
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"io"
+)
+
+type (
+	AttachmentRepository interface {
+		OwnerExists(ownerType, ownerID string) (bool, error)
+		DisputeOwnedBy(disputeID, username string) (bool, error)
+		Add(attachment attachmentDto.Attachment) (attachmentDto.Attachment, error)
+		GetByOwner(ownerType, ownerID string) ([]attachmentDto.Attachment, error)
+		GetById(id string) (attachmentDto.Attachment, error)
+		Delete(id string) error
+	}
+
+	AttachmentUsecase interface {
+		UploadAttachment(request attachmentDto.UploadAttachmentRequest) (attachmentDto.Attachment, error)
+		GetAttachmentsByOwner(ownerType, ownerID string) ([]attachmentDto.Attachment, error)
+		IsDisputeOwner(disputeID, username string) (bool, error)
+		DeleteAttachment(ownerType, ownerID, id string) error
+		OpenSignedFile(request attachmentDto.SignedFileRequest) (io.ReadCloser, string, error)
+	}
+)
@@ -0,0 +1,127 @@
+package attachmentRepository
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"bike-rent-express/src/attachment"
+	"database/sql"
+)
+
+type owner struct {
+	column      string
+	existsQuery string
+}
+
+// owners maps the owner types accepted in the URL to their attachment column.
+var owners = map[string]owner{
+	"motor-return":     {"motor_return_id", "SELECT EXISTS(SELECT 1 FROM motor_return WHERE id = $1);"},
+	"motor-pickup":     {"motor_pickup_id", "SELECT EXISTS(SELECT 1 FROM motor_pickup WHERE id = $1);"},
+	"motor-vehicle":    {"motor_vehicle_id", "SELECT EXISTS(SELECT 1 FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL);"},
+	"dispute":          {"dispute_id", "SELECT EXISTS(SELECT 1 FROM dispute WHERE id = $1);"},
+	"vehicle-model":    {"vehicle_model_id", "SELECT EXISTS(SELECT 1 FROM vehicle_model WHERE id = $1 AND deleted_at IS NULL);"},
+	"vehicle-document": {"vehicle_document_id", "SELECT EXISTS(SELECT 1 FROM vehicle_document WHERE id = $1 AND deleted_at IS NULL);"},
+}
+
+type attachmentRepository struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) attachment.AttachmentRepository {
+	return &attachmentRepository{db}
+}
+
+func (a *attachmentRepository) OwnerExists(ownerType, ownerID string) (bool, error) {
+	owner, ok := owners[ownerType]
+	if !ok {
+		return false, nil
+	}
+
+	var exists bool
+	if err := a.db.QueryRow(owner.existsQuery, ownerID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// DisputeOwnedBy reports whether the dispute was opened by the customer with the username.
+func (a *attachmentRepository) DisputeOwnedBy(disputeID, username string) (bool, error) {
+	var owned bool
+	query := "SELECT EXISTS(SELECT 1 FROM dispute d JOIN users u ON u.id = d.user_id WHERE d.id = $1 AND u.username = $2);"
+	if err := a.db.QueryRow(query, disputeID, username).Scan(&owned); err != nil {
+		return false, err
+	}
+
+	return owned, nil
+}
+
+func (a *attachmentRepository) Add(newAttachment attachmentDto.Attachment) (attachmentDto.Attachment, error) {
+	owner, ok := owners[newAttachment.OwnerType]
+	if !ok {
+		return newAttachment, sql.ErrNoRows
+	}
+
+	query := "INSERT INTO attachment(" + owner.column + ", file_name, content_type, size_bytes, storage_key, thumbnail_key) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')) RETURNING id, created_at;"
+	err := a.db.QueryRow(query, newAttachment.OwnerID, newAttachment.FileName, newAttachment.ContentType, newAttachment.Size, newAttachment.StorageKey, newAttachment.ThumbnailKey).Scan(&newAttachment.ID, &newAttachment.CreatedAt)
+	if err != nil {
+		return newAttachment, err
+	}
+
+	return newAttachment, nil
+}
+
+func (a *attachmentRepository) GetByOwner(ownerType, ownerID string) ([]attachmentDto.Attachment, error) {
+	owner, ok := owners[ownerType]
+	if !ok {
+		return nil, nil
+	}
+
+	query := "SELECT id, file_name, content_type, size_bytes, storage_key, COALESCE(thumbnail_key, ''), created_at FROM attachment WHERE " + owner.column + " = $1 ORDER BY created_at;"
+	rows, err := a.db.Query(query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []attachmentDto.Attachment
+	for rows.Next() {
+		attachment := attachmentDto.Attachment{OwnerType: ownerType, OwnerID: ownerID}
+		if err := rows.Scan(&attachment.ID, &attachment.FileName, &attachment.ContentType, &attachment.Size, &attachment.StorageKey, &attachment.ThumbnailKey, &attachment.CreatedAt); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (a *attachmentRepository) GetById(id string) (attachmentDto.Attachment, error) {
+	var attachment attachmentDto.Attachment
+	query := `SELECT id,
+		CASE WHEN motor_return_id IS NOT NULL THEN 'motor-return' WHEN motor_pickup_id IS NOT NULL THEN 'motor-pickup' WHEN motor_vehicle_id IS NOT NULL THEN 'motor-vehicle' WHEN vehicle_model_id IS NOT NULL THEN 'vehicle-model' WHEN vehicle_document_id IS NOT NULL THEN 'vehicle-document' ELSE 'dispute' END,
+		COALESCE(motor_return_id, motor_pickup_id, motor_vehicle_id, vehicle_model_id, vehicle_document_id, dispute_id), file_name, content_type, size_bytes, storage_key, COALESCE(thumbnail_key, ''), created_at
+		FROM attachment WHERE id = $1;`
+	err := a.db.QueryRow(query, id).Scan(&attachment.ID, &attachment.OwnerType, &attachment.OwnerID, &attachment.FileName, &attachment.ContentType, &attachment.Size, &attachment.StorageKey, &attachment.ThumbnailKey, &attachment.CreatedAt)
+	if err != nil {
+		return attachment, err
+	}
+
+	return attachment, nil
+}
+
+func (a *attachmentRepository) Delete(id string) error {
+	result, err := a.db.Exec("DELETE FROM attachment WHERE id = $1;", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
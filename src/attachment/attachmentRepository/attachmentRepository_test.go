@@ -0,0 +1,158 @@
+package attachmentRepository
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var expectAttachment = attachmentDto.Attachment{
+	ID:           "1",
+	OwnerType:    "motor-return",
+	OwnerID:      "2",
+	FileName:     "ban.jpg",
+	ContentType:  "image/jpeg",
+	Size:         2048,
+	StorageKey:   "motor-return/2/abc.jpg",
+	ThumbnailKey: "motor-return/2/abc_thumb.jpg",
+	CreatedAt:    "0000",
+}
+
+func TestOwnerExists_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL\\);").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := repository.OwnerExists("motor-vehicle", "2")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+}
+
+func TestOwnerExists_UnknownOwnerType(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	exists, err := repository.OwnerExists("customer", "2")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+}
+
+func TestDisputeOwnedBy_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM dispute d JOIN users u ON u.id = d.user_id WHERE d.id = \\$1 AND u.username = \\$2\\);").WithArgs("2", "customer").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	owned, err := repository.DisputeOwnedBy("2", "customer")
+	assert.Nil(t, err)
+	assert.False(t, owned)
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	newAttachment := expectAttachment
+	newAttachment.ID, newAttachment.CreatedAt = "", ""
+
+	query := "INSERT INTO attachment\\(motor_return_id, (.+)\\) VALUES (.+) RETURNING id, created_at;"
+	mock.ExpectQuery(query).WithArgs("2", "ban.jpg", "image/jpeg", int64(2048), "motor-return/2/abc.jpg", "motor-return/2/abc_thumb.jpg").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("1", "0000"))
+
+	actualAttachment, err := repository.Add(newAttachment)
+	assert.Nil(t, err)
+	assert.Equal(t, expectAttachment, actualAttachment)
+}
+
+func TestAdd_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	mock.ExpectQuery("INSERT INTO attachment(.+)").WillReturnError(errors.New("error sql"))
+
+	_, err = repository.Add(expectAttachment)
+	assert.Error(t, err)
+}
+
+func TestGetByOwner_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	query := "SELECT (.+) FROM attachment WHERE motor_return_id = \\$1 ORDER BY created_at;"
+	mock.ExpectQuery(query).WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"id", "file_name", "content_type", "size_bytes", "storage_key", "thumbnail_key", "created_at"}).
+		AddRow("1", "ban.jpg", "image/jpeg", 2048, "motor-return/2/abc.jpg", "motor-return/2/abc_thumb.jpg", "0000"))
+
+	actualAttachments, err := repository.GetByOwner("motor-return", "2")
+	assert.Nil(t, err)
+	assert.Equal(t, []attachmentDto.Attachment{expectAttachment}, actualAttachments)
+}
+
+func TestGetById_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	query := "SELECT (.+) FROM attachment WHERE id = \\$1;"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"id", "owner_type", "owner_id", "file_name", "content_type", "size_bytes", "storage_key", "thumbnail_key", "created_at"}).
+		AddRow("1", "motor-return", "2", "ban.jpg", "image/jpeg", 2048, "motor-return/2/abc.jpg", "motor-return/2/abc_thumb.jpg", "0000"))
+
+	actualAttachment, err := repository.GetById("1")
+	assert.Nil(t, err)
+	assert.Equal(t, expectAttachment, actualAttachment)
+}
+
+func TestDelete_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewAttachmentRepository(db)
+
+	mock.ExpectExec("DELETE FROM attachment WHERE id = \\$1;").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.Delete("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
@@ -0,0 +1,229 @@
+package attachmentUsecase
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"bike-rent-express/pkg/storage"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/attachment"
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const thumbnailSize = 256
+
+// allowedTypes maps the sniffed content types that may be uploaded to their stored extension.
+var allowedTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type attachmentUsecase struct {
+	attachmentRepository attachment.AttachmentRepository
+	blobStore            storage.BlobStore
+	maxUploadSize        int64
+	urlExpiry            time.Duration
+}
+
+func NewAttachmentUsecase(attachmentRepository attachment.AttachmentRepository, blobStore storage.BlobStore, maxUploadSize int64, urlExpiry time.Duration) attachment.AttachmentUsecase {
+	return &attachmentUsecase{attachmentRepository, blobStore, maxUploadSize, urlExpiry}
+}
+
+func (a *attachmentUsecase) UploadAttachment(request attachmentDto.UploadAttachmentRequest) (attachmentDto.Attachment, error) {
+	if request.Size > a.maxUploadSize {
+		return attachmentDto.Attachment{}, errors.New("1")
+	}
+
+	contentType, err := utils.SniffContentType(request.File)
+	if err != nil {
+		return attachmentDto.Attachment{}, err
+	}
+
+	extension, ok := allowedTypes[contentType]
+	if !ok {
+		return attachmentDto.Attachment{}, errors.New("2")
+	}
+
+	var thumbnail []byte
+	if strings.HasPrefix(contentType, "image/") {
+		thumbnail, err = utils.Thumbnail(request.File, thumbnailSize)
+		if err != nil {
+			return attachmentDto.Attachment{}, errors.New("2")
+		}
+
+		if _, err := request.File.Seek(0, io.SeekStart); err != nil {
+			return attachmentDto.Attachment{}, err
+		}
+	}
+
+	exists, err := a.attachmentRepository.OwnerExists(request.OwnerType, request.OwnerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return attachmentDto.Attachment{}, errors.New("3")
+		}
+		return attachmentDto.Attachment{}, err
+	}
+
+	if !exists {
+		return attachmentDto.Attachment{}, errors.New("3")
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return attachmentDto.Attachment{}, err
+	}
+
+	newAttachment := attachmentDto.Attachment{
+		OwnerType:   request.OwnerType,
+		OwnerID:     request.OwnerID,
+		FileName:    path.Base(request.FileName),
+		ContentType: contentType,
+		Size:        request.Size,
+		StorageKey:  request.OwnerType + "/" + request.OwnerID + "/" + name + extension,
+	}
+
+	if err := a.blobStore.Put(newAttachment.StorageKey, request.File, request.Size, contentType); err != nil {
+		return attachmentDto.Attachment{}, err
+	}
+
+	if thumbnail != nil {
+		newAttachment.ThumbnailKey = request.OwnerType + "/" + request.OwnerID + "/" + name + "_thumb.jpg"
+		if err := a.blobStore.Put(newAttachment.ThumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+			a.removeBlobs(newAttachment)
+			return attachmentDto.Attachment{}, err
+		}
+	}
+
+	savedAttachment, err := a.attachmentRepository.Add(newAttachment)
+	if err != nil {
+		a.removeBlobs(newAttachment)
+		return attachmentDto.Attachment{}, err
+	}
+
+	return a.signURLs(savedAttachment)
+}
+
+func (a *attachmentUsecase) GetAttachmentsByOwner(ownerType, ownerID string) ([]attachmentDto.Attachment, error) {
+	attachments, err := a.attachmentRepository.GetByOwner(ownerType, ownerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for i := range attachments {
+		attachments[i], err = a.signURLs(attachments[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return attachments, nil
+}
+
+func (a *attachmentUsecase) IsDisputeOwner(disputeID, username string) (bool, error) {
+	owned, err := a.attachmentRepository.DisputeOwnedBy(disputeID, username)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return owned, nil
+}
+
+func (a *attachmentUsecase) DeleteAttachment(ownerType, ownerID, id string) error {
+	attachment, err := a.attachmentRepository.GetById(id)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return errors.New("1")
+		}
+		return err
+	}
+
+	if attachment.OwnerType != ownerType || attachment.OwnerID != ownerID {
+		return errors.New("1")
+	}
+
+	if err := a.attachmentRepository.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("1")
+		}
+		return err
+	}
+
+	a.removeBlobs(attachment)
+	return nil
+}
+
+// OpenSignedFile serves blobs of stores that sign their URLs themselves, e.g. the local store.
+func (a *attachmentUsecase) OpenSignedFile(request attachmentDto.SignedFileRequest) (io.ReadCloser, string, error) {
+	verifier, ok := a.blobStore.(storage.SignatureVerifier)
+	if !ok {
+		return nil, "", errors.New("2")
+	}
+
+	if err := verifier.Verify(request.Key, request.Expires, request.Signature); err != nil {
+		return nil, "", errors.New("1")
+	}
+
+	file, err := a.blobStore.Get(request.Key)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return nil, "", errors.New("2")
+		}
+		return nil, "", err
+	}
+
+	return file, mime.TypeByExtension(path.Ext(request.Key)), nil
+}
+
+func (a *attachmentUsecase) signURLs(attachment attachmentDto.Attachment) (attachmentDto.Attachment, error) {
+	var err error
+	attachment.URL, err = a.blobStore.SignedURL(attachment.StorageKey, a.urlExpiry)
+	if err != nil {
+		return attachment, err
+	}
+
+	if attachment.ThumbnailKey != "" {
+		attachment.ThumbnailURL, err = a.blobStore.SignedURL(attachment.ThumbnailKey, a.urlExpiry)
+		if err != nil {
+			return attachment, err
+		}
+	}
+
+	return attachment, nil
+}
+
+// removeBlobs is best effort, a leftover blob is only wasted space.
+func (a *attachmentUsecase) removeBlobs(attachment attachmentDto.Attachment) {
+	for _, key := range []string{attachment.StorageKey, attachment.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := a.blobStore.Delete(key); err != nil {
+			log.Error().Err(err).Msg("failed to delete blob " + key)
+		}
+	}
+}
+
+func randomName() (string, error) {
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(name), nil
+}
@@ -0,0 +1,218 @@
+package attachmentUsecase
+
+import (
+	"bike-rent-express/model/dto/attachmentDto"
+	"bike-rent-express/pkg/storage"
+	"bike-rent-express/src/attachment"
+	"bytes"
+	"database/sql"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *mockAttachmentRepository) OwnerExists(ownerType, ownerID string) (bool, error) {
+	args := m.Called(ownerType, ownerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAttachmentRepository) DisputeOwnedBy(disputeID, username string) (bool, error) {
+	args := m.Called(disputeID, username)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAttachmentRepository) Add(newAttachment attachmentDto.Attachment) (attachmentDto.Attachment, error) {
+	args := m.Called(newAttachment)
+	return args.Get(0).(attachmentDto.Attachment), args.Error(1)
+}
+
+func (m *mockAttachmentRepository) GetByOwner(ownerType, ownerID string) ([]attachmentDto.Attachment, error) {
+	args := m.Called(ownerType, ownerID)
+	return args.Get(0).([]attachmentDto.Attachment), args.Error(1)
+}
+
+func (m *mockAttachmentRepository) GetById(id string) (attachmentDto.Attachment, error) {
+	args := m.Called(id)
+	return args.Get(0).(attachmentDto.Attachment), args.Error(1)
+}
+
+func (m *mockAttachmentRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type mockBlobStore struct {
+	mock.Mock
+}
+
+func (m *mockBlobStore) Put(key string, data io.Reader, size int64, contentType string) error {
+	args := m.Called(key, data, size, contentType)
+	return args.Error(0)
+}
+
+func (m *mockBlobStore) Get(key string) (io.ReadCloser, error) {
+	args := m.Called(key)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockBlobStore) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *mockBlobStore) SignedURL(key string, expiry time.Duration) (string, error) {
+	args := m.Called(key, expiry)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockBlobStore) Verify(key, expires, signature string) error {
+	args := m.Called(key, expires, signature)
+	return args.Error(0)
+}
+
+func pngFile() *bytes.Reader {
+	var buffer bytes.Buffer
+	png.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, 640, 480)))
+	return bytes.NewReader(buffer.Bytes())
+}
+
+type AttachmentUsecaseTestSuite struct {
+	suite.Suite
+	mockAttachmentRepository *mockAttachmentRepository
+	mockBlobStore            *mockBlobStore
+	attachmentUC             attachment.AttachmentUsecase
+}
+
+func (suite *AttachmentUsecaseTestSuite) SetupTest() {
+	suite.mockAttachmentRepository = new(mockAttachmentRepository)
+	suite.mockBlobStore = new(mockBlobStore)
+	suite.attachmentUC = NewAttachmentUsecase(suite.mockAttachmentRepository, suite.mockBlobStore, 1<<20, time.Minute)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUploadAttachment_SuccessImage() {
+	file := pngFile()
+	request := attachmentDto.UploadAttachmentRequest{OwnerType: "motor-return", OwnerID: "2", FileName: "../ban.png", Size: file.Size(), File: file}
+
+	suite.mockAttachmentRepository.On("OwnerExists", "motor-return", "2").Return(true, nil)
+	suite.mockBlobStore.On("Put", mock.MatchedBy(func(key string) bool { return strings.HasSuffix(key, ".png") }), file, file.Size(), "image/png").Return(nil)
+	suite.mockBlobStore.On("Put", mock.MatchedBy(func(key string) bool { return strings.HasSuffix(key, "_thumb.jpg") }), mock.Anything, mock.Anything, "image/jpeg").Return(nil)
+	suite.mockAttachmentRepository.On("Add", mock.MatchedBy(func(newAttachment attachmentDto.Attachment) bool {
+		return newAttachment.FileName == "ban.png" && newAttachment.ContentType == "image/png" && newAttachment.ThumbnailKey != ""
+	})).Return(attachmentDto.Attachment{ID: "1", StorageKey: "a.png", ThumbnailKey: "a_thumb.jpg"}, nil)
+	suite.mockBlobStore.On("SignedURL", "a.png", time.Minute).Return("/file?key=a.png", nil)
+	suite.mockBlobStore.On("SignedURL", "a_thumb.jpg", time.Minute).Return("/file?key=a_thumb.jpg", nil)
+
+	actualAttachment, err := suite.attachmentUC.UploadAttachment(request)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "/file?key=a.png", actualAttachment.URL)
+	assert.Equal(suite.T(), "/file?key=a_thumb.jpg", actualAttachment.ThumbnailURL)
+
+	offset, _ := file.Seek(0, io.SeekCurrent)
+	assert.Equal(suite.T(), int64(0), offset)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUploadAttachment_FailedTooLarge() {
+	request := attachmentDto.UploadAttachmentRequest{OwnerType: "motor-return", OwnerID: "2", Size: 2 << 20, File: pngFile()}
+
+	_, err := suite.attachmentUC.UploadAttachment(request)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUploadAttachment_FailedContentType() {
+	file := strings.NewReader("<html><script>alert(1)</script></html>")
+	request := attachmentDto.UploadAttachmentRequest{OwnerType: "motor-return", OwnerID: "2", FileName: "ban.jpg", Size: file.Size(), File: file}
+
+	_, err := suite.attachmentUC.UploadAttachment(request)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUploadAttachment_FailedOwnerNotFound() {
+	file := strings.NewReader("%PDF-1.4 surat jalan")
+	request := attachmentDto.UploadAttachmentRequest{OwnerType: "motor-pickup", OwnerID: "2", FileName: "surat.pdf", Size: file.Size(), File: file}
+
+	suite.mockAttachmentRepository.On("OwnerExists", "motor-pickup", "2").Return(false, nil)
+
+	_, err := suite.attachmentUC.UploadAttachment(request)
+	assert.Equal(suite.T(), errors.New("3"), err)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestIsDisputeOwner_InvalidID() {
+	suite.mockAttachmentRepository.On("DisputeOwnedBy", "x", "customer").Return(false, errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	owned, err := suite.attachmentUC.IsDisputeOwner("x", "customer")
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), owned)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUploadAttachment_FailedAddRemovesBlob() {
+	file := strings.NewReader("%PDF-1.4 surat jalan")
+	request := attachmentDto.UploadAttachmentRequest{OwnerType: "motor-pickup", OwnerID: "2", FileName: "surat.pdf", Size: file.Size(), File: file}
+
+	suite.mockAttachmentRepository.On("OwnerExists", "motor-pickup", "2").Return(true, nil)
+	suite.mockBlobStore.On("Put", mock.Anything, file, file.Size(), "application/pdf").Return(nil)
+	suite.mockAttachmentRepository.On("Add", mock.Anything).Return(attachmentDto.Attachment{}, errors.New("error sql"))
+	suite.mockBlobStore.On("Delete", mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "motor-pickup/2/") })).Return(nil)
+
+	_, err := suite.attachmentUC.UploadAttachment(request)
+	assert.Error(suite.T(), err)
+	suite.mockBlobStore.AssertNumberOfCalls(suite.T(), "Delete", 1)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestDeleteAttachment_Success() {
+	suite.mockAttachmentRepository.On("GetById", "1").Return(attachmentDto.Attachment{ID: "1", OwnerType: "motor-return", OwnerID: "2", StorageKey: "a.png", ThumbnailKey: "a_thumb.jpg"}, nil)
+	suite.mockAttachmentRepository.On("Delete", "1").Return(nil)
+	suite.mockBlobStore.On("Delete", "a.png").Return(nil)
+	suite.mockBlobStore.On("Delete", "a_thumb.jpg").Return(nil)
+
+	err := suite.attachmentUC.DeleteAttachment("motor-return", "2", "1")
+	assert.Nil(suite.T(), err)
+	suite.mockBlobStore.AssertExpectations(suite.T())
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestDeleteAttachment_FailedOtherOwner() {
+	suite.mockAttachmentRepository.On("GetById", "1").Return(attachmentDto.Attachment{ID: "1", OwnerType: "motor-return", OwnerID: "3"}, nil)
+
+	err := suite.attachmentUC.DeleteAttachment("motor-return", "2", "1")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestDeleteAttachment_FailedNotFound() {
+	suite.mockAttachmentRepository.On("GetById", "1").Return(attachmentDto.Attachment{}, sql.ErrNoRows)
+
+	err := suite.attachmentUC.DeleteAttachment("motor-return", "2", "1")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestOpenSignedFile_Success() {
+	request := attachmentDto.SignedFileRequest{Key: "motor-return/2/a.pdf", Expires: "1", Signature: "abc"}
+	suite.mockBlobStore.On("Verify", request.Key, request.Expires, request.Signature).Return(nil)
+	suite.mockBlobStore.On("Get", request.Key).Return(io.NopCloser(strings.NewReader("%PDF")), nil)
+
+	_, contentType, err := suite.attachmentUC.OpenSignedFile(request)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "application/pdf", contentType)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestOpenSignedFile_FailedSignature() {
+	request := attachmentDto.SignedFileRequest{Key: "motor-return/2/a.pdf", Expires: "1", Signature: "abc"}
+	suite.mockBlobStore.On("Verify", request.Key, request.Expires, request.Signature).Return(storage.ErrInvalidSignature)
+
+	_, _, err := suite.attachmentUC.OpenSignedFile(request)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func TestAttachmentUsecase(t *testing.T) {
+	suite.Run(t, new(AttachmentUsecaseTestSuite))
+}
@@ -0,0 +1,133 @@
+package bookingDelivery
+
+import (
+	"bike-rent-express/model/dto/bookingDto"
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/booking"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bookingDelivery struct {
+	bookingUC booking.BookingUsecase
+}
+
+func NewBookingDelivery(v1Group *gin.RouterGroup, bookingUC booking.BookingUsecase) {
+	handler := bookingDelivery{bookingUC}
+
+	bookingGroup := v1Group.Group("/users/bookings")
+	{
+		bookingGroup.POST("", middleware.JWTAuth("ADMIN", "USER"), handler.CreateBooking)
+		bookingGroup.POST("/quote", middleware.JWTAuth("ADMIN", "USER"), handler.QuoteBooking)
+		bookingGroup.GET("/:id", middleware.JWTAuth("ADMIN", "USER"), handler.GetBookingById)
+	}
+}
+
+func (b *bookingDelivery) CreateBooking(c *gin.Context) {
+	var bookingRequest bookingDto.CreateBookingRequest
+
+	c.ShouldBindJSON(&bookingRequest)
+	if err := utils.Validated(bookingRequest); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
+		return
+	}
+	bookingRequest.Actor = c.GetString("username")
+
+	newBooking, err := b.bookingUC.CreateBooking(bookingRequest)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "motor not available", "01", "01")
+			return
+		}
+
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "balance is not enought", "01", "02")
+			return
+		}
+
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "active rental limit reached", "01", "03")
+			return
+		}
+
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(c, nil, "customer has overdue rentals", "01", "04")
+			return
+		}
+
+		if err.Error() == "5" {
+			json.NewResponseBadRequest(c, nil, "customer has unpaid charges", "01", "05")
+			return
+		}
+
+		if err.Error() == "7" {
+			json.NewResponseBadRequest(c, nil, "customer account is not active", "01", "07")
+			return
+		}
+
+		if err.Error() == "8" {
+			json.NewResponseBadRequest(c, nil, "motor is not at the pickup branch", "01", "08")
+			return
+		}
+
+		if err.Error() == "9" {
+			json.NewResponseBadRequest(c, nil, "one-way rental is not offered between the branches", "01", "09")
+			return
+		}
+
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(c, newBooking, "Booking Created", "01", "01")
+}
+
+func (b *bookingDelivery) QuoteBooking(c *gin.Context) {
+	var bookingRequest bookingDto.CreateBookingRequest
+
+	c.ShouldBindJSON(&bookingRequest)
+	if err := utils.Validated(bookingRequest); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "02", "01")
+		return
+	}
+
+	quote, err := b.bookingUC.QuoteBooking(bookingRequest)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "motor not available", "02", "02")
+			return
+		}
+
+		if err.Error() == "8" {
+			json.NewResponseBadRequest(c, nil, "motor is not at the pickup branch", "02", "03")
+			return
+		}
+
+		if err.Error() == "9" {
+			json.NewResponseBadRequest(c, nil, "one-way rental is not offered between the branches", "02", "04")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, quote, "Success get booking quote", "02", "01")
+}
+
+func (b *bookingDelivery) GetBookingById(c *gin.Context) {
+	id := c.Param("id")
+
+	bookingDetail, err := b.bookingUC.GetBookingById(id)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(c, nil, "Data not found", "03", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, bookingDetail, "Success get booking by id", "03", "02")
+}
@@ -0,0 +1,186 @@
+package bookingDelivery
+
+import (
+	"bike-rent-express/model/dto/bookingDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var bookingRequest = bookingDto.CreateBookingRequest{
+	UserID:          "1",
+	MotorVehicleIds: []string{"a", "b"},
+	StartDate:       "2024-08-13T09:00:00+07:00",
+	EndDate:         "2024-08-15T09:00:00+07:00",
+}
+
+// bookedBy is the request as the delivery passes it on, with the username of the token
+func bookedBy(request bookingDto.CreateBookingRequest, actor string) bookingDto.CreateBookingRequest {
+	request.Actor = actor
+	return request
+}
+
+var expectBooking = bookingDto.Booking{
+	ID:         "10",
+	UserID:     "1",
+	StartDate:  "2024-08-13",
+	EndDate:    "2024-08-15",
+	TotalPrice: 6000,
+	CreatedAt:  "0000",
+	UpdatedAt:  "0000",
+}
+
+type mockBookingUC struct {
+	mock.Mock
+}
+
+func (m *mockBookingUC) CreateBooking(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.Booking, error) {
+	args := m.Called(bookingRequest)
+	return args.Get(0).(bookingDto.Booking), args.Error(1)
+}
+
+func (m *mockBookingUC) GetBookingById(id string) (bookingDto.Booking, error) {
+	args := m.Called(id)
+	return args.Get(0).(bookingDto.Booking), args.Error(1)
+}
+
+func (m *mockBookingUC) QuoteBooking(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.BookingQuote, error) {
+	args := m.Called(bookingRequest)
+	return args.Get(0).(bookingDto.BookingQuote), args.Error(1)
+}
+
+type BookingDeliveryTestSuite struct {
+	suite.Suite
+	mockBookingUC *mockBookingUC
+	router        *gin.Engine
+	accessToken   string
+}
+
+func (suite *BookingDeliveryTestSuite) SetupTest() {
+	suite.mockBookingUC = new(mockBookingUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewBookingDelivery(v1, suite.mockBookingUC)
+
+	token, err := middleware.GenerateTokenJwt("user", "USER")
+	suite.Require().Nil(err)
+	suite.accessToken = "Bearer " + token
+}
+
+func (suite *BookingDeliveryTestSuite) TestCreateBooking_Success() {
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Booking Created","data":{"id":"10","user_id":"1","employee_id":"","start_date":"2024-08-13","end_date":"2024-08-15","total_price":6000,"transactions":null,"created_at":"0000","updated_at":"0000"}}`
+	suite.mockBookingUC.On("CreateBooking", bookedBy(bookingRequest, "user")).Return(expectBooking, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(bookingRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/bookings", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BookingDeliveryTestSuite) TestCreateBooking_FailedDuplicateVehicle() {
+	expectResponse := `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"MotorVehicleIds","message":"field must not contain duplicates"}]}`
+	request := bookingRequest
+	request.MotorVehicleIds = []string{"a", "a"}
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/bookings", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BookingDeliveryTestSuite) TestCreateBooking_FailedMotorNotAvailable() {
+	expectResponse := `{"responseCode":"4000101","responseMessage":"motor not available"}`
+	suite.mockBookingUC.On("CreateBooking", bookedBy(bookingRequest, "user")).Return(bookingDto.Booking{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(bookingRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/bookings", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BookingDeliveryTestSuite) TestCreateBooking_Failed() {
+	expectResponse := `{"responseCode":"5000101","responseMessage":"internal server error","error":"error"}`
+	suite.mockBookingUC.On("CreateBooking", bookedBy(bookingRequest, "user")).Return(bookingDto.Booking{}, errors.New("error"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(bookingRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/bookings", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 500, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BookingDeliveryTestSuite) TestQuoteBooking_Success() {
+	expectResponse := `{"responseCode":"2000201","responseMessage":"Success get booking quote","data":{"hours":48,"items":[{"motor_vehicle_id":"a","price":2000},{"motor_vehicle_id":"b","price":4000}],"total_price":6000}}`
+	quote := bookingDto.BookingQuote{
+		Hours: 48,
+		Items: []bookingDto.BookingQuoteItem{
+			{MotorVehicleId: "a", Price: 2000},
+			{MotorVehicleId: "b", Price: 4000},
+		},
+		TotalPrice: 6000,
+	}
+	suite.mockBookingUC.On("QuoteBooking", bookingRequest).Return(quote, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(bookingRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/bookings/quote", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BookingDeliveryTestSuite) TestGetBookingById_Success() {
+	expectResponse := `{"responseCode":"2000302","responseMessage":"Success get booking by id","data":{"id":"10","user_id":"1","employee_id":"","start_date":"2024-08-13","end_date":"2024-08-15","total_price":6000,"transactions":null,"created_at":"0000","updated_at":"0000"}}`
+	suite.mockBookingUC.On("GetBookingById", expectBooking.ID).Return(expectBooking, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/bookings/"+expectBooking.ID, nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BookingDeliveryTestSuite) TestGetBookingById_FailedDataNotFound() {
+	expectResponse := `{"responseCode":"2000301","responseMessage":"Data not found"}`
+	suite.mockBookingUC.On("GetBookingById", expectBooking.ID).Return(bookingDto.Booking{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/bookings/"+expectBooking.ID, nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestBookingDelivery(t *testing.T) {
+	suite.Run(t, new(BookingDeliveryTestSuite))
+}
@@ -0,0 +1,17 @@
+package booking
+
+import "bike-rent-express/model/dto/bookingDto"
+
+type (
+	BookingRepository interface {
+		Add(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.CreateBookingRequest, error)
+		GetById(id string) (bookingDto.Booking, error)
+		Quote(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.BookingQuote, error)
+	}
+
+	BookingUsecase interface {
+		CreateBooking(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.Booking, error)
+		GetBookingById(id string) (bookingDto.Booking, error)
+		QuoteBooking(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.BookingQuote, error)
+	}
+)
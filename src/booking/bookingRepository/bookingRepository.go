@@ -0,0 +1,274 @@
+package bookingRepository
+
+import (
+	"bike-rent-express/model/dto/bookingDto"
+	"bike-rent-express/model/dto/transactionDto"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/Users/usersRepository"
+	"bike-rent-express/src/booking"
+	"bike-rent-express/src/dispatch/dispatchRepository"
+	"bike-rent-express/src/transaction/transactionRepository"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+type bookingRepository struct {
+	db               *sql.DB
+	maxActiveRentals int
+}
+
+// errors returned when a vehicle can not be routed, keyed by the error of the rental route
+var routeErrors = map[string]error{
+	"6": errors.New("8"),
+	"7": errors.New("9"),
+}
+
+// route of a booked vehicle, from the branch it is picked up at to the one it is returned to
+type route struct {
+	pickupBranchID string
+	returnBranchID string
+	oneWayFee      int
+}
+
+func NewBookingRepository(db *sql.DB, maxActiveRentals int) booking.BookingRepository {
+	return &bookingRepository{db, maxActiveRentals}
+}
+
+// unitQuery picks the cheapest available unit of a vehicle model among the ones not taken yet
+// and not in maintenance during the rental
+var unitQuery = "SELECT mv.id FROM " + utils.UnitModelJoin + " WHERE mv.vehicle_model_id = $1 AND mv.status = 'AVAILABLE' AND mv.deleted_at IS NULL AND NOT (mv.id::text = ANY(string_to_array($2, ','))) AND " + utils.NoMaintenance("$3", "$4") + " AND " + utils.DocumentsValid("$4") + " ORDER BY COALESCE(mv.price, vm.default_price), mv.id LIMIT 1"
+
+// assignUnits returns the motor vehicles given by id followed by a unit for every vehicle
+// model requested, or "1" when a model has no unit left. Locking skips the units another
+// booking is taking at the same time instead of waiting for it.
+func assignUnits(q utils.RowQuerier, bookingRequest bookingDto.CreateBookingRequest, startDate, endDate time.Time, lock bool) ([]string, error) {
+	query := unitQuery
+	if lock {
+		query += " FOR UPDATE OF mv SKIP LOCKED"
+	}
+
+	motorVehicleIds := append([]string{}, bookingRequest.MotorVehicleIds...)
+	for _, vehicleModelId := range bookingRequest.VehicleModelIds {
+		var motorVehicleId string
+		if err := q.QueryRow(query, vehicleModelId, strings.Join(motorVehicleIds, ","), startDate, endDate).Scan(&motorVehicleId); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("1")
+			}
+			return nil, err
+		}
+		motorVehicleIds = append(motorVehicleIds, motorVehicleId)
+	}
+
+	if len(motorVehicleIds) == 0 {
+		return nil, errors.New("1")
+	}
+
+	return motorVehicleIds, nil
+}
+
+// resolveRoute routes a booked vehicle standing at currentBranchID the same way a rental is
+// routed, a vehicle not at the pickup branch gives "8" and a missing one-way route "9"
+func resolveRoute(q utils.RowQuerier, bookingRequest bookingDto.CreateBookingRequest, currentBranchID string) (route, error) {
+	pickupBranchID, returnBranchID, oneWayFee, err := transactionRepository.RouteBranches(q, bookingRequest.PickupBranchID, bookingRequest.ReturnBranchID, currentBranchID)
+	if err != nil {
+		if routeErr, ok := routeErrors[err.Error()]; ok {
+			return route{}, routeErr
+		}
+		return route{}, err
+	}
+
+	return route{pickupBranchID, returnBranchID, oneWayFee}, nil
+}
+
+// Add rents every requested motor vehicle in one database transaction, either all of them
+// are booked and paid or none of them.
+func (b *bookingRepository) Add(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.CreateBookingRequest, error) {
+	startDate, endDate, hours, err := utils.RentalPeriod(bookingRequest.StartDate, bookingRequest.EndDate)
+	if err != nil {
+		return bookingRequest, err
+	}
+
+	tx, err := b.db.Begin()
+	if err != nil {
+		return bookingRequest, err
+	}
+
+	// a deactivated or closed account can not book
+	var userId string
+	query := "SELECT id FROM users WHERE id = $1 AND role = 'USER' AND deleted_at IS NULL FOR UPDATE;"
+	if err := tx.QueryRow(query, bookingRequest.UserID).Scan(&userId); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return bookingRequest, errors.New("7")
+		}
+		return bookingRequest, err
+	}
+
+	eligibility, err := usersRepository.CheckRentalEligibility(tx, userId, b.maxActiveRentals)
+	if err != nil {
+		tx.Rollback()
+		return bookingRequest, err
+	}
+
+	if eligibility.ActiveRentals+len(bookingRequest.MotorVehicleIds)+len(bookingRequest.VehicleModelIds) > eligibility.MaxActiveRentals {
+		tx.Rollback()
+		return bookingRequest, errors.New("3")
+	}
+
+	motorVehicleIds, err := assignUnits(tx, bookingRequest, startDate, endDate, true)
+	if err != nil {
+		tx.Rollback()
+		return bookingRequest, err
+	}
+
+	// lock the vehicles in a stable order so concurrent bookings can not deadlock each other
+	sort.Strings(motorVehicleIds)
+
+	prices := map[string]int{}
+	routes := map[string]route{}
+	totalPrice := 0
+	query = "SELECT " + utils.UnitPrices + ", COALESCE(mv.current_branch_id::text, '') FROM " + utils.UnitModelJoin + " WHERE mv.id = $1 AND mv.status = 'AVAILABLE' AND mv.deleted_at IS NULL AND " + utils.NoMaintenance("$2", "$3") + " AND " + utils.DocumentsValid("$3") + " FOR UPDATE OF mv;"
+	for _, motorVehicleId := range motorVehicleIds {
+		var dailyPrice, hourlyPrice int
+		var currentBranchID string
+		if err := tx.QueryRow(query, motorVehicleId, startDate, endDate).Scan(&dailyPrice, &hourlyPrice, &currentBranchID); err != nil {
+			tx.Rollback()
+			return bookingRequest, errors.New("1")
+		}
+
+		vehicleRoute, err := resolveRoute(tx, bookingRequest, currentBranchID)
+		if err != nil {
+			tx.Rollback()
+			return bookingRequest, err
+		}
+		routes[motorVehicleId] = vehicleRoute
+
+		prices[motorVehicleId] = utils.RentalPrice(hours, dailyPrice, hourlyPrice)
+		totalPrice += prices[motorVehicleId] + vehicleRoute.oneWayFee
+	}
+
+	var userBalance int
+	query = "SELECT amount FROM balance WHERE user_id = $1 FOR UPDATE;"
+	if err := tx.QueryRow(query, userId).Scan(&userBalance); err != nil {
+		tx.Rollback()
+		return bookingRequest, err
+	}
+
+	if userBalance < totalPrice {
+		tx.Rollback()
+		return bookingRequest, errors.New("2")
+	}
+
+	query = "UPDATE balance SET amount = $1 WHERE user_id = $2;"
+	if _, err := tx.Exec(query, userBalance-totalPrice, userId); err != nil {
+		tx.Rollback()
+		return bookingRequest, err
+	}
+
+	query = "INSERT INTO booking(user_id, start_date, end_date, total_price) VALUES($1, $2, $3, $4) RETURNING id;"
+	if err := tx.QueryRow(query, userId, startDate, endDate, totalPrice).Scan(&bookingRequest.ID); err != nil {
+		tx.Rollback()
+		return bookingRequest, err
+	}
+
+	for _, motorVehicleId := range motorVehicleIds {
+		var transactionId string
+		vehicleRoute := routes[motorVehicleId]
+		query = "INSERT INTO transaction(user_id, motor_vehicle_id, start_date, end_date, price, booking_id, pickup_branch_id, return_branch_id, one_way_fee, km_limit, overage_per_km) VALUES($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, NULLIF($8, '')::uuid, $9, (SELECT " + utils.KmAllowance("$3", "$4") + " FROM motor_vehicle mv WHERE mv.id = $2), (SELECT overage_per_km FROM motor_vehicle WHERE id = $2)) RETURNING id;"
+		if err := tx.QueryRow(query, userId, motorVehicleId, startDate, endDate, prices[motorVehicleId], bookingRequest.ID, vehicleRoute.pickupBranchID, vehicleRoute.returnBranchID, vehicleRoute.oneWayFee).Scan(&transactionId); err != nil {
+			tx.Rollback()
+			return bookingRequest, err
+		}
+
+		if err := dispatchRepository.AddTasks(tx, transactionId); err != nil {
+			tx.Rollback()
+			return bookingRequest, err
+		}
+
+		query = utils.VehicleStatusEvent("NOT_AVAILABLE", "")
+		if _, err := tx.Exec(query, motorVehicleId, bookingRequest.Actor, "booked", transactionId); err != nil {
+			tx.Rollback()
+			return bookingRequest, err
+		}
+
+		query = "UPDATE motor_vehicle SET status = 'NOT_AVAILABLE' WHERE id = $1;"
+		if _, err := tx.Exec(query, motorVehicleId); err != nil {
+			tx.Rollback()
+			return bookingRequest, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return bookingRequest, err
+	}
+
+	return bookingRequest, nil
+}
+
+func (b *bookingRepository) GetById(id string) (bookingDto.Booking, error) {
+	var bookingDetail bookingDto.Booking
+	query := "SELECT id, user_id, COALESCE(employee_id::text, ''), start_date, end_date, total_price, created_at, updated_at FROM booking WHERE id = $1;"
+	if err := b.db.QueryRow(query, id).Scan(&bookingDetail.ID, &bookingDetail.UserID, &bookingDetail.EmployeeId, &bookingDetail.StartDate, &bookingDetail.EndDate, &bookingDetail.TotalPrice, &bookingDetail.CreatedAt, &bookingDetail.UpdatedAt); err != nil {
+		return bookingDetail, err
+	}
+
+	query = "SELECT id, user_id, motor_vehicle_id, start_date, end_date, price, created_at, updated_at, COALESCE(employee_id::text, ''), status, late_fee FROM transaction WHERE booking_id = $1 ORDER BY motor_vehicle_id;"
+	rows, err := b.db.Query(query, id)
+	if err != nil {
+		return bookingDetail, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction transactionDto.Transaction
+		if err := rows.Scan(&transaction.ID, &transaction.UserID, &transaction.MotorVehicleId, &transaction.StartDate, &transaction.EndDate, &transaction.Price, &transaction.CreatedAt, &transaction.UpdatedAt, &transaction.EmployeeId, &transaction.Status, &transaction.LateFee); err != nil {
+			return bookingDetail, err
+		}
+		bookingDetail.Transactions = append(bookingDetail.Transactions, transaction)
+	}
+
+	return bookingDetail, nil
+}
+
+func (b *bookingRepository) Quote(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.BookingQuote, error) {
+	var quote bookingDto.BookingQuote
+
+	startDate, endDate, hours, err := utils.RentalPeriod(bookingRequest.StartDate, bookingRequest.EndDate)
+	if err != nil {
+		return quote, err
+	}
+	quote.Hours = hours
+
+	motorVehicleIds, err := assignUnits(b.db, bookingRequest, startDate, endDate, false)
+	if err != nil {
+		return quote, err
+	}
+
+	query := "SELECT " + utils.UnitPrices + ", COALESCE(mv.current_branch_id::text, '') FROM " + utils.UnitModelJoin + " WHERE mv.id = $1 AND mv.status = 'AVAILABLE' AND mv.deleted_at IS NULL AND " + utils.NoMaintenance("$2", "$3") + " AND " + utils.DocumentsValid("$3") + ";"
+	for i, motorVehicleId := range motorVehicleIds {
+		var dailyPrice, hourlyPrice int
+		var currentBranchID string
+		if err := b.db.QueryRow(query, motorVehicleId, startDate, endDate).Scan(&dailyPrice, &hourlyPrice, &currentBranchID); err != nil {
+			return quote, errors.New("1")
+		}
+
+		vehicleRoute, err := resolveRoute(b.db, bookingRequest, currentBranchID)
+		if err != nil {
+			return quote, err
+		}
+
+		item := bookingDto.BookingQuoteItem{MotorVehicleId: motorVehicleId, Price: utils.RentalPrice(hours, dailyPrice, hourlyPrice), OneWayFee: vehicleRoute.oneWayFee}
+		// the unit of a model is only picked for the price, the booking may get another one
+		if i >= len(bookingRequest.MotorVehicleIds) {
+			item.MotorVehicleId = ""
+			item.VehicleModelId = bookingRequest.VehicleModelIds[i-len(bookingRequest.MotorVehicleIds)]
+		}
+		quote.Items = append(quote.Items, item)
+		quote.TotalPrice += item.Price + item.OneWayFee
+	}
+
+	return quote, nil
+}
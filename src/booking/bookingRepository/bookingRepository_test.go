@@ -0,0 +1,486 @@
+package bookingRepository
+
+import (
+	"bike-rent-express/model/dto/bookingDto"
+	"bike-rent-express/model/dto/transactionDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var bookingRequest = bookingDto.CreateBookingRequest{
+	UserID:          "1",
+	MotorVehicleIds: []string{"b", "a"},
+	StartDate:       "2024-08-13T09:00:00+07:00",
+	EndDate:         "2024-08-15T09:00:00+07:00",
+}
+
+func expectRentalEligibility(mock sqlmock.Sqlmock, activeRentals, maxActiveRentals, overdueRentals int) {
+	query := "SELECT id FROM users WHERE .+ FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(bookingRequest.UserID)
+	mock.ExpectQuery(query).WithArgs(bookingRequest.UserID).WillReturnRows(rows)
+
+	query = "SELECT (.+) FROM users WHERE users.id = \\$1 AND users.role = 'USER';"
+	rows = sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+"}).AddRow(bookingRequest.UserID, activeRentals, maxActiveRentals, overdueRentals, 0)
+	mock.ExpectQuery(query).WithArgs(bookingRequest.UserID, 3).WillReturnRows(rows)
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	// vehicles are locked in sorted order
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(2000, 0, "branch-1"))
+
+	query = "SELECT amount FROM balance WHERE .+ FOR UPDATE;"
+	mock.ExpectQuery(query).WithArgs(bookingRequest.UserID).WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(10000))
+
+	query = "UPDATE balance SET amount"
+	mock.ExpectExec(query).WithArgs(4000, bookingRequest.UserID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "INSERT INTO booking(.+) RETURNING id;"
+	mock.ExpectQuery(query).WithArgs(bookingRequest.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), 6000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+
+	mock.ExpectQuery("INSERT INTO transaction(.+) RETURNING id;").WithArgs(bookingRequest.UserID, "a", sqlmock.AnyArg(), sqlmock.AnyArg(), 2000, "10", "branch-1", "branch-1", 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ta"))
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs("ta").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+)").WithArgs("a", "", "booked", "ta").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'NOT_AVAILABLE'").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO transaction(.+) RETURNING id;").WithArgs(bookingRequest.UserID, "b", sqlmock.AnyArg(), sqlmock.AnyArg(), 4000, "10", "branch-1", "branch-1", 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("tb"))
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs("tb").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+)").WithArgs("b", "", "booked", "tb").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'NOT_AVAILABLE'").WithArgs("b").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	result, err := repository.Add(bookingRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, "10", result.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedInvalidPeriod(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.EndDate = request.StartDate
+
+	_, err = repository.Add(request)
+	assert.Error(t, err)
+}
+
+func TestAdd_FailedCustomerNotActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	query := "SELECT id FROM users WHERE id = \\$1 AND role = 'USER' AND deleted_at IS NULL FOR UPDATE;"
+	mock.ExpectQuery(query).WithArgs(bookingRequest.UserID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(bookingRequest)
+	assert.Equal(t, "7", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedRentalLimitReached(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	// one rental left but two vehicles requested
+	expectRentalEligibility(mock, 2, 3, 0)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(bookingRequest)
+	assert.Equal(t, "3", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedOverdueRentals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 1, 3, 1)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(bookingRequest)
+	assert.Equal(t, "4", err.Error())
+}
+
+func TestAdd_FailedMotorNotAvailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(bookingRequest)
+	assert.Equal(t, "1", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedMotorNotAtPickupBranch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.PickupBranchID = "branch-2"
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectRollback()
+
+	_, err = repository.Add(request)
+	assert.Equal(t, "8", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedNoOneWayRoute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.ReturnBranchID = "branch-2"
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery("SELECT fee FROM one_way_fee").WithArgs("branch-1", "branch-2").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(request)
+	assert.Equal(t, "9", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_OneWay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.MotorVehicleIds = []string{"a"}
+	request.ReturnBranchID = "branch-2"
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery("SELECT fee FROM one_way_fee").WithArgs("branch-1", "branch-2").WillReturnRows(sqlmock.NewRows([]string{"fee"}).AddRow(500))
+	mock.ExpectQuery("SELECT amount FROM balance WHERE .+ FOR UPDATE;").WithArgs(request.UserID).WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(10000))
+	mock.ExpectExec("UPDATE balance SET amount").WithArgs(7500, request.UserID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO booking(.+) RETURNING id;").WithArgs(request.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), 2500).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+	mock.ExpectQuery("INSERT INTO transaction(.+) RETURNING id;").WithArgs(request.UserID, "a", sqlmock.AnyArg(), sqlmock.AnyArg(), 2000, "10", "branch-1", "branch-2", 500).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ta"))
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs("ta").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+)").WithArgs("a", "", "booked", "ta").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'NOT_AVAILABLE'").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := repository.Add(request)
+	assert.Nil(t, err)
+	assert.Equal(t, "10", result.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedBalanceNotEnough(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(2000, 0, "branch-1"))
+
+	query = "SELECT amount FROM balance WHERE .+ FOR UPDATE;"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(5000))
+	mock.ExpectRollback()
+
+	_, err = repository.Add(bookingRequest)
+	assert.Equal(t, "2", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedInsertTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(2000, 0, "branch-1"))
+	mock.ExpectQuery("SELECT amount FROM balance WHERE .+ FOR UPDATE;").WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(10000))
+	mock.ExpectExec("UPDATE balance SET amount").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO booking(.+) RETURNING id;").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+	mock.ExpectQuery("INSERT INTO transaction(.+) RETURNING id;").WillReturnError(errors.New("error sql"))
+
+	// the whole booking is rolled back, including the balance already charged
+	mock.ExpectRollback()
+
+	_, err = repository.Add(bookingRequest)
+	assert.Error(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetById_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	expectTransaction := transactionDto.Transaction{
+		ID:             "2",
+		UserID:         "1",
+		MotorVehicleId: "a",
+		EmployeeId:     "1",
+		StartDate:      "2024-08-13",
+		EndDate:        "2024-08-15",
+		Price:          2000,
+		Status:         "ACTIVE",
+		CreatedAt:      "0000",
+		UpdatedAt:      "0000",
+	}
+	expectBooking := bookingDto.Booking{
+		ID:           "10",
+		UserID:       "1",
+		EmployeeId:   "1",
+		StartDate:    "2024-08-13",
+		EndDate:      "2024-08-15",
+		TotalPrice:   2000,
+		Transactions: []transactionDto.Transaction{expectTransaction},
+		CreatedAt:    "0000",
+		UpdatedAt:    "0000",
+	}
+
+	query := "SELECT (.+) FROM booking WHERE id = \\$1;"
+	rows := sqlmock.NewRows([]string{"id", "user_id", "employee_id", "start_date", "end_date", "total_price", "created_at", "updated_at"}).AddRow(expectBooking.ID, expectBooking.UserID, expectBooking.EmployeeId, expectBooking.StartDate, expectBooking.EndDate, expectBooking.TotalPrice, expectBooking.CreatedAt, expectBooking.UpdatedAt)
+	mock.ExpectQuery(query).WithArgs(expectBooking.ID).WillReturnRows(rows)
+
+	query = "SELECT (.+) FROM transaction WHERE booking_id = \\$1"
+	rows = sqlmock.NewRows([]string{"id", "user_id", "motor_vehicle_id", "start_date", "end_date", "price", "created_at", "updated_at", "employee_id", "status", "late_fee"}).AddRow(expectTransaction.ID, expectTransaction.UserID, expectTransaction.MotorVehicleId, expectTransaction.StartDate, expectTransaction.EndDate, expectTransaction.Price, expectTransaction.CreatedAt, expectTransaction.UpdatedAt, expectTransaction.EmployeeId, expectTransaction.Status, expectTransaction.LateFee)
+	mock.ExpectQuery(query).WithArgs(expectBooking.ID).WillReturnRows(rows)
+
+	actualBooking, err := repository.GetById(expectBooking.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectBooking, actualBooking)
+}
+
+func TestGetById_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	query := "SELECT (.+) FROM booking WHERE id = \\$1;"
+	mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.GetById("10")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestQuote_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	expectQuote := bookingDto.BookingQuote{
+		Hours: 48,
+		Items: []bookingDto.BookingQuoteItem{
+			{MotorVehicleId: "b", Price: 4000},
+			{MotorVehicleId: "a", Price: 2000},
+		},
+		TotalPrice: 6000,
+	}
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+"
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(2000, 0, "branch-1"))
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+
+	actualQuote, err := repository.Quote(bookingRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectQuote, actualQuote)
+}
+
+func TestQuote_FailedMotorNotAvailable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+
+	query := "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+"
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.Quote(bookingRequest)
+	assert.Equal(t, "1", err.Error())
+}
+
+func TestAdd_AssignsUnitOfModel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.MotorVehicleIds = []string{"b"}
+	request.VehicleModelIds = []string{"m"}
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT mv.id FROM motor_vehicle mv (.+) WHERE mv.vehicle_model_id = \\$1 (.+) LIMIT 1 FOR UPDATE OF mv SKIP LOCKED"
+	mock.ExpectQuery(query).WithArgs("m", "b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a"))
+
+	query = "SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	mock.ExpectQuery(query).WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+	mock.ExpectQuery(query).WithArgs("b", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(2000, 0, "branch-1"))
+
+	mock.ExpectQuery("SELECT amount FROM balance WHERE .+ FOR UPDATE;").WithArgs(request.UserID).WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(10000))
+	mock.ExpectExec("UPDATE balance SET amount").WithArgs(4000, request.UserID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO booking(.+) RETURNING id;").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+	mock.ExpectQuery("INSERT INTO transaction(.+) RETURNING id;").WithArgs(request.UserID, "a", sqlmock.AnyArg(), sqlmock.AnyArg(), 2000, "10", "branch-1", "branch-1", 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ta"))
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs("ta").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+)").WithArgs("a", "", "booked", "ta").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'NOT_AVAILABLE'").WithArgs("a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO transaction(.+) RETURNING id;").WithArgs(request.UserID, "b", sqlmock.AnyArg(), sqlmock.AnyArg(), 4000, "10", "branch-1", "branch-1", 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("tb"))
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs("tb").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+)").WithArgs("b", "", "booked", "tb").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'NOT_AVAILABLE'").WithArgs("b").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := repository.Add(request)
+	assert.Nil(t, err)
+	assert.Equal(t, "10", result.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedNoUnitOfModelLeft(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.MotorVehicleIds = nil
+	request.VehicleModelIds = []string{"m", "m"}
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, 0, 3, 0)
+
+	query := "SELECT mv.id FROM motor_vehicle mv (.+) SKIP LOCKED"
+	mock.ExpectQuery(query).WithArgs("m", "", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a"))
+	mock.ExpectQuery(query).WithArgs("m", "a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(request)
+	assert.Equal(t, "1", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestQuote_VehicleModel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBookingRepository(db, 3)
+	request := bookingRequest
+	request.MotorVehicleIds = nil
+	request.VehicleModelIds = []string{"m"}
+
+	mock.ExpectQuery("SELECT mv.id FROM motor_vehicle mv (.+) LIMIT 1$").WithArgs("m", "", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a"))
+	mock.ExpectQuery("SELECT COALESCE(.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+").WithArgs("a", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(1000, 0, "branch-1"))
+
+	quote, err := repository.Quote(request)
+	assert.Nil(t, err)
+	assert.Equal(t, []bookingDto.BookingQuoteItem{{VehicleModelId: "m", Price: 2000}}, quote.Items)
+}
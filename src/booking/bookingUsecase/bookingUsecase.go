@@ -0,0 +1,67 @@
+package bookingUsecase
+
+import (
+	"bike-rent-express/model/dto/bookingDto"
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/src/booking"
+	"bike-rent-express/src/dispatch"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+type bookingUsecase struct {
+	bookingRepository booking.BookingRepository
+	dispatchUsecase   dispatch.DispatchUsecase
+}
+
+func NewBookingUsecase(bookingRepository booking.BookingRepository, dispatchUsecase dispatch.DispatchUsecase) booking.BookingUsecase {
+	return &bookingUsecase{bookingRepository, dispatchUsecase}
+}
+
+func (b *bookingUsecase) CreateBooking(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.Booking, error) {
+	newBooking, err := b.bookingRepository.Add(bookingRequest)
+	if err != nil {
+		return bookingDto.Booking{}, err
+	}
+
+	bookingDetail, err := b.bookingRepository.GetById(newBooking.ID)
+	if err != nil {
+		return bookingDetail, err
+	}
+
+	// every rental of the booking is dispatched like a single one, a task nobody could take
+	// now is left to the dispatch job
+	for i, transaction := range bookingDetail.Transactions {
+		tasks, err := b.dispatchUsecase.AssignPending(transaction.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to assign the tasks of transaction " + transaction.ID)
+		}
+
+		for _, task := range tasks {
+			if task.Type == dispatchDto.TaskDelivery {
+				bookingDetail.Transactions[i].EmployeeId = task.EmployeeID
+			}
+		}
+	}
+
+	return bookingDetail, nil
+}
+
+func (b *bookingUsecase) GetBookingById(id string) (bookingDto.Booking, error) {
+	bookingDetail, err := b.bookingRepository.GetById(id)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return bookingDetail, errors.New("1")
+		}
+		return bookingDetail, err
+	}
+
+	return bookingDetail, nil
+}
+
+func (b *bookingUsecase) QuoteBooking(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.BookingQuote, error) {
+	return b.bookingRepository.Quote(bookingRequest)
+}
@@ -0,0 +1,138 @@
+package bookingUsecase
+
+import (
+	"bike-rent-express/model/dto/bookingDto"
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/model/dto/transactionDto"
+	"bike-rent-express/src/booking"
+	"bike-rent-express/src/dispatch/dispatchMock"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var bookingRequest = bookingDto.CreateBookingRequest{
+	UserID:          "1",
+	MotorVehicleIds: []string{"a", "b"},
+	StartDate:       "2024-08-13T09:00:00+07:00",
+	EndDate:         "2024-08-15T09:00:00+07:00",
+}
+
+var expectBooking = bookingDto.Booking{
+	ID:         "10",
+	UserID:     "1",
+	StartDate:  "2024-08-13",
+	EndDate:    "2024-08-15",
+	TotalPrice: 6000,
+	Transactions: []transactionDto.Transaction{
+		{ID: "ta", UserID: "1", MotorVehicleId: "a", Price: 2000, Status: "ACTIVE"},
+		{ID: "tb", UserID: "1", MotorVehicleId: "b", Price: 4000, Status: "ACTIVE"},
+	},
+}
+
+type mockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *mockBookingRepository) Add(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.CreateBookingRequest, error) {
+	args := m.Called(bookingRequest)
+	return args.Get(0).(bookingDto.CreateBookingRequest), args.Error(1)
+}
+
+func (m *mockBookingRepository) GetById(id string) (bookingDto.Booking, error) {
+	args := m.Called(id)
+	return args.Get(0).(bookingDto.Booking), args.Error(1)
+}
+
+func (m *mockBookingRepository) Quote(bookingRequest bookingDto.CreateBookingRequest) (bookingDto.BookingQuote, error) {
+	args := m.Called(bookingRequest)
+	return args.Get(0).(bookingDto.BookingQuote), args.Error(1)
+}
+
+type BookingUsecaseTestSuite struct {
+	suite.Suite
+	mockBookingRepository *mockBookingRepository
+	mockDispatchUsecase   *dispatchMock.DispatchUsecase
+	bookingUC             booking.BookingUsecase
+}
+
+func (suite *BookingUsecaseTestSuite) SetupTest() {
+	suite.mockBookingRepository = new(mockBookingRepository)
+	suite.mockDispatchUsecase = new(dispatchMock.DispatchUsecase)
+	suite.bookingUC = NewBookingUsecase(suite.mockBookingRepository, suite.mockDispatchUsecase)
+}
+
+func (suite *BookingUsecaseTestSuite) TestCreateBooking_Success() {
+	createdRequest := bookingRequest
+	createdRequest.ID = expectBooking.ID
+	suite.mockBookingRepository.On("Add", bookingRequest).Return(createdRequest, nil)
+	// the usecase fills in the employees, keep expectBooking as it is
+	createdBooking := expectBooking
+	createdBooking.Transactions = append([]transactionDto.Transaction{}, expectBooking.Transactions...)
+	suite.mockBookingRepository.On("GetById", expectBooking.ID).Return(createdBooking, nil)
+	suite.mockDispatchUsecase.On("AssignPending", "ta").Return([]dispatchDto.Task{
+		{ID: "1", TransactionID: "ta", Type: dispatchDto.TaskDelivery, EmployeeID: "1", Status: dispatchDto.StatusAssigned},
+		{ID: "2", TransactionID: "ta", Type: dispatchDto.TaskPickup, EmployeeID: "2", Status: dispatchDto.StatusAssigned},
+	}, nil)
+	// nobody could take the tasks of the second motor yet, the dispatch job will
+	suite.mockDispatchUsecase.On("AssignPending", "tb").Return([]dispatchDto.Task(nil), nil)
+
+	actualBooking, err := suite.bookingUC.CreateBooking(bookingRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "1", actualBooking.Transactions[0].EmployeeId)
+	assert.Equal(suite.T(), "", actualBooking.Transactions[1].EmployeeId)
+	suite.mockDispatchUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *BookingUsecaseTestSuite) TestCreateBooking_SuccessDispatchFailed() {
+	createdRequest := bookingRequest
+	createdRequest.ID = expectBooking.ID
+	suite.mockBookingRepository.On("Add", bookingRequest).Return(createdRequest, nil)
+	suite.mockBookingRepository.On("GetById", expectBooking.ID).Return(expectBooking, nil)
+	suite.mockDispatchUsecase.On("AssignPending", mock.Anything).Return([]dispatchDto.Task(nil), errors.New("error"))
+
+	actualBooking, err := suite.bookingUC.CreateBooking(bookingRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectBooking, actualBooking)
+}
+
+func (suite *BookingUsecaseTestSuite) TestCreateBooking_Failed() {
+	suite.mockBookingRepository.On("Add", bookingRequest).Return(bookingRequest, errors.New("2"))
+
+	_, err := suite.bookingUC.CreateBooking(bookingRequest)
+	assert.Equal(suite.T(), "2", err.Error())
+	suite.mockBookingRepository.AssertNotCalled(suite.T(), "GetById", mock.Anything)
+	suite.mockDispatchUsecase.AssertNotCalled(suite.T(), "AssignPending", mock.Anything)
+}
+
+func (suite *BookingUsecaseTestSuite) TestGetBookingById_Success() {
+	suite.mockBookingRepository.On("GetById", expectBooking.ID).Return(expectBooking, nil)
+
+	actualBooking, err := suite.bookingUC.GetBookingById(expectBooking.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectBooking, actualBooking)
+}
+
+func (suite *BookingUsecaseTestSuite) TestGetBookingById_FailedNotFound() {
+	suite.mockBookingRepository.On("GetById", expectBooking.ID).Return(bookingDto.Booking{}, sql.ErrNoRows)
+
+	_, err := suite.bookingUC.GetBookingById(expectBooking.ID)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *BookingUsecaseTestSuite) TestQuoteBooking_Success() {
+	expectQuote := bookingDto.BookingQuote{Hours: 48, TotalPrice: 6000}
+	suite.mockBookingRepository.On("Quote", bookingRequest).Return(expectQuote, nil)
+
+	actualQuote, err := suite.bookingUC.QuoteBooking(bookingRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectQuote, actualQuote)
+}
+
+func TestBookingUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(BookingUsecaseTestSuite))
+}
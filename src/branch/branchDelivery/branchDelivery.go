@@ -0,0 +1,176 @@
+package branchDelivery
+
+import (
+	"bike-rent-express/model/dto/branchDto"
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/branch"
+
+	"github.com/gin-gonic/gin"
+)
+
+type branchDelivery struct {
+	branchUC branch.BranchUsecase
+}
+
+func NewBranchDelivery(v1Group *gin.RouterGroup, branchUC branch.BranchUsecase) {
+	handler := branchDelivery{branchUC}
+
+	branchGroup := v1Group.Group("/branches")
+	{
+		branchGroup.GET("", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER", "USER"), handler.GetAllBranch)
+		branchGroup.GET("/:id", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER", "USER"), handler.GetBranchById)
+		branchGroup.POST("", middleware.JWTAuth("ADMIN"), handler.CreateBranch)
+		branchGroup.PUT("/:id", middleware.JWTAuth("ADMIN"), handler.UpdateBranch)
+		branchGroup.DELETE("/:id", middleware.JWTAuth("ADMIN"), handler.DeleteBranch)
+
+		branchGroup.GET("/one-way-fees", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER", "USER"), handler.GetOneWayFees)
+		branchGroup.PUT("/one-way-fees", middleware.JWTAuth("ADMIN"), handler.SetOneWayFee)
+		branchGroup.DELETE("/one-way-fees/:from-branch-id/:to-branch-id", middleware.JWTAuth("ADMIN"), handler.DeleteOneWayFee)
+	}
+}
+
+func (b *branchDelivery) GetAllBranch(ctx *gin.Context) {
+	branches, err := b.branchUC.GetAllBranch()
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	if len(branches) == 0 {
+		json.NewResponseSuccess(ctx, nil, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, branches, "Success get all branch", "01", "02")
+}
+
+func (b *branchDelivery) GetBranchById(ctx *gin.Context) {
+	branch, err := b.branchUC.GetBranchById(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "02", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, branch, "Success get branch by id", "02", "02")
+}
+
+func (b *branchDelivery) CreateBranch(ctx *gin.Context) {
+	var request branchDto.BranchRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "03", "01")
+		return
+	}
+
+	branch, err := b.branchUC.CreateBranch(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(ctx, nil, "branch name already exists", "03", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseCreated(ctx, branch, "Branch created", "03", "01")
+}
+
+func (b *branchDelivery) UpdateBranch(ctx *gin.Context) {
+	var request branchDto.BranchRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "04", "01")
+		return
+	}
+
+	branch, err := b.branchUC.UpdateBranch(ctx.Param("id"), request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "04", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "branch name already exists", "04", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "04", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, branch, "Branch updated", "04", "02")
+}
+
+func (b *branchDelivery) DeleteBranch(ctx *gin.Context) {
+	if err := b.branchUC.DeleteBranch(ctx.Param("id")); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "05", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "the branch still has motor vehicles", "05", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "05", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Branch deleted", "05", "02")
+}
+
+func (b *branchDelivery) GetOneWayFees(ctx *gin.Context) {
+	fees, err := b.branchUC.GetOneWayFees()
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "06", "01")
+		return
+	}
+
+	if len(fees) == 0 {
+		json.NewResponseSuccess(ctx, nil, "Data empty", "06", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, fees, "Success get one-way fees", "06", "02")
+}
+
+func (b *branchDelivery) SetOneWayFee(ctx *gin.Context) {
+	var request branchDto.OneWayFeeRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "07", "01")
+		return
+	}
+
+	fee, err := b.branchUC.SetOneWayFee(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(ctx, nil, "Branch not found", "07", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "07", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, fee, "One-way fee saved", "07", "01")
+}
+
+func (b *branchDelivery) DeleteOneWayFee(ctx *gin.Context) {
+	if err := b.branchUC.DeleteOneWayFee(ctx.Param("from-branch-id"), ctx.Param("to-branch-id")); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "08", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "08", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "One-way fee deleted", "08", "02")
+}
@@ -0,0 +1,208 @@
+package branchDelivery
+
+import (
+	"bike-rent-express/model/dto/branchDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectBranch = branchDto.Branch{
+	ID:        "1",
+	Name:      "Padang",
+	Address:   "Jl. Sudirman No. 1",
+	CreatedAt: "0000",
+	UpdatedAt: "0000",
+}
+
+var branchRequest = branchDto.BranchRequest{
+	Name:    "Padang",
+	Address: "Jl. Sudirman No. 1",
+}
+
+var oneWayFeeRequest = branchDto.OneWayFeeRequest{
+	FromBranchID: "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+	ToBranchID:   "6c2a3b4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e",
+	Fee:          15000,
+}
+
+type mockBranchUC struct {
+	mock.Mock
+}
+
+func (m *mockBranchUC) CreateBranch(branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	args := m.Called(branch)
+	return args.Get(0).(branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchUC) GetAllBranch() ([]branchDto.Branch, error) {
+	args := m.Called()
+	return args.Get(0).([]branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchUC) GetBranchById(id string) (branchDto.Branch, error) {
+	args := m.Called(id)
+	return args.Get(0).(branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchUC) UpdateBranch(id string, branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	args := m.Called(id, branch)
+	return args.Get(0).(branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchUC) DeleteBranch(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockBranchUC) GetOneWayFees() ([]branchDto.OneWayFee, error) {
+	args := m.Called()
+	return args.Get(0).([]branchDto.OneWayFee), args.Error(1)
+}
+
+func (m *mockBranchUC) SetOneWayFee(fee branchDto.OneWayFeeRequest) (branchDto.OneWayFee, error) {
+	args := m.Called(fee)
+	return args.Get(0).(branchDto.OneWayFee), args.Error(1)
+}
+
+func (m *mockBranchUC) DeleteOneWayFee(fromBranchID, toBranchID string) error {
+	args := m.Called(fromBranchID, toBranchID)
+	return args.Error(0)
+}
+
+type BranchDeliveryTestSuite struct {
+	suite.Suite
+	mockBranchUC *mockBranchUC
+	router       *gin.Engine
+	accessToken  string
+}
+
+func (suite *BranchDeliveryTestSuite) SetupTest() {
+	suite.mockBranchUC = new(mockBranchUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewBranchDelivery(v1, suite.mockBranchUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.accessToken = "Bearer " + token
+}
+
+func (suite *BranchDeliveryTestSuite) TestCreateBranch_Success() {
+	expectResponse := `{"responseCode":"2010301","responseMessage":"Branch created","data":{"id":"1","name":"Padang","address":"Jl. Sudirman No. 1","created_at":"0000","updated_at":"0000"}}`
+	suite.mockBranchUC.On("CreateBranch", branchRequest).Return(expectBranch, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(branchRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/branches", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BranchDeliveryTestSuite) TestCreateBranch_FailedDuplicate() {
+	expectResponse := `{"responseCode":"4000302","responseMessage":"branch name already exists"}`
+	suite.mockBranchUC.On("CreateBranch", branchRequest).Return(branchDto.Branch{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(branchRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/branches", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BranchDeliveryTestSuite) TestGetBranchById_Success() {
+	expectResponse := `{"responseCode":"2000202","responseMessage":"Success get branch by id","data":{"id":"1","name":"Padang","address":"Jl. Sudirman No. 1","created_at":"0000","updated_at":"0000"}}`
+	suite.mockBranchUC.On("GetBranchById", "1").Return(expectBranch, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/branches/1", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BranchDeliveryTestSuite) TestDeleteBranch_FailedHasMotorVehicles() {
+	expectResponse := `{"responseCode":"4000501","responseMessage":"the branch still has motor vehicles"}`
+	suite.mockBranchUC.On("DeleteBranch", "1").Return(errors.New("2"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/branches/1", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BranchDeliveryTestSuite) TestGetOneWayFees_Success() {
+	expectResponse := `{"responseCode":"2000602","responseMessage":"Success get one-way fees","data":[{"from_branch_id":"1","to_branch_id":"2","fee":15000,"updated_at":"0000"}]}`
+	suite.mockBranchUC.On("GetOneWayFees").Return([]branchDto.OneWayFee{{FromBranchID: "1", ToBranchID: "2", Fee: 15000, UpdatedAt: "0000"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/branches/one-way-fees", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BranchDeliveryTestSuite) TestSetOneWayFee_FailedSameBranch() {
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(branchDto.OneWayFeeRequest{FromBranchID: oneWayFeeRequest.FromBranchID, ToBranchID: oneWayFeeRequest.FromBranchID, Fee: 15000})
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/branches/one-way-fees", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"4000701"`)
+	assert.Contains(suite.T(), w.Body.String(), `"field":"FromBranchID"`)
+}
+
+func (suite *BranchDeliveryTestSuite) TestSetOneWayFee_FailedBranchNotFound() {
+	expectResponse := `{"responseCode":"4000702","responseMessage":"Branch not found"}`
+	suite.mockBranchUC.On("SetOneWayFee", oneWayFeeRequest).Return(branchDto.OneWayFee{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(oneWayFeeRequest)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/branches/one-way-fees", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *BranchDeliveryTestSuite) TestDeleteOneWayFee_Success() {
+	expectResponse := `{"responseCode":"2000802","responseMessage":"One-way fee deleted"}`
+	suite.mockBranchUC.On("DeleteOneWayFee", "1", "2").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/branches/one-way-fees/1/2", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestBranchDelivery(t *testing.T) {
+	suite.Run(t, new(BranchDeliveryTestSuite))
+}
@@ -0,0 +1,27 @@
+package branch
+
+import "bike-rent-express/model/dto/branchDto"
+
+type (
+	BranchRepository interface {
+		Add(branch branchDto.BranchRequest) (branchDto.Branch, error)
+		GetAll() ([]branchDto.Branch, error)
+		GetById(id string) (branchDto.Branch, error)
+		Update(id string, branch branchDto.BranchRequest) (branchDto.Branch, error)
+		Delete(id string) error
+		GetOneWayFees() ([]branchDto.OneWayFee, error)
+		SetOneWayFee(fee branchDto.OneWayFeeRequest) (branchDto.OneWayFee, error)
+		DeleteOneWayFee(fromBranchID, toBranchID string) error
+	}
+
+	BranchUsecase interface {
+		CreateBranch(branch branchDto.BranchRequest) (branchDto.Branch, error)
+		GetAllBranch() ([]branchDto.Branch, error)
+		GetBranchById(id string) (branchDto.Branch, error)
+		UpdateBranch(id string, branch branchDto.BranchRequest) (branchDto.Branch, error)
+		DeleteBranch(id string) error
+		GetOneWayFees() ([]branchDto.OneWayFee, error)
+		SetOneWayFee(fee branchDto.OneWayFeeRequest) (branchDto.OneWayFee, error)
+		DeleteOneWayFee(fromBranchID, toBranchID string) error
+	}
+)
@@ -0,0 +1,149 @@
+package branchRepository
+
+import (
+	"bike-rent-express/model/dto/branchDto"
+	"bike-rent-express/src/branch"
+	"database/sql"
+	"errors"
+)
+
+type branchRepository struct {
+	db *sql.DB
+}
+
+func NewBranchRepository(db *sql.DB) branch.BranchRepository {
+	return &branchRepository{db}
+}
+
+func (b *branchRepository) Add(branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	var newBranch branchDto.Branch
+	query := "INSERT INTO branch(name, address) VALUES ($1, $2) RETURNING id, name, address, created_at, updated_at;"
+	err := b.db.QueryRow(query, branch.Name, branch.Address).Scan(&newBranch.ID, &newBranch.Name, &newBranch.Address, &newBranch.CreatedAt, &newBranch.UpdatedAt)
+	if err != nil {
+		return newBranch, err
+	}
+
+	return newBranch, nil
+}
+
+func (b *branchRepository) GetAll() ([]branchDto.Branch, error) {
+	query := "SELECT id, name, address, created_at, updated_at FROM branch WHERE deleted_at IS NULL ORDER BY name;"
+	rows, err := b.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var branches []branchDto.Branch
+	for rows.Next() {
+		var branch branchDto.Branch
+		if err := rows.Scan(&branch.ID, &branch.Name, &branch.Address, &branch.CreatedAt, &branch.UpdatedAt); err != nil {
+			return nil, err
+		}
+		branches = append(branches, branch)
+	}
+
+	return branches, nil
+}
+
+func (b *branchRepository) GetById(id string) (branchDto.Branch, error) {
+	var branch branchDto.Branch
+	query := "SELECT id, name, address, created_at, updated_at FROM branch WHERE id = $1 AND deleted_at IS NULL;"
+	if err := b.db.QueryRow(query, id).Scan(&branch.ID, &branch.Name, &branch.Address, &branch.CreatedAt, &branch.UpdatedAt); err != nil {
+		return branch, err
+	}
+
+	return branch, nil
+}
+
+func (b *branchRepository) Update(id string, branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	var updatedBranch branchDto.Branch
+	query := "UPDATE branch SET name = $1, address = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 AND deleted_at IS NULL RETURNING id, name, address, created_at, updated_at;"
+	err := b.db.QueryRow(query, branch.Name, branch.Address, id).Scan(&updatedBranch.ID, &updatedBranch.Name, &updatedBranch.Address, &updatedBranch.CreatedAt, &updatedBranch.UpdatedAt)
+	if err != nil {
+		return updatedBranch, err
+	}
+
+	return updatedBranch, nil
+}
+
+// Delete refuses with "2" while a motor vehicle still belongs to or stands at the branch.
+func (b *branchRepository) Delete(id string) error {
+	var inUse bool
+	query := "SELECT EXISTS(SELECT 1 FROM motor_vehicle WHERE (home_branch_id = $1 OR current_branch_id = $1) AND deleted_at IS NULL);"
+	if err := b.db.QueryRow(query, id).Scan(&inUse); err != nil {
+		return err
+	}
+
+	if inUse {
+		return errors.New("2")
+	}
+
+	query = "UPDATE branch SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;"
+	result, err := b.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (b *branchRepository) GetOneWayFees() ([]branchDto.OneWayFee, error) {
+	query := "SELECT from_branch_id, to_branch_id, fee, updated_at FROM one_way_fee ORDER BY from_branch_id, to_branch_id;"
+	rows, err := b.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fees []branchDto.OneWayFee
+	for rows.Next() {
+		var fee branchDto.OneWayFee
+		if err := rows.Scan(&fee.FromBranchID, &fee.ToBranchID, &fee.Fee, &fee.UpdatedAt); err != nil {
+			return nil, err
+		}
+		fees = append(fees, fee)
+	}
+
+	return fees, nil
+}
+
+func (b *branchRepository) SetOneWayFee(fee branchDto.OneWayFeeRequest) (branchDto.OneWayFee, error) {
+	var oneWayFee branchDto.OneWayFee
+	query := `INSERT INTO one_way_fee(from_branch_id, to_branch_id, fee) VALUES ($1, $2, $3)
+		ON CONFLICT (from_branch_id, to_branch_id) DO UPDATE SET fee = EXCLUDED.fee, updated_at = CURRENT_TIMESTAMP
+		RETURNING from_branch_id, to_branch_id, fee, updated_at;`
+	err := b.db.QueryRow(query, fee.FromBranchID, fee.ToBranchID, fee.Fee).Scan(&oneWayFee.FromBranchID, &oneWayFee.ToBranchID, &oneWayFee.Fee, &oneWayFee.UpdatedAt)
+	if err != nil {
+		return oneWayFee, err
+	}
+
+	return oneWayFee, nil
+}
+
+func (b *branchRepository) DeleteOneWayFee(fromBranchID, toBranchID string) error {
+	result, err := b.db.Exec("DELETE FROM one_way_fee WHERE from_branch_id = $1 AND to_branch_id = $2;", fromBranchID, toBranchID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
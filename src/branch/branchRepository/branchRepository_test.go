@@ -0,0 +1,231 @@
+package branchRepository
+
+import (
+	"bike-rent-express/model/dto/branchDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var expectBranch = branchDto.Branch{
+	ID:        "1",
+	Name:      "Padang",
+	Address:   "Jl. Sudirman No. 1",
+	CreatedAt: "0000",
+	UpdatedAt: "0000",
+}
+
+var branchRequest = branchDto.BranchRequest{
+	Name:    "Padang",
+	Address: "Jl. Sudirman No. 1",
+}
+
+var expectOneWayFee = branchDto.OneWayFee{
+	FromBranchID: "1",
+	ToBranchID:   "2",
+	Fee:          15000,
+	UpdatedAt:    "0000",
+}
+
+func branchRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "name", "address", "created_at", "updated_at"}).
+		AddRow(expectBranch.ID, expectBranch.Name, expectBranch.Address, expectBranch.CreatedAt, expectBranch.UpdatedAt)
+}
+
+func oneWayFeeRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"from_branch_id", "to_branch_id", "fee", "updated_at"}).
+		AddRow(expectOneWayFee.FromBranchID, expectOneWayFee.ToBranchID, expectOneWayFee.Fee, expectOneWayFee.UpdatedAt)
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "INSERT INTO branch(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs(branchRequest.Name, branchRequest.Address).WillReturnRows(branchRows())
+
+	actualBranch, err := repository.Add(branchRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectBranch, actualBranch)
+}
+
+func TestAdd_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "INSERT INTO branch(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	_, err = repository.Add(branchRequest)
+	assert.Error(t, err)
+}
+
+func TestGetAll_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "SELECT (.+) FROM branch WHERE deleted_at IS NULL"
+	mock.ExpectQuery(query).WillReturnRows(branchRows())
+
+	actualBranches, err := repository.GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []branchDto.Branch{expectBranch}, actualBranches)
+}
+
+func TestGetById_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "SELECT (.+) FROM branch WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.GetById("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "UPDATE branch SET (.+) WHERE id = \\$3 AND deleted_at IS NULL RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs(branchRequest.Name, branchRequest.Address, "1").WillReturnRows(branchRows())
+
+	actualBranch, err := repository.Update("1", branchRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectBranch, actualBranch)
+}
+
+func TestDelete_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "SELECT EXISTS(.+) FROM motor_vehicle .+"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	query = "UPDATE branch SET deleted_at = CURRENT_TIMESTAMP WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.Delete("1")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete_FailedHasMotorVehicles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "SELECT EXISTS(.+) FROM motor_vehicle .+"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	err = repository.Delete("1")
+	assert.Equal(t, errors.New("2"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "SELECT EXISTS(.+) FROM motor_vehicle .+"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	query = "UPDATE branch SET deleted_at .+"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.Delete("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestGetOneWayFees_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "SELECT (.+) FROM one_way_fee ORDER BY .+"
+	mock.ExpectQuery(query).WillReturnRows(oneWayFeeRows())
+
+	actualFees, err := repository.GetOneWayFees()
+	assert.Nil(t, err)
+	assert.Equal(t, []branchDto.OneWayFee{expectOneWayFee}, actualFees)
+}
+
+func TestSetOneWayFee_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+	request := branchDto.OneWayFeeRequest{FromBranchID: "1", ToBranchID: "2", Fee: 15000}
+
+	query := "INSERT INTO one_way_fee(.+) ON CONFLICT (.+) DO UPDATE SET .+"
+	mock.ExpectQuery(query).WithArgs(request.FromBranchID, request.ToBranchID, request.Fee).WillReturnRows(oneWayFeeRows())
+
+	actualFee, err := repository.SetOneWayFee(request)
+	assert.Nil(t, err)
+	assert.Equal(t, expectOneWayFee, actualFee)
+}
+
+func TestDeleteOneWayFee_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewBranchRepository(db)
+
+	query := "DELETE FROM one_way_fee WHERE .+"
+	mock.ExpectExec(query).WithArgs("1", "2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.DeleteOneWayFee("1", "2")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
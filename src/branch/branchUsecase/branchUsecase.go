@@ -0,0 +1,93 @@
+package branchUsecase
+
+import (
+	"bike-rent-express/model/dto/branchDto"
+	"bike-rent-express/src/branch"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type branchUsecase struct {
+	branchRepository branch.BranchRepository
+}
+
+func NewBranchUsecase(branchRepository branch.BranchRepository) branch.BranchUsecase {
+	return &branchUsecase{branchRepository}
+}
+
+func (b *branchUsecase) CreateBranch(branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	newBranch, err := b.branchRepository.Add(branch)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return newBranch, errors.New("1")
+		}
+		return newBranch, err
+	}
+
+	return newBranch, nil
+}
+
+func (b *branchUsecase) GetAllBranch() ([]branchDto.Branch, error) {
+	return b.branchRepository.GetAll()
+}
+
+func (b *branchUsecase) GetBranchById(id string) (branchDto.Branch, error) {
+	branch, err := b.branchRepository.GetById(id)
+	if err != nil {
+		return branch, notFound(err)
+	}
+
+	return branch, nil
+}
+
+func (b *branchUsecase) UpdateBranch(id string, branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	updatedBranch, err := b.branchRepository.Update(id, branch)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return updatedBranch, errors.New("2")
+		}
+		return updatedBranch, notFound(err)
+	}
+
+	return updatedBranch, nil
+}
+
+func (b *branchUsecase) DeleteBranch(id string) error {
+	if err := b.branchRepository.Delete(id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+func (b *branchUsecase) GetOneWayFees() ([]branchDto.OneWayFee, error) {
+	return b.branchRepository.GetOneWayFees()
+}
+
+func (b *branchUsecase) SetOneWayFee(fee branchDto.OneWayFeeRequest) (branchDto.OneWayFee, error) {
+	// deleted branches cannot get new routes
+	for _, id := range []string{fee.FromBranchID, fee.ToBranchID} {
+		if _, err := b.branchRepository.GetById(id); err != nil {
+			return branchDto.OneWayFee{}, notFound(err)
+		}
+	}
+
+	return b.branchRepository.SetOneWayFee(fee)
+}
+
+func (b *branchUsecase) DeleteOneWayFee(fromBranchID, toBranchID string) error {
+	if err := b.branchRepository.DeleteOneWayFee(fromBranchID, toBranchID); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// notFound maps a missing or malformed branch id to "1".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+		return errors.New("1")
+	}
+	return err
+}
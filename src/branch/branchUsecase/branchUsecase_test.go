@@ -0,0 +1,152 @@
+package branchUsecase
+
+import (
+	"bike-rent-express/model/dto/branchDto"
+	"bike-rent-express/src/branch"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectBranch = branchDto.Branch{
+	ID:      "1",
+	Name:    "Padang",
+	Address: "Jl. Sudirman No. 1",
+}
+
+var branchRequest = branchDto.BranchRequest{
+	Name:    "Padang",
+	Address: "Jl. Sudirman No. 1",
+}
+
+var oneWayFeeRequest = branchDto.OneWayFeeRequest{
+	FromBranchID: "1",
+	ToBranchID:   "2",
+	Fee:          15000,
+}
+
+type mockBranchRepository struct {
+	mock.Mock
+}
+
+func (m *mockBranchRepository) Add(branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	args := m.Called(branch)
+	return args.Get(0).(branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchRepository) GetAll() ([]branchDto.Branch, error) {
+	args := m.Called()
+	return args.Get(0).([]branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchRepository) GetById(id string) (branchDto.Branch, error) {
+	args := m.Called(id)
+	return args.Get(0).(branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchRepository) Update(id string, branch branchDto.BranchRequest) (branchDto.Branch, error) {
+	args := m.Called(id, branch)
+	return args.Get(0).(branchDto.Branch), args.Error(1)
+}
+
+func (m *mockBranchRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockBranchRepository) GetOneWayFees() ([]branchDto.OneWayFee, error) {
+	args := m.Called()
+	return args.Get(0).([]branchDto.OneWayFee), args.Error(1)
+}
+
+func (m *mockBranchRepository) SetOneWayFee(fee branchDto.OneWayFeeRequest) (branchDto.OneWayFee, error) {
+	args := m.Called(fee)
+	return args.Get(0).(branchDto.OneWayFee), args.Error(1)
+}
+
+func (m *mockBranchRepository) DeleteOneWayFee(fromBranchID, toBranchID string) error {
+	args := m.Called(fromBranchID, toBranchID)
+	return args.Error(0)
+}
+
+type BranchUsecaseTestSuite struct {
+	suite.Suite
+	mockBranchRepository *mockBranchRepository
+	branchUC             branch.BranchUsecase
+}
+
+func (suite *BranchUsecaseTestSuite) SetupTest() {
+	suite.mockBranchRepository = new(mockBranchRepository)
+	suite.branchUC = NewBranchUsecase(suite.mockBranchRepository)
+}
+
+func (suite *BranchUsecaseTestSuite) TestCreateBranch_Success() {
+	suite.mockBranchRepository.On("Add", branchRequest).Return(expectBranch, nil)
+
+	actualBranch, err := suite.branchUC.CreateBranch(branchRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectBranch, actualBranch)
+}
+
+func (suite *BranchUsecaseTestSuite) TestCreateBranch_FailedDuplicate() {
+	suite.mockBranchRepository.On("Add", branchRequest).Return(branchDto.Branch{}, errors.New("pq: duplicate key value violates unique constraint"))
+
+	_, err := suite.branchUC.CreateBranch(branchRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *BranchUsecaseTestSuite) TestGetBranchById_FailedNotFound() {
+	suite.mockBranchRepository.On("GetById", "1").Return(branchDto.Branch{}, sql.ErrNoRows)
+
+	_, err := suite.branchUC.GetBranchById("1")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *BranchUsecaseTestSuite) TestUpdateBranch_FailedDuplicate() {
+	suite.mockBranchRepository.On("Update", "1", branchRequest).Return(branchDto.Branch{}, errors.New("pq: duplicate key value violates unique constraint"))
+
+	_, err := suite.branchUC.UpdateBranch("1", branchRequest)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *BranchUsecaseTestSuite) TestDeleteBranch_FailedHasMotorVehicles() {
+	suite.mockBranchRepository.On("Delete", "1").Return(errors.New("2"))
+
+	err := suite.branchUC.DeleteBranch("1")
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *BranchUsecaseTestSuite) TestSetOneWayFee_Success() {
+	expectFee := branchDto.OneWayFee{FromBranchID: "1", ToBranchID: "2", Fee: 15000}
+	suite.mockBranchRepository.On("GetById", "1").Return(expectBranch, nil)
+	suite.mockBranchRepository.On("GetById", "2").Return(branchDto.Branch{ID: "2"}, nil)
+	suite.mockBranchRepository.On("SetOneWayFee", oneWayFeeRequest).Return(expectFee, nil)
+
+	actualFee, err := suite.branchUC.SetOneWayFee(oneWayFeeRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectFee, actualFee)
+}
+
+func (suite *BranchUsecaseTestSuite) TestSetOneWayFee_FailedBranchNotFound() {
+	suite.mockBranchRepository.On("GetById", "1").Return(expectBranch, nil)
+	suite.mockBranchRepository.On("GetById", "2").Return(branchDto.Branch{}, sql.ErrNoRows)
+
+	_, err := suite.branchUC.SetOneWayFee(oneWayFeeRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+	suite.mockBranchRepository.AssertNotCalled(suite.T(), "SetOneWayFee", oneWayFeeRequest)
+}
+
+func (suite *BranchUsecaseTestSuite) TestDeleteOneWayFee_FailedNotFound() {
+	suite.mockBranchRepository.On("DeleteOneWayFee", "1", "2").Return(sql.ErrNoRows)
+
+	err := suite.branchUC.DeleteOneWayFee("1", "2")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func TestBranchUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(BranchUsecaseTestSuite))
+}
@@ -0,0 +1,106 @@
+package damageCatalogDelivery
+
+import (
+	"bike-rent-express/model/dto/damageCatalogDto"
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/damageCatalog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type damageCatalogDelivery struct {
+	damageCatalogUC damageCatalog.DamageCatalogUsecase
+}
+
+func NewDamageCatalogDelivery(v1Group *gin.RouterGroup, damageCatalogUC damageCatalog.DamageCatalogUsecase) {
+	handler := damageCatalogDelivery{damageCatalogUC}
+
+	damageCatalogGroup := v1Group.Group("/damage-catalog")
+	{
+		damageCatalogGroup.POST("", middleware.JWTAuth("ADMIN"), handler.CreateDamageCatalog)
+		damageCatalogGroup.GET("", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.GetAllDamageCatalog)
+		damageCatalogGroup.PUT("/:id", middleware.JWTAuth("ADMIN"), handler.UpdateDamageCatalog)
+		damageCatalogGroup.DELETE("/:id", middleware.JWTAuth("ADMIN"), handler.DeleteDamageCatalog)
+	}
+}
+
+func (d *damageCatalogDelivery) CreateDamageCatalog(ctx *gin.Context) {
+	var request damageCatalogDto.DamageCatalogRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "01", "01")
+		return
+	}
+
+	damage, err := d.damageCatalogUC.CreateDamageCatalog(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(ctx, nil, "damage part and severity already exists", "01", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(ctx, damage, "Damage catalog created", "01", "01")
+}
+
+func (d *damageCatalogDelivery) GetAllDamageCatalog(ctx *gin.Context) {
+	damages, err := d.damageCatalogUC.GetAllDamageCatalog()
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	if len(damages) == 0 {
+		json.NewResponseSuccess(ctx, nil, "Data empty", "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, damages, "Success get all damage catalog", "02", "02")
+}
+
+func (d *damageCatalogDelivery) UpdateDamageCatalog(ctx *gin.Context) {
+	var request damageCatalogDto.DamageCatalogRequest
+	id := ctx.Param("id")
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "03", "01")
+		return
+	}
+
+	damage, err := d.damageCatalogUC.UpdateDamageCatalog(id, request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "03", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "damage part and severity already exists", "03", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, damage, "Damage catalog updated", "03", "02")
+}
+
+func (d *damageCatalogDelivery) DeleteDamageCatalog(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	if err := d.damageCatalogUC.DeleteDamageCatalog(id); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "04", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "04", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Damage catalog deleted", "04", "02")
+}
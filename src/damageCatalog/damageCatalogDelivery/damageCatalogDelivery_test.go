@@ -0,0 +1,157 @@
+package damageCatalogDelivery
+
+import (
+	"bike-rent-express/model/dto/damageCatalogDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectDamage = damageCatalogDto.DamageCatalog{
+	ID:         "1",
+	Part:       "Ban depan",
+	Severity:   "MODERATE",
+	RepairCost: 25000,
+	CreatedAt:  "0000",
+	UpdatedAt:  "0000",
+}
+
+var damageRequest = damageCatalogDto.DamageCatalogRequest{
+	Part:       "Ban depan",
+	Severity:   "MODERATE",
+	RepairCost: 25000,
+}
+
+type mockDamageCatalogUC struct {
+	mock.Mock
+}
+
+func (m *mockDamageCatalogUC) CreateDamageCatalog(damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	args := m.Called(damage)
+	return args.Get(0).(damageCatalogDto.DamageCatalog), args.Error(1)
+}
+
+func (m *mockDamageCatalogUC) GetAllDamageCatalog() ([]damageCatalogDto.DamageCatalog, error) {
+	args := m.Called()
+	return args.Get(0).([]damageCatalogDto.DamageCatalog), args.Error(1)
+}
+
+func (m *mockDamageCatalogUC) UpdateDamageCatalog(id string, damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	args := m.Called(id, damage)
+	return args.Get(0).(damageCatalogDto.DamageCatalog), args.Error(1)
+}
+
+func (m *mockDamageCatalogUC) DeleteDamageCatalog(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type DamageCatalogDeliveryTestSuite struct {
+	suite.Suite
+	mockDamageCatalogUC *mockDamageCatalogUC
+	router              *gin.Engine
+	accessToken         string
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) SetupTest() {
+	suite.mockDamageCatalogUC = new(mockDamageCatalogUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewDamageCatalogDelivery(v1, suite.mockDamageCatalogUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.accessToken = "Bearer " + token
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) TestCreateDamageCatalog_Success() {
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Damage catalog created","data":{"id":"1","part":"Ban depan","severity":"MODERATE","repair_cost":25000,"created_at":"0000","updated_at":"0000"}}`
+	suite.mockDamageCatalogUC.On("CreateDamageCatalog", damageRequest).Return(expectDamage, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(damageRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/damage-catalog", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) TestCreateDamageCatalog_FailedBind() {
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(damageCatalogDto.DamageCatalogRequest{Part: "Ban depan", Severity: "BROKEN"})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/damage-catalog", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"4000101"`)
+	assert.Contains(suite.T(), w.Body.String(), `"field":"Severity"`)
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) TestCreateDamageCatalog_FailedDuplicate() {
+	expectResponse := `{"responseCode":"4000102","responseMessage":"damage part and severity already exists"}`
+	suite.mockDamageCatalogUC.On("CreateDamageCatalog", damageRequest).Return(damageCatalogDto.DamageCatalog{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(damageRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/damage-catalog", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) TestGetAllDamageCatalog_Success() {
+	suite.mockDamageCatalogUC.On("GetAllDamageCatalog").Return([]damageCatalogDto.DamageCatalog{expectDamage}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/damage-catalog", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2000202"`)
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) TestUpdateDamageCatalog_FailedNotFound() {
+	expectResponse := `{"responseCode":"2000301","responseMessage":"Data not found"}`
+	suite.mockDamageCatalogUC.On("UpdateDamageCatalog", "1", damageRequest).Return(damageCatalogDto.DamageCatalog{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(damageRequest)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/damage-catalog/1", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DamageCatalogDeliveryTestSuite) TestDeleteDamageCatalog_Success() {
+	expectResponse := `{"responseCode":"2000402","responseMessage":"Damage catalog deleted"}`
+	suite.mockDamageCatalogUC.On("DeleteDamageCatalog", "1").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/damage-catalog/1", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestDamageCatalogDelivery(t *testing.T) {
+	suite.Run(t, new(DamageCatalogDeliveryTestSuite))
+}
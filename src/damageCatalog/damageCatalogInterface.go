@@ -0,0 +1,19 @@
+package damageCatalog
+
+import "bike-rent-express/model/dto/damageCatalogDto"
+
+type (
+	DamageCatalogRepository interface {
+		Add(damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error)
+		GetAll() ([]damageCatalogDto.DamageCatalog, error)
+		Update(id string, damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error)
+		Delete(id string) error
+	}
+
+	DamageCatalogUsecase interface {
+		CreateDamageCatalog(damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error)
+		GetAllDamageCatalog() ([]damageCatalogDto.DamageCatalog, error)
+		UpdateDamageCatalog(id string, damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error)
+		DeleteDamageCatalog(id string) error
+	}
+)
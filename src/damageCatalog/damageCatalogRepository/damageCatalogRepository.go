@@ -0,0 +1,77 @@
+package damageCatalogRepository
+
+import (
+	"bike-rent-express/model/dto/damageCatalogDto"
+	"bike-rent-express/src/damageCatalog"
+	"database/sql"
+)
+
+type damageCatalogRepository struct {
+	db *sql.DB
+}
+
+func NewDamageCatalogRepository(db *sql.DB) damageCatalog.DamageCatalogRepository {
+	return &damageCatalogRepository{db}
+}
+
+func (d *damageCatalogRepository) Add(damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	var newDamage damageCatalogDto.DamageCatalog
+	query := "INSERT INTO damage_catalog(part, severity, repair_cost) VALUES ($1, $2, $3) RETURNING id, part, severity, repair_cost, created_at, updated_at;"
+	err := d.db.QueryRow(query, damage.Part, damage.Severity, damage.RepairCost).Scan(&newDamage.ID, &newDamage.Part, &newDamage.Severity, &newDamage.RepairCost, &newDamage.CreatedAt, &newDamage.UpdatedAt)
+	if err != nil {
+		return newDamage, err
+	}
+
+	return newDamage, nil
+}
+
+func (d *damageCatalogRepository) GetAll() ([]damageCatalogDto.DamageCatalog, error) {
+	query := "SELECT id, part, severity, repair_cost, created_at, updated_at FROM damage_catalog WHERE deleted_at IS NULL ORDER BY part, severity;"
+	rows, err := d.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var damages []damageCatalogDto.DamageCatalog
+	for rows.Next() {
+		var damage damageCatalogDto.DamageCatalog
+		if err := rows.Scan(&damage.ID, &damage.Part, &damage.Severity, &damage.RepairCost, &damage.CreatedAt, &damage.UpdatedAt); err != nil {
+			return nil, err
+		}
+		damages = append(damages, damage)
+	}
+
+	return damages, nil
+}
+
+func (d *damageCatalogRepository) Update(id string, damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	var updatedDamage damageCatalogDto.DamageCatalog
+	query := "UPDATE damage_catalog SET part = $1, severity = $2, repair_cost = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $4 AND deleted_at IS NULL RETURNING id, part, severity, repair_cost, created_at, updated_at;"
+	err := d.db.QueryRow(query, damage.Part, damage.Severity, damage.RepairCost, id).Scan(&updatedDamage.ID, &updatedDamage.Part, &updatedDamage.Severity, &updatedDamage.RepairCost, &updatedDamage.CreatedAt, &updatedDamage.UpdatedAt)
+	if err != nil {
+		return updatedDamage, err
+	}
+
+	return updatedDamage, nil
+}
+
+// Delete only hides the entry from new returns, past returns keep referring to it.
+func (d *damageCatalogRepository) Delete(id string) error {
+	query := "UPDATE damage_catalog SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;"
+	result, err := d.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
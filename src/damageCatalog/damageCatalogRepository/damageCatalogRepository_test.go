@@ -0,0 +1,162 @@
+package damageCatalogRepository
+
+import (
+	"bike-rent-express/model/dto/damageCatalogDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var expectDamage = damageCatalogDto.DamageCatalog{
+	ID:         "1",
+	Part:       "Ban depan",
+	Severity:   "MODERATE",
+	RepairCost: 25000,
+	CreatedAt:  "0000",
+	UpdatedAt:  "0000",
+}
+
+var damageRequest = damageCatalogDto.DamageCatalogRequest{
+	Part:       "Ban depan",
+	Severity:   "MODERATE",
+	RepairCost: 25000,
+}
+
+func damageRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "part", "severity", "repair_cost", "created_at", "updated_at"}).
+		AddRow(expectDamage.ID, expectDamage.Part, expectDamage.Severity, expectDamage.RepairCost, expectDamage.CreatedAt, expectDamage.UpdatedAt)
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "INSERT INTO damage_catalog(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs(damageRequest.Part, damageRequest.Severity, damageRequest.RepairCost).WillReturnRows(damageRows())
+
+	actualDamage, err := repository.Add(damageRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectDamage, actualDamage)
+}
+
+func TestAdd_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "INSERT INTO damage_catalog(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	_, err = repository.Add(damageRequest)
+	assert.Error(t, err)
+}
+
+func TestGetAll_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "SELECT (.+) FROM damage_catalog WHERE deleted_at IS NULL"
+	mock.ExpectQuery(query).WillReturnRows(damageRows())
+
+	actualDamages, err := repository.GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []damageCatalogDto.DamageCatalog{expectDamage}, actualDamages)
+}
+
+func TestGetAll_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "SELECT (.+) FROM damage_catalog"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	_, err = repository.GetAll()
+	assert.Error(t, err)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "UPDATE damage_catalog SET (.+) WHERE id = \\$4 AND deleted_at IS NULL RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs(damageRequest.Part, damageRequest.Severity, damageRequest.RepairCost, "1").WillReturnRows(damageRows())
+
+	actualDamage, err := repository.Update("1", damageRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectDamage, actualDamage)
+}
+
+func TestUpdate_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "UPDATE damage_catalog SET (.+) RETURNING .+;"
+	mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.Update("1", damageRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestDelete_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "UPDATE damage_catalog SET deleted_at = CURRENT_TIMESTAMP WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.Delete("1")
+	assert.Nil(t, err)
+}
+
+func TestDelete_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDamageCatalogRepository(db)
+
+	query := "UPDATE damage_catalog SET deleted_at"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.Delete("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
@@ -0,0 +1,60 @@
+package damageCatalogUsecase
+
+import (
+	"bike-rent-express/model/dto/damageCatalogDto"
+	"bike-rent-express/src/damageCatalog"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type damageCatalogUsecase struct {
+	damageCatalogRepository damageCatalog.DamageCatalogRepository
+}
+
+func NewDamageCatalogUsecase(damageCatalogRepository damageCatalog.DamageCatalogRepository) damageCatalog.DamageCatalogUsecase {
+	return &damageCatalogUsecase{damageCatalogRepository}
+}
+
+func (d *damageCatalogUsecase) CreateDamageCatalog(damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	newDamage, err := d.damageCatalogRepository.Add(damage)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return newDamage, errors.New("1")
+		}
+		return newDamage, err
+	}
+
+	return newDamage, nil
+}
+
+func (d *damageCatalogUsecase) GetAllDamageCatalog() ([]damageCatalogDto.DamageCatalog, error) {
+	return d.damageCatalogRepository.GetAll()
+}
+
+func (d *damageCatalogUsecase) UpdateDamageCatalog(id string, damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	updatedDamage, err := d.damageCatalogRepository.Update(id, damage)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return updatedDamage, errors.New("1")
+		}
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return updatedDamage, errors.New("2")
+		}
+		return updatedDamage, err
+	}
+
+	return updatedDamage, nil
+}
+
+func (d *damageCatalogUsecase) DeleteDamageCatalog(id string) error {
+	err := d.damageCatalogRepository.Delete(id)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return errors.New("1")
+		}
+		return err
+	}
+
+	return nil
+}
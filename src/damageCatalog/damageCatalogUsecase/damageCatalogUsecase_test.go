@@ -0,0 +1,124 @@
+package damageCatalogUsecase
+
+import (
+	"bike-rent-express/model/dto/damageCatalogDto"
+	"bike-rent-express/src/damageCatalog"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectDamage = damageCatalogDto.DamageCatalog{
+	ID:         "1",
+	Part:       "Ban depan",
+	Severity:   "MODERATE",
+	RepairCost: 25000,
+}
+
+var damageRequest = damageCatalogDto.DamageCatalogRequest{
+	Part:       "Ban depan",
+	Severity:   "MODERATE",
+	RepairCost: 25000,
+}
+
+type mockDamageCatalogRepository struct {
+	mock.Mock
+}
+
+func (m *mockDamageCatalogRepository) Add(damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	args := m.Called(damage)
+	return args.Get(0).(damageCatalogDto.DamageCatalog), args.Error(1)
+}
+
+func (m *mockDamageCatalogRepository) GetAll() ([]damageCatalogDto.DamageCatalog, error) {
+	args := m.Called()
+	return args.Get(0).([]damageCatalogDto.DamageCatalog), args.Error(1)
+}
+
+func (m *mockDamageCatalogRepository) Update(id string, damage damageCatalogDto.DamageCatalogRequest) (damageCatalogDto.DamageCatalog, error) {
+	args := m.Called(id, damage)
+	return args.Get(0).(damageCatalogDto.DamageCatalog), args.Error(1)
+}
+
+func (m *mockDamageCatalogRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type DamageCatalogUsecaseTestSuite struct {
+	suite.Suite
+	mockDamageCatalogRepository *mockDamageCatalogRepository
+	damageCatalogUC             damageCatalog.DamageCatalogUsecase
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) SetupTest() {
+	suite.mockDamageCatalogRepository = new(mockDamageCatalogRepository)
+	suite.damageCatalogUC = NewDamageCatalogUsecase(suite.mockDamageCatalogRepository)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestCreateDamageCatalog_Success() {
+	suite.mockDamageCatalogRepository.On("Add", damageRequest).Return(expectDamage, nil)
+
+	actualDamage, err := suite.damageCatalogUC.CreateDamageCatalog(damageRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectDamage, actualDamage)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestCreateDamageCatalog_FailedDuplicate() {
+	suite.mockDamageCatalogRepository.On("Add", damageRequest).Return(damageCatalogDto.DamageCatalog{}, errors.New("pq: duplicate key value violates unique constraint"))
+
+	_, err := suite.damageCatalogUC.CreateDamageCatalog(damageRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestGetAllDamageCatalog_Success() {
+	suite.mockDamageCatalogRepository.On("GetAll").Return([]damageCatalogDto.DamageCatalog{expectDamage}, nil)
+
+	actualDamages, err := suite.damageCatalogUC.GetAllDamageCatalog()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []damageCatalogDto.DamageCatalog{expectDamage}, actualDamages)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestUpdateDamageCatalog_Success() {
+	suite.mockDamageCatalogRepository.On("Update", "1", damageRequest).Return(expectDamage, nil)
+
+	actualDamage, err := suite.damageCatalogUC.UpdateDamageCatalog("1", damageRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectDamage, actualDamage)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestUpdateDamageCatalog_FailedNotFound() {
+	suite.mockDamageCatalogRepository.On("Update", "1", damageRequest).Return(damageCatalogDto.DamageCatalog{}, sql.ErrNoRows)
+
+	_, err := suite.damageCatalogUC.UpdateDamageCatalog("1", damageRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestUpdateDamageCatalog_FailedDuplicate() {
+	suite.mockDamageCatalogRepository.On("Update", "1", damageRequest).Return(damageCatalogDto.DamageCatalog{}, errors.New("pq: duplicate key value violates unique constraint"))
+
+	_, err := suite.damageCatalogUC.UpdateDamageCatalog("1", damageRequest)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestDeleteDamageCatalog_Success() {
+	suite.mockDamageCatalogRepository.On("Delete", "1").Return(nil)
+
+	err := suite.damageCatalogUC.DeleteDamageCatalog("1")
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *DamageCatalogUsecaseTestSuite) TestDeleteDamageCatalog_FailedNotFound() {
+	suite.mockDamageCatalogRepository.On("Delete", "1").Return(sql.ErrNoRows)
+
+	err := suite.damageCatalogUC.DeleteDamageCatalog("1")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func TestDamageCatalogUsecase(t *testing.T) {
+	suite.Run(t, new(DamageCatalogUsecaseTestSuite))
+}
@@ -0,0 +1,78 @@
+package dispatchDelivery
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/src/dispatch"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dispatchDelivery struct {
+	dispatchUC dispatch.DispatchUsecase
+}
+
+func NewDispatchDelivery(v1Group *gin.RouterGroup, dispatchUC dispatch.DispatchUsecase) {
+	handler := dispatchDelivery{dispatchUC}
+
+	// an employee only moves the tasks on their own queue, whoever the path names
+	taskGroup := v1Group.Group("/employee/:id/tasks")
+	{
+		taskGroup.GET("", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.GetEmployeeTasks)
+		taskGroup.PUT("/:task-id/accept", middleware.JWTAuth("EMPLOYEE", "BRANCH_MANAGER"), handler.AcceptTask)
+		taskGroup.PUT("/:task-id/start", middleware.JWTAuth("EMPLOYEE", "BRANCH_MANAGER"), handler.StartTask)
+		taskGroup.PUT("/:task-id/complete", middleware.JWTAuth("EMPLOYEE", "BRANCH_MANAGER"), handler.CompleteTask)
+	}
+}
+
+func (d *dispatchDelivery) GetEmployeeTasks(c *gin.Context) {
+	tasks, err := d.dispatchUC.GetEmployeeTasks(c.Param("id"), c.GetString("branch_id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(c, nil, "Data not found", "01", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	if len(tasks) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, tasks, "Success get tasks", "01", "02")
+}
+
+func (d *dispatchDelivery) AcceptTask(c *gin.Context) {
+	task, err := d.dispatchUC.AcceptTask(c.Param("task-id"), c.GetString("username"))
+	taskResponse(c, task, err, "Task accepted", "02")
+}
+
+func (d *dispatchDelivery) StartTask(c *gin.Context) {
+	task, err := d.dispatchUC.StartTask(c.Param("task-id"), c.GetString("username"))
+	taskResponse(c, task, err, "Task started", "03")
+}
+
+func (d *dispatchDelivery) CompleteTask(c *gin.Context) {
+	task, err := d.dispatchUC.CompleteTask(c.Param("task-id"), c.GetString("username"))
+	taskResponse(c, task, err, "Task completed", "04")
+}
+
+func taskResponse(c *gin.Context, task dispatchDto.Task, err error, message, serviceCode string) {
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Data not found", serviceCode, "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Task can not make this step from its status", serviceCode, "02")
+			return
+		}
+		json.NewResponseError(c, err.Error(), serviceCode, "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, task, message, serviceCode, "01")
+}
@@ -0,0 +1,155 @@
+package dispatchDelivery
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/pkg/middleware"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectTask = dispatchDto.Task{
+	ID:            "1",
+	TransactionID: "1",
+	Type:          dispatchDto.TaskDelivery,
+	EmployeeID:    "1",
+	Status:        dispatchDto.StatusAccepted,
+	ScheduledAt:   "0000",
+	AcceptedAt:    "0000",
+	CreatedAt:     "0000",
+	UpdatedAt:     "0000",
+}
+
+type mockDispatchUC struct {
+	mock.Mock
+}
+
+func (m *mockDispatchUC) AssignPending(transactionID string) ([]dispatchDto.Task, error) {
+	args := m.Called(transactionID)
+	return args.Get(0).([]dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchUC) GetEmployeeTasks(employeeID, branchID string) ([]dispatchDto.Task, error) {
+	args := m.Called(employeeID, branchID)
+	return args.Get(0).([]dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchUC) AcceptTask(id, username string) (dispatchDto.Task, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchUC) StartTask(id, username string) (dispatchDto.Task, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchUC) CompleteTask(id, username string) (dispatchDto.Task, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+type DispatchDeliveryTestSuite struct {
+	suite.Suite
+	mockDispatchUC *mockDispatchUC
+	router         *gin.Engine
+	employeeToken  string
+}
+
+func (suite *DispatchDeliveryTestSuite) SetupTest() {
+	suite.mockDispatchUC = new(mockDispatchUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewDispatchDelivery(v1, suite.mockDispatchUC)
+
+	token, err := middleware.GenerateTokenJwt("employee", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.employeeToken = "Bearer " + token
+}
+
+func (suite *DispatchDeliveryTestSuite) TestGetEmployeeTasks_Success() {
+	expectResponse := `{"responseCode":"2000102","responseMessage":"Success get tasks","data":[{"id":"1","transaction_id":"1","type":"DELIVERY","employee_id":"1","status":"ACCEPTED","scheduled_at":"0000","accepted_at":"0000","created_at":"0000","updated_at":"0000"}]}`
+	suite.mockDispatchUC.On("GetEmployeeTasks", "1", "").Return([]dispatchDto.Task{expectTask}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee/1/tasks", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DispatchDeliveryTestSuite) TestGetEmployeeTasks_Empty() {
+	expectResponse := `{"responseCode":"2000101","responseMessage":"Data empty"}`
+	suite.mockDispatchUC.On("GetEmployeeTasks", "1", "").Return([]dispatchDto.Task{}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee/1/tasks", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DispatchDeliveryTestSuite) TestAcceptTask_Success() {
+	suite.mockDispatchUC.On("AcceptTask", "1", "employee").Return(expectTask, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/1/tasks/1/accept", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2000201","responseMessage":"Task accepted"`)
+}
+
+func (suite *DispatchDeliveryTestSuite) TestStartTask_FailedWrongStatus() {
+	expectResponse := `{"responseCode":"4000302","responseMessage":"Task can not make this step from its status"}`
+	suite.mockDispatchUC.On("StartTask", "1", "employee").Return(dispatchDto.Task{}, errors.New("2"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/1/tasks/1/start", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DispatchDeliveryTestSuite) TestCompleteTask_FailedNotFound() {
+	expectResponse := `{"responseCode":"4000401","responseMessage":"Data not found"}`
+	// the task is looked up on the queue of the token's employee, not of the path's
+	suite.mockDispatchUC.On("CompleteTask", "1", "employee").Return(dispatchDto.Task{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/2/tasks/1/complete", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DispatchDeliveryTestSuite) TestAcceptTask_FailedRole() {
+	token, _ := middleware.GenerateTokenJwt("admin", "ADMIN")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/1/tasks/1/accept", nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+	suite.mockDispatchUC.AssertNotCalled(suite.T(), "AcceptTask", "1", "1")
+}
+
+func TestDispatchDelivery(t *testing.T) {
+	suite.Run(t, new(DispatchDeliveryTestSuite))
+}
@@ -0,0 +1,32 @@
+package dispatch
+
+import "bike-rent-express/model/dto/dispatchDto"
+
+// AssignJobName names the job giving the pending tasks to the employees on shift.
+const AssignJobName = "dispatch-assign"
+
+type (
+	DispatchRepository interface {
+		GetCandidates(branchID string) ([]dispatchDto.Candidate, error)
+		GetPending(transactionID string) ([]dispatchDto.Task, error)
+		Assign(id, employeeID string) (dispatchDto.Task, error)
+		GetEmployeeID(username string) (string, error)
+		GetById(id string) (dispatchDto.Task, error)
+		GetByEmployee(employeeID, branchID string) ([]dispatchDto.Task, error)
+		UpdateStatus(id, employeeID, fromStatus, toStatus string) (dispatchDto.Task, error)
+	}
+
+	DispatchUsecase interface {
+		AssignPending(transactionID string) ([]dispatchDto.Task, error)
+		GetEmployeeTasks(employeeID, branchID string) ([]dispatchDto.Task, error)
+		AcceptTask(id, username string) (dispatchDto.Task, error)
+		StartTask(id, username string) (dispatchDto.Task, error)
+		CompleteTask(id, username string) (dispatchDto.Task, error)
+	}
+
+	// Strategy picks the employee a new task goes to from the candidates of its branch,
+	// ok is false when nobody can take it.
+	Strategy interface {
+		Pick(candidates []dispatchDto.Candidate) (employeeID string, ok bool)
+	}
+)
@@ -0,0 +1,38 @@
+// Package dispatchMock holds the dispatch usecase mock shared by the tests of the modules that
+// dispatch the tasks of a new rental.
+package dispatchMock
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type DispatchUsecase struct {
+	mock.Mock
+}
+
+func (m *DispatchUsecase) AssignPending(transactionID string) ([]dispatchDto.Task, error) {
+	args := m.Called(transactionID)
+	return args.Get(0).([]dispatchDto.Task), args.Error(1)
+}
+
+func (m *DispatchUsecase) GetEmployeeTasks(employeeID, branchID string) ([]dispatchDto.Task, error) {
+	args := m.Called(employeeID, branchID)
+	return args.Get(0).([]dispatchDto.Task), args.Error(1)
+}
+
+func (m *DispatchUsecase) AcceptTask(id, username string) (dispatchDto.Task, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+func (m *DispatchUsecase) StartTask(id, username string) (dispatchDto.Task, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+func (m *DispatchUsecase) CompleteTask(id, username string) (dispatchDto.Task, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
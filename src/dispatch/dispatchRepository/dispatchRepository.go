@@ -0,0 +1,179 @@
+package dispatchRepository
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/src/dispatch"
+	"database/sql"
+)
+
+const taskColumns = "id, transaction_id, type, COALESCE(branch_id::text, ''), COALESCE(employee_id::text, ''), status, scheduled_at, COALESCE(accepted_at::text, ''), COALESCE(started_at::text, ''), COALESCE(completed_at::text, ''), created_at, updated_at"
+
+type dispatchRepository struct {
+	db *sql.DB
+}
+
+func NewDispatchRepository(db *sql.DB) dispatch.DispatchRepository {
+	return &dispatchRepository{db}
+}
+
+// GetCandidates lists the active employees of the branch with their open tasks, the one
+// given a task longest ago comes first.
+func (d *dispatchRepository) GetCandidates(branchID string) ([]dispatchDto.Candidate, error) {
+	var candidates []dispatchDto.Candidate
+
+	query := `SELECT e.id, COUNT(dt.id) FILTER (WHERE dt.status IN ('ASSIGNED', 'ACCEPTED', 'IN_PROGRESS'))
+		FROM employee e LEFT JOIN dispatch_task dt ON dt.employee_id = e.id
+		WHERE e.deleted_at IS NULL AND e.role = 'EMPLOYEE' AND COALESCE(e.branch_id::text, '') = $1
+		GROUP BY e.id ORDER BY MAX(dt.created_at) ASC NULLS FIRST, e.id;`
+
+	rows, err := d.db.Query(query, branchID)
+	if err != nil {
+		return candidates, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var candidate dispatchDto.Candidate
+		if err := rows.Scan(&candidate.EmployeeID, &candidate.OpenTasks); err != nil {
+			return candidates, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// AddTasks creates, in the database transaction of a new rental, its delivery from the
+// pickup branch and its later pickup at the return branch. Both wait PENDING until
+// AssignPending gives them to an employee.
+func AddTasks(tx *sql.Tx, transactionID string) error {
+	query := `INSERT INTO dispatch_task(transaction_id, type, branch_id, scheduled_at)
+		SELECT t.id, 'DELIVERY', t.pickup_branch_id, t.start_date FROM transaction t WHERE t.id = $1
+		UNION ALL
+		SELECT t.id, 'PICKUP', t.return_branch_id, t.end_date FROM transaction t WHERE t.id = $1;`
+	_, err := tx.Exec(query, transactionID)
+	return err
+}
+
+// GetPending lists the tasks still waiting for an employee, of the transaction or of every
+// transaction when it is empty. Tasks already due are listed too, a rental may start at once.
+func (d *dispatchRepository) GetPending(transactionID string) ([]dispatchDto.Task, error) {
+	var tasks []dispatchDto.Task
+
+	query := "SELECT " + taskColumns + " FROM dispatch_task WHERE status = 'PENDING' AND ($1 = '' OR transaction_id::text = $1) ORDER BY scheduled_at;"
+	rows, err := d.db.Query(query, transactionID)
+	if err != nil {
+		return tasks, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task dispatchDto.Task
+		if err := scanTask(rows, &task); err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// Assign gives a pending task to the employee, the employee assigned to a delivery also
+// becomes the employee of the transaction. sql.ErrNoRows when the task is no longer pending.
+func (d *dispatchRepository) Assign(id, employeeID string) (dispatchDto.Task, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return dispatchDto.Task{}, err
+	}
+
+	var task dispatchDto.Task
+	query := "UPDATE dispatch_task SET employee_id = $1, status = 'ASSIGNED', updated_at = CURRENT_TIMESTAMP WHERE id = $2 AND status = 'PENDING' RETURNING " + taskColumns + ";"
+	if err := scanTask(tx.QueryRow(query, employeeID, id), &task); err != nil {
+		tx.Rollback()
+		return dispatchDto.Task{}, err
+	}
+
+	if task.Type == dispatchDto.TaskDelivery {
+		query = "UPDATE transaction SET employee_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;"
+		if _, err := tx.Exec(query, employeeID, task.TransactionID); err != nil {
+			tx.Rollback()
+			return dispatchDto.Task{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dispatchDto.Task{}, err
+	}
+
+	return task, nil
+}
+
+// GetEmployeeID returns the id of the active employee with the username.
+func (d *dispatchRepository) GetEmployeeID(username string) (string, error) {
+	var employeeID string
+	query := "SELECT id FROM employee WHERE username = $1 AND deleted_at IS NULL;"
+	if err := d.db.QueryRow(query, username).Scan(&employeeID); err != nil {
+		return "", err
+	}
+
+	return employeeID, nil
+}
+
+func (d *dispatchRepository) GetById(id string) (dispatchDto.Task, error) {
+	var task dispatchDto.Task
+	query := "SELECT " + taskColumns + " FROM dispatch_task WHERE id = $1;"
+	if err := scanTask(d.db.QueryRow(query, id), &task); err != nil {
+		return task, err
+	}
+
+	return task, nil
+}
+
+// GetByEmployee returns the queue of the employee at branchID, all of it when branchID is empty,
+// open tasks first in the order they are due.
+func (d *dispatchRepository) GetByEmployee(employeeID, branchID string) ([]dispatchDto.Task, error) {
+	var tasks []dispatchDto.Task
+
+	query := "SELECT " + taskColumns + " FROM dispatch_task WHERE employee_id = $1 AND ($2 = '' OR branch_id::text = $2) ORDER BY status = 'COMPLETED', scheduled_at;"
+	rows, err := d.db.Query(query, employeeID, branchID)
+	if err != nil {
+		return tasks, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task dispatchDto.Task
+		if err := scanTask(rows, &task); err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// UpdateStatus moves a task of the employee from fromStatus to toStatus and stamps the
+// time of the step, sql.ErrNoRows when the task is not theirs or not in fromStatus.
+func (d *dispatchRepository) UpdateStatus(id, employeeID, fromStatus, toStatus string) (dispatchDto.Task, error) {
+	var task dispatchDto.Task
+
+	query := `UPDATE dispatch_task SET status = $1,
+		accepted_at = CASE WHEN $1 = 'ACCEPTED' THEN CURRENT_TIMESTAMP ELSE accepted_at END,
+		started_at = CASE WHEN $1 = 'IN_PROGRESS' THEN CURRENT_TIMESTAMP ELSE started_at END,
+		completed_at = CASE WHEN $1 = 'COMPLETED' THEN CURRENT_TIMESTAMP ELSE completed_at END,
+		updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND employee_id::text = $3 AND status = $4 RETURNING ` + taskColumns + ";"
+	if err := scanTask(d.db.QueryRow(query, toStatus, id, employeeID, fromStatus), &task); err != nil {
+		return task, err
+	}
+
+	return task, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTask(row scanner, task *dispatchDto.Task) error {
+	return row.Scan(&task.ID, &task.TransactionID, &task.Type, &task.BranchID, &task.EmployeeID, &task.Status, &task.ScheduledAt, &task.AcceptedAt, &task.StartedAt, &task.CompletedAt, &task.CreatedAt, &task.UpdatedAt)
+}
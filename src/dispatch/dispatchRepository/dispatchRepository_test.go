@@ -0,0 +1,220 @@
+package dispatchRepository
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var expectTask = dispatchDto.Task{
+	ID:            "1",
+	TransactionID: "1",
+	Type:          dispatchDto.TaskDelivery,
+	BranchID:      "1",
+	EmployeeID:    "1",
+	Status:        dispatchDto.StatusAssigned,
+	ScheduledAt:   "2024-09-13T09:00:00+07:00",
+	CreatedAt:     "0000",
+	UpdatedAt:     "0000",
+}
+
+func taskRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "transaction_id", "type", "branch_id", "employee_id", "status", "scheduled_at", "accepted_at", "started_at", "completed_at", "created_at", "updated_at"}).
+		AddRow(expectTask.ID, expectTask.TransactionID, expectTask.Type, expectTask.BranchID, expectTask.EmployeeID, expectTask.Status, expectTask.ScheduledAt, expectTask.AcceptedAt, expectTask.StartedAt, expectTask.CompletedAt, expectTask.CreatedAt, expectTask.UpdatedAt)
+}
+
+func TestGetCandidates_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "SELECT (.+) FROM employee e LEFT JOIN dispatch_task dt (.+) GROUP BY e.id ORDER BY .+;"
+	rows := sqlmock.NewRows([]string{"id", "count"}).AddRow("1", 0).AddRow("2", 3)
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(rows)
+
+	candidates, err := repository.GetCandidates("1")
+	assert.Nil(t, err)
+	assert.Equal(t, []dispatchDto.Candidate{{EmployeeID: "1", OpenTasks: 0}, {EmployeeID: "2", OpenTasks: 3}}, candidates)
+}
+
+func TestGetCandidates_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "SELECT (.+) FROM employee e LEFT JOIN dispatch_task dt .+"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnError(errors.New("error sql"))
+
+	_, err = repository.GetCandidates("1")
+	assert.Error(t, err)
+}
+
+func TestAddTasks_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	query := "INSERT INTO dispatch_task(.+) SELECT t.id, 'DELIVERY', (.+) UNION ALL SELECT t.id, 'PICKUP', .+;"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	tx, err := db.Begin()
+	assert.Nil(t, err)
+	assert.Nil(t, AddTasks(tx, "1"))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPending_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "SELECT (.+) FROM dispatch_task WHERE status = 'PENDING' AND \\(\\$1 = '' OR transaction_id::text = \\$1\\) ORDER BY scheduled_at;"
+	mock.ExpectQuery(query).WithArgs("").WillReturnRows(taskRows())
+
+	tasks, err := repository.GetPending("")
+	assert.Nil(t, err)
+	assert.Equal(t, []dispatchDto.Task{expectTask}, tasks)
+}
+
+func TestAssign_SuccessDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	mock.ExpectBegin()
+	query := "UPDATE dispatch_task SET employee_id = \\$1, status = 'ASSIGNED', (.+) WHERE id = \\$2 AND status = 'PENDING' RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs(expectTask.EmployeeID, expectTask.ID).WillReturnRows(taskRows())
+	query = "UPDATE transaction SET employee_id = \\$1, (.+) WHERE id = \\$2;"
+	mock.ExpectExec(query).WithArgs(expectTask.EmployeeID, expectTask.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	actualTask, err := repository.Assign(expectTask.ID, expectTask.EmployeeID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectTask, actualTask)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAssign_FailedNoLongerPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	mock.ExpectBegin()
+	query := "UPDATE dispatch_task SET employee_id .+"
+	mock.ExpectQuery(query).WithArgs("1", "1").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Assign("1", "1")
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEmployeeID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "SELECT id FROM employee WHERE username = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs("dino").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+	employeeID, err := repository.GetEmployeeID("dino")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", employeeID)
+}
+
+func TestGetById_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "SELECT (.+) FROM dispatch_task WHERE id = \\$1;"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.GetById("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestGetByEmployee_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "SELECT (.+) FROM dispatch_task WHERE employee_id = \\$1 AND .+ ORDER BY .+;"
+	mock.ExpectQuery(query).WithArgs("1", "branch-1").WillReturnRows(taskRows())
+
+	tasks, err := repository.GetByEmployee("1", "branch-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []dispatchDto.Task{expectTask}, tasks)
+}
+
+func TestUpdateStatus_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "UPDATE dispatch_task SET status = \\$1, (.+) WHERE id = \\$2 AND employee_id::text = \\$3 AND status = \\$4 RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs(dispatchDto.StatusAccepted, "1", "1", dispatchDto.StatusAssigned).WillReturnRows(taskRows())
+
+	actualTask, err := repository.UpdateStatus("1", "1", dispatchDto.StatusAssigned, dispatchDto.StatusAccepted)
+	assert.Nil(t, err)
+	assert.Equal(t, expectTask, actualTask)
+}
+
+func TestUpdateStatus_FailedNoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDispatchRepository(db)
+
+	query := "UPDATE dispatch_task SET status .+"
+	mock.ExpectQuery(query).WithArgs(dispatchDto.StatusAccepted, "1", "1", dispatchDto.StatusAssigned).WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.UpdateStatus("1", "1", dispatchDto.StatusAssigned, dispatchDto.StatusAccepted)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
@@ -0,0 +1,143 @@
+package dispatchUsecase
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/src/dispatch"
+	"bike-rent-express/src/shift"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+type dispatchUsecase struct {
+	dispatchRepository dispatch.DispatchRepository
+	shiftUsecase       shift.ShiftUsecase
+	strategy           dispatch.Strategy
+}
+
+func NewDispatchUsecase(dispatchRepository dispatch.DispatchRepository, shiftUsecase shift.ShiftUsecase, strategy dispatch.Strategy) dispatch.DispatchUsecase {
+	return &dispatchUsecase{dispatchRepository, shiftUsecase, strategy}
+}
+
+// AssignPending gives the pending tasks of the transaction, or of every transaction when
+// it is empty, to an employee of their branch on shift when they are due. A task nobody
+// can take stays PENDING for the next run and one taken in the meantime is skipped.
+func (d *dispatchUsecase) AssignPending(transactionID string) ([]dispatchDto.Task, error) {
+	pending, err := d.dispatchRepository.GetPending(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var assigned []dispatchDto.Task
+	for _, task := range pending {
+		candidates, err := d.onShift(task)
+		if err != nil {
+			return assigned, err
+		}
+
+		employeeID, ok := d.strategy.Pick(candidates)
+		if !ok {
+			continue
+		}
+
+		task, err := d.dispatchRepository.Assign(task.ID, employeeID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			return assigned, err
+		}
+		assigned = append(assigned, task)
+	}
+
+	return assigned, nil
+}
+
+// onShift returns the candidates of the task's branch who work at the time it is due.
+func (d *dispatchUsecase) onShift(task dispatchDto.Task) ([]dispatchDto.Candidate, error) {
+	dueAt, err := time.Parse(time.RFC3339, task.ScheduledAt)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := d.dispatchRepository.GetCandidates(task.BranchID)
+	if err != nil {
+		return nil, err
+	}
+
+	var available []dispatchDto.Candidate
+	for _, candidate := range candidates {
+		onShift, err := d.shiftUsecase.IsOnShift(candidate.EmployeeID, dueAt)
+		if err != nil {
+			return nil, err
+		}
+		if onShift {
+			available = append(available, candidate)
+		}
+	}
+
+	return available, nil
+}
+
+func (d *dispatchUsecase) GetEmployeeTasks(employeeID, branchID string) ([]dispatchDto.Task, error) {
+	tasks, err := d.dispatchRepository.GetByEmployee(employeeID, branchID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.New("1")
+		}
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (d *dispatchUsecase) AcceptTask(id, username string) (dispatchDto.Task, error) {
+	return d.moveTask(id, username, dispatchDto.StatusAssigned, dispatchDto.StatusAccepted)
+}
+
+func (d *dispatchUsecase) StartTask(id, username string) (dispatchDto.Task, error) {
+	return d.moveTask(id, username, dispatchDto.StatusAccepted, dispatchDto.StatusInProgress)
+}
+
+func (d *dispatchUsecase) CompleteTask(id, username string) (dispatchDto.Task, error) {
+	return d.moveTask(id, username, dispatchDto.StatusInProgress, dispatchDto.StatusCompleted)
+}
+
+// moveTask makes the step for the employee with the username, it returns "1" when the task
+// is not on their queue and "2" when it is not in the status the step starts from.
+func (d *dispatchUsecase) moveTask(id, username, fromStatus, toStatus string) (dispatchDto.Task, error) {
+	employeeID, err := d.dispatchRepository.GetEmployeeID(username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dispatchDto.Task{}, errors.New("1")
+		}
+		return dispatchDto.Task{}, err
+	}
+
+	task, err := d.dispatchRepository.UpdateStatus(id, employeeID, fromStatus, toStatus)
+	if err == nil {
+		return task, nil
+	}
+	if !isNotFound(err) {
+		return task, err
+	}
+
+	current, err := d.dispatchRepository.GetById(id)
+	if err != nil {
+		if isNotFound(err) {
+			return task, errors.New("1")
+		}
+		return task, err
+	}
+
+	if current.EmployeeID != employeeID {
+		return task, errors.New("1")
+	}
+
+	return task, errors.New("2")
+}
+
+func isNotFound(err error) bool {
+	return err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid")
+}
@@ -0,0 +1,209 @@
+package dispatchUsecase
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/src/dispatch"
+	"bike-rent-express/src/shift/shiftMock"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var pendingDelivery = dispatchDto.Task{ID: "1", TransactionID: "1", Type: dispatchDto.TaskDelivery, BranchID: "1", Status: dispatchDto.StatusPending, ScheduledAt: "2024-09-13T09:00:00+07:00"}
+
+var pendingPickup = dispatchDto.Task{ID: "2", TransactionID: "1", Type: dispatchDto.TaskPickup, BranchID: "2", Status: dispatchDto.StatusPending, ScheduledAt: "2024-09-15T09:00:00+07:00"}
+
+var expectTask = dispatchDto.Task{
+	ID:            "1",
+	TransactionID: "1",
+	Type:          dispatchDto.TaskDelivery,
+	EmployeeID:    "1",
+	Status:        dispatchDto.StatusAssigned,
+}
+
+type mockDispatchRepository struct {
+	mock.Mock
+}
+
+func (m *mockDispatchRepository) GetCandidates(branchID string) ([]dispatchDto.Candidate, error) {
+	args := m.Called(branchID)
+	return args.Get(0).([]dispatchDto.Candidate), args.Error(1)
+}
+
+func (m *mockDispatchRepository) GetPending(transactionID string) ([]dispatchDto.Task, error) {
+	args := m.Called(transactionID)
+	return args.Get(0).([]dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchRepository) Assign(id, employeeID string) (dispatchDto.Task, error) {
+	args := m.Called(id, employeeID)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchRepository) GetEmployeeID(username string) (string, error) {
+	args := m.Called(username)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockDispatchRepository) GetById(id string) (dispatchDto.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchRepository) GetByEmployee(employeeID, branchID string) ([]dispatchDto.Task, error) {
+	args := m.Called(employeeID, branchID)
+	return args.Get(0).([]dispatchDto.Task), args.Error(1)
+}
+
+func (m *mockDispatchRepository) UpdateStatus(id, employeeID, fromStatus, toStatus string) (dispatchDto.Task, error) {
+	args := m.Called(id, employeeID, fromStatus, toStatus)
+	return args.Get(0).(dispatchDto.Task), args.Error(1)
+}
+
+type DispatchUsecaseTestSuite struct {
+	suite.Suite
+	mockDispatchRepository *mockDispatchRepository
+	mockShiftUsecase       *shiftMock.ShiftUsecase
+	dispatchUC             dispatch.DispatchUsecase
+}
+
+func (suite *DispatchUsecaseTestSuite) SetupTest() {
+	suite.mockDispatchRepository = new(mockDispatchRepository)
+	suite.mockShiftUsecase = new(shiftMock.ShiftUsecase)
+	strategy, err := NewStrategy(StrategyLeastLoaded)
+	suite.Require().Nil(err)
+	suite.dispatchUC = NewDispatchUsecase(suite.mockDispatchRepository, suite.mockShiftUsecase, strategy)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestAssignPending_Success() {
+	suite.mockDispatchRepository.On("GetPending", "1").Return([]dispatchDto.Task{pendingDelivery, pendingPickup}, nil)
+	suite.mockDispatchRepository.On("GetCandidates", "1").Return([]dispatchDto.Candidate{{EmployeeID: "1", OpenTasks: 2}, {EmployeeID: "2", OpenTasks: 0}, {EmployeeID: "3", OpenTasks: 0}}, nil)
+	suite.mockDispatchRepository.On("GetCandidates", "2").Return([]dispatchDto.Candidate{{EmployeeID: "4", OpenTasks: 0}}, nil)
+	suite.mockShiftUsecase.On("IsOnShift", "1", mock.Anything).Return(true, nil)
+	suite.mockShiftUsecase.On("IsOnShift", "2", mock.Anything).Return(true, nil)
+	suite.mockShiftUsecase.On("IsOnShift", "3", mock.Anything).Return(false, nil)
+	suite.mockShiftUsecase.On("IsOnShift", "4", mock.Anything).Return(false, nil)
+
+	delivery := pendingDelivery
+	delivery.EmployeeID, delivery.Status = "2", dispatchDto.StatusAssigned
+	suite.mockDispatchRepository.On("Assign", "1", "2").Return(delivery, nil)
+
+	tasks, err := suite.dispatchUC.AssignPending("1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []dispatchDto.Task{delivery}, tasks)
+	// nobody at the return branch is on shift, the pickup waits for the next run
+	suite.mockDispatchRepository.AssertNotCalled(suite.T(), "Assign", "2", mock.Anything)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestAssignPending_SkipsTaskTakenMeanwhile() {
+	suite.mockDispatchRepository.On("GetPending", "").Return([]dispatchDto.Task{pendingDelivery}, nil)
+	suite.mockDispatchRepository.On("GetCandidates", "1").Return([]dispatchDto.Candidate{{EmployeeID: "1", OpenTasks: 0}}, nil)
+	suite.mockShiftUsecase.On("IsOnShift", "1", mock.Anything).Return(true, nil)
+	suite.mockDispatchRepository.On("Assign", "1", "1").Return(dispatchDto.Task{}, sql.ErrNoRows)
+
+	tasks, err := suite.dispatchUC.AssignPending("")
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), tasks)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestAssignPending_ChecksShiftAtDueTime() {
+	dueAt, _ := time.Parse(time.RFC3339, pendingDelivery.ScheduledAt)
+	suite.mockDispatchRepository.On("GetPending", "1").Return([]dispatchDto.Task{pendingDelivery}, nil)
+	suite.mockDispatchRepository.On("GetCandidates", "1").Return([]dispatchDto.Candidate{{EmployeeID: "1", OpenTasks: 0}}, nil)
+	suite.mockShiftUsecase.On("IsOnShift", "1", dueAt).Return(false, errors.New("error"))
+
+	_, err := suite.dispatchUC.AssignPending("1")
+	assert.EqualError(suite.T(), err, "error")
+	suite.mockShiftUsecase.AssertCalled(suite.T(), "IsOnShift", "1", dueAt)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestAssignPending_FailedGetCandidates() {
+	expectError := errors.New("error")
+	suite.mockDispatchRepository.On("GetPending", "1").Return([]dispatchDto.Task{pendingDelivery}, nil)
+	suite.mockDispatchRepository.On("GetCandidates", "1").Return([]dispatchDto.Candidate{}, expectError)
+
+	_, err := suite.dispatchUC.AssignPending("1")
+	assert.Equal(suite.T(), expectError, err)
+	suite.mockDispatchRepository.AssertNotCalled(suite.T(), "Assign", mock.Anything, mock.Anything)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestGetEmployeeTasks_FailedInvalidId() {
+	suite.mockDispatchRepository.On("GetByEmployee", "x", "").Return([]dispatchDto.Task{}, errors.New("pq: invalid input syntax for type uuid: \"x\""))
+
+	_, err := suite.dispatchUC.GetEmployeeTasks("x", "")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestAcceptTask_Success() {
+	accepted := expectTask
+	accepted.Status = dispatchDto.StatusAccepted
+	suite.mockDispatchRepository.On("GetEmployeeID", "dino").Return("1", nil)
+	suite.mockDispatchRepository.On("UpdateStatus", "1", "1", dispatchDto.StatusAssigned, dispatchDto.StatusAccepted).Return(accepted, nil)
+
+	task, err := suite.dispatchUC.AcceptTask("1", "dino")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), accepted, task)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestStartTask_FailedWrongStatus() {
+	suite.mockDispatchRepository.On("GetEmployeeID", "dino").Return("1", nil)
+	suite.mockDispatchRepository.On("UpdateStatus", "1", "1", dispatchDto.StatusAccepted, dispatchDto.StatusInProgress).Return(dispatchDto.Task{}, sql.ErrNoRows)
+	suite.mockDispatchRepository.On("GetById", "1").Return(expectTask, nil)
+
+	_, err := suite.dispatchUC.StartTask("1", "dino")
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestCompleteTask_FailedOtherEmployee() {
+	suite.mockDispatchRepository.On("GetEmployeeID", "budi").Return("2", nil)
+	suite.mockDispatchRepository.On("UpdateStatus", "1", "2", dispatchDto.StatusInProgress, dispatchDto.StatusCompleted).Return(dispatchDto.Task{}, sql.ErrNoRows)
+	suite.mockDispatchRepository.On("GetById", "1").Return(expectTask, nil)
+
+	_, err := suite.dispatchUC.CompleteTask("1", "budi")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *DispatchUsecaseTestSuite) TestCompleteTask_FailedNotFound() {
+	suite.mockDispatchRepository.On("GetEmployeeID", "dino").Return("1", nil)
+	suite.mockDispatchRepository.On("UpdateStatus", "1", "1", dispatchDto.StatusInProgress, dispatchDto.StatusCompleted).Return(dispatchDto.Task{}, sql.ErrNoRows)
+	suite.mockDispatchRepository.On("GetById", "1").Return(dispatchDto.Task{}, sql.ErrNoRows)
+
+	_, err := suite.dispatchUC.CompleteTask("1", "dino")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func TestDispatchUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(DispatchUsecaseTestSuite))
+}
+
+func TestNewStrategy_Unknown(t *testing.T) {
+	_, err := NewStrategy("random")
+	assert.Error(t, err)
+}
+
+func TestRoundRobin_Pick(t *testing.T) {
+	strategy, _ := NewStrategy(StrategyRoundRobin)
+
+	employeeID, ok := strategy.Pick([]dispatchDto.Candidate{{EmployeeID: "1", OpenTasks: 4}, {EmployeeID: "2", OpenTasks: 0}})
+	assert.True(t, ok)
+	assert.Equal(t, "1", employeeID)
+
+	_, ok = strategy.Pick(nil)
+	assert.False(t, ok)
+}
+
+func TestLeastLoaded_Pick(t *testing.T) {
+	strategy, _ := NewStrategy(StrategyLeastLoaded)
+
+	employeeID, ok := strategy.Pick([]dispatchDto.Candidate{{EmployeeID: "1", OpenTasks: 2}, {EmployeeID: "2", OpenTasks: 1}, {EmployeeID: "3", OpenTasks: 1}})
+	assert.True(t, ok)
+	assert.Equal(t, "2", employeeID)
+
+	_, ok = strategy.Pick(nil)
+	assert.False(t, ok)
+}
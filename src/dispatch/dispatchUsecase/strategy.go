@@ -0,0 +1,53 @@
+package dispatchUsecase
+
+import (
+	"bike-rent-express/model/dto/dispatchDto"
+	"bike-rent-express/src/dispatch"
+	"errors"
+)
+
+const (
+	StrategyRoundRobin  = "round-robin"
+	StrategyLeastLoaded = "least-loaded"
+)
+
+// NewStrategy returns the dispatch strategy configured by name.
+func NewStrategy(name string) (dispatch.Strategy, error) {
+	switch name {
+	case StrategyRoundRobin:
+		return roundRobin{}, nil
+	case StrategyLeastLoaded:
+		return leastLoaded{}, nil
+	}
+
+	return nil, errors.New("unknown dispatch strategy " + name)
+}
+
+// roundRobin takes turns, the candidates come ordered by who was given a task longest ago.
+type roundRobin struct{}
+
+func (roundRobin) Pick(candidates []dispatchDto.Candidate) (string, bool) {
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	return candidates[0].EmployeeID, true
+}
+
+// leastLoaded takes the candidate with the fewest open tasks, ties go to the one whose turn it is.
+type leastLoaded struct{}
+
+func (leastLoaded) Pick(candidates []dispatchDto.Candidate) (string, bool) {
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	picked := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.OpenTasks < picked.OpenTasks {
+			picked = candidate
+		}
+	}
+
+	return picked.EmployeeID, true
+}
@@ -0,0 +1,189 @@
+package disputeDelivery
+
+import (
+	"bike-rent-express/model/dto/disputeDto"
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/dispute"
+
+	"github.com/gin-gonic/gin"
+)
+
+type disputeDelivery struct {
+	disputeUC dispute.DisputeUsecase
+}
+
+func NewDisputeDelivery(v1Group *gin.RouterGroup, disputeUC dispute.DisputeUsecase) {
+	handler := disputeDelivery{disputeUC}
+
+	userDisputeGroup := v1Group.Group("/users/:id/disputes")
+	{
+		userDisputeGroup.POST("", middleware.JWTAuth("USER"), handler.OpenDispute)
+		userDisputeGroup.GET("", middleware.JWTAuth("USER"), handler.GetDisputesByUser)
+		userDisputeGroup.GET("/:dispute-id", middleware.JWTAuth("USER"), handler.GetUserDisputeById)
+		userDisputeGroup.POST("/:dispute-id/messages", middleware.JWTAuth("USER"), handler.AddUserDisputeMessage)
+	}
+
+	disputeGroup := v1Group.Group("/disputes")
+	{
+		disputeGroup.GET("", middleware.JWTAuth("ADMIN"), handler.GetAllDisputes)
+		disputeGroup.GET("/:id", middleware.JWTAuth("ADMIN"), handler.GetDisputeById)
+		disputeGroup.POST("/:id/messages", middleware.JWTAuth("ADMIN"), handler.AddAdminDisputeMessage)
+		disputeGroup.PUT("/:id/resolve", middleware.JWTAuth("ADMIN"), handler.ResolveDispute)
+	}
+}
+
+func (d *disputeDelivery) OpenDispute(c *gin.Context) {
+	var request disputeDto.CreateDisputeRequest
+
+	c.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
+		return
+	}
+	request.UserID = c.Param("id")
+	request.Actor = c.GetString("username")
+
+	newDispute, err := d.disputeUC.OpenDispute(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "01", "02")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Dispute window has passed", "01", "03")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "Motor return is already disputed", "01", "04")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(c, newDispute, "Dispute opened", "01", "01")
+}
+
+func (d *disputeDelivery) GetDisputesByUser(c *gin.Context) {
+	disputes, err := d.disputeUC.GetDisputesByUser(c.Param("id"))
+	if err != nil {
+		json.NewResponseError(c, err.Error(), "02", "01")
+		return
+	}
+
+	if len(disputes) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, disputes, "Success get disputes", "02", "02")
+}
+
+func (d *disputeDelivery) GetUserDisputeById(c *gin.Context) {
+	d.getDisputeById(c, c.Param("dispute-id"), c.Param("id"), "03")
+}
+
+func (d *disputeDelivery) GetDisputeById(c *gin.Context) {
+	d.getDisputeById(c, c.Param("id"), "", "06")
+}
+
+func (d *disputeDelivery) getDisputeById(c *gin.Context, id, userID, serviceCode string) {
+	dispute, err := d.disputeUC.GetDisputeById(id, userID)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(c, nil, "Data not found", serviceCode, "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), serviceCode, "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, dispute, "Success get dispute", serviceCode, "02")
+}
+
+func (d *disputeDelivery) AddUserDisputeMessage(c *gin.Context) {
+	d.addDisputeMessage(c, c.Param("dispute-id"), c.Param("id"), "04")
+}
+
+func (d *disputeDelivery) AddAdminDisputeMessage(c *gin.Context) {
+	d.addDisputeMessage(c, c.Param("id"), "", "07")
+}
+
+func (d *disputeDelivery) addDisputeMessage(c *gin.Context, disputeID, userID, serviceCode string) {
+	var request disputeDto.DisputeMessageRequest
+
+	c.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", serviceCode, "01")
+		return
+	}
+	request.DisputeID = disputeID
+	request.UserID = userID
+	request.AuthorRole = c.GetString("role")
+	request.Author = c.GetString("username")
+
+	message, err := d.disputeUC.AddDisputeMessage(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Data not found", serviceCode, "02")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Dispute is already resolved", serviceCode, "03")
+			return
+		}
+		json.NewResponseError(c, err.Error(), serviceCode, "01")
+		return
+	}
+
+	json.NewResponseCreated(c, message, "Message added", serviceCode, "01")
+}
+
+func (d *disputeDelivery) GetAllDisputes(c *gin.Context) {
+	disputes, err := d.disputeUC.GetAllDisputes(c.Query("status"))
+	if err != nil {
+		json.NewResponseError(c, err.Error(), "05", "01")
+		return
+	}
+
+	if len(disputes) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "05", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, disputes, "Success get disputes", "05", "02")
+}
+
+func (d *disputeDelivery) ResolveDispute(c *gin.Context) {
+	var request disputeDto.ResolveDisputeRequest
+
+	c.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "08", "01")
+		return
+	}
+	request.DisputeID = c.Param("id")
+	request.Actor = c.GetString("username")
+
+	resolved, err := d.disputeUC.ResolveDispute(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "08", "02")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Dispute is already resolved", "08", "03")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "Refund exceeds the disputed extra charge", "08", "04")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "08", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, resolved, "Dispute resolved", "08", "01")
+}
@@ -0,0 +1,155 @@
+package disputeDelivery
+
+import (
+	"bike-rent-express/model/dto/disputeDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockDisputeUC struct {
+	mock.Mock
+}
+
+func (m *mockDisputeUC) OpenDispute(request disputeDto.CreateDisputeRequest) (disputeDto.Dispute, error) {
+	args := m.Called(request)
+	return args.Get(0).(disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeUC) GetDisputesByUser(userID string) ([]disputeDto.Dispute, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeUC) GetAllDisputes(status string) ([]disputeDto.Dispute, error) {
+	args := m.Called(status)
+	return args.Get(0).([]disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeUC) GetDisputeById(id, userID string) (disputeDto.Dispute, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeUC) AddDisputeMessage(request disputeDto.DisputeMessageRequest) (disputeDto.DisputeMessage, error) {
+	args := m.Called(request)
+	return args.Get(0).(disputeDto.DisputeMessage), args.Error(1)
+}
+
+func (m *mockDisputeUC) ResolveDispute(request disputeDto.ResolveDisputeRequest) (disputeDto.Dispute, error) {
+	args := m.Called(request)
+	return args.Get(0).(disputeDto.Dispute), args.Error(1)
+}
+
+type DisputeDeliveryTestSuite struct {
+	suite.Suite
+	mockDisputeUC *mockDisputeUC
+	router        *gin.Engine
+	userToken     string
+	adminToken    string
+}
+
+func (suite *DisputeDeliveryTestSuite) SetupTest() {
+	suite.mockDisputeUC = new(mockDisputeUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewDisputeDelivery(v1, suite.mockDisputeUC)
+
+	token, err := middleware.GenerateTokenJwt("billkin", "USER")
+	suite.Require().Nil(err)
+	suite.userToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+}
+
+func (suite *DisputeDeliveryTestSuite) TestOpenDispute_Success() {
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Dispute opened","data":{"id":"1","motor_return_id":"2","user_id":"3","status":"OPEN","reason":"Ban sudah bocor","refund_amount":0,"created_at":"0000","updated_at":"0000"}}`
+	request := disputeDto.CreateDisputeRequest{MotorReturnID: "2", Reason: "Ban sudah bocor"}
+	suite.mockDisputeUC.On("OpenDispute", disputeDto.CreateDisputeRequest{MotorReturnID: "2", Reason: "Ban sudah bocor", UserID: "3", Actor: "billkin"}).
+		Return(disputeDto.Dispute{ID: "1", MotorReturnID: "2", UserID: "3", Status: "OPEN", Reason: "Ban sudah bocor", CreatedAt: "0000", UpdatedAt: "0000"}, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/3/disputes", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.userToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DisputeDeliveryTestSuite) TestOpenDispute_FailedWindowPassed() {
+	expectResponse := `{"responseCode":"4000103","responseMessage":"Dispute window has passed"}`
+	suite.mockDisputeUC.On("OpenDispute", mock.Anything).Return(disputeDto.Dispute{}, errors.New("2"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(disputeDto.CreateDisputeRequest{MotorReturnID: "2", Reason: "Ban sudah bocor"})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/3/disputes", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.userToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DisputeDeliveryTestSuite) TestAddUserDisputeMessage_Success() {
+	suite.mockDisputeUC.On("AddDisputeMessage", disputeDto.DisputeMessageRequest{Message: "Lihat foto", DisputeID: "1", UserID: "3", AuthorRole: "USER", Author: "billkin"}).
+		Return(disputeDto.DisputeMessage{ID: "4", AuthorRole: "USER", Author: "billkin", Message: "Lihat foto", CreatedAt: "0000"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/3/disputes/1/messages", bytes.NewBufferString(`{"message":"Lihat foto"}`))
+	req.Header.Add("Authorization", suite.userToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2010401"`)
+}
+
+func (suite *DisputeDeliveryTestSuite) TestResolveDispute_FailedBind() {
+	expectResponse := `{"responseCode":"4000801","responseMessage":"Bad Request","error_description":[{"field":"RefundAmount","message":"field is required for the selected option"}]}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/disputes/1/resolve", bytes.NewBufferString(`{"resolution":"PARTIAL_REFUND","note":"sebagian"}`))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *DisputeDeliveryTestSuite) TestResolveDispute_Success() {
+	request := disputeDto.ResolveDisputeRequest{Resolution: "FULL_REFUND", Note: "Kerusakan sudah ada saat pickup", DisputeID: "1", Actor: "admin"}
+	suite.mockDisputeUC.On("ResolveDispute", request).Return(disputeDto.Dispute{ID: "1", Status: "RESOLVED", Resolution: "FULL_REFUND", RefundAmount: 25000}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/disputes/1/resolve", bytes.NewBufferString(`{"resolution":"FULL_REFUND","note":"Kerusakan sudah ada saat pickup"}`))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"refund_amount":25000`)
+}
+
+func (suite *DisputeDeliveryTestSuite) TestResolveDispute_FailedForbiddenForUser() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/disputes/1/resolve", bytes.NewBufferString(`{"resolution":"FULL_REFUND","note":"saya sendiri"}`))
+	req.Header.Add("Authorization", suite.userToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
+func TestDisputeDelivery(t *testing.T) {
+	suite.Run(t, new(DisputeDeliveryTestSuite))
+}
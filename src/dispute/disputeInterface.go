@@ -0,0 +1,24 @@
+package dispute
+
+import "bike-rent-express/model/dto/disputeDto"
+
+type (
+	DisputeRepository interface {
+		GetDisputedReturn(motorReturnID string) (disputeDto.DisputedReturn, error)
+		Add(request disputeDto.CreateDisputeRequest) (disputeDto.Dispute, error)
+		GetById(id string) (disputeDto.Dispute, error)
+		GetByUser(userID string) ([]disputeDto.Dispute, error)
+		GetAll(status string) ([]disputeDto.Dispute, error)
+		AddMessage(request disputeDto.DisputeMessageRequest) (disputeDto.DisputeMessage, error)
+		Resolve(request disputeDto.ResolveDisputeRequest) (disputeDto.Dispute, error)
+	}
+
+	DisputeUsecase interface {
+		OpenDispute(request disputeDto.CreateDisputeRequest) (disputeDto.Dispute, error)
+		GetDisputesByUser(userID string) ([]disputeDto.Dispute, error)
+		GetAllDisputes(status string) ([]disputeDto.Dispute, error)
+		GetDisputeById(id, userID string) (disputeDto.Dispute, error)
+		AddDisputeMessage(request disputeDto.DisputeMessageRequest) (disputeDto.DisputeMessage, error)
+		ResolveDispute(request disputeDto.ResolveDisputeRequest) (disputeDto.Dispute, error)
+	}
+)
@@ -0,0 +1,220 @@
+package disputeRepository
+
+import (
+	"bike-rent-express/model/dto/disputeDto"
+	"bike-rent-express/src/dispute"
+	"database/sql"
+	"errors"
+)
+
+const disputeColumns = "id, motor_return_id, user_id, status, reason, COALESCE(resolution::text, ''), refund_amount, COALESCE(resolution_note, ''), created_at, updated_at"
+
+type disputeRepository struct {
+	db *sql.DB
+}
+
+func NewDisputeRepository(db *sql.DB) dispute.DisputeRepository {
+	return &disputeRepository{db}
+}
+
+func (d *disputeRepository) GetDisputedReturn(motorReturnID string) (disputeDto.DisputedReturn, error) {
+	var disputedReturn disputeDto.DisputedReturn
+	query := "SELECT mr.id, t.user_id, mr.extra_charge, mr.created_at FROM motor_return mr JOIN transaction t ON t.id = mr.transaction_id WHERE mr.id = $1;"
+	err := d.db.QueryRow(query, motorReturnID).Scan(&disputedReturn.MotorReturnID, &disputedReturn.UserID, &disputedReturn.ExtraCharge, &disputedReturn.ReturnedAt)
+	if err != nil {
+		return disputedReturn, err
+	}
+
+	return disputedReturn, nil
+}
+
+// Add opens the dispute and records its first event in one database transaction.
+func (d *disputeRepository) Add(request disputeDto.CreateDisputeRequest) (disputeDto.Dispute, error) {
+	newDispute := disputeDto.Dispute{MotorReturnID: request.MotorReturnID, UserID: request.UserID, Reason: request.Reason}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return newDispute, err
+	}
+
+	query := "INSERT INTO dispute(motor_return_id, user_id, reason) VALUES ($1, $2, $3) RETURNING id, status, created_at, updated_at;"
+	if err := tx.QueryRow(query, request.MotorReturnID, request.UserID, request.Reason).Scan(&newDispute.ID, &newDispute.Status, &newDispute.CreatedAt, &newDispute.UpdatedAt); err != nil {
+		tx.Rollback()
+		return newDispute, err
+	}
+
+	query = "INSERT INTO dispute_event(dispute_id, to_status, actor_role, actor, note) VALUES ($1, 'OPEN', 'USER', $2, $3);"
+	if _, err := tx.Exec(query, newDispute.ID, request.Actor, request.Reason); err != nil {
+		tx.Rollback()
+		return newDispute, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return newDispute, err
+	}
+
+	return newDispute, nil
+}
+
+func (d *disputeRepository) GetById(id string) (disputeDto.Dispute, error) {
+	var dispute disputeDto.Dispute
+	query := "SELECT " + disputeColumns + " FROM dispute WHERE id = $1;"
+	if err := scanDispute(d.db.QueryRow(query, id), &dispute); err != nil {
+		return dispute, err
+	}
+
+	query = "SELECT id, author_role, author, message, created_at FROM dispute_message WHERE dispute_id = $1 ORDER BY created_at;"
+	rows, err := d.db.Query(query, id)
+	if err != nil {
+		return dispute, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var message disputeDto.DisputeMessage
+		if err := rows.Scan(&message.ID, &message.AuthorRole, &message.Author, &message.Message, &message.CreatedAt); err != nil {
+			return dispute, err
+		}
+		dispute.Messages = append(dispute.Messages, message)
+	}
+
+	query = "SELECT COALESCE(from_status::text, ''), to_status, actor_role, actor, COALESCE(note, ''), created_at FROM dispute_event WHERE dispute_id = $1 ORDER BY created_at;"
+	eventRows, err := d.db.Query(query, id)
+	if err != nil {
+		return dispute, err
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var event disputeDto.DisputeEvent
+		if err := eventRows.Scan(&event.FromStatus, &event.ToStatus, &event.ActorRole, &event.Actor, &event.Note, &event.CreatedAt); err != nil {
+			return dispute, err
+		}
+		dispute.Events = append(dispute.Events, event)
+	}
+
+	return dispute, nil
+}
+
+func (d *disputeRepository) GetByUser(userID string) ([]disputeDto.Dispute, error) {
+	query := "SELECT " + disputeColumns + " FROM dispute WHERE user_id = $1 ORDER BY created_at DESC;"
+	return d.list(query, userID)
+}
+
+func (d *disputeRepository) GetAll(status string) ([]disputeDto.Dispute, error) {
+	query := "SELECT " + disputeColumns + " FROM dispute WHERE $1 = '' OR status::text = $1 ORDER BY created_at;"
+	return d.list(query, status)
+}
+
+func (d *disputeRepository) list(query string, arg string) ([]disputeDto.Dispute, error) {
+	rows, err := d.db.Query(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disputes []disputeDto.Dispute
+	for rows.Next() {
+		var dispute disputeDto.Dispute
+		if err := scanDispute(rows, &dispute); err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, dispute)
+	}
+
+	return disputes, rows.Err()
+}
+
+func (d *disputeRepository) AddMessage(request disputeDto.DisputeMessageRequest) (disputeDto.DisputeMessage, error) {
+	message := disputeDto.DisputeMessage{AuthorRole: request.AuthorRole, Author: request.Author, Message: request.Message}
+	query := "INSERT INTO dispute_message(dispute_id, author_role, author, message) VALUES ($1, $2, $3, $4) RETURNING id, created_at;"
+	if err := d.db.QueryRow(query, request.DisputeID, request.AuthorRole, request.Author, request.Message).Scan(&message.ID, &message.CreatedAt); err != nil {
+		return message, err
+	}
+
+	return message, nil
+}
+
+// Resolve closes an open dispute. A refund is credited to the customer's balance, written
+// to the balance ledger and taken off the return's extra charge in the same database
+// transaction, so a later amend or void of the return does not refund it again. Fails
+// with "1" when the dispute is no longer open and "2" when the refund exceeds the
+// disputed extra charge.
+func (d *disputeRepository) Resolve(request disputeDto.ResolveDisputeRequest) (disputeDto.Dispute, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return disputeDto.Dispute{}, err
+	}
+
+	var status, userID, motorReturnID string
+	var extraCharge int
+	query := "SELECT d.status, d.user_id, d.motor_return_id, mr.extra_charge FROM dispute d JOIN motor_return mr ON mr.id = d.motor_return_id WHERE d.id = $1 FOR UPDATE OF d, mr;"
+	if err := tx.QueryRow(query, request.DisputeID).Scan(&status, &userID, &motorReturnID, &extraCharge); err != nil {
+		tx.Rollback()
+		return disputeDto.Dispute{}, err
+	}
+
+	if status != "OPEN" {
+		tx.Rollback()
+		return disputeDto.Dispute{}, errors.New("1")
+	}
+
+	var refund int
+	switch request.Resolution {
+	case "FULL_REFUND":
+		refund = extraCharge
+	case "PARTIAL_REFUND":
+		refund = request.RefundAmount
+	}
+
+	if refund > extraCharge {
+		tx.Rollback()
+		return disputeDto.Dispute{}, errors.New("2")
+	}
+
+	query = "UPDATE dispute SET status = 'RESOLVED', resolution = $1, refund_amount = $2, resolution_note = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $4;"
+	if _, err := tx.Exec(query, request.Resolution, refund, request.Note, request.DisputeID); err != nil {
+		tx.Rollback()
+		return disputeDto.Dispute{}, err
+	}
+
+	if refund > 0 {
+		query = "UPDATE balance SET amount = amount + $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2;"
+		if _, err := tx.Exec(query, refund, userID); err != nil {
+			tx.Rollback()
+			return disputeDto.Dispute{}, err
+		}
+
+		query = "INSERT INTO balance_ledger(user_id, amount, kind, reference_id, description) VALUES ($1, $2, 'DISPUTE_REFUND', $3, $4);"
+		if _, err := tx.Exec(query, userID, refund, request.DisputeID, "Refund for dispute "+request.DisputeID); err != nil {
+			tx.Rollback()
+			return disputeDto.Dispute{}, err
+		}
+
+		query = "UPDATE motor_return SET extra_charge = extra_charge - $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;"
+		if _, err := tx.Exec(query, refund, motorReturnID); err != nil {
+			tx.Rollback()
+			return disputeDto.Dispute{}, err
+		}
+	}
+
+	query = "INSERT INTO dispute_event(dispute_id, from_status, to_status, actor_role, actor, note) VALUES ($1, 'OPEN', 'RESOLVED', 'ADMIN', $2, $3);"
+	if _, err := tx.Exec(query, request.DisputeID, request.Actor, request.Resolution+": "+request.Note); err != nil {
+		tx.Rollback()
+		return disputeDto.Dispute{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return disputeDto.Dispute{}, err
+	}
+
+	return d.GetById(request.DisputeID)
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDispute(row scanner, dispute *disputeDto.Dispute) error {
+	return row.Scan(&dispute.ID, &dispute.MotorReturnID, &dispute.UserID, &dispute.Status, &dispute.Reason, &dispute.Resolution, &dispute.RefundAmount, &dispute.ResolutionNote, &dispute.CreatedAt, &dispute.UpdatedAt)
+}
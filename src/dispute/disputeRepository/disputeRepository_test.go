@@ -0,0 +1,221 @@
+package disputeRepository
+
+import (
+	"bike-rent-express/model/dto/disputeDto"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var disputeColumnNames = []string{"id", "motor_return_id", "user_id", "status", "reason", "resolution", "refund_amount", "resolution_note", "created_at", "updated_at"}
+
+var expectDispute = disputeDto.Dispute{
+	ID:            "1",
+	MotorReturnID: "2",
+	UserID:        "3",
+	Status:        "OPEN",
+	Reason:        "Ban sudah bocor sebelum disewa",
+	CreatedAt:     "0000",
+	UpdatedAt:     "0000",
+}
+
+var resolveRequest = disputeDto.ResolveDisputeRequest{
+	Resolution:   "PARTIAL_REFUND",
+	RefundAmount: 10000,
+	Note:         "Foto pickup menunjukkan ban sudah aus",
+	DisputeID:    "1",
+	Actor:        "admin",
+}
+
+func expectGetById(mock sqlmock.Sqlmock, status, resolution string, refund int) {
+	mock.ExpectQuery("SELECT (.+) FROM dispute WHERE id = \\$1;").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(disputeColumnNames).AddRow("1", "2", "3", status, expectDispute.Reason, resolution, refund, "", "0000", "0000"))
+	mock.ExpectQuery("SELECT (.+) FROM dispute_message WHERE dispute_id = \\$1").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "author_role", "author", "message", "created_at"}).AddRow("4", "USER", "billkin", "Lihat foto terlampir", "0000"))
+	mock.ExpectQuery("SELECT (.+) FROM dispute_event WHERE dispute_id = \\$1").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"from_status", "to_status", "actor_role", "actor", "note", "created_at"}).AddRow("", "OPEN", "USER", "billkin", expectDispute.Reason, "0000"))
+}
+
+func TestGetDisputedReturn_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+	returnedAt := time.Date(2024, 3, 7, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM motor_return mr JOIN transaction t ON (.+) WHERE mr.id = \\$1;").WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "extra_charge", "created_at"}).AddRow("2", "3", 25000, returnedAt))
+
+	disputedReturn, err := repository.GetDisputedReturn("2")
+	assert.Nil(t, err)
+	assert.Equal(t, disputeDto.DisputedReturn{MotorReturnID: "2", UserID: "3", ExtraCharge: 25000, ReturnedAt: returnedAt}, disputedReturn)
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+	request := disputeDto.CreateDisputeRequest{MotorReturnID: "2", Reason: expectDispute.Reason, UserID: "3", Actor: "billkin"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO dispute\\((.+)\\) VALUES (.+) RETURNING (.+);").WithArgs("2", "3", expectDispute.Reason).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "created_at", "updated_at"}).AddRow("1", "OPEN", "0000", "0000"))
+	mock.ExpectExec("INSERT INTO dispute_event\\((.+)\\) VALUES \\(\\$1, 'OPEN', 'USER', \\$2, \\$3\\);").WithArgs("1", "billkin", expectDispute.Reason).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	actualDispute, err := repository.Add(request)
+	assert.Nil(t, err)
+	assert.Equal(t, expectDispute, actualDispute)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetById_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+	expectGetById(mock, "OPEN", "", 0)
+
+	actualDispute, err := repository.GetById("1")
+	assert.Nil(t, err)
+	assert.Equal(t, "OPEN", actualDispute.Status)
+	assert.Len(t, actualDispute.Messages, 1)
+	assert.Equal(t, []disputeDto.DisputeEvent{{ToStatus: "OPEN", ActorRole: "USER", Actor: "billkin", Note: expectDispute.Reason, CreatedAt: "0000"}}, actualDispute.Events)
+}
+
+func TestGetAll_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+
+	mock.ExpectQuery("SELECT (.+) FROM dispute WHERE \\$1 = '' OR status::text = \\$1").WithArgs("OPEN").
+		WillReturnRows(sqlmock.NewRows(disputeColumnNames).AddRow("1", "2", "3", "OPEN", expectDispute.Reason, "", 0, "", "0000", "0000"))
+
+	actualDisputes, err := repository.GetAll("OPEN")
+	assert.Nil(t, err)
+	assert.Equal(t, []disputeDto.Dispute{expectDispute}, actualDisputes)
+}
+
+func TestResolve_SuccessPartialRefund(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.status, d.user_id, d.motor_return_id, mr.extra_charge FROM dispute d (.+) FOR UPDATE OF d, mr;").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "user_id", "motor_return_id", "extra_charge"}).AddRow("OPEN", "3", "2", 25000))
+	mock.ExpectExec("UPDATE dispute SET status = 'RESOLVED'").WithArgs("PARTIAL_REFUND", 10000, resolveRequest.Note, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE balance SET amount = amount \\+ \\$1").WithArgs(10000, "3").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO balance_ledger(.+)'DISPUTE_REFUND'").WithArgs("3", 10000, "1", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	// the refunded part no longer counts as charged when the return is amended or voided
+	mock.ExpectExec("UPDATE motor_return SET extra_charge = extra_charge - \\$1").WithArgs(10000, "2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO dispute_event(.+)'OPEN', 'RESOLVED', 'ADMIN'").WithArgs("1", "admin", "PARTIAL_REFUND: "+resolveRequest.Note).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	expectGetById(mock, "RESOLVED", "PARTIAL_REFUND", 10000)
+
+	actualDispute, err := repository.Resolve(resolveRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, "RESOLVED", actualDispute.Status)
+	assert.Equal(t, 10000, actualDispute.RefundAmount)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestResolve_UpholdDoesNotTouchBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+	request := resolveRequest
+	request.Resolution, request.RefundAmount = "UPHOLD", 5000
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.status, d.user_id, d.motor_return_id, mr.extra_charge FROM dispute d").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "user_id", "motor_return_id", "extra_charge"}).AddRow("OPEN", "3", "2", 25000))
+	mock.ExpectExec("UPDATE dispute SET status = 'RESOLVED'").WithArgs("UPHOLD", 0, request.Note, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO dispute_event").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	expectGetById(mock, "RESOLVED", "UPHOLD", 0)
+
+	_, err = repository.Resolve(request)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestResolve_FailedAlreadyResolved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.status, d.user_id, d.motor_return_id, mr.extra_charge FROM dispute d").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "user_id", "motor_return_id", "extra_charge"}).AddRow("RESOLVED", "3", "2", 25000))
+	mock.ExpectRollback()
+
+	_, err = repository.Resolve(resolveRequest)
+	assert.Equal(t, errors.New("1"), err)
+}
+
+func TestResolve_FailedRefundExceedsCharge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.status, d.user_id, d.motor_return_id, mr.extra_charge FROM dispute d").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "user_id", "motor_return_id", "extra_charge"}).AddRow("OPEN", "3", "2", 5000))
+	mock.ExpectRollback()
+
+	_, err = repository.Resolve(resolveRequest)
+	assert.Equal(t, errors.New("2"), err)
+}
+
+func TestResolve_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewDisputeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT d.status, d.user_id, d.motor_return_id, mr.extra_charge FROM dispute d").WithArgs("1").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Resolve(resolveRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
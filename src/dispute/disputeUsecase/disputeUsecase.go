@@ -0,0 +1,117 @@
+package disputeUsecase
+
+import (
+	"bike-rent-express/model/dto/disputeDto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/src/dispute"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+type disputeUsecase struct {
+	disputeRepository dispute.DisputeRepository
+	clock             clock.Clock
+	windowDays        int
+}
+
+func NewDisputeUsecase(disputeRepository dispute.DisputeRepository, clock clock.Clock, windowDays int) dispute.DisputeUsecase {
+	return &disputeUsecase{disputeRepository, clock, windowDays}
+}
+
+// OpenDispute lets a customer contest the extra charge of one of their own motor returns
+// within the dispute window.
+func (d *disputeUsecase) OpenDispute(request disputeDto.CreateDisputeRequest) (disputeDto.Dispute, error) {
+	disputedReturn, err := d.disputeRepository.GetDisputedReturn(request.MotorReturnID)
+	if err != nil {
+		if isNotFound(err) {
+			return disputeDto.Dispute{}, errors.New("1")
+		}
+		return disputeDto.Dispute{}, err
+	}
+
+	if disputedReturn.UserID != request.UserID {
+		return disputeDto.Dispute{}, errors.New("1")
+	}
+
+	deadline := disputedReturn.ReturnedAt.Add(time.Duration(d.windowDays) * 24 * time.Hour)
+	if d.clock.Now().After(deadline) {
+		return disputeDto.Dispute{}, errors.New("2")
+	}
+
+	newDispute, err := d.disputeRepository.Add(request)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return newDispute, errors.New("3")
+		}
+		return newDispute, err
+	}
+
+	return newDispute, nil
+}
+
+func (d *disputeUsecase) GetDisputesByUser(userID string) ([]disputeDto.Dispute, error) {
+	disputes, err := d.disputeRepository.GetByUser(userID)
+	if err != nil && isNotFound(err) {
+		return nil, nil
+	}
+
+	return disputes, err
+}
+
+func (d *disputeUsecase) GetAllDisputes(status string) ([]disputeDto.Dispute, error) {
+	return d.disputeRepository.GetAll(status)
+}
+
+// GetDisputeById hides disputes of other customers when userID is set.
+func (d *disputeUsecase) GetDisputeById(id, userID string) (disputeDto.Dispute, error) {
+	dispute, err := d.disputeRepository.GetById(id)
+	if err != nil {
+		if isNotFound(err) {
+			return dispute, errors.New("1")
+		}
+		return dispute, err
+	}
+
+	if userID != "" && dispute.UserID != userID {
+		return disputeDto.Dispute{}, errors.New("1")
+	}
+
+	return dispute, nil
+}
+
+func (d *disputeUsecase) AddDisputeMessage(request disputeDto.DisputeMessageRequest) (disputeDto.DisputeMessage, error) {
+	dispute, err := d.GetDisputeById(request.DisputeID, request.UserID)
+	if err != nil {
+		return disputeDto.DisputeMessage{}, err
+	}
+
+	if dispute.Status != "OPEN" {
+		return disputeDto.DisputeMessage{}, errors.New("2")
+	}
+
+	return d.disputeRepository.AddMessage(request)
+}
+
+func (d *disputeUsecase) ResolveDispute(request disputeDto.ResolveDisputeRequest) (disputeDto.Dispute, error) {
+	resolved, err := d.disputeRepository.Resolve(request)
+	if err != nil {
+		if isNotFound(err) {
+			return resolved, errors.New("1")
+		}
+		if err.Error() == "1" {
+			return resolved, errors.New("2")
+		}
+		if err.Error() == "2" {
+			return resolved, errors.New("3")
+		}
+		return resolved, err
+	}
+
+	return resolved, nil
+}
+
+func isNotFound(err error) bool {
+	return err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid")
+}
@@ -0,0 +1,153 @@
+package disputeUsecase
+
+import (
+	"bike-rent-express/model/dto/disputeDto"
+	"bike-rent-express/src/dispute"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (f fixedClock) Now() time.Time {
+	return f.now
+}
+
+var returnedAt = time.Date(2024, 3, 7, 10, 0, 0, 0, time.UTC)
+
+var createRequest = disputeDto.CreateDisputeRequest{MotorReturnID: "2", Reason: "Ban sudah bocor sebelum disewa", UserID: "3", Actor: "billkin"}
+
+type mockDisputeRepository struct {
+	mock.Mock
+}
+
+func (m *mockDisputeRepository) GetDisputedReturn(motorReturnID string) (disputeDto.DisputedReturn, error) {
+	args := m.Called(motorReturnID)
+	return args.Get(0).(disputeDto.DisputedReturn), args.Error(1)
+}
+
+func (m *mockDisputeRepository) Add(request disputeDto.CreateDisputeRequest) (disputeDto.Dispute, error) {
+	args := m.Called(request)
+	return args.Get(0).(disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeRepository) GetById(id string) (disputeDto.Dispute, error) {
+	args := m.Called(id)
+	return args.Get(0).(disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeRepository) GetByUser(userID string) ([]disputeDto.Dispute, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeRepository) GetAll(status string) ([]disputeDto.Dispute, error) {
+	args := m.Called(status)
+	return args.Get(0).([]disputeDto.Dispute), args.Error(1)
+}
+
+func (m *mockDisputeRepository) AddMessage(request disputeDto.DisputeMessageRequest) (disputeDto.DisputeMessage, error) {
+	args := m.Called(request)
+	return args.Get(0).(disputeDto.DisputeMessage), args.Error(1)
+}
+
+func (m *mockDisputeRepository) Resolve(request disputeDto.ResolveDisputeRequest) (disputeDto.Dispute, error) {
+	args := m.Called(request)
+	return args.Get(0).(disputeDto.Dispute), args.Error(1)
+}
+
+type DisputeUsecaseTestSuite struct {
+	suite.Suite
+	mockDisputeRepository *mockDisputeRepository
+	disputeUC             dispute.DisputeUsecase
+}
+
+func (suite *DisputeUsecaseTestSuite) SetupTest() {
+	suite.mockDisputeRepository = new(mockDisputeRepository)
+	suite.disputeUC = NewDisputeUsecase(suite.mockDisputeRepository, fixedClock{returnedAt.Add(72 * time.Hour)}, 7)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_Success() {
+	suite.mockDisputeRepository.On("GetDisputedReturn", "2").Return(disputeDto.DisputedReturn{MotorReturnID: "2", UserID: "3", ExtraCharge: 25000, ReturnedAt: returnedAt}, nil)
+	suite.mockDisputeRepository.On("Add", createRequest).Return(disputeDto.Dispute{ID: "1", Status: "OPEN"}, nil)
+
+	newDispute, err := suite.disputeUC.OpenDispute(createRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "OPEN", newDispute.Status)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_FailedOtherCustomer() {
+	suite.mockDisputeRepository.On("GetDisputedReturn", "2").Return(disputeDto.DisputedReturn{MotorReturnID: "2", UserID: "9", ReturnedAt: returnedAt}, nil)
+
+	_, err := suite.disputeUC.OpenDispute(createRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_FailedWindowPassed() {
+	suite.mockDisputeRepository.On("GetDisputedReturn", "2").Return(disputeDto.DisputedReturn{MotorReturnID: "2", UserID: "3", ReturnedAt: returnedAt.Add(-5 * 24 * time.Hour)}, nil)
+
+	_, err := suite.disputeUC.OpenDispute(createRequest)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestOpenDispute_FailedAlreadyDisputed() {
+	suite.mockDisputeRepository.On("GetDisputedReturn", "2").Return(disputeDto.DisputedReturn{MotorReturnID: "2", UserID: "3", ReturnedAt: returnedAt}, nil)
+	suite.mockDisputeRepository.On("Add", createRequest).Return(disputeDto.Dispute{}, errors.New("pq: duplicate key value violates unique constraint"))
+
+	_, err := suite.disputeUC.OpenDispute(createRequest)
+	assert.Equal(suite.T(), errors.New("3"), err)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestGetDisputeById_FailedOtherCustomer() {
+	suite.mockDisputeRepository.On("GetById", "1").Return(disputeDto.Dispute{ID: "1", UserID: "9"}, nil)
+
+	_, err := suite.disputeUC.GetDisputeById("1", "3")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestAddDisputeMessage_FailedResolved() {
+	request := disputeDto.DisputeMessageRequest{Message: "Tolong ditinjau ulang", DisputeID: "1", UserID: "3", AuthorRole: "USER", Author: "billkin"}
+	suite.mockDisputeRepository.On("GetById", "1").Return(disputeDto.Dispute{ID: "1", UserID: "3", Status: "RESOLVED"}, nil)
+
+	_, err := suite.disputeUC.AddDisputeMessage(request)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestAddDisputeMessage_SuccessAdmin() {
+	request := disputeDto.DisputeMessageRequest{Message: "Mohon kirim foto", DisputeID: "1", AuthorRole: "ADMIN", Author: "admin"}
+	suite.mockDisputeRepository.On("GetById", "1").Return(disputeDto.Dispute{ID: "1", UserID: "3", Status: "OPEN"}, nil)
+	suite.mockDisputeRepository.On("AddMessage", request).Return(disputeDto.DisputeMessage{ID: "4", Message: request.Message}, nil)
+
+	message, err := suite.disputeUC.AddDisputeMessage(request)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "4", message.ID)
+}
+
+func (suite *DisputeUsecaseTestSuite) TestResolveDispute_Errors() {
+	cases := map[error]error{
+		sql.ErrNoRows:   errors.New("1"),
+		errors.New("1"): errors.New("2"),
+		errors.New("2"): errors.New("3"),
+	}
+
+	for repositoryErr, expectErr := range cases {
+		suite.SetupTest()
+		request := disputeDto.ResolveDisputeRequest{Resolution: "FULL_REFUND", Note: "Foto pickup menunjukkan ban sudah aus", DisputeID: "1"}
+		suite.mockDisputeRepository.On("Resolve", request).Return(disputeDto.Dispute{}, repositoryErr)
+
+		_, err := suite.disputeUC.ResolveDispute(request)
+		assert.Equal(suite.T(), expectErr, err)
+	}
+}
+
+func TestDisputeUsecase(t *testing.T) {
+	suite.Run(t, new(DisputeUsecaseTestSuite))
+}
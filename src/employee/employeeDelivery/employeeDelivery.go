@@ -22,11 +22,12 @@ func NewEmployeeDelivery(v1Group *gin.RouterGroup, employeeUC employee.EmployeeU
 	{
 		employeeGroup.POST("/register", handler.AddEmployee)
 		employeeGroup.POST("/login", handler.LoginEmployee)
-		employeeGroup.PUT("/:id/change-password", middleware.JWTAuth("ADMIN", "EMPLOYEE"), handler.ChangePassword)
-		employeeGroup.GET("/:id", middleware.JWTAuth("ADMIN", "EMPLOYEE"), handler.GetEmployeById)
-		employeeGroup.GET("", middleware.JWTAuth("ADMIN"), handler.GetEmployeeAll)
-		employeeGroup.PUT("/:id", middleware.JWTAuth("ADMIN", "EMPLOYEE"), handler.UpdateEmployeeById)
-		employeeGroup.DELETE("/:id", middleware.JWTAuth("ADMIN"), handler.DeleteEmployeeById)
+		employeeGroup.PUT("/:id/change-password", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.ChangePassword)
+		employeeGroup.GET("/:id", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.GetEmployeById)
+		employeeGroup.GET("", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER"), handler.GetEmployeeAll)
+		employeeGroup.PUT("/:id", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.UpdateEmployeeById)
+		employeeGroup.PUT("/:id/assignment", middleware.JWTAuth("ADMIN"), handler.AssignEmployee)
+		employeeGroup.DELETE("/:id", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER"), handler.DeleteEmployeeById)
 	}
 }
 
@@ -55,7 +56,7 @@ func (e *employeeDelivery) AddEmployee(c *gin.Context) {
 func (e *employeeDelivery) GetEmployeById(c *gin.Context) {
 	id := c.Param("id")
 
-	resultEmployee, err := e.employeeUC.GetById(id)
+	resultEmployee, err := e.employeeUC.GetById(id, c.GetString("branch_id"))
 	if err != nil {
 		if err.Error() == "1" || errors.Is(sql.ErrNoRows, err) {
 			json.NewResponseSuccess(c, nil, "Data not found", "02", "01")
@@ -69,7 +70,7 @@ func (e *employeeDelivery) GetEmployeById(c *gin.Context) {
 }
 
 func (e *employeeDelivery) GetEmployeeAll(c *gin.Context) {
-	resultEmployee, err := e.employeeUC.Get()
+	resultEmployee, err := e.employeeUC.Get(c.GetString("branch_id"))
 	if err != nil {
 		json.NewResponseError(c, err.Error(), "03", "01")
 		return
@@ -88,6 +89,7 @@ func (e *employeeDelivery) UpdateEmployeeById(c *gin.Context) {
 
 	id := c.Param("id")
 	employeUpdateRequest.ID = id
+	employeUpdateRequest.BranchID = c.GetString("branch_id")
 
 	c.BindJSON(&employeUpdateRequest)
 	if err := utils.Validated(employeUpdateRequest); err != nil {
@@ -108,10 +110,37 @@ func (e *employeeDelivery) UpdateEmployeeById(c *gin.Context) {
 	json.NewResponseSuccess(c, employee, "Success update employee", "04", "01")
 }
 
+func (e *employeeDelivery) AssignEmployee(c *gin.Context) {
+	var assignEmployeeRequest employeeDto.AssignEmployeeRequest
+
+	c.BindJSON(&assignEmployeeRequest)
+	if err := utils.Validated(assignEmployeeRequest); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "08", "01")
+		return
+	}
+	assignEmployeeRequest.ID = c.Param("id")
+
+	employee, err := e.employeeUC.Assign(assignEmployeeRequest)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "08", "02")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Branch not found", "08", "03")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "08", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, employee, "Success assign employee", "08", "01")
+}
+
 func (e *employeeDelivery) DeleteEmployeeById(c *gin.Context) {
 	id := c.Param("id")
 
-	msg, err := e.employeeUC.Delete(id)
+	msg, err := e.employeeUC.Delete(id, c.GetString("branch_id"))
 	if err != nil {
 		if err.Error() == "1" {
 			json.NewResponseBadRequest(c, nil, "Data not found", "05", "01")
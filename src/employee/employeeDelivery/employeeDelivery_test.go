@@ -2,6 +2,7 @@ package employeeDelivery
 
 import (
 	employeeDto "bike-rent-express/model/dto/employee"
+	"bike-rent-express/pkg/middleware"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -37,13 +38,13 @@ func (m *mockEmployeeUsecase) Register(employee employeeDto.CreateEmployeeReques
 	return args.Get(0).(employeeDto.CreateEmployeeRequest), args.Error(1)
 }
 
-func (m *mockEmployeeUsecase) Get() ([]employeeDto.Employee, error) {
-	args := m.Called()
+func (m *mockEmployeeUsecase) Get(branchID string) ([]employeeDto.Employee, error) {
+	args := m.Called(branchID)
 	return args.Get(0).([]employeeDto.Employee), args.Error(1)
 }
 
-func (m *mockEmployeeUsecase) GetById(id string) (employeeDto.Employee, error) {
-	args := m.Called(id)
+func (m *mockEmployeeUsecase) GetById(id string, branchID string) (employeeDto.Employee, error) {
+	args := m.Called(id, branchID)
 	return args.Get(0).(employeeDto.Employee), args.Error(1)
 }
 
@@ -52,8 +53,13 @@ func (m *mockEmployeeUsecase) Update(employeUpdateRequest employeeDto.UpdateEmpl
 	return args.Get(0).(employeeDto.Employee), args.Error(1)
 }
 
-func (m *mockEmployeeUsecase) Delete(id string) (string, error) {
-	args := m.Called(id)
+func (m *mockEmployeeUsecase) Assign(assignEmployeeRequest employeeDto.AssignEmployeeRequest) (employeeDto.Employee, error) {
+	args := m.Called(assignEmployeeRequest)
+	return args.Get(0).(employeeDto.Employee), args.Error(1)
+}
+
+func (m *mockEmployeeUsecase) Delete(id string, branchID string) (string, error) {
+	args := m.Called(id, branchID)
 	return args.String(0), args.Error(1)
 }
 
@@ -161,7 +167,7 @@ func (suite *EmployeeDeliverySuite) TestAddEmployee_FailedUsername() {
 }
 
 func (suite *EmployeeDeliverySuite) TestGetEmployeeById_Success() {
-	suite.mockEmployeeUC.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
+	suite.mockEmployeeUC.On("GetById", expectEmployee.ID, "").Return(expectEmployee, nil)
 
 	w := httptest.NewRecorder()
 	expectResponse := `{"responseCode":"2000202","data":{"id":"ec22df4c-1c1c-4012-9395-bc0994807e35","name":"dino","telp":"0812321412312","username":"dino123","created_at":"2024-03-07T00:00:00Z","updated_at":"2024-03-07T00:00:00Z"}}`
@@ -176,7 +182,7 @@ func (suite *EmployeeDeliverySuite) TestGetEmployeeById_Success() {
 }
 
 func (suite *EmployeeDeliverySuite) TestGetEmployeeById_FailedGetByIdDataNotFound() {
-	suite.mockEmployeeUC.On("GetById", expectEmployee.ID).Return(expectEmployee, errors.New("1"))
+	suite.mockEmployeeUC.On("GetById", expectEmployee.ID, "").Return(expectEmployee, errors.New("1"))
 
 	w := httptest.NewRecorder()
 	expectResponse := `{"responseCode":"2000201","responseMessage":"Data not found"}`
@@ -191,7 +197,7 @@ func (suite *EmployeeDeliverySuite) TestGetEmployeeById_FailedGetByIdDataNotFoun
 }
 
 func (suite *EmployeeDeliverySuite) TestGetEmployeeById_FailedGetById() {
-	suite.mockEmployeeUC.On("GetById", expectEmployee.ID).Return(expectEmployee, errors.New("error"))
+	suite.mockEmployeeUC.On("GetById", expectEmployee.ID, "").Return(expectEmployee, errors.New("error"))
 
 	w := httptest.NewRecorder()
 	expectResponse := `{"responseCode":"5000201","responseMessage":"internal server error","error":"error"}`
@@ -207,7 +213,7 @@ func (suite *EmployeeDeliverySuite) TestGetEmployeeById_FailedGetById() {
 
 func (suite *EmployeeDeliverySuite) TestGetAllEmployee_Success() {
 	expectResponse := `{"responseCode":"2000302","responseMessage":"Success Get All Employee","data":[{"id":"ec22df4c-1c1c-4012-9395-bc0994807e35","name":"dino","telp":"0812321412312","username":"dino123","created_at":"2024-03-07T00:00:00Z","updated_at":"2024-03-07T00:00:00Z"}]}`
-	suite.mockEmployeeUC.On("Get").Return([]employeeDto.Employee{expectEmployee}, nil)
+	suite.mockEmployeeUC.On("Get", "").Return([]employeeDto.Employee{expectEmployee}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee", nil)
@@ -219,7 +225,7 @@ func (suite *EmployeeDeliverySuite) TestGetAllEmployee_Success() {
 }
 func (suite *EmployeeDeliverySuite) TestGetAllEmployee_FailedDataEmpty() {
 	expectResponse := `{"responseCode":"2000301","responseMessage":"Data empty"}`
-	suite.mockEmployeeUC.On("Get").Return([]employeeDto.Employee{}, nil)
+	suite.mockEmployeeUC.On("Get", "").Return([]employeeDto.Employee{}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee", nil)
@@ -232,7 +238,7 @@ func (suite *EmployeeDeliverySuite) TestGetAllEmployee_FailedDataEmpty() {
 
 func (suite *EmployeeDeliverySuite) TestGetAllEmployee_Failed() {
 	expectResponse := `{"responseCode":"5000301","responseMessage":"internal server error","error":"error"}`
-	suite.mockEmployeeUC.On("Get").Return([]employeeDto.Employee{expectEmployee}, errors.New("error"))
+	suite.mockEmployeeUC.On("Get", "").Return([]employeeDto.Employee{expectEmployee}, errors.New("error"))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee", nil)
@@ -324,7 +330,7 @@ func (suite *EmployeeDeliverySuite) TestUpdateEmployee_Failed() {
 
 func (suite *EmployeeDeliverySuite) TestDeleteEmployee_Success() {
 	expectResponse := `{"responseCode":"2000502","responseMessage":"Sucessfully delete employee"}`
-	suite.mockEmployeeUC.On("Delete", expectEmployee.ID).Return("Sucessfully delete employee", nil)
+	suite.mockEmployeeUC.On("Delete", expectEmployee.ID, "").Return("Sucessfully delete employee", nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/employee/"+expectEmployee.ID, nil)
@@ -337,7 +343,7 @@ func (suite *EmployeeDeliverySuite) TestDeleteEmployee_Success() {
 
 func (suite *EmployeeDeliverySuite) TestDeleteEmployee_FailedDataNotFound() {
 	expectResponse := `{"responseCode":"4000501","responseMessage":"Data not found"}`
-	suite.mockEmployeeUC.On("Delete", expectEmployee.ID).Return("Sucessfully delete employee", errors.New("1"))
+	suite.mockEmployeeUC.On("Delete", expectEmployee.ID, "").Return("Sucessfully delete employee", errors.New("1"))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/employee/"+expectEmployee.ID, nil)
@@ -350,7 +356,7 @@ func (suite *EmployeeDeliverySuite) TestDeleteEmployee_FailedDataNotFound() {
 
 func (suite *EmployeeDeliverySuite) TestDeleteEmployee_Failed() {
 	expectResponse := `{"responseCode":"5000501","responseMessage":"internal server error","error":"errror"}`
-	suite.mockEmployeeUC.On("Delete", expectEmployee.ID).Return("Sucessfully delete employee", errors.New("errror"))
+	suite.mockEmployeeUC.On("Delete", expectEmployee.ID, "").Return("Sucessfully delete employee", errors.New("errror"))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/employee/"+expectEmployee.ID, nil)
@@ -573,6 +579,77 @@ func (suite *EmployeeDeliverySuite) TestChangePassword_Failed() {
 	assert.Equal(suite.T(), expectResponse, w.Body.String())
 }
 
+func (suite *EmployeeDeliverySuite) TestGetAllEmployee_SuccessBranchManager() {
+	branchId := "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+	token, err := middleware.GenerateBranchTokenJwt("manager", "BRANCH_MANAGER", branchId)
+	suite.Require().Nil(err)
+
+	// the list is held to the branch of the manager
+	suite.mockEmployeeUC.On("Get", branchId).Return([]employeeDto.Employee{expectEmployee}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee", nil)
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	suite.mockEmployeeUC.AssertExpectations(suite.T())
+}
+
+func (suite *EmployeeDeliverySuite) adminToken() string {
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	return "Bearer " + token
+}
+
+func (suite *EmployeeDeliverySuite) TestAssignEmployee_Success() {
+	assignEmployeeRequest := employeeDto.AssignEmployeeRequest{
+		ID:       expectEmployee.ID,
+		Role:     "BRANCH_MANAGER",
+		BranchID: "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+	}
+	manager := expectEmployee
+	manager.Role = assignEmployeeRequest.Role
+	manager.BranchID = assignEmployeeRequest.BranchID
+	expectResponse := `{"responseCode":"2000801","responseMessage":"Success assign employee","data":{"id":"ec22df4c-1c1c-4012-9395-bc0994807e35","name":"dino","telp":"0812321412312","username":"dino123","role":"BRANCH_MANAGER","branch_id":"5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d","created_at":"2024-03-07T00:00:00Z","updated_at":"2024-03-07T00:00:00Z"}}`
+
+	suite.mockEmployeeUC.On("Assign", assignEmployeeRequest).Return(manager, nil)
+
+	w := httptest.NewRecorder()
+	json, _ := json.Marshal(assignEmployeeRequest)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/"+expectEmployee.ID+"/assignment", bytes.NewBuffer(json))
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *EmployeeDeliverySuite) TestAssignEmployee_FailedManagerWithoutBranch() {
+	w := httptest.NewRecorder()
+	json, _ := json.Marshal(employeeDto.AssignEmployeeRequest{Role: "BRANCH_MANAGER"})
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/"+expectEmployee.ID+"/assignment", bytes.NewBuffer(json))
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"4000801"`)
+	assert.Contains(suite.T(), w.Body.String(), `"field":"BranchID"`)
+}
+
+func (suite *EmployeeDeliverySuite) TestAssignEmployee_FailedBranchManager() {
+	token, err := middleware.GenerateBranchTokenJwt("manager", "BRANCH_MANAGER", "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	suite.Require().Nil(err)
+
+	w := httptest.NewRecorder()
+	json, _ := json.Marshal(employeeDto.AssignEmployeeRequest{Role: "BRANCH_MANAGER", BranchID: "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"})
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/employee/"+expectEmployee.ID+"/assignment", bytes.NewBuffer(json))
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
 func TestEmployeeDelivery(t *testing.T) {
 	suite.Run(t, new(EmployeeDeliverySuite))
 }
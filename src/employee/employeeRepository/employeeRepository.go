@@ -36,9 +36,9 @@ func (e *employeeRepository) Add(employee employeeDto.CreateEmployeeRequest) (em
 
 func (e *employeeRepository) GetByUsername(username string) (employeeDto.Employee, error) {
 	var employee employeeDto.Employee
-	query := "SELECT id, name, telp, username, password, created_at, updated_at FROM employee WHERE username = $1 AND deleted_at IS NULL;"
+	query := "SELECT id, name, telp, username, password, role, COALESCE(branch_id::text, ''), created_at, updated_at FROM employee WHERE username = $1 AND deleted_at IS NULL;"
 
-	if err := e.db.QueryRow(query, username).Scan(&employee.ID, &employee.Name, &employee.Telp, &employee.Username, &employee.Password, &employee.CreatedAt, &employee.UpdatedAt); err != nil {
+	if err := e.db.QueryRow(query, username).Scan(&employee.ID, &employee.Name, &employee.Telp, &employee.Username, &employee.Password, &employee.Role, &employee.BranchID, &employee.CreatedAt, &employee.UpdatedAt); err != nil {
 		return employee, err
 	}
 
@@ -56,18 +56,19 @@ func (e *employeeRepository) UsernameIsReady(username string) (bool, error) {
 	return result == 0, nil
 }
 
-func (e *employeeRepository) Get() ([]employeeDto.Employee, error) {
-	query := "SELECT id, name, telp, username, created_at, updated_at FROM employee WHERE deleted_at IS NULL;"
+// Get lists the employees of branchID, all of them when branchID is empty.
+func (e *employeeRepository) Get(branchID string) ([]employeeDto.Employee, error) {
+	query := "SELECT id, name, telp, username, role, COALESCE(branch_id::text, ''), created_at, updated_at FROM employee WHERE deleted_at IS NULL AND ($1 = '' OR branch_id::text = $1);"
 
 	var employee []employeeDto.Employee
-	row, err := e.db.Query(query)
+	row, err := e.db.Query(query, branchID)
 	if err != nil {
 		return employee, err
 	}
 
 	for row.Next() {
 		var employe employeeDto.Employee
-		err := row.Scan(&employe.ID, &employe.Name, &employe.Telp, &employe.Username, &employe.CreatedAt, &employe.UpdatedAt)
+		err := row.Scan(&employe.ID, &employe.Name, &employe.Telp, &employe.Username, &employe.Role, &employe.BranchID, &employe.CreatedAt, &employe.UpdatedAt)
 		if err != nil {
 			return employee, err
 		}
@@ -80,8 +81,8 @@ func (e *employeeRepository) Get() ([]employeeDto.Employee, error) {
 func (e *employeeRepository) GetById(id string) (employeeDto.Employee, error) {
 
 	var employee employeeDto.Employee
-	query := "SELECT id, name, telp, username, password, created_at, updated_at FROM employee WHERE id = $1 AND deleted_at IS NULL;"
-	if err := e.db.QueryRow(query, id).Scan(&employee.ID, &employee.Name, &employee.Telp, &employee.Username, &employee.Password, &employee.CreatedAt, &employee.UpdatedAt); err != nil {
+	query := "SELECT id, name, telp, username, password, role, COALESCE(branch_id::text, ''), created_at, updated_at FROM employee WHERE id = $1 AND deleted_at IS NULL;"
+	if err := e.db.QueryRow(query, id).Scan(&employee.ID, &employee.Name, &employee.Telp, &employee.Username, &employee.Password, &employee.Role, &employee.BranchID, &employee.CreatedAt, &employee.UpdatedAt); err != nil {
 		return employee, err
 	}
 
@@ -102,6 +103,25 @@ func (e *employeeRepository) Update(employeeUpdateRequest employeeDto.UpdateEmpl
 	return employee, nil
 }
 
+func (e *employeeRepository) Assign(assignEmployeeRequest employeeDto.AssignEmployeeRequest) (employeeDto.Employee, error) {
+	query := "UPDATE employee SET role = $1, branch_id = NULLIF($2, '')::uuid, updated_at = CURRENT_TIMESTAMP WHERE id = $3 AND deleted_at IS NULL;"
+	result, err := e.db.Exec(query, assignEmployeeRequest.Role, assignEmployeeRequest.BranchID, assignEmployeeRequest.ID)
+	if err != nil {
+		return employeeDto.Employee{}, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return employeeDto.Employee{}, err
+	}
+
+	if affected == 0 {
+		return employeeDto.Employee{}, sql.ErrNoRows
+	}
+
+	return e.GetById(assignEmployeeRequest.ID)
+}
+
 func (e *employeeRepository) Delete(id string) (string, error) {
 
 	query := "UPDATE employee SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;"
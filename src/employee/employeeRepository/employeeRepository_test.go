@@ -2,6 +2,7 @@ package employeeRepository
 
 import (
 	employeeDto "bike-rent-express/model/dto/employee"
+	"database/sql"
 	"database/sql/driver"
 	"testing"
 
@@ -15,6 +16,7 @@ var expectEmployee = employeeDto.Employee{
 	Telp:      "0812321412312",
 	Username:  "dino123",
 	Password:  "dino12345",
+	Role:      "EMPLOYEE",
 	CreatedAt: "2024-03-07T00:00:00Z",
 	UpdatedAt: "2024-03-07T00:00:00Z",
 }
@@ -30,7 +32,7 @@ func TestGetById_Success(t *testing.T) {
 
 	query := "SELECT (.+) FROM employee WHERE .+ = \\$1 AND deleted_at IS NULL;"
 
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.BranchID, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -70,7 +72,7 @@ func TestGetByUsername_Success(t *testing.T) {
 
 	query := "SELECT (.+) FROM employee WHERE .+ = \\$1 AND deleted_at IS NULL;"
 
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.BranchID, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
 	employee, err := employeeRepository.GetByUsername(expectEmployee.ID)
@@ -143,13 +145,15 @@ func TestGet_Success(t *testing.T) {
 
 	employeeRepository := NewEmployeeRepository(dbMock)
 
-	query := "SELECT (.+) FROM employee WHERE .+ IS NULL;"
+	query := "SELECT (.+) FROM employee WHERE deleted_at IS NULL AND .+;"
 	values := [][]driver.Value{
 		{
 			expectEmployee.ID,
 			expectEmployee.Name,
 			expectEmployee.Telp,
 			expectEmployee.Username,
+			expectEmployee.Role,
+			expectEmployee.BranchID,
 			expectEmployee.CreatedAt,
 			expectEmployee.UpdatedAt,
 		},
@@ -161,15 +165,16 @@ func TestGet_Success(t *testing.T) {
 			Name:      expectEmployee.Name,
 			Telp:      expectEmployee.Telp,
 			Username:  expectEmployee.Username,
+			Role:      expectEmployee.Role,
 			CreatedAt: expectEmployee.CreatedAt,
 			UpdatedAt: expectEmployee.UpdatedAt,
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+"}).AddRows(values...)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRows(values...)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	actualAllEmployee, err := employeeRepository.Get()
+	actualAllEmployee, err := employeeRepository.Get("")
 	assert.Nil(t, err)
 	assert.Equal(t, expectedAllEmployee, actualAllEmployee)
 }
@@ -183,7 +188,7 @@ func TestGet_Failed(t *testing.T) {
 
 	employeeRepository := NewEmployeeRepository(dbMock)
 
-	query := "SELECT .+, .+, .+, .+, .+, .+ FROM employee WHERE .+ ;"
+	query := "SELECT .+, .+, .+, .+, .+, .+, .+, .+ FROM employee WHERE .+ ;"
 	values := [][]driver.Value{}
 
 	expectedAllEmployee := []employeeDto.Employee{
@@ -192,15 +197,16 @@ func TestGet_Failed(t *testing.T) {
 			Name:      expectEmployee.Name,
 			Telp:      expectEmployee.Telp,
 			Username:  expectEmployee.Username,
+			Role:      expectEmployee.Role,
 			CreatedAt: expectEmployee.CreatedAt,
 			UpdatedAt: expectEmployee.UpdatedAt,
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+"}).AddRows(values...)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRows(values...)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	actualAllEmployee, err := employeeRepository.Get()
+	actualAllEmployee, err := employeeRepository.Get("")
 	assert.NotNil(t, err)
 	assert.Error(t, err)
 	assert.NotEqual(t, expectedAllEmployee, actualAllEmployee)
@@ -354,7 +360,7 @@ func TestUpdate_Success(t *testing.T) {
 	mock.ExpectExec(query).WithArgs(expectEmployeeRequest.Name, expectEmployeeRequest.Telp, expectEmployeeRequest.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	query = "SELECT (.+) FROM employee .+"
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.BranchID, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -380,7 +386,7 @@ func TestUpdate_FailedQuery(t *testing.T) {
 	mock.ExpectExec(query).WithArgs(expectEmployeeRequest.Name, expectEmployeeRequest.Telp, expectEmployeeRequest.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	query = "SELECT (.+) FROM employee .+"
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.BranchID, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -407,7 +413,7 @@ func TestUpdate_FailedGetById(t *testing.T) {
 	mock.ExpectExec(query).WithArgs(expectEmployeeRequest.Name, expectEmployeeRequest.Telp, expectEmployeeRequest.ID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	query = "SELECT (.+) FROM employees .+"
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.BranchID, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -473,3 +479,63 @@ func TestDeleteSuccess_Failed(t *testing.T) {
 	assert.NotEqual(t, expectResult, actualResult)
 
 }
+
+func TestGet_SuccessByBranch(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	employeeRepository := NewEmployeeRepository(dbMock)
+	branchId := "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+
+	query := "SELECT (.+) FROM employee WHERE deleted_at IS NULL AND \\(\\$1 = '' OR branch_id::text = \\$1\\);"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, "BRANCH_MANAGER", branchId, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	mock.ExpectQuery(query).WithArgs(branchId).WillReturnRows(rows)
+
+	actualAllEmployee, err := employeeRepository.Get(branchId)
+	assert.Nil(t, err)
+	assert.Len(t, actualAllEmployee, 1)
+	assert.Equal(t, branchId, actualAllEmployee[0].BranchID)
+}
+
+func TestAssign_Success(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	employeeRepository := NewEmployeeRepository(dbMock)
+	assignEmployeeRequest := employeeDto.AssignEmployeeRequest{ID: expectEmployee.ID, Role: "BRANCH_MANAGER", BranchID: "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+
+	query := "UPDATE employee SET role = \\$1, branch_id = (.+) WHERE id = \\$3 AND deleted_at IS NULL;"
+	mock.ExpectExec(query).WithArgs(assignEmployeeRequest.Role, assignEmployeeRequest.BranchID, assignEmployeeRequest.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "SELECT (.+) FROM employee WHERE id = \\$1 AND deleted_at IS NULL;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Telp, expectEmployee.Username, expectEmployee.Password, assignEmployeeRequest.Role, assignEmployeeRequest.BranchID, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	mock.ExpectQuery(query).WithArgs(expectEmployee.ID).WillReturnRows(rows)
+
+	employee, err := employeeRepository.Assign(assignEmployeeRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, "BRANCH_MANAGER", employee.Role)
+	assert.Equal(t, assignEmployeeRequest.BranchID, employee.BranchID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAssign_FailedNotFound(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	employeeRepository := NewEmployeeRepository(dbMock)
+
+	query := "UPDATE employee SET role .+"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err = employeeRepository.Assign(employeeDto.AssignEmployeeRequest{ID: expectEmployee.ID, Role: "EMPLOYEE"})
+	assert.Equal(t, sql.ErrNoRows, err)
+}
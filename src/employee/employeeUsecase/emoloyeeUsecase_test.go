@@ -1,12 +1,14 @@
 package employeeUsecase
 
 import (
+	"bike-rent-express/model"
 	employeeDto "bike-rent-express/model/dto/employee"
 	"bike-rent-express/src/employee"
 	"database/sql"
 	"errors"
 	"testing"
 
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -31,8 +33,8 @@ func (m *mockEmployeeRepository) Add(employee employeeDto.CreateEmployeeRequest)
 	return args.Get(0).(employeeDto.CreateEmployeeRequest), args.Error(1)
 }
 
-func (m *mockEmployeeRepository) Get() ([]employeeDto.Employee, error) {
-	args := m.Called()
+func (m *mockEmployeeRepository) Get(branchID string) ([]employeeDto.Employee, error) {
+	args := m.Called(branchID)
 	return args.Get(0).([]employeeDto.Employee), args.Error(1)
 }
 
@@ -61,6 +63,11 @@ func (m *mockEmployeeRepository) UpdatePassword(employee employeeDto.Employee) e
 	return args.Error(0)
 }
 
+func (m *mockEmployeeRepository) Assign(assignEmployeeRequest employeeDto.AssignEmployeeRequest) (employeeDto.Employee, error) {
+	args := m.Called(assignEmployeeRequest)
+	return args.Get(0).(employeeDto.Employee), args.Error(1)
+}
+
 func (m *mockEmployeeRepository) Delete(id string) (string, error) {
 	args := m.Called(id)
 	return args.String(0), args.Error(1)
@@ -114,8 +121,8 @@ func (suite *EmployeeUCTestSuite) TestGet_Success() {
 		expectEmployee,
 	}
 
-	suite.mockEmployeeRepository.On("Get").Return(expectAllEmployee, nil)
-	actualGetEmployee, err := suite.employeeUC.Get()
+	suite.mockEmployeeRepository.On("Get", "").Return(expectAllEmployee, nil)
+	actualGetEmployee, err := suite.employeeUC.Get("")
 
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.Nil(suite.T(), err)
@@ -128,9 +135,9 @@ func (suite *EmployeeUCTestSuite) TestGet_Failed() {
 	}
 
 	expectError := errors.New("error")
-	suite.mockEmployeeRepository.On("Get").Return([]employeeDto.Employee{}, expectError)
+	suite.mockEmployeeRepository.On("Get", "").Return([]employeeDto.Employee{}, expectError)
 
-	actualGetEmployee, err := suite.employeeUC.Get()
+	actualGetEmployee, err := suite.employeeUC.Get("")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.NotNil(suite.T(), err)
 	assert.NotEqual(suite.T(), expectAllEmployee, actualGetEmployee)
@@ -141,7 +148,7 @@ func (suite *EmployeeUCTestSuite) TestGetById_Success() {
 
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
 
-	actualEmployee, err := suite.employeeUC.GetById(expectEmployee.ID)
+	actualEmployee, err := suite.employeeUC.GetById(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, actualEmployee)
@@ -150,7 +157,7 @@ func (suite *EmployeeUCTestSuite) TestGetById_Success() {
 func (suite *EmployeeUCTestSuite) TestGetById_FailedInvalidInput() {
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(employeeDto.Employee{}, errors.New("invalid input syntax for type uuid"))
 
-	actualEmployee, err := suite.employeeUC.GetById(expectEmployee.ID)
+	actualEmployee, err := suite.employeeUC.GetById(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -160,7 +167,7 @@ func (suite *EmployeeUCTestSuite) TestGetById_FailedInvalidInput() {
 func (suite *EmployeeUCTestSuite) TestGetById_FailedNoRows() {
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(employeeDto.Employee{}, sql.ErrNoRows)
 
-	actualEmployee, err := suite.employeeUC.GetById(expectEmployee.ID)
+	actualEmployee, err := suite.employeeUC.GetById(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), sql.ErrNoRows, err)
@@ -220,7 +227,7 @@ func (suite *EmployeeUCTestSuite) TestDelete_Success() {
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
 	suite.mockEmployeeRepository.On("Delete", expectEmployee.ID).Return(expectResult, nil)
 
-	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID)
+	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectResult, actualResult)
@@ -231,7 +238,7 @@ func (suite *EmployeeUCTestSuite) TestDelete_FailedInvalidInputOrSqlNoRows() {
 
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, sql.ErrNoRows)
 
-	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID)
+	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -243,7 +250,7 @@ func (suite *EmployeeUCTestSuite) TestDelete_FailedGetById() {
 
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, errors.New("error"))
 
-	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID)
+	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -256,7 +263,7 @@ func (suite *EmployeeUCTestSuite) TestDelete_Failed() {
 	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
 	suite.mockEmployeeRepository.On("Delete", expectEmployee.ID).Return("", errors.New("error"))
 
-	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID)
+	actualResult, err := suite.employeeUC.Delete(expectEmployee.ID, "")
 	suite.mockEmployeeRepository.AssertExpectations(suite.T())
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -350,6 +357,60 @@ func (suite *EmployeeUCTestSuite) TestChangePassword_FailedGetById() {
 	assert.Error(suite.T(), err)
 }
 
+func (suite *EmployeeUCTestSuite) TestGetById_FailedOtherBranch() {
+	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
+
+	_, err := suite.employeeUC.GetById(expectEmployee.ID, "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *EmployeeUCTestSuite) TestUpdate_FailedOtherBranch() {
+	updateRequest := employeeDto.UpdateEmployeeRequest{ID: expectEmployee.ID, Name: "dino", Telp: "0812", BranchID: "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
+
+	_, err := suite.employeeUC.Update(updateRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+	suite.mockEmployeeRepository.AssertNotCalled(suite.T(), "Update", updateRequest)
+}
+
+func (suite *EmployeeUCTestSuite) TestDelete_FailedOtherBranch() {
+	suite.mockEmployeeRepository.On("GetById", expectEmployee.ID).Return(expectEmployee, nil)
+
+	_, err := suite.employeeUC.Delete(expectEmployee.ID, "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	assert.Equal(suite.T(), errors.New("1"), err)
+	suite.mockEmployeeRepository.AssertNotCalled(suite.T(), "Delete", expectEmployee.ID)
+}
+
+func (suite *EmployeeUCTestSuite) TestAssign_FailedBranchNotFound() {
+	assignRequest := employeeDto.AssignEmployeeRequest{ID: expectEmployee.ID, Role: "BRANCH_MANAGER", BranchID: "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+	suite.mockEmployeeRepository.On("Assign", assignRequest).Return(employeeDto.Employee{}, errors.New(`pq: insert or update on table "employee" violates foreign key constraint "employee_branch_id_fkey"`))
+
+	_, err := suite.employeeUC.Assign(assignRequest)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *EmployeeUCTestSuite) TestLogin_SuccessBranchManager() {
+	manager := expectEmployee
+	manager.Role = "BRANCH_MANAGER"
+	manager.BranchID = "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+	loginRequest := employeeDto.LoginRequest{
+		Username: manager.Username,
+		Password: "daniel",
+	}
+
+	suite.mockEmployeeRepository.On("GetByUsername", loginRequest.Username).Return(manager, nil)
+
+	loginResponse, err := suite.employeeUC.Login(loginRequest)
+	assert.Nil(suite.T(), err)
+
+	// the token carries the role and the branch the manager is scoped to
+	claims := &model.JWTClaim{}
+	_, _, err = new(jwt.Parser).ParseUnverified(loginResponse.AccessToken, claims)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "BRANCH_MANAGER", claims.Roles)
+	assert.Equal(suite.T(), manager.BranchID, claims.BranchID)
+}
+
 func TestEmployeeUCTestSuite(t *testing.T) {
 	suite.Run(t, new(EmployeeUCTestSuite))
 }
@@ -35,8 +35,8 @@ func (e *employeeUsecase) Register(employee employeeDto.CreateEmployeeRequest) (
 	return returnEmployee, nil
 }
 
-func (e *employeeUsecase) Get() ([]employeeDto.Employee, error) {
-	employee, err := e.employeeRepository.Get()
+func (e *employeeUsecase) Get(branchID string) ([]employeeDto.Employee, error) {
+	employee, err := e.employeeRepository.Get(branchID)
 
 	if err != nil {
 		return employee, err
@@ -45,7 +45,7 @@ func (e *employeeUsecase) Get() ([]employeeDto.Employee, error) {
 	return employee, nil
 }
 
-func (e *employeeUsecase) GetById(id string) (employeeDto.Employee, error) {
+func (e *employeeUsecase) GetById(id string, branchID string) (employeeDto.Employee, error) {
 	employee, err := e.employeeRepository.GetById(id)
 
 	if err != nil {
@@ -55,10 +55,20 @@ func (e *employeeUsecase) GetById(id string) (employeeDto.Employee, error) {
 		return employee, err
 	}
 
+	if !inBranch(employee, branchID) {
+		return employeeDto.Employee{}, errors.New("1")
+	}
+
 	return employee, nil
 }
 
 func (e *employeeUsecase) Update(employeUpdateRequest employeeDto.UpdateEmployeeRequest) (employeeDto.Employee, error) {
+	if employeUpdateRequest.BranchID != "" {
+		if _, err := e.GetById(employeUpdateRequest.ID, employeUpdateRequest.BranchID); err != nil {
+			return employeeDto.Employee{}, err
+		}
+	}
+
 	employee, err := e.employeeRepository.Update(employeUpdateRequest)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid input syntax for type uuid") {
@@ -70,8 +80,23 @@ func (e *employeeUsecase) Update(employeUpdateRequest employeeDto.UpdateEmployee
 	return employee, err
 }
 
-func (e *employeeUsecase) Delete(id string) (string, error) {
-	_, err := e.employeeRepository.GetById(id)
+func (e *employeeUsecase) Assign(assignEmployeeRequest employeeDto.AssignEmployeeRequest) (employeeDto.Employee, error) {
+	employee, err := e.employeeRepository.Assign(assignEmployeeRequest)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
+			return employee, errors.New("1")
+		}
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return employee, errors.New("2")
+		}
+		return employee, err
+	}
+
+	return employee, nil
+}
+
+func (e *employeeUsecase) Delete(id string, branchID string) (string, error) {
+	employee, err := e.employeeRepository.GetById(id)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
 			return "", errors.New("1")
@@ -79,6 +104,10 @@ func (e *employeeUsecase) Delete(id string) (string, error) {
 		return "", err
 	}
 
+	if !inBranch(employee, branchID) {
+		return "", errors.New("1")
+	}
+
 	resultDelete, err := e.employeeRepository.Delete(id)
 	if err != nil {
 		return resultDelete, err
@@ -101,7 +130,7 @@ func (e *employeeUsecase) Login(loginRequest employeeDto.LoginRequest) (employee
 		return employeeDto.LoginResponse{}, errors.New("2")
 	}
 
-	token, err := middleware.GenerateTokenJwt(employee.Username, "EMPLOYEE")
+	token, err := middleware.GenerateBranchTokenJwt(employee.Username, employee.Role, employee.BranchID)
 	if err != nil {
 		return employeeDto.LoginResponse{}, err
 	}
@@ -138,3 +167,9 @@ func (e *employeeUsecase) ChangePassword(id string, changePasswordRequest employ
 
 	return err
 }
+
+// inBranch tells whether a caller scoped to branchID may see the employee, callers without a
+// branch see every employee.
+func inBranch(employee employeeDto.Employee, branchID string) bool {
+	return branchID == "" || employee.BranchID == branchID
+}
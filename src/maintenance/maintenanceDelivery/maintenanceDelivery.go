@@ -0,0 +1,256 @@
+package maintenanceDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/maintenance"
+
+	"github.com/gin-gonic/gin"
+)
+
+type maintenanceDelivery struct {
+	maintenanceUC maintenance.MaintenanceUsecase
+}
+
+func NewMaintenanceDelivery(v1Group *gin.RouterGroup, maintenanceUC maintenance.MaintenanceUsecase) {
+	handler := maintenanceDelivery{maintenanceUC}
+
+	maintenanceGroup := v1Group.Group("/maintenance")
+	{
+		maintenanceGroup.GET("", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.GetAllMaintenance)
+		maintenanceGroup.GET("/:id", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.GetMaintenanceById)
+		maintenanceGroup.POST("", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.CreateMaintenance)
+		maintenanceGroup.PUT("/:id/start", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.StartMaintenance)
+		maintenanceGroup.PUT("/:id/complete", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.CompleteMaintenance)
+		maintenanceGroup.PUT("/:id/cancel", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.CancelMaintenance)
+
+		maintenanceGroup.GET("/schedules", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.GetAllSchedule)
+		maintenanceGroup.GET("/schedules/:id", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.GetScheduleById)
+		maintenanceGroup.POST("/schedules", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER"), handler.CreateSchedule)
+		maintenanceGroup.PUT("/schedules/:id", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER"), handler.UpdateSchedule)
+		maintenanceGroup.DELETE("/schedules/:id", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER"), handler.DeleteSchedule)
+	}
+}
+
+func (m *maintenanceDelivery) GetAllMaintenance(ctx *gin.Context) {
+	spec, errs := queryspec.Parse(ctx.Request.URL.Query(), maintenance.Query)
+	if errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "01", "01")
+		return
+	}
+
+	tickets, page, err := m.maintenanceUC.GetAllMaintenance(spec)
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	if len(tickets) == 0 {
+		json.NewResponseSuccessPaged(ctx, []maintenanceDto.Maintenance{}, page, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccessPaged(ctx, tickets, page, "Success get all maintenance", "01", "02")
+}
+
+func (m *maintenanceDelivery) GetMaintenanceById(ctx *gin.Context) {
+	ticket, err := m.maintenanceUC.GetMaintenanceById(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "02", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, ticket, "Success get maintenance by id", "02", "02")
+}
+
+func (m *maintenanceDelivery) CreateMaintenance(ctx *gin.Context) {
+	var request maintenanceDto.CreateMaintenanceRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "03", "01")
+		return
+	}
+
+	ticket, err := m.maintenanceUC.CreateMaintenance(request)
+	if err != nil {
+		switch err.Error() {
+		case "3":
+			json.NewResponseBadRequest(ctx, nil, "motor vehicle not found", "03", "02")
+		case "4":
+			json.NewResponseBadRequest(ctx, nil, "end date must be after the start date", "03", "03")
+		case "5":
+			json.NewResponseBadRequest(ctx, nil, "the motor vehicle is rented during the maintenance", "03", "04")
+		case "6":
+			json.NewResponseBadRequest(ctx, nil, "maintenance schedule not found for the motor vehicle", "03", "05")
+		default:
+			json.NewResponseError(ctx, err.Error(), "03", "01")
+		}
+		return
+	}
+
+	json.NewResponseCreated(ctx, ticket, "Maintenance scheduled", "03", "01")
+}
+
+func (m *maintenanceDelivery) StartMaintenance(ctx *gin.Context) {
+	ticket, err := m.maintenanceUC.StartMaintenance(ctx.Param("id"), ctx.GetString("username"))
+	if err != nil {
+		switch err.Error() {
+		case "1":
+			json.NewResponseSuccess(ctx, nil, "Data not found", "04", "01")
+		case "2":
+			json.NewResponseBadRequest(ctx, nil, "only a scheduled maintenance can be started", "04", "01")
+		case "5":
+			json.NewResponseBadRequest(ctx, nil, "the motor vehicle is rented out", "04", "02")
+		default:
+			json.NewResponseError(ctx, err.Error(), "04", "01")
+		}
+		return
+	}
+
+	json.NewResponseSuccess(ctx, ticket, "Maintenance started", "04", "02")
+}
+
+func (m *maintenanceDelivery) CompleteMaintenance(ctx *gin.Context) {
+	var request maintenanceDto.CompleteMaintenanceRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "05", "01")
+		return
+	}
+
+	ticket, err := m.maintenanceUC.CompleteMaintenance(ctx.Param("id"), request, ctx.GetString("username"))
+	if err != nil {
+		switch err.Error() {
+		case "1":
+			json.NewResponseSuccess(ctx, nil, "Data not found", "05", "01")
+		case "2":
+			json.NewResponseBadRequest(ctx, nil, "only a maintenance in progress can be completed", "05", "02")
+		default:
+			json.NewResponseError(ctx, err.Error(), "05", "01")
+		}
+		return
+	}
+
+	json.NewResponseSuccess(ctx, ticket, "Maintenance completed", "05", "02")
+}
+
+func (m *maintenanceDelivery) CancelMaintenance(ctx *gin.Context) {
+	ticket, err := m.maintenanceUC.CancelMaintenance(ctx.Param("id"), ctx.GetString("username"))
+	if err != nil {
+		switch err.Error() {
+		case "1":
+			json.NewResponseSuccess(ctx, nil, "Data not found", "06", "01")
+		case "2":
+			json.NewResponseBadRequest(ctx, nil, "the maintenance is already completed or cancelled", "06", "01")
+		default:
+			json.NewResponseError(ctx, err.Error(), "06", "01")
+		}
+		return
+	}
+
+	json.NewResponseSuccess(ctx, ticket, "Maintenance cancelled", "06", "02")
+}
+
+func (m *maintenanceDelivery) GetAllSchedule(ctx *gin.Context) {
+	spec, errs := queryspec.Parse(ctx.Request.URL.Query(), maintenance.ScheduleQuery)
+	if errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "07", "01")
+		return
+	}
+
+	schedules, page, err := m.maintenanceUC.GetAllSchedule(spec)
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "07", "01")
+		return
+	}
+
+	if len(schedules) == 0 {
+		json.NewResponseSuccessPaged(ctx, []maintenanceDto.MaintenanceSchedule{}, page, "Data empty", "07", "01")
+		return
+	}
+
+	json.NewResponseSuccessPaged(ctx, schedules, page, "Success get all maintenance schedule", "07", "02")
+}
+
+func (m *maintenanceDelivery) GetScheduleById(ctx *gin.Context) {
+	schedule, err := m.maintenanceUC.GetScheduleById(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "08", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "08", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, schedule, "Success get maintenance schedule by id", "08", "02")
+}
+
+func (m *maintenanceDelivery) CreateSchedule(ctx *gin.Context) {
+	var request maintenanceDto.MaintenanceScheduleRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "09", "01")
+		return
+	}
+
+	schedule, err := m.maintenanceUC.CreateSchedule(request)
+	if err != nil {
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "motor vehicle not found", "09", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "09", "01")
+		return
+	}
+
+	json.NewResponseCreated(ctx, schedule, "Maintenance schedule created", "09", "01")
+}
+
+func (m *maintenanceDelivery) UpdateSchedule(ctx *gin.Context) {
+	var request maintenanceDto.MaintenanceScheduleRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "10", "01")
+		return
+	}
+
+	schedule, err := m.maintenanceUC.UpdateSchedule(ctx.Param("id"), request)
+	if err != nil {
+		switch err.Error() {
+		case "1":
+			json.NewResponseSuccess(ctx, nil, "Data not found", "10", "01")
+		case "3":
+			json.NewResponseBadRequest(ctx, nil, "motor vehicle not found", "10", "02")
+		default:
+			json.NewResponseError(ctx, err.Error(), "10", "01")
+		}
+		return
+	}
+
+	json.NewResponseSuccess(ctx, schedule, "Maintenance schedule updated", "10", "02")
+}
+
+func (m *maintenanceDelivery) DeleteSchedule(ctx *gin.Context) {
+	if err := m.maintenanceUC.DeleteSchedule(ctx.Param("id")); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "11", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "11", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Maintenance schedule deleted", "11", "02")
+}
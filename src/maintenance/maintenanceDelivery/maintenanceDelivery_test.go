@@ -0,0 +1,282 @@
+package maintenanceDelivery
+
+import (
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var ticketRequest = maintenanceDto.CreateMaintenanceRequest{
+	MotorVehicleID: "11111111-1111-1111-1111-111111111111",
+	Description:    "Ganti oli",
+	StartDate:      "2024-08-13T09:00:00+07:00",
+	EndDate:        "2024-08-13T17:00:00+07:00",
+}
+
+var expectTicket = maintenanceDto.Maintenance{
+	ID:             "1",
+	MotorVehicleID: ticketRequest.MotorVehicleID,
+	Status:         "SCHEDULED",
+	Description:    "Ganti oli",
+	StartDate:      ticketRequest.StartDate,
+	EndDate:        &ticketRequest.EndDate,
+	CreatedAt:      "0000",
+	UpdatedAt:      "0000",
+}
+
+var expectSchedule = maintenanceDto.MaintenanceSchedule{
+	ID:              "2",
+	MotorVehicleID:  ticketRequest.MotorVehicleID,
+	Description:     "Ganti oli",
+	IntervalKm:      2000,
+	LastServiceKm:   1000,
+	LastServiceDate: "2024-08-13",
+	NextServiceKm:   3000,
+	CurrentOdometer: 3200,
+	Due:             true,
+	CreatedAt:       "0000",
+	UpdatedAt:       "0000",
+}
+
+type mockMaintenanceUC struct {
+	mock.Mock
+}
+
+func (m *mockMaintenanceUC) CreateMaintenance(request maintenanceDto.CreateMaintenanceRequest) (maintenanceDto.Maintenance, error) {
+	args := m.Called(request)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) GetAllMaintenance(spec queryspec.Spec) ([]maintenanceDto.Maintenance, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]maintenanceDto.Maintenance), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockMaintenanceUC) GetMaintenanceById(id string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) StartMaintenance(id string, actor string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id, actor)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) CompleteMaintenance(id string, request maintenanceDto.CompleteMaintenanceRequest, actor string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id, request, actor)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) CancelMaintenance(id string, actor string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id, actor)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) CreateSchedule(request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	args := m.Called(request)
+	return args.Get(0).(maintenanceDto.MaintenanceSchedule), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) GetAllSchedule(spec queryspec.Spec) ([]maintenanceDto.MaintenanceSchedule, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]maintenanceDto.MaintenanceSchedule), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockMaintenanceUC) GetScheduleById(id string) (maintenanceDto.MaintenanceSchedule, error) {
+	args := m.Called(id)
+	return args.Get(0).(maintenanceDto.MaintenanceSchedule), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) UpdateSchedule(id string, request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	args := m.Called(id, request)
+	return args.Get(0).(maintenanceDto.MaintenanceSchedule), args.Error(1)
+}
+
+func (m *mockMaintenanceUC) DeleteSchedule(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MaintenanceDeliveryTestSuite struct {
+	suite.Suite
+	mockMaintenanceUC *mockMaintenanceUC
+	router            *gin.Engine
+	adminToken        string
+	employeeToken     string
+	customerToken     string
+}
+
+func (suite *MaintenanceDeliveryTestSuite) SetupTest() {
+	suite.mockMaintenanceUC = new(mockMaintenanceUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewMaintenanceDelivery(v1, suite.mockMaintenanceUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("employee", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.employeeToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("customer", "USER")
+	suite.Require().Nil(err)
+	suite.customerToken = "Bearer " + token
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCreateMaintenance_Success() {
+	expectResponse := `{"responseCode":"2010301","responseMessage":"Maintenance scheduled","data":{"id":"1","motor_vehicle_id":"11111111-1111-1111-1111-111111111111","status":"SCHEDULED","description":"Ganti oli","start_date":"2024-08-13T09:00:00+07:00","end_date":"2024-08-13T17:00:00+07:00","cost":0,"notes":"","created_at":"0000","updated_at":"0000"}}`
+	suite.mockMaintenanceUC.On("CreateMaintenance", ticketRequest).Return(expectTicket, nil)
+
+	body := []byte(`{"motor_vehicle_id":"11111111-1111-1111-1111-111111111111","description":"Ganti oli","start_date":"2024-08-13T09:00:00+07:00","end_date":"2024-08-13T17:00:00+07:00"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/maintenance", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCreateMaintenance_FailedRented() {
+	suite.mockMaintenanceUC.On("CreateMaintenance", ticketRequest).Return(maintenanceDto.Maintenance{}, errors.New("5"))
+
+	body := []byte(`{"motor_vehicle_id":"11111111-1111-1111-1111-111111111111","description":"Ganti oli","start_date":"2024-08-13T09:00:00+07:00","end_date":"2024-08-13T17:00:00+07:00"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/maintenance", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000304","responseMessage":"the motor vehicle is rented during the maintenance"}`, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCreateMaintenance_FailedCustomer() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/maintenance", bytes.NewBuffer([]byte(`{}`)))
+	req.Header.Add("Authorization", suite.customerToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+	suite.mockMaintenanceUC.AssertNotCalled(suite.T(), "CreateMaintenance", mock.Anything)
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestGetAllMaintenance_FailedQuery() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/maintenance?status=BROKEN", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"status","message":"field is not one of the allowed values"}]}`, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestStartMaintenance_FailedVehicleRented() {
+	suite.mockMaintenanceUC.On("StartMaintenance", "1", "employee").Return(maintenanceDto.Maintenance{}, errors.New("5"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/maintenance/1/start", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000402","responseMessage":"the motor vehicle is rented out"}`, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCompleteMaintenance_Success() {
+	odometer := 3100
+	request := maintenanceDto.CompleteMaintenanceRequest{Odometer: &odometer, Cost: 75000}
+	completed := expectTicket
+	completed.Status = "COMPLETED"
+	suite.mockMaintenanceUC.On("CompleteMaintenance", "1", request, "employee").Return(completed, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/maintenance/1/complete", bytes.NewBuffer([]byte(`{"odometer":3100,"cost":75000}`)))
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2000502"`)
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCompleteMaintenance_FailedNoOdometer() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/maintenance/1/complete", bytes.NewBuffer([]byte(`{"cost":75000}`)))
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000501","responseMessage":"Bad Request","error_description":[{"field":"Odometer","message":"field is required"}]}`, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCancelMaintenance_FailedAlreadyDone() {
+	suite.mockMaintenanceUC.On("CancelMaintenance", "1", "admin").Return(maintenanceDto.Maintenance{}, errors.New("2"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/maintenance/1/cancel", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000601","responseMessage":"the maintenance is already completed or cancelled"}`, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestGetAllSchedule_Success() {
+	expectResponse := `{"responseCode":"2000702","responseMessage":"Success get all maintenance schedule","data":[{"id":"2","motor_vehicle_id":"11111111-1111-1111-1111-111111111111","description":"Ganti oli","interval_km":2000,"last_service_km":1000,"last_service_date":"2024-08-13","next_service_km":3000,"current_odometer":3200,"due":true,"created_at":"0000","updated_at":"0000"}],"paging":{"limit":20,"has_more":false}}`
+	suite.mockMaintenanceUC.On("GetAllSchedule", mock.Anything).Return([]maintenanceDto.MaintenanceSchedule{expectSchedule}, queryspec.Page{Limit: 20}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/maintenance/schedules?due_only=true", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCreateSchedule_FailedNoInterval() {
+	body := []byte(`{"motor_vehicle_id":"11111111-1111-1111-1111-111111111111","description":"Ganti oli"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/maintenance/schedules", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000901","responseMessage":"Bad Request","error_description":[{"field":"IntervalKm","message":"field is required when the related field is empty"},{"field":"IntervalDays","message":"field is required when the related field is empty"}]}`, w.Body.String())
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestCreateSchedule_FailedEmployee() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/maintenance/schedules", bytes.NewBuffer([]byte(`{}`)))
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
+func (suite *MaintenanceDeliveryTestSuite) TestDeleteSchedule_Success() {
+	suite.mockMaintenanceUC.On("DeleteSchedule", "2").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/maintenance/schedules/2", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"2001102","responseMessage":"Maintenance schedule deleted"}`, w.Body.String())
+}
+
+func TestMaintenanceDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceDeliveryTestSuite))
+}
@@ -0,0 +1,36 @@
+package maintenance
+
+import (
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/queryspec"
+)
+
+type (
+	MaintenanceRepository interface {
+		Add(request maintenanceDto.CreateMaintenanceRequest) (maintenanceDto.Maintenance, error)
+		GetAll(spec queryspec.Spec) ([]maintenanceDto.Maintenance, queryspec.Page, error)
+		GetById(id string) (maintenanceDto.Maintenance, error)
+		Start(id string, actor string) (maintenanceDto.Maintenance, error)
+		Complete(id string, request maintenanceDto.CompleteMaintenanceRequest, actor string) (maintenanceDto.Maintenance, error)
+		Cancel(id string, actor string) (maintenanceDto.Maintenance, error)
+		AddSchedule(request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error)
+		GetAllSchedule(spec queryspec.Spec) ([]maintenanceDto.MaintenanceSchedule, queryspec.Page, error)
+		GetScheduleById(id string) (maintenanceDto.MaintenanceSchedule, error)
+		UpdateSchedule(id string, request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error)
+		DeleteSchedule(id string) error
+	}
+
+	MaintenanceUsecase interface {
+		CreateMaintenance(request maintenanceDto.CreateMaintenanceRequest) (maintenanceDto.Maintenance, error)
+		GetAllMaintenance(spec queryspec.Spec) ([]maintenanceDto.Maintenance, queryspec.Page, error)
+		GetMaintenanceById(id string) (maintenanceDto.Maintenance, error)
+		StartMaintenance(id string, actor string) (maintenanceDto.Maintenance, error)
+		CompleteMaintenance(id string, request maintenanceDto.CompleteMaintenanceRequest, actor string) (maintenanceDto.Maintenance, error)
+		CancelMaintenance(id string, actor string) (maintenanceDto.Maintenance, error)
+		CreateSchedule(request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error)
+		GetAllSchedule(spec queryspec.Spec) ([]maintenanceDto.MaintenanceSchedule, queryspec.Page, error)
+		GetScheduleById(id string) (maintenanceDto.MaintenanceSchedule, error)
+		UpdateSchedule(id string, request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error)
+		DeleteSchedule(id string) error
+	}
+)
@@ -0,0 +1,34 @@
+package maintenance
+
+import "bike-rent-express/pkg/queryspec"
+
+// Query is what GET /maintenance takes, from and to keep the tickets whose window overlaps
+// them, an open ended ticket overlaps everything after its start.
+var Query = queryspec.Schema{
+	Filters: []queryspec.Filter{
+		{Params: []string{"motor_vehicle_id"}, Condition: "motor_vehicle_id = ?::uuid", Validate: "uuid"},
+		{Params: []string{"status"}, Condition: "status = ?", Validate: "oneof=SCHEDULED IN_PROGRESS COMPLETED CANCELLED"},
+		{Params: []string{"from", "to"}, Condition: "COALESCE(end_date, 'infinity') > ? AND start_date < ?", Validate: "format-datetime"},
+	},
+	Sorts: map[string]string{
+		"start_date": "start_date",
+		"created_at": "created_at",
+	},
+	DefaultSort: "start_date",
+	IDColumn:    "id",
+}
+
+// ScheduleQuery is what GET /maintenance/schedules takes, due_only keeps the schedules whose
+// next service is due by distance or by date.
+var ScheduleQuery = queryspec.Schema{
+	Filters: []queryspec.Filter{
+		{Params: []string{"motor_vehicle_id"}, Condition: "motor_vehicle_id = ?::uuid", Validate: "uuid"},
+		{Params: []string{"due_only"}, Condition: "due = ?::boolean", Validate: "boolean"},
+	},
+	Sorts: map[string]string{
+		"last_service_date": "last_service_date",
+		"created_at":        "created_at",
+	},
+	DefaultSort: "last_service_date",
+	IDColumn:    "id",
+}
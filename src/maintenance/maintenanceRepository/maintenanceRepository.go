@@ -0,0 +1,383 @@
+package maintenanceRepository
+
+import (
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/maintenance"
+	"database/sql"
+	"errors"
+)
+
+const columns = "id, motor_vehicle_id, COALESCE(maintenance_schedule_id::text, ''), COALESCE(motor_return_id::text, ''), status, description, start_date, end_date, odometer, cost, notes, completed_at, created_at, updated_at"
+
+// schedules are the maintenance schedules that are not deleted with their next service and
+// the current odometer of the motor vehicle, so the filters can use them like any other column
+const schedules = `(SELECT *, (next_service_km IS NOT NULL AND current_odometer >= next_service_km) OR (next_service_date IS NOT NULL AND next_service_date <= CURRENT_DATE) AS due FROM
+	(SELECT s.id, s.motor_vehicle_id, s.description, s.interval_km, s.interval_days, s.last_service_km, s.last_service_date,
+		s.last_service_km + s.interval_km AS next_service_km, s.last_service_date + s.interval_days AS next_service_date,
+		GREATEST(s.last_service_km, mv.odometer) AS current_odometer, s.created_at, s.updated_at
+	FROM maintenance_schedule s JOIN motor_vehicle mv ON mv.id = s.motor_vehicle_id WHERE s.deleted_at IS NULL) s) maintenance_schedule`
+
+const scheduleColumns = "id, motor_vehicle_id, description, COALESCE(interval_km, 0), COALESCE(interval_days, 0), last_service_km, last_service_date::text, COALESCE(next_service_km, 0), COALESCE(next_service_date::text, ''), current_odometer, due, created_at, updated_at"
+
+type maintenanceRepository struct {
+	db *sql.DB
+}
+
+func NewMaintenanceRepository(db *sql.DB) maintenance.MaintenanceRepository {
+	return &maintenanceRepository{db}
+}
+
+// Add schedules a maintenance in one database transaction. The motor vehicle is locked so
+// that no rental of it is made between the check and the insert. A missing vehicle fails
+// with "3", a rental not returned yet overlapping the window with "5" and a schedule that
+// is not one of the vehicle's with "6".
+func (m *maintenanceRepository) Add(request maintenanceDto.CreateMaintenanceRequest) (maintenanceDto.Maintenance, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	var motorVehicleId string
+	query := "SELECT id FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;"
+	if err := tx.QueryRow(query, request.MotorVehicleID).Scan(&motorVehicleId); err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return maintenanceDto.Maintenance{}, errors.New("3")
+		}
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if request.MaintenanceScheduleID != "" {
+		var ownSchedule bool
+		query = "SELECT EXISTS(SELECT 1 FROM maintenance_schedule WHERE id = $1 AND motor_vehicle_id = $2 AND deleted_at IS NULL);"
+		if err := tx.QueryRow(query, request.MaintenanceScheduleID, motorVehicleId).Scan(&ownSchedule); err != nil {
+			tx.Rollback()
+			return maintenanceDto.Maintenance{}, err
+		}
+
+		if !ownSchedule {
+			tx.Rollback()
+			return maintenanceDto.Maintenance{}, errors.New("6")
+		}
+	}
+
+	var rented bool
+	query = "SELECT EXISTS(SELECT 1 FROM transaction WHERE motor_vehicle_id = $1 AND status <> 'RETURNED' AND end_date > $2 AND start_date < $3);"
+	if err := tx.QueryRow(query, motorVehicleId, request.StartDate, request.EndDate).Scan(&rented); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if rented {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, errors.New("5")
+	}
+
+	var id string
+	query = "INSERT INTO maintenance(motor_vehicle_id, maintenance_schedule_id, description, start_date, end_date) VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5) RETURNING id;"
+	if err := tx.QueryRow(query, motorVehicleId, request.MaintenanceScheduleID, request.Description, request.StartDate, request.EndDate).Scan(&id); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	return m.GetById(id)
+}
+
+func (m *maintenanceRepository) GetAll(spec queryspec.Spec) ([]maintenanceDto.Maintenance, queryspec.Page, error) {
+	where, args := spec.Where(nil)
+	query := "SELECT " + columns + ", " + spec.SortColumn() + " FROM maintenance WHERE " + where + " ORDER BY " + spec.OrderBy() + " LIMIT " + spec.Limit() + ";"
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, queryspec.Page{}, err
+	}
+	defer rows.Close()
+
+	var tickets []maintenanceDto.Maintenance
+	var keys []queryspec.Key
+	for rows.Next() {
+		var key queryspec.Key
+		ticket, err := scan(rows, &key.Value)
+		if err != nil {
+			return nil, queryspec.Page{}, err
+		}
+		key.ID = ticket.ID
+		tickets = append(tickets, ticket)
+		keys = append(keys, key)
+	}
+
+	n, page := spec.Paginate(keys)
+	if n < len(tickets) {
+		tickets = tickets[:n]
+	}
+	return tickets, page, nil
+}
+
+func (m *maintenanceRepository) GetById(id string) (maintenanceDto.Maintenance, error) {
+	query := "SELECT " + columns + " FROM maintenance WHERE id = $1;"
+	return scan(m.db.QueryRow(query, id))
+}
+
+// Start takes the motor vehicle out of service for a scheduled maintenance. A maintenance
+// that is not scheduled fails with "2" and a vehicle that is rented out with "5".
+func (m *maintenanceRepository) Start(id string, actor string) (maintenanceDto.Maintenance, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	motorVehicleId, status, err := lockTicket(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if status != "SCHEDULED" {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, errors.New("2")
+	}
+
+	query := utils.VehicleStatusEvent("IN_MAINTENANCE", "mv.status IN ('AVAILABLE', 'IN_MAINTENANCE')")
+	if _, err := tx.Exec(query, motorVehicleId, actor, "maintenance started", ""); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	query = "UPDATE motor_vehicle SET status = 'IN_MAINTENANCE', updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status IN ('AVAILABLE', 'IN_MAINTENANCE');"
+	result, err := tx.Exec(query, motorVehicleId)
+	if err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if affected == 0 {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, errors.New("5")
+	}
+
+	// a maintenance started early blocks rentals from now on
+	query = "UPDATE maintenance SET status = 'IN_PROGRESS', start_date = LEAST(start_date, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
+	if _, err := tx.Exec(query, id); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	return m.GetById(id)
+}
+
+// Complete records the service and moves the schedule it was done for to the odometer
+// reading, which the motor vehicle takes as well. The motor vehicle is available again once no
+// other maintenance of it is in progress. A maintenance that is not in progress fails with "2".
+func (m *maintenanceRepository) Complete(id string, request maintenanceDto.CompleteMaintenanceRequest, actor string) (maintenanceDto.Maintenance, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	motorVehicleId, status, err := lockTicket(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if status != "IN_PROGRESS" {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, errors.New("2")
+	}
+
+	var scheduleId string
+	query := "UPDATE maintenance SET status = 'COMPLETED', odometer = $1, cost = $2, notes = $3, completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $4 RETURNING COALESCE(maintenance_schedule_id::text, '');"
+	if err := tx.QueryRow(query, *request.Odometer, request.Cost, request.Notes, id).Scan(&scheduleId); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if scheduleId != "" {
+		query = "UPDATE maintenance_schedule SET last_service_km = $1, last_service_date = CURRENT_DATE, updated_at = CURRENT_TIMESTAMP WHERE id = $2;"
+		if _, err := tx.Exec(query, *request.Odometer, scheduleId); err != nil {
+			tx.Rollback()
+			return maintenanceDto.Maintenance{}, err
+		}
+	}
+
+	query = "UPDATE motor_vehicle SET odometer = GREATEST(odometer, $2), updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
+	if _, err := tx.Exec(query, motorVehicleId, *request.Odometer); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if err := releaseVehicle(tx, motorVehicleId, actor, "maintenance completed"); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	return m.GetById(id)
+}
+
+// Cancel drops a maintenance that is scheduled or in progress, any other fails with "2".
+func (m *maintenanceRepository) Cancel(id string, actor string) (maintenanceDto.Maintenance, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	motorVehicleId, status, err := lockTicket(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if status != "SCHEDULED" && status != "IN_PROGRESS" {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, errors.New("2")
+	}
+
+	query := "UPDATE maintenance SET status = 'CANCELLED', updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
+	if _, err := tx.Exec(query, id); err != nil {
+		tx.Rollback()
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	if status == "IN_PROGRESS" {
+		if err := releaseVehicle(tx, motorVehicleId, actor, "maintenance cancelled"); err != nil {
+			tx.Rollback()
+			return maintenanceDto.Maintenance{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return maintenanceDto.Maintenance{}, err
+	}
+
+	return m.GetById(id)
+}
+
+func lockTicket(tx *sql.Tx, id string) (string, string, error) {
+	var motorVehicleId, status string
+	query := "SELECT motor_vehicle_id, status FROM maintenance WHERE id = $1 FOR UPDATE;"
+	err := tx.QueryRow(query, id).Scan(&motorVehicleId, &status)
+	return motorVehicleId, status, err
+}
+
+// releaseVehicle puts the motor vehicle back in service unless another maintenance of it is
+// still in progress, recording the change with the actor and reason in its history.
+func releaseVehicle(tx *sql.Tx, motorVehicleId string, actor string, reason string) error {
+	query := utils.VehicleStatusEvent("AVAILABLE", "mv.status = 'IN_MAINTENANCE' AND NOT EXISTS (SELECT 1 FROM maintenance WHERE motor_vehicle_id = $1 AND status = 'IN_PROGRESS')")
+	if _, err := tx.Exec(query, motorVehicleId, actor, reason, ""); err != nil {
+		return err
+	}
+
+	query = "UPDATE motor_vehicle SET status = 'AVAILABLE', updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'IN_MAINTENANCE' AND NOT EXISTS (SELECT 1 FROM maintenance WHERE motor_vehicle_id = $1 AND status = 'IN_PROGRESS');"
+	_, err := tx.Exec(query, motorVehicleId)
+	return err
+}
+
+func (m *maintenanceRepository) AddSchedule(request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	var id string
+	query := "INSERT INTO maintenance_schedule(motor_vehicle_id, description, interval_km, interval_days, last_service_km, last_service_date) VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, COALESCE(NULLIF($6, '')::date, CURRENT_DATE)) RETURNING id;"
+	if err := m.db.QueryRow(query, request.MotorVehicleID, request.Description, request.IntervalKm, request.IntervalDays, request.LastServiceKm, request.LastServiceDate).Scan(&id); err != nil {
+		return maintenanceDto.MaintenanceSchedule{}, err
+	}
+
+	return m.GetScheduleById(id)
+}
+
+func (m *maintenanceRepository) GetAllSchedule(spec queryspec.Spec) ([]maintenanceDto.MaintenanceSchedule, queryspec.Page, error) {
+	where, args := spec.Where(nil)
+	query := "SELECT " + scheduleColumns + ", " + spec.SortColumn() + " FROM " + schedules + " WHERE " + where + " ORDER BY " + spec.OrderBy() + " LIMIT " + spec.Limit() + ";"
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, queryspec.Page{}, err
+	}
+	defer rows.Close()
+
+	var maintenanceSchedules []maintenanceDto.MaintenanceSchedule
+	var keys []queryspec.Key
+	for rows.Next() {
+		var key queryspec.Key
+		schedule, err := scanSchedule(rows, &key.Value)
+		if err != nil {
+			return nil, queryspec.Page{}, err
+		}
+		key.ID = schedule.ID
+		maintenanceSchedules = append(maintenanceSchedules, schedule)
+		keys = append(keys, key)
+	}
+
+	n, page := spec.Paginate(keys)
+	if n < len(maintenanceSchedules) {
+		maintenanceSchedules = maintenanceSchedules[:n]
+	}
+	return maintenanceSchedules, page, nil
+}
+
+func (m *maintenanceRepository) GetScheduleById(id string) (maintenanceDto.MaintenanceSchedule, error) {
+	query := "SELECT " + scheduleColumns + " FROM " + schedules + " WHERE id = $1;"
+	return scanSchedule(m.db.QueryRow(query, id))
+}
+
+// UpdateSchedule keeps the last service date when it is left empty.
+func (m *maintenanceRepository) UpdateSchedule(id string, request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	query := "UPDATE maintenance_schedule SET motor_vehicle_id = $1, description = $2, interval_km = NULLIF($3, 0), interval_days = NULLIF($4, 0), last_service_km = $5, last_service_date = COALESCE(NULLIF($6, '')::date, last_service_date), updated_at = CURRENT_TIMESTAMP WHERE id = $7 AND deleted_at IS NULL RETURNING id;"
+	if err := m.db.QueryRow(query, request.MotorVehicleID, request.Description, request.IntervalKm, request.IntervalDays, request.LastServiceKm, request.LastServiceDate, id).Scan(&id); err != nil {
+		return maintenanceDto.MaintenanceSchedule{}, err
+	}
+
+	return m.GetScheduleById(id)
+}
+
+func (m *maintenanceRepository) DeleteSchedule(id string) error {
+	query := "UPDATE maintenance_schedule SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;"
+	result, err := m.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, extra ...interface{}) (maintenanceDto.Maintenance, error) {
+	var ticket maintenanceDto.Maintenance
+	dest := append([]interface{}{&ticket.ID, &ticket.MotorVehicleID, &ticket.MaintenanceScheduleID, &ticket.MotorReturnID, &ticket.Status, &ticket.Description, &ticket.StartDate, &ticket.EndDate, &ticket.Odometer, &ticket.Cost, &ticket.Notes, &ticket.CompletedAt, &ticket.CreatedAt, &ticket.UpdatedAt}, extra...)
+	err := row.Scan(dest...)
+	return ticket, err
+}
+
+func scanSchedule(row scanner, extra ...interface{}) (maintenanceDto.MaintenanceSchedule, error) {
+	var schedule maintenanceDto.MaintenanceSchedule
+	dest := append([]interface{}{&schedule.ID, &schedule.MotorVehicleID, &schedule.Description, &schedule.IntervalKm, &schedule.IntervalDays, &schedule.LastServiceKm, &schedule.LastServiceDate, &schedule.NextServiceKm, &schedule.NextServiceDate, &schedule.CurrentOdometer, &schedule.Due, &schedule.CreatedAt, &schedule.UpdatedAt}, extra...)
+	err := row.Scan(dest...)
+	return schedule, err
+}
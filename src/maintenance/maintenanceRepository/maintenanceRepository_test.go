@@ -0,0 +1,399 @@
+package maintenanceRepository
+
+import (
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/maintenance"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var ticketRequest = maintenanceDto.CreateMaintenanceRequest{
+	MotorVehicleID:        "11111111-1111-1111-1111-111111111111",
+	MaintenanceScheduleID: "22222222-2222-2222-2222-222222222222",
+	Description:           "Ganti oli",
+	StartDate:             "2024-08-13T09:00:00+07:00",
+	EndDate:               "2024-08-13T17:00:00+07:00",
+}
+
+var endDate = "2024-08-13T17:00:00+07:00"
+
+var expectTicket = maintenanceDto.Maintenance{
+	ID:                    "1",
+	MotorVehicleID:        ticketRequest.MotorVehicleID,
+	MaintenanceScheduleID: ticketRequest.MaintenanceScheduleID,
+	Status:                "SCHEDULED",
+	Description:           "Ganti oli",
+	StartDate:             "2024-08-13T09:00:00+07:00",
+	EndDate:               &endDate,
+	CreatedAt:             "0000",
+	UpdatedAt:             "0000",
+}
+
+var ticketRowColumns = []string{"id", "motor_vehicle_id", "maintenance_schedule_id", "motor_return_id", "status", "description", "start_date", "end_date", "odometer", "cost", "notes", "completed_at", "created_at", "updated_at"}
+
+func ticketRow(status string) []driver.Value {
+	return []driver.Value{expectTicket.ID, expectTicket.MotorVehicleID, expectTicket.MaintenanceScheduleID, "", status, expectTicket.Description, expectTicket.StartDate, endDate, nil, 0, "", nil, "0000", "0000"}
+}
+
+var scheduleRequest = maintenanceDto.MaintenanceScheduleRequest{
+	MotorVehicleID: ticketRequest.MotorVehicleID,
+	Description:    "Ganti oli",
+	IntervalKm:     2000,
+	LastServiceKm:  1000,
+}
+
+var expectSchedule = maintenanceDto.MaintenanceSchedule{
+	ID:              "2",
+	MotorVehicleID:  ticketRequest.MotorVehicleID,
+	Description:     "Ganti oli",
+	IntervalKm:      2000,
+	LastServiceKm:   1000,
+	LastServiceDate: "2024-08-13",
+	NextServiceKm:   3000,
+	CurrentOdometer: 3200,
+	Due:             true,
+	CreatedAt:       "0000",
+	UpdatedAt:       "0000",
+}
+
+var scheduleRowColumns = []string{"id", "motor_vehicle_id", "description", "interval_km", "interval_days", "last_service_km", "last_service_date", "next_service_km", "next_service_date", "current_odometer", "due", "created_at", "updated_at"}
+
+func scheduleRow() []driver.Value {
+	return []driver.Value{expectSchedule.ID, expectSchedule.MotorVehicleID, expectSchedule.Description, 2000, 0, 1000, "2024-08-13", 3000, "", 3200, true, "0000", "0000"}
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM motor_vehicle WHERE .+ FOR UPDATE;").WithArgs(ticketRequest.MotorVehicleID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ticketRequest.MotorVehicleID))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM maintenance_schedule .+").WithArgs(ticketRequest.MaintenanceScheduleID, ticketRequest.MotorVehicleID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM transaction .+").WithArgs(ticketRequest.MotorVehicleID, ticketRequest.StartDate, ticketRequest.EndDate).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("INSERT INTO maintenance(.+) RETURNING id;").WithArgs(ticketRequest.MotorVehicleID, ticketRequest.MaintenanceScheduleID, "Ganti oli", ticketRequest.StartDate, ticketRequest.EndDate).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM maintenance WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(ticketRowColumns).AddRow(ticketRow("SCHEDULED")...))
+
+	ticket, err := repository.Add(ticketRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectTicket, ticket)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedMotorVehicleNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM motor_vehicle WHERE .+ FOR UPDATE;").WithArgs(ticketRequest.MotorVehicleID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(ticketRequest)
+	assert.Equal(t, "3", err.Error())
+}
+
+func TestAdd_FailedScheduleOfAnotherVehicle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM motor_vehicle WHERE .+ FOR UPDATE;").WithArgs(ticketRequest.MotorVehicleID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ticketRequest.MotorVehicleID))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM maintenance_schedule .+").WithArgs(ticketRequest.MaintenanceScheduleID, ticketRequest.MotorVehicleID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectRollback()
+
+	_, err = repository.Add(ticketRequest)
+	assert.Equal(t, "6", err.Error())
+}
+
+func TestAdd_FailedRentedDuringWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+	request := ticketRequest
+	request.MaintenanceScheduleID = ""
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM motor_vehicle WHERE .+ FOR UPDATE;").WithArgs(request.MotorVehicleID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(request.MotorVehicleID))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM transaction .+").WithArgs(request.MotorVehicleID, request.StartDate, request.EndDate).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err = repository.Add(request)
+	assert.Equal(t, "5", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAll_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+	spec, errs := queryspec.Parse(url.Values{"status": {"SCHEDULED"}, "from": {"2024-08-13T00:00:00+07:00"}, "to": {"2024-08-14T00:00:00+07:00"}}, maintenance.Query)
+	assert.Nil(t, errs)
+
+	query := "SELECT (.+) FROM maintenance WHERE status = \\$1 AND COALESCE\\(end_date, 'infinity'\\) > \\$2 AND start_date < \\$3 ORDER BY start_date ASC, id::text ASC LIMIT 21;"
+	mock.ExpectQuery(query).WithArgs("SCHEDULED", "2024-08-13T00:00:00+07:00", "2024-08-14T00:00:00+07:00").WillReturnRows(sqlmock.NewRows(append(ticketRowColumns, "sort_key")).AddRow(append(ticketRow("SCHEDULED"), expectTicket.StartDate)...))
+
+	tickets, page, err := repository.GetAll(spec)
+	assert.Nil(t, err)
+	assert.Equal(t, []maintenanceDto.Maintenance{expectTicket}, tickets)
+	assert.Equal(t, queryspec.Page{Limit: 20}, page)
+}
+
+func TestStart_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "SCHEDULED"))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+) SELECT mv.id, 'STATUS', mv.status::text, 'IN_MAINTENANCE', (.+) AND \\(mv.status IN \\('AVAILABLE', 'IN_MAINTENANCE'\\)\\);").WithArgs(expectTicket.MotorVehicleID, "admin", "maintenance started", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'IN_MAINTENANCE'(.+) status IN \\('AVAILABLE', 'IN_MAINTENANCE'\\);").WithArgs(expectTicket.MotorVehicleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE maintenance SET status = 'IN_PROGRESS'").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM maintenance WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(ticketRowColumns).AddRow(ticketRow("IN_PROGRESS")...))
+
+	ticket, err := repository.Start("1", "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, "IN_PROGRESS", ticket.Status)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestStart_FailedVehicleRented(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "SCHEDULED"))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'IN_MAINTENANCE'").WithArgs(expectTicket.MotorVehicleID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = repository.Start("1", "admin")
+	assert.Equal(t, "5", err.Error())
+}
+
+func TestStart_FailedNotScheduled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "COMPLETED"))
+	mock.ExpectRollback()
+
+	_, err = repository.Start("1", "admin")
+	assert.Equal(t, "2", err.Error())
+}
+
+func TestComplete_SuccessMovesSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+	odometer := 3100
+	request := maintenanceDto.CompleteMaintenanceRequest{Odometer: &odometer, Cost: 75000, Notes: "oli dan filter"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "IN_PROGRESS"))
+	mock.ExpectQuery("UPDATE maintenance SET status = 'COMPLETED'(.+) RETURNING .+").WithArgs(3100, 75000, "oli dan filter", "1").WillReturnRows(sqlmock.NewRows([]string{"maintenance_schedule_id"}).AddRow(expectTicket.MaintenanceScheduleID))
+	mock.ExpectExec("UPDATE maintenance_schedule SET last_service_km = \\$1, last_service_date = CURRENT_DATE").WithArgs(3100, expectTicket.MaintenanceScheduleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET odometer = GREATEST\\(odometer, \\$2\\)").WithArgs(expectTicket.MotorVehicleID, 3100).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event(.+) 'AVAILABLE', (.+) AND \\(mv.status = 'IN_MAINTENANCE' AND NOT EXISTS").WithArgs(expectTicket.MotorVehicleID, "admin", "maintenance completed", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'AVAILABLE'(.+) AND NOT EXISTS").WithArgs(expectTicket.MotorVehicleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM maintenance WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(ticketRowColumns).AddRow(ticketRow("COMPLETED")...))
+
+	ticket, err := repository.Complete("1", request, "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, "COMPLETED", ticket.Status)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestComplete_FailedNotInProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+	odometer := 3100
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "SCHEDULED"))
+	mock.ExpectRollback()
+
+	_, err = repository.Complete("1", maintenanceDto.CompleteMaintenanceRequest{Odometer: &odometer}, "admin")
+	assert.Equal(t, "2", err.Error())
+}
+
+func TestCancel_ScheduledKeepsVehicle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "SCHEDULED"))
+	mock.ExpectExec("UPDATE maintenance SET status = 'CANCELLED'").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM maintenance WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(ticketRowColumns).AddRow(ticketRow("CANCELLED")...))
+
+	ticket, err := repository.Cancel("1", "admin")
+	assert.Nil(t, err)
+	assert.Equal(t, "CANCELLED", ticket.Status)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCancel_InProgressReleasesVehicle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "IN_PROGRESS"))
+	mock.ExpectExec("UPDATE maintenance SET status = 'CANCELLED'").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO motor_vehicle_event").WithArgs(expectTicket.MotorVehicleID, "admin", "maintenance cancelled", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'AVAILABLE'").WithArgs(expectTicket.MotorVehicleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM maintenance WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(ticketRowColumns).AddRow(ticketRow("CANCELLED")...))
+
+	_, err = repository.Cancel("1", "admin")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestCancel_FailedAlreadyCompleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT motor_vehicle_id, status FROM maintenance WHERE .+ FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"motor_vehicle_id", "status"}).AddRow(expectTicket.MotorVehicleID, "COMPLETED"))
+	mock.ExpectRollback()
+
+	_, err = repository.Cancel("1", "admin")
+	assert.Equal(t, "2", err.Error())
+}
+
+func TestAddSchedule_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectQuery("INSERT INTO maintenance_schedule(.+) RETURNING id;").WithArgs(scheduleRequest.MotorVehicleID, "Ganti oli", 2000, 0, 1000, "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+	mock.ExpectQuery("SELECT (.+) FROM (.+) maintenance_schedule WHERE id = \\$1;").WithArgs("2").WillReturnRows(sqlmock.NewRows(scheduleRowColumns).AddRow(scheduleRow()...))
+
+	schedule, err := repository.AddSchedule(scheduleRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectSchedule, schedule)
+}
+
+func TestGetAllSchedule_DueOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+	spec, errs := queryspec.Parse(url.Values{"due_only": {"true"}}, maintenance.ScheduleQuery)
+	assert.Nil(t, errs)
+
+	query := "SELECT (.+) FROM (.+) maintenance_schedule WHERE due = \\$1::boolean ORDER BY last_service_date ASC, id::text ASC LIMIT 21;"
+	mock.ExpectQuery(query).WithArgs("true").WillReturnRows(sqlmock.NewRows(append(scheduleRowColumns, "sort_key")).AddRow(append(scheduleRow(), "2024-08-13")...))
+
+	schedules, page, err := repository.GetAllSchedule(spec)
+	assert.Nil(t, err)
+	assert.Equal(t, []maintenanceDto.MaintenanceSchedule{expectSchedule}, schedules)
+	assert.Equal(t, queryspec.Page{Limit: 20}, page)
+}
+
+func TestUpdateSchedule_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectQuery("UPDATE maintenance_schedule SET (.+) RETURNING id;").WithArgs(scheduleRequest.MotorVehicleID, "Ganti oli", 2000, 0, 1000, "", "2").WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.UpdateSchedule("2", scheduleRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestDeleteSchedule_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMaintenanceRepository(db)
+
+	mock.ExpectExec("UPDATE maintenance_schedule SET deleted_at").WithArgs("2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.DeleteSchedule("2")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
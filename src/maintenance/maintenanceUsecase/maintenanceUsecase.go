@@ -0,0 +1,122 @@
+package maintenanceUsecase
+
+import (
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/maintenance"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type maintenanceUsecase struct {
+	maintenanceRepository maintenance.MaintenanceRepository
+}
+
+func NewMaintenanceUsecase(maintenanceRepository maintenance.MaintenanceRepository) maintenance.MaintenanceUsecase {
+	return &maintenanceUsecase{maintenanceRepository}
+}
+
+// CreateMaintenance returns "4" when the window does not end after it starts.
+func (m *maintenanceUsecase) CreateMaintenance(request maintenanceDto.CreateMaintenanceRequest) (maintenanceDto.Maintenance, error) {
+	if _, _, _, err := utils.RentalPeriod(request.StartDate, request.EndDate); err != nil {
+		return maintenanceDto.Maintenance{}, errors.New("4")
+	}
+
+	return m.maintenanceRepository.Add(request)
+}
+
+func (m *maintenanceUsecase) GetAllMaintenance(spec queryspec.Spec) ([]maintenanceDto.Maintenance, queryspec.Page, error) {
+	return m.maintenanceRepository.GetAll(spec)
+}
+
+func (m *maintenanceUsecase) GetMaintenanceById(id string) (maintenanceDto.Maintenance, error) {
+	ticket, err := m.maintenanceRepository.GetById(id)
+	if err != nil {
+		return ticket, notFound(err)
+	}
+
+	return ticket, nil
+}
+
+func (m *maintenanceUsecase) StartMaintenance(id string, actor string) (maintenanceDto.Maintenance, error) {
+	ticket, err := m.maintenanceRepository.Start(id, actor)
+	if err != nil {
+		return ticket, notFound(err)
+	}
+
+	return ticket, nil
+}
+
+func (m *maintenanceUsecase) CompleteMaintenance(id string, request maintenanceDto.CompleteMaintenanceRequest, actor string) (maintenanceDto.Maintenance, error) {
+	ticket, err := m.maintenanceRepository.Complete(id, request, actor)
+	if err != nil {
+		return ticket, notFound(err)
+	}
+
+	return ticket, nil
+}
+
+func (m *maintenanceUsecase) CancelMaintenance(id string, actor string) (maintenanceDto.Maintenance, error) {
+	ticket, err := m.maintenanceRepository.Cancel(id, actor)
+	if err != nil {
+		return ticket, notFound(err)
+	}
+
+	return ticket, nil
+}
+
+// CreateSchedule returns "3" when the motor vehicle does not exist.
+func (m *maintenanceUsecase) CreateSchedule(request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	schedule, err := m.maintenanceRepository.AddSchedule(request)
+	if err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return schedule, errors.New("3")
+		}
+		return schedule, err
+	}
+
+	return schedule, nil
+}
+
+func (m *maintenanceUsecase) GetAllSchedule(spec queryspec.Spec) ([]maintenanceDto.MaintenanceSchedule, queryspec.Page, error) {
+	return m.maintenanceRepository.GetAllSchedule(spec)
+}
+
+func (m *maintenanceUsecase) GetScheduleById(id string) (maintenanceDto.MaintenanceSchedule, error) {
+	schedule, err := m.maintenanceRepository.GetScheduleById(id)
+	if err != nil {
+		return schedule, notFound(err)
+	}
+
+	return schedule, nil
+}
+
+func (m *maintenanceUsecase) UpdateSchedule(id string, request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	schedule, err := m.maintenanceRepository.UpdateSchedule(id, request)
+	if err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return schedule, errors.New("3")
+		}
+		return schedule, notFound(err)
+	}
+
+	return schedule, nil
+}
+
+func (m *maintenanceUsecase) DeleteSchedule(id string) error {
+	if err := m.maintenanceRepository.DeleteSchedule(id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// notFound maps a missing or malformed maintenance or schedule id to "1".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+		return errors.New("1")
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package maintenanceUsecase
+
+import (
+	"bike-rent-express/model/dto/maintenanceDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/maintenance"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var ticketRequest = maintenanceDto.CreateMaintenanceRequest{
+	MotorVehicleID: "11111111-1111-1111-1111-111111111111",
+	Description:    "Ganti oli",
+	StartDate:      "2024-08-13T09:00:00+07:00",
+	EndDate:        "2024-08-13T17:00:00+07:00",
+}
+
+var expectTicket = maintenanceDto.Maintenance{
+	ID:             "1",
+	MotorVehicleID: ticketRequest.MotorVehicleID,
+	Status:         "SCHEDULED",
+	Description:    "Ganti oli",
+	StartDate:      ticketRequest.StartDate,
+}
+
+var scheduleRequest = maintenanceDto.MaintenanceScheduleRequest{
+	MotorVehicleID: ticketRequest.MotorVehicleID,
+	Description:    "Ganti oli",
+	IntervalDays:   90,
+}
+
+var expectSchedule = maintenanceDto.MaintenanceSchedule{
+	ID:             "2",
+	MotorVehicleID: ticketRequest.MotorVehicleID,
+	Description:    "Ganti oli",
+	IntervalDays:   90,
+}
+
+type mockMaintenanceRepository struct {
+	mock.Mock
+}
+
+func (m *mockMaintenanceRepository) Add(request maintenanceDto.CreateMaintenanceRequest) (maintenanceDto.Maintenance, error) {
+	args := m.Called(request)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) GetAll(spec queryspec.Spec) ([]maintenanceDto.Maintenance, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]maintenanceDto.Maintenance), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockMaintenanceRepository) GetById(id string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) Start(id string, actor string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id, actor)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) Complete(id string, request maintenanceDto.CompleteMaintenanceRequest, actor string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id, request, actor)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) Cancel(id string, actor string) (maintenanceDto.Maintenance, error) {
+	args := m.Called(id, actor)
+	return args.Get(0).(maintenanceDto.Maintenance), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) AddSchedule(request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	args := m.Called(request)
+	return args.Get(0).(maintenanceDto.MaintenanceSchedule), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) GetAllSchedule(spec queryspec.Spec) ([]maintenanceDto.MaintenanceSchedule, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]maintenanceDto.MaintenanceSchedule), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockMaintenanceRepository) GetScheduleById(id string) (maintenanceDto.MaintenanceSchedule, error) {
+	args := m.Called(id)
+	return args.Get(0).(maintenanceDto.MaintenanceSchedule), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) UpdateSchedule(id string, request maintenanceDto.MaintenanceScheduleRequest) (maintenanceDto.MaintenanceSchedule, error) {
+	args := m.Called(id, request)
+	return args.Get(0).(maintenanceDto.MaintenanceSchedule), args.Error(1)
+}
+
+func (m *mockMaintenanceRepository) DeleteSchedule(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MaintenanceUsecaseTestSuite struct {
+	suite.Suite
+	mockMaintenanceRepository *mockMaintenanceRepository
+	maintenanceUC             maintenance.MaintenanceUsecase
+}
+
+func (suite *MaintenanceUsecaseTestSuite) SetupTest() {
+	suite.mockMaintenanceRepository = new(mockMaintenanceRepository)
+	suite.maintenanceUC = NewMaintenanceUsecase(suite.mockMaintenanceRepository)
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestCreateMaintenance_Success() {
+	suite.mockMaintenanceRepository.On("Add", ticketRequest).Return(expectTicket, nil)
+
+	ticket, err := suite.maintenanceUC.CreateMaintenance(ticketRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectTicket, ticket)
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestCreateMaintenance_FailedEndBeforeStart() {
+	request := ticketRequest
+	request.EndDate = "2024-08-13T08:00:00+07:00"
+
+	_, err := suite.maintenanceUC.CreateMaintenance(request)
+	assert.Equal(suite.T(), "4", err.Error())
+	suite.mockMaintenanceRepository.AssertNotCalled(suite.T(), "Add", mock.Anything)
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestGetMaintenanceById_NotFound() {
+	suite.mockMaintenanceRepository.On("GetById", "1").Return(maintenanceDto.Maintenance{}, sql.ErrNoRows)
+
+	_, err := suite.maintenanceUC.GetMaintenanceById("1")
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestStartMaintenance_NotFound() {
+	suite.mockMaintenanceRepository.On("Start", "x", "admin").Return(maintenanceDto.Maintenance{}, errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	_, err := suite.maintenanceUC.StartMaintenance("x", "admin")
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestCompleteMaintenance_PassesStatusError() {
+	odometer := 100
+	request := maintenanceDto.CompleteMaintenanceRequest{Odometer: &odometer}
+	suite.mockMaintenanceRepository.On("Complete", "1", request, "admin").Return(maintenanceDto.Maintenance{}, errors.New("2"))
+
+	_, err := suite.maintenanceUC.CompleteMaintenance("1", request, "admin")
+	assert.Equal(suite.T(), "2", err.Error())
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestCancelMaintenance_Success() {
+	cancelled := expectTicket
+	cancelled.Status = "CANCELLED"
+	suite.mockMaintenanceRepository.On("Cancel", "1", "admin").Return(cancelled, nil)
+
+	ticket, err := suite.maintenanceUC.CancelMaintenance("1", "admin")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), cancelled, ticket)
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestCreateSchedule_FailedMotorVehicleNotFound() {
+	suite.mockMaintenanceRepository.On("AddSchedule", scheduleRequest).Return(maintenanceDto.MaintenanceSchedule{}, errors.New(`pq: insert or update on table "maintenance_schedule" violates foreign key constraint "maintenance_schedule_motor_vehicle_id_fkey"`))
+
+	_, err := suite.maintenanceUC.CreateSchedule(scheduleRequest)
+	assert.Equal(suite.T(), "3", err.Error())
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestUpdateSchedule_NotFound() {
+	suite.mockMaintenanceRepository.On("UpdateSchedule", "2", scheduleRequest).Return(maintenanceDto.MaintenanceSchedule{}, sql.ErrNoRows)
+
+	_, err := suite.maintenanceUC.UpdateSchedule("2", scheduleRequest)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestGetAllSchedule_Success() {
+	suite.mockMaintenanceRepository.On("GetAllSchedule", queryspec.Spec{}).Return([]maintenanceDto.MaintenanceSchedule{expectSchedule}, queryspec.Page{Limit: 20}, nil)
+
+	schedules, page, err := suite.maintenanceUC.GetAllSchedule(queryspec.Spec{})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []maintenanceDto.MaintenanceSchedule{expectSchedule}, schedules)
+	assert.Equal(suite.T(), queryspec.Page{Limit: 20}, page)
+}
+
+func (suite *MaintenanceUsecaseTestSuite) TestDeleteSchedule_NotFound() {
+	suite.mockMaintenanceRepository.On("DeleteSchedule", "2").Return(sql.ErrNoRows)
+
+	err := suite.maintenanceUC.DeleteSchedule("2")
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func TestMaintenanceUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceUsecaseTestSuite))
+}
@@ -0,0 +1,73 @@
+package motorPickupDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/motorPickupDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/motorPickup"
+
+	"github.com/gin-gonic/gin"
+)
+
+type motorPickupDelivery struct {
+	motorPickupUC motorPickup.MotorPickupUsecase
+}
+
+func NewMotorPickupDelivery(v1Group *gin.RouterGroup, motorPickupUC motorPickup.MotorPickupUsecase) {
+	handler := motorPickupDelivery{motorPickupUC}
+
+	// the handover is recorded for the employee logged in, whoever the path names
+	motorPickupGroup := v1Group.Group("employee/:id/motor-pickup")
+	{
+		motorPickupGroup.POST("", middleware.JWTAuth("EMPLOYEE", "BRANCH_MANAGER"), handler.CreateMotorPickup)
+		motorPickupGroup.GET("/:motor-pickup-id", middleware.JWTAuth("EMPLOYEE", "ADMIN", "BRANCH_MANAGER"), handler.GetMotorPickupById)
+	}
+}
+
+func (m *motorPickupDelivery) CreateMotorPickup(c *gin.Context) {
+	var createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest
+
+	c.BindJSON(&createMotorPickupRequest)
+	if err := utils.Validated(createMotorPickupRequest); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
+		return
+	}
+	createMotorPickupRequest.Actor = c.GetString("username")
+	createMotorPickupRequest.BranchID = c.GetString("branch_id")
+
+	motorPickupCreated, err := m.motorPickupUC.AddMotorPickup(createMotorPickupRequest)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Customer identity is not verified", "01", "02")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(c, nil, "Transaction is not waiting for pickup", "01", "03")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "01", "04")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(c, motorPickupCreated, "Motor pickup created", "01", "01")
+}
+
+func (m *motorPickupDelivery) GetMotorPickupById(c *gin.Context) {
+	id := c.Param("motor-pickup-id")
+	motorPickupDetail, err := m.motorPickupUC.GetMotorPickupById(id, c.GetString("branch_id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "02", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, motorPickupDetail, "Success get motor pickup by id", "02", "01")
+}
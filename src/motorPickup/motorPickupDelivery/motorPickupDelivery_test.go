@@ -0,0 +1,163 @@
+package motorPickupDelivery
+
+import (
+	"bike-rent-express/model/dto/motorPickupDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var pickupRequest = motorPickupDto.CreateMotorPickupRequest{
+	TransactionID:    "621dfcb6-06df-4420-b98e-3ec04def9547",
+	Odometer:         1200,
+	FuelLevel:        100,
+	DamageNotes:      "baret di spakbor",
+	Photos:           []string{"https://cdn.example.com/a.jpg"},
+	IdentityVerified: true,
+}
+
+var expectPickup = motorPickupDto.MotorPickup{
+	ID:               "10",
+	TransactionID:    pickupRequest.TransactionID,
+	EmployeeId:       "1",
+	PickupDate:       "0000",
+	Odometer:         1200,
+	FuelLevel:        100,
+	DamageNotes:      "baret di spakbor",
+	Photos:           []string{"https://cdn.example.com/a.jpg"},
+	IdentityVerified: true,
+	CreatedAt:        "0000",
+	UpdatedAt:        "0000",
+}
+
+type mockMotorPickupUC struct {
+	mock.Mock
+}
+
+func (m *mockMotorPickupUC) AddMotorPickup(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error) {
+	args := m.Called(createMotorPickupRequest)
+	return args.Get(0).(motorPickupDto.CreateMotorPickupRequest), args.Error(1)
+}
+
+func (m *mockMotorPickupUC) GetMotorPickupById(id string, branchID string) (motorPickupDto.MotorPickup, error) {
+	args := m.Called(id, branchID)
+	return args.Get(0).(motorPickupDto.MotorPickup), args.Error(1)
+}
+
+type MotorPickupDeliveryTestSuite struct {
+	suite.Suite
+	mockMotorPickupUC *mockMotorPickupUC
+	router            *gin.Engine
+	accessToken       string
+}
+
+func (suite *MotorPickupDeliveryTestSuite) SetupTest() {
+	suite.mockMotorPickupUC = new(mockMotorPickupUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewMotorPickupDelivery(v1, suite.mockMotorPickupUC)
+
+	token, err := middleware.GenerateTokenJwt("dino", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.accessToken = "Bearer " + token
+}
+
+func (suite *MotorPickupDeliveryTestSuite) TestCreateMotorPickup_Success() {
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Motor pickup created","data":{"id":"10","transaction_id":"621dfcb6-06df-4420-b98e-3ec04def9547","odometer":1200,"fuel_level":100,"damage_notes":"baret di spakbor","photos":["https://cdn.example.com/a.jpg"],"identity_verified":true}}`
+
+	// the employee is the one logged in, not the one on the route or in the body
+	request := pickupRequest
+	request.Actor = "dino"
+	created := request
+	created.ID = "10"
+	suite.mockMotorPickupUC.On("AddMotorPickup", request).Return(created, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(pickupRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/employee/1/motor-pickup", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *MotorPickupDeliveryTestSuite) TestCreateMotorPickup_FailedValidation() {
+	expectResponse := `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"FuelLevel","message":"field is above the maximum value"}]}`
+
+	request := pickupRequest
+	request.FuelLevel = 120
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(request)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/employee/1/motor-pickup", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *MotorPickupDeliveryTestSuite) TestCreateMotorPickup_FailedUsecase() {
+	cases := map[string]string{
+		"1": `{"responseCode":"4000102","responseMessage":"Customer identity is not verified"}`,
+		"2": `{"responseCode":"4000103","responseMessage":"Transaction is not waiting for pickup"}`,
+		"3": `{"responseCode":"4000104","responseMessage":"Data not found"}`,
+	}
+
+	for code, expectResponse := range cases {
+		suite.SetupTest()
+
+		request := pickupRequest
+		request.Actor = "dino"
+		suite.mockMotorPickupUC.On("AddMotorPickup", request).Return(request, errors.New(code))
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(pickupRequest)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/employee/1/motor-pickup", bytes.NewBuffer(body))
+		req.Header.Add("Authorization", suite.accessToken)
+
+		suite.router.ServeHTTP(w, req)
+		assert.Equal(suite.T(), 400, w.Code)
+		assert.Equal(suite.T(), expectResponse, w.Body.String())
+	}
+}
+
+func (suite *MotorPickupDeliveryTestSuite) TestGetMotorPickupById_Success() {
+	expectResponse := `{"responseCode":"2000201","responseMessage":"Success get motor pickup by id","data":{"id":"10","transaction_id":"621dfcb6-06df-4420-b98e-3ec04def9547","employee_id":"1","pickup_date":"0000","odometer":1200,"fuel_level":100,"damage_notes":"baret di spakbor","photos":["https://cdn.example.com/a.jpg"],"identity_verified":true,"created_at":"0000","updated_at":"0000"}}`
+	suite.mockMotorPickupUC.On("GetMotorPickupById", "10", "").Return(expectPickup, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee/1/motor-pickup/10", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *MotorPickupDeliveryTestSuite) TestGetMotorPickupById_FailedNotFound() {
+	expectResponse := `{"responseCode":"4000201","responseMessage":"Data not found"}`
+	suite.mockMotorPickupUC.On("GetMotorPickupById", "10", "").Return(motorPickupDto.MotorPickup{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee/1/motor-pickup/10", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestMotorPickupDelivery(t *testing.T) {
+	suite.Run(t, new(MotorPickupDeliveryTestSuite))
+}
@@ -0,0 +1,16 @@
+package motorPickup
+
+import "bike-rent-express/model/dto/motorPickupDto"
+
+type (
+	MotorPickupRepository interface {
+		Add(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error)
+		GetById(id string, branchID string) (motorPickupDto.MotorPickup, error)
+		GetByTransactionId(transactionId string) (motorPickupDto.MotorPickup, error)
+	}
+
+	MotorPickupUsecase interface {
+		AddMotorPickup(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error)
+		GetMotorPickupById(id string, branchID string) (motorPickupDto.MotorPickup, error)
+	}
+)
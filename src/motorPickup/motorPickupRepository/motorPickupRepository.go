@@ -0,0 +1,114 @@
+package motorPickupRepository
+
+import (
+	"bike-rent-express/model/dto/motorPickupDto"
+	"bike-rent-express/src/motorPickup"
+	"database/sql"
+	"errors"
+)
+
+type motorPickupRepository struct {
+	db *sql.DB
+}
+
+func NewMotorPickupRepository(db *sql.DB) motorPickup.MotorPickupRepository {
+	return &motorPickupRepository{db}
+}
+
+// Add records the handover of a rented motor vehicle by the employee logged in as the actor and
+// moves its transaction to PICKED_UP, an overdue rental stays OVERDUE. An employee of a branch
+// only hands over the rentals picked up there.
+func (m *motorPickupRepository) Add(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return createMotorPickupRequest, err
+	}
+
+	query := "SELECT id FROM employee WHERE username = $1 AND deleted_at IS NULL;"
+	if err := tx.QueryRow(query, createMotorPickupRequest.Actor).Scan(&createMotorPickupRequest.EmployeeId); err != nil {
+		tx.Rollback()
+		return createMotorPickupRequest, err
+	}
+
+	var status string
+	var pickedUp bool
+	query = "SELECT t.status, EXISTS(SELECT 1 FROM motor_pickup mp WHERE mp.transaction_id = t.id) FROM transaction t WHERE t.id = $1 AND ($2 = '' OR t.pickup_branch_id::text = $2) FOR UPDATE OF t;"
+	if err := tx.QueryRow(query, createMotorPickupRequest.TransactionID, createMotorPickupRequest.BranchID).Scan(&status, &pickedUp); err != nil {
+		tx.Rollback()
+		return createMotorPickupRequest, err
+	}
+
+	// only a rental that has not been handed over yet can be picked up, even when it is late
+	if (status != "ACTIVE" && status != "OVERDUE") || pickedUp {
+		tx.Rollback()
+		return createMotorPickupRequest, errors.New("2")
+	}
+
+	query = "INSERT INTO motor_pickup(transaction_id, employee_id, pickup_date, odometer, fuel_level, damage_notes, identity_verified) VALUES($1, $2, CURRENT_TIMESTAMP, $3, $4, $5, $6) RETURNING id;"
+	if err := tx.QueryRow(query, createMotorPickupRequest.TransactionID, createMotorPickupRequest.EmployeeId, createMotorPickupRequest.Odometer, createMotorPickupRequest.FuelLevel, createMotorPickupRequest.DamageNotes, createMotorPickupRequest.IdentityVerified).Scan(&createMotorPickupRequest.ID); err != nil {
+		tx.Rollback()
+		return createMotorPickupRequest, err
+	}
+
+	query = "INSERT INTO motor_pickup_photo(motor_pickup_id, url) VALUES($1, $2);"
+	for _, photo := range createMotorPickupRequest.Photos {
+		if _, err := tx.Exec(query, createMotorPickupRequest.ID, photo); err != nil {
+			tx.Rollback()
+			return createMotorPickupRequest, err
+		}
+	}
+
+	query = "UPDATE transaction SET status = 'PICKED_UP', updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND status = 'ACTIVE';"
+	if _, err := tx.Exec(query, createMotorPickupRequest.TransactionID); err != nil {
+		tx.Rollback()
+		return createMotorPickupRequest, err
+	}
+
+	// the vehicle keeps the latest readings, an odometer never runs backwards
+	query = "UPDATE motor_vehicle SET odometer = GREATEST(odometer, $2), fuel_level = $3, updated_at = CURRENT_TIMESTAMP WHERE id = (SELECT motor_vehicle_id FROM transaction WHERE id = $1);"
+	if _, err := tx.Exec(query, createMotorPickupRequest.TransactionID, createMotorPickupRequest.Odometer, createMotorPickupRequest.FuelLevel); err != nil {
+		tx.Rollback()
+		return createMotorPickupRequest, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return createMotorPickupRequest, err
+	}
+
+	return createMotorPickupRequest, nil
+}
+
+// GetById returns the motor pickup of a rental picked up at branchID, any of them when branchID is empty.
+func (m *motorPickupRepository) GetById(id string, branchID string) (motorPickupDto.MotorPickup, error) {
+	query := "SELECT mp.id, mp.transaction_id, mp.employee_id, mp.pickup_date, mp.odometer, mp.fuel_level, mp.damage_notes, mp.identity_verified, mp.created_at, mp.updated_at FROM motor_pickup mp JOIN transaction t ON t.id = mp.transaction_id WHERE mp.id = $1 AND ($2 = '' OR t.pickup_branch_id::text = $2);"
+	return m.get(query, id, branchID)
+}
+
+func (m *motorPickupRepository) GetByTransactionId(transactionId string) (motorPickupDto.MotorPickup, error) {
+	query := "SELECT id, transaction_id, employee_id, pickup_date, odometer, fuel_level, damage_notes, identity_verified, created_at, updated_at FROM motor_pickup WHERE transaction_id = $1;"
+	return m.get(query, transactionId)
+}
+
+func (m *motorPickupRepository) get(query string, args ...any) (motorPickupDto.MotorPickup, error) {
+	var pickup motorPickupDto.MotorPickup
+	if err := m.db.QueryRow(query, args...).Scan(&pickup.ID, &pickup.TransactionID, &pickup.EmployeeId, &pickup.PickupDate, &pickup.Odometer, &pickup.FuelLevel, &pickup.DamageNotes, &pickup.IdentityVerified, &pickup.CreatedAt, &pickup.UpdatedAt); err != nil {
+		return pickup, err
+	}
+
+	query = "SELECT url FROM motor_pickup_photo WHERE motor_pickup_id = $1 ORDER BY created_at;"
+	rows, err := m.db.Query(query, pickup.ID)
+	if err != nil {
+		return pickup, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var photo string
+		if err := rows.Scan(&photo); err != nil {
+			return pickup, err
+		}
+		pickup.Photos = append(pickup.Photos, photo)
+	}
+
+	return pickup, nil
+}
@@ -0,0 +1,301 @@
+package motorPickupRepository
+
+import (
+	"bike-rent-express/model/dto/motorPickupDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var pickupRequest = motorPickupDto.CreateMotorPickupRequest{
+	TransactionID:    "621dfcb6-06df-4420-b98e-3ec04def9547",
+	Actor:            "employee",
+	Odometer:         1200,
+	FuelLevel:        100,
+	DamageNotes:      "baret di spakbor",
+	Photos:           []string{"https://cdn.example.com/a.jpg", "https://cdn.example.com/b.jpg"},
+	IdentityVerified: true,
+}
+
+var expectPickup = motorPickupDto.MotorPickup{
+	ID:               "10",
+	TransactionID:    pickupRequest.TransactionID,
+	EmployeeId:       "1",
+	PickupDate:       "2024-08-13T09:05:00Z",
+	Odometer:         1200,
+	FuelLevel:        100,
+	DamageNotes:      "baret di spakbor",
+	Photos:           []string{"https://cdn.example.com/a.jpg"},
+	IdentityVerified: true,
+	CreatedAt:        "2024-08-13T09:05:00Z",
+	UpdatedAt:        "2024-08-13T09:05:00Z",
+}
+
+func expectEmployee(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT id FROM employee WHERE username = \\$1 (.+);").WithArgs(pickupRequest.Actor).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+}
+
+func statusRows(status string, pickedUp bool) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"status", "picked_up"}).AddRow(status, pickedUp)
+}
+
+func pickupRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "transaction_id", "employee_id", "pickup_date", "odometer", "fuel_level", "damage_notes", "identity_verified", "created_at", "updated_at"}).
+		AddRow(expectPickup.ID, expectPickup.TransactionID, expectPickup.EmployeeId, expectPickup.PickupDate, expectPickup.Odometer, expectPickup.FuelLevel, expectPickup.DamageNotes, expectPickup.IdentityVerified, expectPickup.CreatedAt, expectPickup.UpdatedAt)
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	mock.ExpectBegin()
+
+	expectEmployee(mock)
+
+	query := "SELECT t.status, (.+) FROM transaction t WHERE t.id = \\$1 AND (.+) FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "").WillReturnRows(statusRows("ACTIVE", false))
+
+	query = "INSERT INTO motor_pickup(.+) RETURNING id;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "1", 1200, 100, "baret di spakbor", true).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+
+	query = "INSERT INTO motor_pickup_photo"
+	mock.ExpectExec(query).WithArgs("10", "https://cdn.example.com/a.jpg").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs("10", "https://cdn.example.com/b.jpg").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "UPDATE transaction SET status = 'PICKED_UP'"
+	mock.ExpectExec(query).WithArgs(pickupRequest.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "UPDATE motor_vehicle SET odometer = GREATEST\\(odometer, \\$2\\), fuel_level = \\$3"
+	mock.ExpectExec(query).WithArgs(pickupRequest.TransactionID, 1200, 100).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	expected := pickupRequest
+	expected.ID = "10"
+	expected.EmployeeId = "1"
+
+	result, err := repository.Add(pickupRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedNotActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	mock.ExpectBegin()
+
+	expectEmployee(mock)
+
+	query := "SELECT t.status, (.+) FROM transaction t WHERE t.id = \\$1 AND (.+) FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "").WillReturnRows(statusRows("PICKED_UP", true))
+
+	mock.ExpectRollback()
+
+	_, err = repository.Add(pickupRequest)
+	assert.Equal(t, errors.New("2"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_SuccessOverdue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+	request := pickupRequest
+	request.Photos = nil
+
+	mock.ExpectBegin()
+
+	expectEmployee(mock)
+
+	query := "SELECT t.status, (.+) FROM transaction t WHERE t.id = \\$1 AND (.+) FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "").WillReturnRows(statusRows("OVERDUE", false))
+
+	query = "INSERT INTO motor_pickup(.+) RETURNING id;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "1", 1200, 100, "baret di spakbor", true).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+
+	// the rental stays overdue, only an active one moves to PICKED_UP
+	query = "UPDATE transaction SET status = 'PICKED_UP', (.+) WHERE id = \\$1 AND status = 'ACTIVE';"
+	mock.ExpectExec(query).WithArgs(pickupRequest.TransactionID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	query = "UPDATE motor_vehicle SET odometer = GREATEST\\(odometer, \\$2\\), fuel_level = \\$3"
+	mock.ExpectExec(query).WithArgs(pickupRequest.TransactionID, 1200, 100).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	result, err := repository.Add(request)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", result.EmployeeId)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedOverdueAlreadyPickedUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	mock.ExpectBegin()
+
+	expectEmployee(mock)
+
+	query := "SELECT t.status, (.+) FROM transaction t WHERE t.id = \\$1 AND (.+) FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "").WillReturnRows(statusRows("OVERDUE", true))
+
+	mock.ExpectRollback()
+
+	_, err = repository.Add(pickupRequest)
+	assert.Equal(t, errors.New("2"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedNotAnEmployee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM employee WHERE username = \\$1 (.+);").WithArgs(pickupRequest.Actor).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repository.Add(pickupRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedTransactionNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	mock.ExpectBegin()
+
+	// a rental picked up at another branch is not found
+	request := pickupRequest
+	request.BranchID = "branch-1"
+
+	expectEmployee(mock)
+
+	query := "SELECT t.status, (.+) FROM transaction t WHERE t.id = \\$1 AND (.+) FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "branch-1").WillReturnError(sql.ErrNoRows)
+
+	mock.ExpectRollback()
+
+	_, err = repository.Add(request)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAdd_FailedInsertPhoto(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	mock.ExpectBegin()
+
+	expectEmployee(mock)
+
+	query := "SELECT t.status, (.+) FROM transaction t WHERE t.id = \\$1 AND (.+) FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID, "").WillReturnRows(statusRows("ACTIVE", false))
+
+	query = "INSERT INTO motor_pickup(.+) RETURNING id;"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("10"))
+
+	query = "INSERT INTO motor_pickup_photo"
+	mock.ExpectExec(query).WillReturnError(errors.New("error sql"))
+
+	mock.ExpectRollback()
+
+	_, err = repository.Add(pickupRequest)
+	assert.Equal(t, errors.New("error sql"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetById_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	query := "SELECT (.+) FROM motor_pickup mp JOIN transaction t (.+) WHERE mp.id = \\$1 AND (.+);"
+	mock.ExpectQuery(query).WithArgs("10", "branch-1").WillReturnRows(pickupRows())
+
+	query = "SELECT url FROM motor_pickup_photo WHERE motor_pickup_id = \\$1"
+	mock.ExpectQuery(query).WithArgs("10").WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("https://cdn.example.com/a.jpg"))
+
+	result, err := repository.GetById("10", "branch-1")
+	assert.Nil(t, err)
+	assert.Equal(t, expectPickup, result)
+}
+
+func TestGetById_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	query := "SELECT (.+) FROM motor_pickup mp JOIN transaction t (.+) WHERE mp.id = \\$1 AND (.+);"
+	mock.ExpectQuery(query).WithArgs("10", "").WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.GetById("10", "")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestGetByTransactionId_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorPickupRepository(db)
+
+	query := "SELECT (.+) FROM motor_pickup WHERE transaction_id = \\$1;"
+	mock.ExpectQuery(query).WithArgs(pickupRequest.TransactionID).WillReturnRows(pickupRows())
+
+	query = "SELECT url FROM motor_pickup_photo WHERE motor_pickup_id = \\$1"
+	mock.ExpectQuery(query).WithArgs("10").WillReturnRows(sqlmock.NewRows([]string{"url"}).AddRow("https://cdn.example.com/a.jpg"))
+
+	result, err := repository.GetByTransactionId(pickupRequest.TransactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, expectPickup, result)
+}
@@ -0,0 +1,46 @@
+package motorPickupUsecase
+
+import (
+	"bike-rent-express/model/dto/motorPickupDto"
+	"bike-rent-express/src/motorPickup"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type motorPickupUsecase struct {
+	motorPickupRepo motorPickup.MotorPickupRepository
+}
+
+func NewMotorPickupUsecase(motorPickupRepo motorPickup.MotorPickupRepository) motorPickup.MotorPickupUsecase {
+	return &motorPickupUsecase{motorPickupRepo}
+}
+
+func (m *motorPickupUsecase) AddMotorPickup(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error) {
+	// the vehicle is only handed over after the employee has checked the customer's ID
+	if !createMotorPickupRequest.IdentityVerified {
+		return createMotorPickupRequest, errors.New("1")
+	}
+
+	motorPickupCreated, err := m.motorPickupRepo.Add(createMotorPickupRequest)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
+			return motorPickupCreated, errors.New("3")
+		}
+		return motorPickupCreated, err
+	}
+
+	return motorPickupCreated, nil
+}
+
+func (m *motorPickupUsecase) GetMotorPickupById(id string, branchID string) (motorPickupDto.MotorPickup, error) {
+	motorPickupDetail, err := m.motorPickupRepo.GetById(id, branchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
+			return motorPickupDetail, errors.New("1")
+		}
+		return motorPickupDetail, err
+	}
+
+	return motorPickupDetail, nil
+}
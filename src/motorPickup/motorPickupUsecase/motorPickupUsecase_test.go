@@ -0,0 +1,114 @@
+package motorPickupUsecase
+
+import (
+	"bike-rent-express/model/dto/motorPickupDto"
+	"bike-rent-express/src/motorPickup"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var pickupRequest = motorPickupDto.CreateMotorPickupRequest{
+	TransactionID:    "621dfcb6-06df-4420-b98e-3ec04def9547",
+	EmployeeId:       "1",
+	Odometer:         1200,
+	FuelLevel:        100,
+	DamageNotes:      "baret di spakbor",
+	IdentityVerified: true,
+}
+
+var expectPickup = motorPickupDto.MotorPickup{
+	ID:               "10",
+	TransactionID:    pickupRequest.TransactionID,
+	EmployeeId:       "1",
+	Odometer:         1200,
+	FuelLevel:        100,
+	DamageNotes:      "baret di spakbor",
+	IdentityVerified: true,
+}
+
+type mockMotorPickupRepository struct {
+	mock.Mock
+}
+
+func (m *mockMotorPickupRepository) Add(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error) {
+	args := m.Called(createMotorPickupRequest)
+	return args.Get(0).(motorPickupDto.CreateMotorPickupRequest), args.Error(1)
+}
+
+func (m *mockMotorPickupRepository) GetById(id string, branchID string) (motorPickupDto.MotorPickup, error) {
+	args := m.Called(id, branchID)
+	return args.Get(0).(motorPickupDto.MotorPickup), args.Error(1)
+}
+
+func (m *mockMotorPickupRepository) GetByTransactionId(transactionId string) (motorPickupDto.MotorPickup, error) {
+	args := m.Called(transactionId)
+	return args.Get(0).(motorPickupDto.MotorPickup), args.Error(1)
+}
+
+type MotorPickupUsecaseTestSuite struct {
+	suite.Suite
+	mockMotorPickupRepository *mockMotorPickupRepository
+	motorPickupUC             motorPickup.MotorPickupUsecase
+}
+
+func (suite *MotorPickupUsecaseTestSuite) SetupTest() {
+	suite.mockMotorPickupRepository = new(mockMotorPickupRepository)
+	suite.motorPickupUC = NewMotorPickupUsecase(suite.mockMotorPickupRepository)
+}
+
+func (suite *MotorPickupUsecaseTestSuite) TestAddMotorPickup_Success() {
+	expected := pickupRequest
+	expected.ID = "10"
+	suite.mockMotorPickupRepository.On("Add", pickupRequest).Return(expected, nil)
+
+	actual, err := suite.motorPickupUC.AddMotorPickup(pickupRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *MotorPickupUsecaseTestSuite) TestAddMotorPickup_FailedIdentityNotVerified() {
+	request := pickupRequest
+	request.IdentityVerified = false
+
+	_, err := suite.motorPickupUC.AddMotorPickup(request)
+	assert.Equal(suite.T(), errors.New("1"), err)
+	suite.mockMotorPickupRepository.AssertNotCalled(suite.T(), "Add", request)
+}
+
+func (suite *MotorPickupUsecaseTestSuite) TestAddMotorPickup_FailedNotFound() {
+	suite.mockMotorPickupRepository.On("Add", pickupRequest).Return(pickupRequest, sql.ErrNoRows)
+
+	_, err := suite.motorPickupUC.AddMotorPickup(pickupRequest)
+	assert.Equal(suite.T(), errors.New("3"), err)
+}
+
+func (suite *MotorPickupUsecaseTestSuite) TestAddMotorPickup_Failed() {
+	suite.mockMotorPickupRepository.On("Add", pickupRequest).Return(pickupRequest, errors.New("2"))
+
+	_, err := suite.motorPickupUC.AddMotorPickup(pickupRequest)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *MotorPickupUsecaseTestSuite) TestGetMotorPickupById_Success() {
+	suite.mockMotorPickupRepository.On("GetById", "10", "branch-1").Return(expectPickup, nil)
+
+	actual, err := suite.motorPickupUC.GetMotorPickupById("10", "branch-1")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectPickup, actual)
+}
+
+func (suite *MotorPickupUsecaseTestSuite) TestGetMotorPickupById_FailedNotFound() {
+	suite.mockMotorPickupRepository.On("GetById", "10", "branch-1").Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
+
+	_, err := suite.motorPickupUC.GetMotorPickupById("10", "branch-1")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func TestMotorPickupUsecase(t *testing.T) {
+	suite.Run(t, new(MotorPickupUsecaseTestSuite))
+}
@@ -19,11 +19,18 @@ func NewMotorReturnDelivey(v1Group *gin.RouterGroup, motorReturnUC motorReturn.M
 
 	motorReturnGroup := v1Group.Group("employee/:id/motor-return")
 	{
-		motorReturnGroup.POST("", middleware.JWTAuth("EMPLOYEE"), handler.CreateMotorReturn)
-		motorReturnGroup.GET("/:motor-return-id", middleware.JWTAuth("EMPLOYEE", "ADMIN"), handler.GetMotorReturnById)
+		motorReturnGroup.POST("", middleware.JWTAuth("EMPLOYEE", "BRANCH_MANAGER"), handler.CreateMotorReturn)
+		motorReturnGroup.GET("/:motor-return-id", middleware.JWTAuth("EMPLOYEE", "ADMIN", "BRANCH_MANAGER"), handler.GetMotorReturnById)
 	}
 
-	v1Group.GET("/users/motor-return", middleware.JWTAuth("ADMIN", "EMPLOYEE"), handler.GetAllMotorReturn)
+	v1Group.GET("/users/motor-return", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.GetAllMotorReturn)
+
+	correctionGroup := v1Group.Group("/motor-return/:motor-return-id")
+	{
+		correctionGroup.PUT("", middleware.JWTAuth("ADMIN"), handler.AmendMotorReturn)
+		correctionGroup.POST("/void", middleware.JWTAuth("ADMIN"), handler.VoidMotorReturn)
+		correctionGroup.GET("/revisions", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.GetMotorReturnRevisions)
+	}
 
 }
 
@@ -35,6 +42,14 @@ func (m *motorReturnDelivery) CreateMotorReturn(c *gin.Context) {
 		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
 		return
 	}
+	// the employee in the path has to be the one logged in
+	createMotorReturnRequest.EmployeeId = c.Param("id")
+	createMotorReturnRequest.Actor = c.GetString("username")
+
+	// the motor comes back at the branch of the employee taking it in
+	if createMotorReturnRequest.BranchID == "" {
+		createMotorReturnRequest.BranchID = c.GetString("branch_id")
+	}
 
 	motorReturnCreated, err := m.motorReturnUC.AddMotorReturn(createMotorReturnRequest)
 	if err != nil {
@@ -50,6 +65,18 @@ func (m *motorReturnDelivery) CreateMotorReturn(c *gin.Context) {
 			json.NewResponseBadRequest(c, nil, "Data not found", "01", "02")
 			return
 		}
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(c, nil, "Odometer is lower than at pickup", "01", "03")
+			return
+		}
+		if err.Error() == "5" {
+			json.NewResponseBadRequest(c, nil, "Damage catalog entry not found", "01", "04")
+			return
+		}
+		if err.Error() == "6" {
+			json.NewResponseForbidden(c, "Employee id does not match the employee logged in", "01", "01")
+			return
+		}
 		json.NewResponseError(c, err.Error(), "01", "01")
 		return
 	}
@@ -59,8 +86,12 @@ func (m *motorReturnDelivery) CreateMotorReturn(c *gin.Context) {
 
 func (m *motorReturnDelivery) GetMotorReturnById(c *gin.Context) {
 	id := c.Param("motor-return-id")
-	motorReturnDetail, err := m.motorReturnUC.GetMotorReturnById(id)
+	motorReturnDetail, err := m.motorReturnUC.GetMotorReturnById(id, c.GetString("branch_id"))
 	if err != nil {
+		if err.Error() == "3" {
+			json.NewResponseSuccess(c, nil, "Data not found", "02", "01")
+			return
+		}
 		json.NewResponseError(c, err.Error(), "02", "01")
 		return
 	}
@@ -70,7 +101,7 @@ func (m *motorReturnDelivery) GetMotorReturnById(c *gin.Context) {
 
 func (m *motorReturnDelivery) GetAllMotorReturn(c *gin.Context) {
 
-	motorsReturn, err := m.motorReturnUC.GetMotorReturnAll()
+	motorsReturn, err := m.motorReturnUC.GetMotorReturnAll(c.GetString("branch_id"))
 	if err != nil {
 		json.NewResponseError(c, err.Error(), "03", "01")
 		return
@@ -83,3 +114,80 @@ func (m *motorReturnDelivery) GetAllMotorReturn(c *gin.Context) {
 
 	json.NewResponseSuccess(c, motorsReturn, "Success get all motor return", "03", "02")
 }
+
+func (m *motorReturnDelivery) AmendMotorReturn(c *gin.Context) {
+	var amendMotorReturnRequest motorReturnDto.AmendMotorReturnRequest
+
+	c.BindJSON(&amendMotorReturnRequest)
+	if err := utils.Validated(amendMotorReturnRequest); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "04", "01")
+		return
+	}
+
+	amendMotorReturnRequest.ID = c.Param("motor-return-id")
+	amendMotorReturnRequest.Actor = c.GetString("username")
+
+	motorReturnDetail, err := m.motorReturnUC.AmendMotorReturn(amendMotorReturnRequest)
+	if err != nil {
+		m.correctionFailed(c, err, "04")
+		return
+	}
+
+	json.NewResponseSuccess(c, motorReturnDetail, "Motor return amended", "04", "01")
+}
+
+func (m *motorReturnDelivery) VoidMotorReturn(c *gin.Context) {
+	var voidMotorReturnRequest motorReturnDto.VoidMotorReturnRequest
+
+	c.BindJSON(&voidMotorReturnRequest)
+	if err := utils.Validated(voidMotorReturnRequest); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "05", "01")
+		return
+	}
+
+	voidMotorReturnRequest.ID = c.Param("motor-return-id")
+	voidMotorReturnRequest.Actor = c.GetString("username")
+
+	motorReturnDetail, err := m.motorReturnUC.VoidMotorReturn(voidMotorReturnRequest)
+	if err != nil {
+		m.correctionFailed(c, err, "05")
+		return
+	}
+
+	json.NewResponseSuccess(c, motorReturnDetail, "Motor return voided", "05", "01")
+}
+
+func (m *motorReturnDelivery) correctionFailed(c *gin.Context, err error, serviceCode string) {
+	if err.Error() == "1" {
+		json.NewResponseBadRequest(c, nil, "Not enough balance", serviceCode, "02")
+		return
+	}
+	if err.Error() == "2" {
+		json.NewResponseBadRequest(c, nil, "Motor return is already voided", serviceCode, "03")
+		return
+	}
+	if err.Error() == "3" {
+		json.NewResponseBadRequest(c, nil, "Data not found", serviceCode, "04")
+		return
+	}
+	json.NewResponseError(c, err.Error(), serviceCode, "01")
+}
+
+func (m *motorReturnDelivery) GetMotorReturnRevisions(c *gin.Context) {
+	revisions, err := m.motorReturnUC.GetMotorReturnRevisions(c.Param("motor-return-id"), c.GetString("branch_id"))
+	if err != nil {
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "Data not found", "06", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "06", "01")
+		return
+	}
+
+	if len(revisions) == 0 {
+		json.NewResponseSuccess(c, nil, "Empty data", "06", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, revisions, "Success get motor return revisions", "06", "02")
+}
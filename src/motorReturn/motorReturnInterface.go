@@ -6,12 +6,18 @@ type (
 	MotorReturnRepository interface {
 		Add(createMotorReturnRequest motorReturnDto.CreateMotorReturnRequest) (motorReturnDto.CreateMotorReturnRequest, error)
 		GetById(id string) (motorReturnDto.MotorReturn, error)
-		GetAll() ([]motorReturnDto.MotorReturn, error)
+		GetAll(branchID string) ([]motorReturnDto.MotorReturn, error)
+		Amend(amendMotorReturnRequest motorReturnDto.AmendMotorReturnRequest) error
+		Void(voidMotorReturnRequest motorReturnDto.VoidMotorReturnRequest) error
+		GetRevisions(id string) ([]motorReturnDto.MotorReturnRevision, error)
 	}
 
 	MotorReturnUsecase interface {
 		AddMotorReturn(createMotorReturnRequest motorReturnDto.CreateMotorReturnRequest) (motorReturnDto.CreateMotorReturnRequest, error)
-		GetMotorReturnById(id string) (motorReturnDto.MotorReturnResponse, error)
-		GetMotorReturnAll() ([]motorReturnDto.MotorReturnResponse, error)
+		GetMotorReturnById(id string, branchID string) (motorReturnDto.MotorReturnResponse, error)
+		GetMotorReturnAll(branchID string) ([]motorReturnDto.MotorReturnResponse, error)
+		AmendMotorReturn(amendMotorReturnRequest motorReturnDto.AmendMotorReturnRequest) (motorReturnDto.MotorReturnResponse, error)
+		VoidMotorReturn(voidMotorReturnRequest motorReturnDto.VoidMotorReturnRequest) (motorReturnDto.MotorReturnResponse, error)
+		GetMotorReturnRevisions(id string, branchID string) ([]motorReturnDto.MotorReturnRevision, error)
 	}
 )
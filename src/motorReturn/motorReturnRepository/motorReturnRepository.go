@@ -2,121 +2,425 @@ package motorReturnRepository
 
 import (
 	"bike-rent-express/model/dto/motorReturnDto"
+	"bike-rent-express/pkg/utils"
 	"bike-rent-express/src/motorReturn"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
 type motorReturnRepository struct {
-	db *sql.DB
+	db                 *sql.DB
+	autoTicketSeverity string
 }
 
-func NewMotorRepository(db *sql.DB) motorReturn.MotorReturnRepository {
-	return &motorReturnRepository{db}
+// severityRank orders the damage severities, a return recording damage ranked at or above
+// the auto ticket severity opens a maintenance ticket for the vehicle
+var severityRank = map[string]int{"MINOR": 1, "MODERATE": 2, "SEVERE": 3}
+
+func NewMotorRepository(db *sql.DB, autoTicketSeverity string) motorReturn.MotorReturnRepository {
+	return &motorReturnRepository{db, autoTicketSeverity}
 }
 
+// Add settles the return of a rented motor vehicle in one database transaction. The
+// transaction row is locked first so that concurrent returns of the same rental are
+// rejected before any charge is applied. The return is recorded for the employee logged in,
+// who may only take it in under their own id.
 func (m *motorReturnRepository) Add(createMotorReturnRequest motorReturnDto.CreateMotorReturnRequest) (motorReturnDto.CreateMotorReturnRequest, error) {
 	tx, err := m.db.Begin()
 	if err != nil {
+		return createMotorReturnRequest, err
+	}
+
+	var employeeId string
+	query := "SELECT id FROM employee WHERE username = $1 AND deleted_at IS NULL;"
+	if err := tx.QueryRow(query, createMotorReturnRequest.Actor).Scan(&employeeId); err != nil {
 		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return createMotorReturnRequest, errors.New("6")
+		}
 		return createMotorReturnRequest, err
 	}
-	var userId string
-	query := "SELECT user_id FROM transaction WHERE id = $1;"
-	if err := tx.QueryRow(query, createMotorReturnRequest.TransactionID).Scan(&userId); err != nil {
+	if employeeId != createMotorReturnRequest.EmployeeId {
+		tx.Rollback()
+		return createMotorReturnRequest, errors.New("6")
+	}
+
+	var userId, motorVehicleId, status, returnBranchId string
+	var lateFee, overagePerKm int
+	var kmLimit, pickupOdometer *int
+	query = "SELECT t.user_id, t.motor_vehicle_id, t.status, t.late_fee, COALESCE(t.return_branch_id::text, ''), t.km_limit, t.overage_per_km, mp.odometer FROM transaction t LEFT JOIN motor_pickup mp ON mp.transaction_id = t.id WHERE t.id = $1 FOR UPDATE OF t;"
+	if err := tx.QueryRow(query, createMotorReturnRequest.TransactionID).Scan(&userId, &motorVehicleId, &status, &lateFee, &returnBranchId, &kmLimit, &overagePerKm, &pickupOdometer); err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
 
-	var balanceUser int
-	query = "SELECT amount FROM balance WHERE user_id = $1;"
+	if status == "RETURNED" {
+		tx.Rollback()
+		return createMotorReturnRequest, errors.New("2")
+	}
 
-	if err := tx.QueryRow(query, userId).Scan(&balanceUser); err != nil {
+	damages, damageCharge, err := priceDamages(tx, createMotorReturnRequest.Damages)
+	if err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
 
-	if balanceUser < createMotorReturnRequest.ExtraCharge {
-		tx.Rollback()
-		return createMotorReturnRequest, errors.New("1")
+	createMotorReturnRequest.ExtraCharge = damageCharge
+	if createMotorReturnRequest.ExtraChargeOverride != nil {
+		createMotorReturnRequest.ExtraCharge = *createMotorReturnRequest.ExtraChargeOverride
+	}
+
+	// kilometres driven over the allowance of the rental are charged at its overage rate
+	if pickupOdometer != nil {
+		createMotorReturnRequest.Distance = createMotorReturnRequest.Odometer - *pickupOdometer
+	}
+	if kmLimit != nil && createMotorReturnRequest.Distance > *kmLimit {
+		createMotorReturnRequest.OverageKm = createMotorReturnRequest.Distance - *kmLimit
+		createMotorReturnRequest.OverageCharge = createMotorReturnRequest.OverageKm * overagePerKm
 	}
 
-	balanceUser -= createMotorReturnRequest.ExtraCharge
+	if createMotorReturnRequest.BranchID == "" {
+		createMotorReturnRequest.BranchID = returnBranchId
+	}
 
-	query = "UPDATE balance SET amount = $1 WHERE user_id = $2;"
-	_, err = tx.Exec(query, balanceUser, userId)
-	if err != nil {
+	// the vehicle is now available wherever it was dropped off, with the readings taken at return
+	query = utils.VehicleStatusEvent("AVAILABLE", "")
+	if _, err := tx.Exec(query, motorVehicleId, createMotorReturnRequest.Actor, "returned", createMotorReturnRequest.TransactionID); err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
 
-	query = "UPDATE users SET can_rent = true WHERE id = $1"
-	_, err = tx.Exec(query, userId)
-	if err != nil {
+	query = "UPDATE motor_vehicle SET status = 'AVAILABLE', current_branch_id = COALESCE(NULLIF($2, '')::uuid, current_branch_id), odometer = GREATEST(odometer, $3), fuel_level = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
+	if _, err := tx.Exec(query, motorVehicleId, createMotorReturnRequest.BranchID, createMotorReturnRequest.Odometer, createMotorReturnRequest.FuelLevel); err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
 
-	query = "UPDATE motor_vehicle SET status = 'AVAILABLE';"
-	_, err = tx.Exec(query)
-	if err != nil {
+	var balanceUser int
+	query = "SELECT amount FROM balance WHERE user_id = $1 FOR UPDATE;"
+	if err := tx.QueryRow(query, userId).Scan(&balanceUser); err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
 
-	returnDate := time.Now()
+	// late fee accrued by the overdue worker and the mileage overage are settled together with the extra charge
+	totalCharge := createMotorReturnRequest.ExtraCharge + lateFee + createMotorReturnRequest.OverageCharge
+	if balanceUser < totalCharge {
+		tx.Rollback()
+		return createMotorReturnRequest, errors.New("1")
+	}
 
-	query = "SELECT COUNT(transaction_id) FROM motor_return WHERE transaction_id = $1;"
-	var transactionExist int
-	if err := tx.QueryRow(query, createMotorReturnRequest.TransactionID).Scan(&transactionExist); err != nil {
+	query = "UPDATE balance SET amount = $1 WHERE user_id = $2;"
+	if _, err := tx.Exec(query, balanceUser-totalCharge, userId); err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
 
-	if transactionExist > 0 {
+	query = "UPDATE transaction SET status = 'RETURNED', updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
+	if _, err := tx.Exec(query, createMotorReturnRequest.TransactionID); err != nil {
 		tx.Rollback()
-		return createMotorReturnRequest, errors.New("2")
+		return createMotorReturnRequest, err
 	}
 
-	query = "INSERT INTO motor_return(transaction_id, return_date, extra_charge, condition_motor, description) VALUES($1, $2, $3, $4, $5) RETURNING id;"
-
-	if err := tx.QueryRow(query, createMotorReturnRequest.TransactionID, returnDate, createMotorReturnRequest.ExtraCharge, createMotorReturnRequest.ConditionMotor, createMotorReturnRequest.Description).Scan(&createMotorReturnRequest.ID); err != nil {
+	query = "INSERT INTO motor_return(transaction_id, return_date, extra_charge, condition_motor, description, odometer, fuel_level, override_reason, branch_id, employee_id, overage_km, overage_charge) VALUES($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, '')::uuid, NULLIF($10, '')::uuid, $11, $12) RETURNING id;"
+	if err := tx.QueryRow(query, createMotorReturnRequest.TransactionID, time.Now(), createMotorReturnRequest.ExtraCharge, createMotorReturnRequest.ConditionMotor, createMotorReturnRequest.Description, createMotorReturnRequest.Odometer, createMotorReturnRequest.FuelLevel, createMotorReturnRequest.OverrideReason, createMotorReturnRequest.BranchID, createMotorReturnRequest.EmployeeId, createMotorReturnRequest.OverageKm, createMotorReturnRequest.OverageCharge).Scan(&createMotorReturnRequest.ID); err != nil {
 		tx.Rollback()
 		return createMotorReturnRequest, err
 	}
-	tx.Commit()
+
+	query = "INSERT INTO motor_return_damage(motor_return_id, damage_catalog_id, part, severity, quantity, repair_cost) VALUES($1, $2, $3, $4, $5, $6);"
+	for _, damage := range damages {
+		if _, err := tx.Exec(query, createMotorReturnRequest.ID, damage.DamageCatalogID, damage.Part, damage.Severity, damage.Quantity, damage.RepairCost); err != nil {
+			tx.Rollback()
+			return createMotorReturnRequest, err
+		}
+	}
+
+	if notes := m.maintenanceNotes(damages); notes != "" {
+		query = "INSERT INTO maintenance(motor_vehicle_id, motor_return_id, status, description, start_date, notes) VALUES($1, $2, 'IN_PROGRESS', 'Damage recorded at motor return', CURRENT_TIMESTAMP, $3) RETURNING id;"
+		if err := tx.QueryRow(query, motorVehicleId, createMotorReturnRequest.ID, notes).Scan(&createMotorReturnRequest.MaintenanceID); err != nil {
+			tx.Rollback()
+			return createMotorReturnRequest, err
+		}
+
+		query = utils.VehicleStatusEvent("IN_MAINTENANCE", "")
+		if _, err := tx.Exec(query, motorVehicleId, createMotorReturnRequest.Actor, "damaged at return", createMotorReturnRequest.TransactionID); err != nil {
+			tx.Rollback()
+			return createMotorReturnRequest, err
+		}
+
+		query = "UPDATE motor_vehicle SET status = 'IN_MAINTENANCE' WHERE id = $1;"
+		if _, err := tx.Exec(query, motorVehicleId); err != nil {
+			tx.Rollback()
+			return createMotorReturnRequest, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return createMotorReturnRequest, err
+	}
 
 	return createMotorReturnRequest, nil
 }
 
+// maintenanceNotes lists the damages severe enough to open a maintenance ticket, it is empty
+// when there are none or opening tickets is turned off.
+func (m *motorReturnRepository) maintenanceNotes(damages []motorReturnDto.MotorReturnDamage) string {
+	if m.autoTicketSeverity == "" {
+		return ""
+	}
+
+	var notes []string
+	for _, damage := range damages {
+		if severityRank[damage.Severity] >= severityRank[m.autoTicketSeverity] {
+			notes = append(notes, fmt.Sprintf("%s (%s) x%d", damage.Part, damage.Severity, damage.Quantity))
+		}
+	}
+
+	return strings.Join(notes, "\n")
+}
+
+// priceDamages looks up the selected catalogue entries and returns them with the total
+// damage charge. An unknown or deleted entry fails with "5".
+func priceDamages(tx *sql.Tx, items []motorReturnDto.DamageItemRequest) ([]motorReturnDto.MotorReturnDamage, int, error) {
+	var damages []motorReturnDto.MotorReturnDamage
+	var total int
+
+	query := "SELECT part, severity, repair_cost FROM damage_catalog WHERE id = $1 AND deleted_at IS NULL;"
+	for _, item := range items {
+		damage := motorReturnDto.MotorReturnDamage{DamageCatalogID: item.DamageCatalogID, Quantity: item.Quantity}
+		if err := tx.QueryRow(query, item.DamageCatalogID).Scan(&damage.Part, &damage.Severity, &damage.RepairCost); err != nil {
+			if err == sql.ErrNoRows {
+				return damages, total, errors.New("5")
+			}
+			return damages, total, err
+		}
+
+		damage.Subtotal = damage.RepairCost * damage.Quantity
+		total += damage.Subtotal
+		damages = append(damages, damage)
+	}
+
+	return damages, total, nil
+}
+
+const motorReturnColumns = "mr.id, mr.transaction_id, mr.return_date, mr.extra_charge, mr.condition_motor, mr.description, mr.odometer, mr.fuel_level, COALESCE(mr.branch_id::text, ''), COALESCE(mr.override_reason, ''), COALESCE(mr.voided_at::text, ''), COALESCE(mr.void_reason, ''), mr.overage_km, mr.overage_charge, mr.created_at, mr.updated_at"
+
 func (m *motorReturnRepository) GetById(id string) (motorReturnDto.MotorReturn, error) {
 	var motorReturn motorReturnDto.MotorReturn
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return WHERE id = $1;"
+	query := "SELECT " + motorReturnColumns + " FROM motor_return mr WHERE mr.id = $1;"
+
+	if err := scanMotorReturn(m.db.QueryRow(query, id), &motorReturn); err != nil {
+		return motorReturn, err
+	}
 
-	if err := m.db.QueryRow(query, id).Scan(&motorReturn.ID, &motorReturn.TrasactionID, &motorReturn.ReturnDate, &motorReturn.ExtraCharge, &motorReturn.ConditionMotor, &motorReturn.Descrption, &motorReturn.CreatedAt, &motorReturn.UpdatedAt); err != nil {
+	damages, err := m.getDamages(id)
+	if err != nil {
 		return motorReturn, err
 	}
+	motorReturn.Damages = damages
 
 	return motorReturn, nil
 }
 
-func (m *motorReturnRepository) GetAll() ([]motorReturnDto.MotorReturn, error) {
+// GetAll lists the motor returns taken in at branchID or of rentals picked up there, all of them
+// when branchID is empty.
+func (m *motorReturnRepository) GetAll(branchID string) ([]motorReturnDto.MotorReturn, error) {
 	var motorsReturn []motorReturnDto.MotorReturn
 
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return;"
-	rows, err := m.db.Query(query)
+	query := `SELECT ` + motorReturnColumns + `
+		FROM motor_return mr JOIN transaction t ON t.id = mr.transaction_id
+		WHERE $1 = '' OR mr.branch_id::text = $1 OR t.pickup_branch_id::text = $1;`
+	rows, err := m.db.Query(query, branchID)
 	if err != nil {
 		return motorsReturn, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var motorReturn motorReturnDto.MotorReturn
-		if err := rows.Scan(&motorReturn.ID, &motorReturn.TrasactionID, &motorReturn.ReturnDate, &motorReturn.ExtraCharge, &motorReturn.ConditionMotor, &motorReturn.Descrption, &motorReturn.CreatedAt, &motorReturn.UpdatedAt); err != nil {
+		if err := scanMotorReturn(rows, &motorReturn); err != nil {
 			return motorsReturn, err
 		}
 		motorsReturn = append(motorsReturn, motorReturn)
 	}
+	if err := rows.Err(); err != nil {
+		return motorsReturn, err
+	}
+
+	for i := range motorsReturn {
+		motorsReturn[i].Damages, err = m.getDamages(motorsReturn[i].ID)
+		if err != nil {
+			return motorsReturn, err
+		}
+	}
 
 	return motorsReturn, nil
 }
+
+func (m *motorReturnRepository) getDamages(id string) ([]motorReturnDto.MotorReturnDamage, error) {
+	var damages []motorReturnDto.MotorReturnDamage
+
+	query := "SELECT damage_catalog_id, part, severity, quantity, repair_cost FROM motor_return_damage WHERE motor_return_id = $1 ORDER BY part, severity;"
+	rows, err := m.db.Query(query, id)
+	if err != nil {
+		return damages, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var damage motorReturnDto.MotorReturnDamage
+		if err := rows.Scan(&damage.DamageCatalogID, &damage.Part, &damage.Severity, &damage.Quantity, &damage.RepairCost); err != nil {
+			return damages, err
+		}
+		damage.Subtotal = damage.RepairCost * damage.Quantity
+		damages = append(damages, damage)
+	}
+
+	return damages, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMotorReturn(row scanner, motorReturn *motorReturnDto.MotorReturn) error {
+	return row.Scan(&motorReturn.ID, &motorReturn.TrasactionID, &motorReturn.ReturnDate, &motorReturn.ExtraCharge, &motorReturn.ConditionMotor, &motorReturn.Descrption, &motorReturn.Odometer, &motorReturn.FuelLevel, &motorReturn.BranchID, &motorReturn.OverrideReason, &motorReturn.VoidedAt, &motorReturn.VoidReason, &motorReturn.OverageKm, &motorReturn.OverageCharge, &motorReturn.CreatedAt, &motorReturn.UpdatedAt)
+}
+
+func (m *motorReturnRepository) Amend(amendMotorReturnRequest motorReturnDto.AmendMotorReturnRequest) error {
+	return m.revise(revision{
+		motorReturnID:  amendMotorReturnRequest.ID,
+		action:         "AMEND",
+		extraCharge:    *amendMotorReturnRequest.ExtraCharge,
+		conditionMotor: amendMotorReturnRequest.ConditionMotor,
+		description:    amendMotorReturnRequest.Description,
+		reason:         amendMotorReturnRequest.Reason,
+		actor:          amendMotorReturnRequest.Actor,
+	})
+}
+
+// Void cancels the extra charge of a return that should not have been charged. The
+// vehicle stays returned, only the money taken for the return is given back.
+func (m *motorReturnRepository) Void(voidMotorReturnRequest motorReturnDto.VoidMotorReturnRequest) error {
+	return m.revise(revision{
+		motorReturnID: voidMotorReturnRequest.ID,
+		action:        "VOID",
+		reason:        voidMotorReturnRequest.Reason,
+		actor:         voidMotorReturnRequest.Actor,
+	})
+}
+
+type revision struct {
+	motorReturnID  string
+	action         string
+	extraCharge    int
+	conditionMotor string
+	description    string
+	reason         string
+	actor          string
+}
+
+// revise applies an amendment or a void in one database transaction. The values being
+// replaced go to motor_return_revision and the difference in extra charge is settled
+// against the customer balance as a RETURN_ADJUSTMENT ledger entry. A voided return
+// fails with "2" and a raise the balance cannot cover fails with "1".
+func (m *motorReturnRepository) revise(r revision) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var userId, conditionMotor, description string
+	var extraCharge int
+	var voided bool
+	query := "SELECT t.user_id, mr.extra_charge, mr.condition_motor, mr.description, mr.voided_at IS NOT NULL FROM motor_return mr JOIN transaction t ON t.id = mr.transaction_id WHERE mr.id = $1 FOR UPDATE OF mr;"
+	if err := tx.QueryRow(query, r.motorReturnID).Scan(&userId, &extraCharge, &conditionMotor, &description, &voided); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if voided {
+		tx.Rollback()
+		return errors.New("2")
+	}
+
+	if r.conditionMotor == "" {
+		r.conditionMotor = conditionMotor
+	}
+	if r.description == "" {
+		r.description = description
+	}
+
+	// adjustment is what the customer balance moves by, negative when charging more
+	adjustment := extraCharge - r.extraCharge
+	if adjustment != 0 {
+		var balanceUser int
+		query = "SELECT amount FROM balance WHERE user_id = $1 FOR UPDATE;"
+		if err := tx.QueryRow(query, userId).Scan(&balanceUser); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if balanceUser+adjustment < 0 {
+			tx.Rollback()
+			return errors.New("1")
+		}
+
+		query = "UPDATE balance SET amount = amount + $1 WHERE user_id = $2;"
+		if _, err := tx.Exec(query, adjustment, userId); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		query = "INSERT INTO balance_ledger(user_id, amount, kind, reference_id, description) VALUES ($1, $2, 'RETURN_ADJUSTMENT', $3, $4);"
+		if _, err := tx.Exec(query, userId, adjustment, r.motorReturnID, r.action+": "+r.reason); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	query = "INSERT INTO motor_return_revision(motor_return_id, action, extra_charge, condition_motor, description, adjustment, reason, actor) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);"
+	if _, err := tx.Exec(query, r.motorReturnID, r.action, extraCharge, conditionMotor, description, adjustment, r.reason, r.actor); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if r.action == "VOID" {
+		query = "UPDATE motor_return SET extra_charge = 0, voided_at = CURRENT_TIMESTAMP, void_reason = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;"
+		_, err = tx.Exec(query, r.reason, r.motorReturnID)
+	} else {
+		query = "UPDATE motor_return SET extra_charge = $1, condition_motor = $2, description = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $4;"
+		_, err = tx.Exec(query, r.extraCharge, r.conditionMotor, r.description, r.motorReturnID)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *motorReturnRepository) GetRevisions(id string) ([]motorReturnDto.MotorReturnRevision, error) {
+	var revisions []motorReturnDto.MotorReturnRevision
+
+	query := "SELECT id, action, extra_charge, condition_motor, description, adjustment, reason, actor, created_at FROM motor_return_revision WHERE motor_return_id = $1 ORDER BY created_at;"
+	rows, err := m.db.Query(query, id)
+	if err != nil {
+		return revisions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var revision motorReturnDto.MotorReturnRevision
+		if err := rows.Scan(&revision.ID, &revision.Action, &revision.ExtraCharge, &revision.ConditionMotor, &revision.Description, &revision.Adjustment, &revision.Reason, &revision.Actor, &revision.CreatedAt); err != nil {
+			return revisions, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
@@ -2,6 +2,7 @@ package motorReturnRepository
 
 import (
 	"bike-rent-express/model/dto/motorReturnDto"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -16,6 +17,8 @@ var expectedMotorReturn = motorReturnDto.MotorReturn{
 	ExtraCharge:    25000,
 	ConditionMotor: "Ban depan bocor",
 	Descrption:     "bocor di jalan",
+	Odometer:       1250,
+	FuelLevel:      40,
 	CreatedAt:      "2024-03-07T23:39:42.63419Z",
 	UpdatedAt:      "2024-03-07T23:39:42.63419Z",
 }
@@ -23,9 +26,49 @@ var expectedMotorReturn = motorReturnDto.MotorReturn{
 var expectedCreateMotorReturn = motorReturnDto.CreateMotorReturnRequest{
 	ID:             expectedMotorReturn.ID,
 	TransactionID:  expectedMotorReturn.TrasactionID,
+	Damages:        []motorReturnDto.DamageItemRequest{{DamageCatalogID: damageCatalogId, Quantity: 1}},
 	ExtraCharge:    expectedMotorReturn.ExtraCharge,
 	ConditionMotor: expectedMotorReturn.ConditionMotor,
 	Description:    expectedMotorReturn.Descrption,
+	EmployeeId:     returningEmployeeId,
+	Actor:          "employee",
+}
+
+const (
+	rentedMotorVehicleId = "3f0c2a9e-8d4b-4a61-9f0e-1c2d3e4f5a6b"
+	damageCatalogId      = "5b7d9f1a-2c4e-4a6b-8d0f-1a3c5e7b9d2f"
+	returningEmployeeId  = "8c1e3a5f-7b9d-4f2a-a4c6-e8f0a2b4c6d8"
+)
+
+// expectEmployee resolves the employee logged in to the one in the path of the request
+func expectEmployee(mock sqlmock.Sqlmock) {
+	query := "SELECT id FROM employee WHERE username = \\$1 AND deleted_at IS NULL;"
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(returningEmployeeId)
+	mock.ExpectQuery(query).WithArgs("employee").WillReturnRows(rows)
+}
+
+// the single damage of expectedCreateMotorReturn costs the whole extra charge
+func expectDamageCatalog(mock sqlmock.Sqlmock) {
+	query := "SELECT part, severity, repair_cost FROM damage_catalog WHERE id = \\$1 AND deleted_at IS NULL;"
+	rows := sqlmock.NewRows([]string{"part", "severity", "repair_cost"}).AddRow("Ban depan", "MODERATE", 25000)
+	mock.ExpectQuery(query).WithArgs(damageCatalogId).WillReturnRows(rows)
+}
+
+func expectLockTransaction(mock sqlmock.Sqlmock, status string, lateFee int) {
+	expectLockRental(mock, status, lateFee, nil, 0, nil)
+}
+
+// expectLockRental also gives the mileage allowance of the rental and the odometer read at pickup
+func expectLockRental(mock sqlmock.Sqlmock, status string, lateFee int, kmLimit interface{}, overagePerKm int, pickupOdometer interface{}) {
+	query := "SELECT t.user_id, t.motor_vehicle_id, t.status, t.late_fee, (.+) FROM transaction t LEFT JOIN motor_pickup mp ON (.+) WHERE t.id = \\$1 FOR UPDATE OF t;"
+	rows := sqlmock.NewRows([]string{"user_id", "motor_vehicle_id", "status", "late_fee", "return_branch_id", "km_limit", "overage_per_km", "odometer"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8", rentedMotorVehicleId, status, lateFee, "", kmLimit, overagePerKm, pickupOdometer)
+	mock.ExpectQuery(query).WithArgs(expectedCreateMotorReturn.TransactionID).WillReturnRows(rows)
+}
+
+// expectStatusEvent records the change of the status of the rented vehicle in its history
+func expectStatusEvent(mock sqlmock.Sqlmock, status string) {
+	query := "INSERT INTO motor_vehicle_event(.+) SELECT mv.id, 'STATUS', mv.status::text, '" + status + "', (.+)"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId, sqlmock.AnyArg(), sqlmock.AnyArg(), expectedCreateMotorReturn.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
 }
 
 // test add success
@@ -37,285 +80,487 @@ func TestAdd_Success(t *testing.T) {
 	defer db.Close()
 
 	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "PICKED_UP", 0)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	// only the rented motor vehicle is released
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE', (.+) WHERE id = \\$1;"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId, "", 0, 0).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"amount"}).AddRow(30000)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
 	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
 	mock.ExpectExec(query).WithArgs(5000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "UPDATE users"
-	mock.ExpectExec(query).WithArgs("907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+	query = "UPDATE transaction SET status = 'RETURNED'"
+	mock.ExpectExec(query).WithArgs(expectedCreateMotorReturn.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "UPDATE motor_vehicle SET status = 'AVAILABLE';"
-	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+	query = "INSERT INTO motor_return(.+) RETURNING id;"
+	rows = sqlmock.NewRows([]string{"id"}).AddRow(expectedCreateMotorReturn.ID)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	query = "INSERT INTO motor_return_damage"
+	mock.ExpectExec(query).WithArgs(expectedCreateMotorReturn.ID, damageCatalogId, "Ban depan", "MODERATE", 1, 25000).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
 
-	query = "SELECT COUNT(.+) FROM motor_return WHERE transaction_id = \\$1"
-	rows = sqlmock.NewRows([]string{"count"}).AddRow(0)
+	result, err := repository.Add(expectedCreateMotorReturn)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedCreateMotorReturn, result)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+// test add success settles the late fee together with the extra charge
+func TestAdd_SuccessWithLateFee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "OVERDUE", 4000)
+	expectDamageCatalog(mock)
+
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId, "", 0, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"amount"}).AddRow(30000)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
+	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
+	mock.ExpectExec(query).WithArgs(1000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "UPDATE transaction SET status = 'RETURNED'"
+	mock.ExpectExec(query).WithArgs(expectedCreateMotorReturn.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
+
 	query = "INSERT INTO motor_return(.+) RETURNING id;"
 	rows = sqlmock.NewRows([]string{"id"}).AddRow(expectedCreateMotorReturn.ID)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
+	query = "INSERT INTO motor_return_damage"
+	mock.ExpectExec(query).WithArgs(expectedCreateMotorReturn.ID, damageCatalogId, "Ban depan", "MODERATE", 1, 25000).WillReturnResult(sqlmock.NewResult(0, 1))
+
 	mock.ExpectCommit()
 
 	result, err := repository.Add(expectedCreateMotorReturn)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedCreateMotorReturn, result)
-
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-// test fail to get user_id from transaction
-func TestAdd_FailToGetUserIdTransaction(t *testing.T) {
+// test add success charges the overridden amount instead of the damages
+func TestAdd_SuccessWithOverride(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
+
+	override := 10000
+	request := expectedCreateMotorReturn
+	request.ExtraChargeOverride = &override
+	request.OverrideReason = "pelanggan tetap"
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "PICKED_UP", 0)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId, "", 0, 0).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectRollback()
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"amount"}).AddRow(30000)
+	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
+	mock.ExpectExec(query).WithArgs(20000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "UPDATE transaction SET status = 'RETURNED'"
+	mock.ExpectExec(query).WithArgs(expectedCreateMotorReturn.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "INSERT INTO motor_return(.+) RETURNING id;"
+	rows = sqlmock.NewRows([]string{"id"}).AddRow(expectedCreateMotorReturn.ID)
+	mock.ExpectQuery(query).WithArgs(expectedCreateMotorReturn.TransactionID, sqlmock.AnyArg(), 10000, expectedCreateMotorReturn.ConditionMotor, expectedCreateMotorReturn.Description, 0, 0, "pelanggan tetap", "", returningEmployeeId, 0, 0).WillReturnRows(rows)
+
+	query = "INSERT INTO motor_return_damage"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	result, err := repository.Add(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 10000, result.ExtraCharge)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-// test fail to get amount from balance
-func TestAdd_FailToGetAmountBalance(t *testing.T) {
+// test add charges the kilometres driven over the mileage allowance of the rental
+func TestAdd_SuccessWithOverage(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
+
+	request := expectedCreateMotorReturn
+	request.Odometer = 1450
+	request.FuelLevel = 40
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockRental(mock, "PICKED_UP", 0, 150, 100, 1200)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE', (.+), odometer = GREATEST\\(odometer, \\$3\\), fuel_level = \\$4"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId, "", 1450, 40).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(50000))
 
-	mock.ExpectRollback()
+	// 25000 of damage and 100 km over the allowance at 100 per km
+	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
+	mock.ExpectExec(query).WithArgs(15000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
 
-	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	query = "UPDATE transaction SET status = 'RETURNED'"
+	mock.ExpectExec(query).WithArgs(expectedCreateMotorReturn.TransactionID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "INSERT INTO motor_return(.+) RETURNING id;"
+	mock.ExpectQuery(query).WithArgs(expectedCreateMotorReturn.TransactionID, sqlmock.AnyArg(), 25000, expectedCreateMotorReturn.ConditionMotor, expectedCreateMotorReturn.Description, 1450, 40, "", "", returningEmployeeId, 100, 10000).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedCreateMotorReturn.ID))
+
+	query = "INSERT INTO motor_return_damage"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	result, err := repository.Add(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 250, result.Distance)
+	assert.Equal(t, 100, result.OverageKm)
+	assert.Equal(t, 10000, result.OverageCharge)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-// test fail when balance less than extra charge
-func TestAdd_FailBalanceLessThanExtraCharge(t *testing.T) {
+// test add opens a maintenance ticket when the damage is at least the auto ticket severity
+func TestAdd_SuccessOpensMaintenance(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "MODERATE")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "PICKED_UP", 0)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId, "", 0, 0).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(10000)
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(30000))
 
-	mock.ExpectRollback()
+	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	query = "UPDATE transaction SET status = 'RETURNED'"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "INSERT INTO motor_return(.+) RETURNING id;"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedCreateMotorReturn.ID))
+
+	query = "INSERT INTO motor_return_damage"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	query = "INSERT INTO maintenance(.+) VALUES\\(\\$1, \\$2, 'IN_PROGRESS'(.+) RETURNING id;"
+	mock.ExpectQuery(query).WithArgs(rentedMotorVehicleId, expectedCreateMotorReturn.ID, "Ban depan (MODERATE) x1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("9"))
+
+	expectStatusEvent(mock, "IN_MAINTENANCE")
+	query = "UPDATE motor_vehicle SET status = 'IN_MAINTENANCE'"
+	mock.ExpectExec(query).WithArgs(rentedMotorVehicleId).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	result, err := repository.Add(expectedCreateMotorReturn)
+	assert.Nil(t, err)
+	assert.Equal(t, "9", result.MaintenanceID)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-// test fail to update balance
-func TestAdd_FailToUpdateBalance(t *testing.T) {
+// test add leaves the vehicle available when the damage is below the auto ticket severity
+func TestAdd_SuccessMinorDamageNoMaintenance(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "SEVERE")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "PICKED_UP", 0)
+	expectDamageCatalog(mock)
+	expectStatusEvent(mock, "AVAILABLE")
+	mock.ExpectExec("UPDATE motor_vehicle SET status = 'AVAILABLE'").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT amount FROM balance").WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(30000))
+	mock.ExpectExec("UPDATE balance SET amount").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE transaction SET status = 'RETURNED'").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO motor_return(.+) RETURNING id;").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedCreateMotorReturn.ID))
+	mock.ExpectExec("INSERT INTO motor_return_damage").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	result, err := repository.Add(expectedCreateMotorReturn)
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.MaintenanceID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
-	mock.ExpectQuery(query).WillReturnRows(rows)
+// test fail when a selected damage is not in the catalogue
+func TestAdd_FailUnknownDamage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
 
-	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
-	mock.ExpectExec(query).WillReturnError(errors.New("error sql"))
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "ACTIVE", 0)
+
+	query := "SELECT part, severity, repair_cost FROM damage_catalog"
+	mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
 
 	mock.ExpectRollback()
 
 	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	assert.Equal(t, errors.New("5"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-func TestAdd_FailToUpdateStatusUser(t *testing.T) {
+// test fail when the transaction does not exist
+func TestAdd_FailTransactionNotFound(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	query := "SELECT t.user_id, t.motor_vehicle_id, t.status, t.late_fee, (.+) FROM transaction t LEFT JOIN motor_pickup mp ON (.+) WHERE t.id = \\$1 FOR UPDATE OF t;"
+	mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectRollback()
 
-	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
-	mock.ExpectExec(query).WithArgs(5000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = repository.Add(expectedCreateMotorReturn)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
 
-	query = "UPDATE users"
-	mock.ExpectExec(query).WillReturnError(errors.New("error sql"))
+// test fail when the employee logged in is not the one in the path, nothing is charged
+func TestAdd_FailEmployeeMismatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
 
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	query := "SELECT id FROM employee WHERE username = \\$1 AND deleted_at IS NULL;"
+	rows := sqlmock.NewRows([]string{"id"}).AddRow("0a2c4e6f-8b1d-4f3a-95c7-e9f1a3b5c7d9")
+	mock.ExpectQuery(query).WithArgs("employee").WillReturnRows(rows)
 	mock.ExpectRollback()
 
 	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	assert.Equal(t, errors.New("6"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-// test fail to update Motor Vehicle
-func TestAdd_FailToUpdateMotorVehicle(t *testing.T) {
+// test fail when the token does not belong to an active employee
+func TestAdd_FailEmployeeNotFound(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	mock.ExpectBegin()
+	query := "SELECT id FROM employee WHERE username = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs("employee").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
-
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
-	mock.ExpectQuery(query).WillReturnRows(rows)
-
-	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
-	mock.ExpectExec(query).WithArgs(5000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = repository.Add(expectedCreateMotorReturn)
+	assert.Equal(t, errors.New("6"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
 
-	query = "UPDATE users"
-	mock.ExpectExec(query).WithArgs("907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+// test fail when the transaction has already been returned, nothing is charged
+func TestAdd_FailAlreadyReturned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
 
-	query = "UPDATE motor_vehicle SET status = 'AVAILABLE';"
-	mock.ExpectExec(query).WillReturnError(errors.New("error sql"))
+	repository := NewMotorRepository(db, "")
 
+	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "RETURNED", 0)
 	mock.ExpectRollback()
 
 	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	assert.Equal(t, errors.New("2"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-func TestAdd_FailToGetTransactionExsist(t *testing.T) {
+// test fail to update Motor Vehicle
+func TestAdd_FailToUpdateMotorVehicle(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "ACTIVE", 0)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
+	mock.ExpectExec(query).WillReturnError(errors.New("error"))
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectRollback()
 
-	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
-	mock.ExpectExec(query).WithArgs(5000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = repository.Add(expectedCreateMotorReturn)
+	assert.Equal(t, errors.New("error"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+// test fail to get amount from balance
+func TestAdd_FailToGetAmountBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "ACTIVE", 0)
+	expectDamageCatalog(mock)
 
-	query = "UPDATE motor_vehicle SET status = 'AVAILABLE';"
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
 	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT COUNT(.+) FROM motor_return WHERE transaction_id = \\$1"
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
 	mock.ExpectQuery(query).WillReturnError(errors.New("error"))
 
 	mock.ExpectRollback()
 
 	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	assert.Equal(t, errors.New("error"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-func TestAdd_FailToGetTransactionExsistGreaterThanZero(t *testing.T) {
+// test fail when balance less than extra charge
+func TestAdd_FailBalanceLessThanExtraCharge(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error creating mock database: ", err)
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "ACTIVE", 0)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"amount"}).AddRow(100)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
-	mock.ExpectExec(query).WithArgs(5000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
 
-	query = "UPDATE motor_vehicle SET status = 'AVAILABLE';"
+	_, err = repository.Add(expectedCreateMotorReturn)
+	assert.Equal(t, errors.New("1"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+// test fail to update balance
+func TestAdd_FailToUpdateBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "ACTIVE", 0)
+	expectDamageCatalog(mock)
+
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
 	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT COUNT(.+) FROM motor_return WHERE transaction_id = \\$1"
-	rows = sqlmock.NewRows([]string{"count"}).AddRow(1)
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"amount"}).AddRow(30000)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
+	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
+	mock.ExpectExec(query).WillReturnError(errors.New("error"))
+
 	mock.ExpectRollback()
 
 	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	assert.Equal(t, errors.New("error"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 // test fail add motor return
@@ -326,36 +571,35 @@ func TestAdd_Fail(t *testing.T) {
 	}
 	defer db.Close()
 
-	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	mock.ExpectBegin()
+	expectEmployee(mock)
+	expectLockTransaction(mock, "ACTIVE", 0)
+	expectDamageCatalog(mock)
 
-	query := "SELECT user_id FROM transaction WHERE id = \\$1;"
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8")
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectStatusEvent(mock, "AVAILABLE")
+	query := "UPDATE motor_vehicle SET status = 'AVAILABLE'"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT amount FROM balance WHERE user_id = \\$1;"
-	rows = sqlmock.NewRows([]string{"amount"}).AddRow(30000)
+	query = "SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{"amount"}).AddRow(30000)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
 	query = "UPDATE balance SET amount = \\$1 WHERE user_id = \\$2;"
-	mock.ExpectExec(query).WithArgs(5000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
-
-	query = "UPDATE motor_vehicle SET status = 'AVAILABLE';"
 	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT COUNT(.+) FROM motor_return WHERE transaction_id = \\$1"
-	rows = sqlmock.NewRows([]string{"count"}).AddRow(0)
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	query = "UPDATE transaction SET status = 'RETURNED'"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	query = "INSERT INTO motor_return(.+) RETURNING id;"
-	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+	mock.ExpectQuery(query).WillReturnError(errors.New("error"))
 
 	mock.ExpectRollback()
 
 	_, err = repository.Add(expectedCreateMotorReturn)
-	assert.Error(t, err)
+	assert.Equal(t, errors.New("error"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 // test get all success
@@ -372,19 +616,28 @@ func TestGetAll_Success(t *testing.T) {
 	defer db.Close()
 
 	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	//mock database
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return;"
-	rows := mock.NewRows([]string{"id", "transaction_id", "return_date", "extra_charge", "condition_motor", "description", "created_at", "updatad_at"}).
-		AddRow(expected[0].ID, expected[0].TrasactionID, expected[0].ReturnDate, expected[0].ExtraCharge, expected[0].ConditionMotor, expected[0].Descrption, expected[0].CreatedAt, expected[0].UpdatedAt)
+	query := "SELECT (.+) FROM motor_return mr JOIN transaction t ON (.+) WHERE (.+);"
+	rows := mock.NewRows([]string{"id", "transaction_id", "return_date", "extra_charge", "condition_motor", "description", "odometer", "fuel_level", "branch_id", "override_reason", "voided_at", "void_reason", "overage_km", "overage_charge", "created_at", "updatad_at"}).
+		AddRow(expected[0].ID, expected[0].TrasactionID, expected[0].ReturnDate, expected[0].ExtraCharge, expected[0].ConditionMotor, expected[0].Descrption, expected[0].Odometer, expected[0].FuelLevel, "", "", "2024-03-08", "charged twice", 0, 0, expected[0].CreatedAt, expected[0].UpdatedAt)
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("").WillReturnRows(rows)
+
+	query = "SELECT damage_catalog_id, part, severity, quantity, repair_cost FROM motor_return_damage WHERE motor_return_id = \\$1"
+	rows = mock.NewRows([]string{"damage_catalog_id", "part", "severity", "quantity", "repair_cost"}).AddRow(damageCatalogId, "Ban depan", "MODERATE", 1, 25000)
+	mock.ExpectQuery(query).WithArgs(expected[0].ID).WillReturnRows(rows)
 
-	result, err := repository.GetAll()
+	// the list carries the same fields as a single return
+	expected[0].VoidedAt, expected[0].VoidReason = "2024-03-08", "charged twice"
+	expected[0].Damages = []motorReturnDto.MotorReturnDamage{{DamageCatalogID: damageCatalogId, Part: "Ban depan", Severity: "MODERATE", Quantity: 1, RepairCost: 25000, Subtotal: 25000}}
+
+	result, err := repository.GetAll("")
 
 	assert.Nil(t, err)
 	assert.Equal(t, expected, result)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 // tes get all (if error when db.Query)
@@ -397,14 +650,14 @@ func TestGetAll_Fail(t *testing.T) {
 	defer db.Close()
 
 	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	//mock database
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return;"
+	query := "SELECT (.+) FROM motor_return mr JOIN transaction t ON (.+) WHERE (.+);"
 
-	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+	mock.ExpectQuery(query).WithArgs("").WillReturnError(errors.New("error sql"))
 
-	result, err := repository.GetAll()
+	result, err := repository.GetAll("")
 
 	assert.Error(t, err)
 	assert.Empty(t, result)
@@ -425,17 +678,17 @@ func TestGetAll_FailRowsScan(t *testing.T) {
 	defer db.Close()
 
 	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
 	//mock database
 	// mengubah input id menjadi nil sehingga nantinya id tidak akan terbaca
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return;"
-	rows := mock.NewRows([]string{"id", "transaction_id", "return_date", "extra_charge", "condition_motor", "description", "created_at", "updatad_at"}).
-		AddRow(nil, expected[0].TrasactionID, expected[0].ReturnDate, expected[0].ExtraCharge, expected[0].ConditionMotor, expected[0].Descrption, expected[0].CreatedAt, expected[0].UpdatedAt).RowError(2, errors.New("scanErr"))
+	query := "SELECT (.+) FROM motor_return mr JOIN transaction t ON (.+) WHERE (.+);"
+	rows := mock.NewRows([]string{"id", "transaction_id", "return_date", "extra_charge", "condition_motor", "description", "odometer", "fuel_level", "branch_id", "override_reason", "voided_at", "void_reason", "overage_km", "overage_charge", "created_at", "updatad_at"}).
+		AddRow(nil, expected[0].TrasactionID, expected[0].ReturnDate, expected[0].ExtraCharge, expected[0].ConditionMotor, expected[0].Descrption, expected[0].Odometer, expected[0].FuelLevel, "", "", "", "", 0, 0, expected[0].CreatedAt, expected[0].UpdatedAt).RowError(2, errors.New("scanErr"))
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("").WillReturnRows(rows)
 
-	_, err = repository.GetAll()
+	_, err = repository.GetAll("")
 
 	assert.Error(t, err)
 }
@@ -450,19 +703,26 @@ func TestGetById_Success(t *testing.T) {
 	defer db.Close()
 
 	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return WHERE id = \\$1;"
+	query := "SELECT mr.id, mr.transaction_id, mr.return_date, mr.extra_charge, mr.condition_motor, mr.description, mr.odometer, mr.fuel_level, COALESCE(.+), mr.created_at, mr.updated_at FROM motor_return mr WHERE mr.id = \\$1;"
 
-	rows := mock.NewRows([]string{"id", "transaction_id", "return_date", "extra_charge", "condition_motor", "description", "created_at", "updatad_at"}).
-		AddRow(expectedMotorReturn.ID, expectedMotorReturn.TrasactionID, expectedMotorReturn.ReturnDate, expectedMotorReturn.ExtraCharge, expectedMotorReturn.ConditionMotor, expectedMotorReturn.Descrption, expectedMotorReturn.CreatedAt, expectedMotorReturn.UpdatedAt)
+	rows := mock.NewRows([]string{"id", "transaction_id", "return_date", "extra_charge", "condition_motor", "description", "odometer", "fuel_level", "branch_id", "override_reason", "voided_at", "void_reason", "overage_km", "overage_charge", "created_at", "updatad_at"}).
+		AddRow(expectedMotorReturn.ID, expectedMotorReturn.TrasactionID, expectedMotorReturn.ReturnDate, expectedMotorReturn.ExtraCharge, expectedMotorReturn.ConditionMotor, expectedMotorReturn.Descrption, expectedMotorReturn.Odometer, expectedMotorReturn.FuelLevel, "", "", "", "", 0, 0, expectedMotorReturn.CreatedAt, expectedMotorReturn.UpdatedAt)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
+	query = "SELECT damage_catalog_id, part, severity, quantity, repair_cost FROM motor_return_damage WHERE motor_return_id = \\$1"
+	rows = mock.NewRows([]string{"damage_catalog_id", "part", "severity", "quantity", "repair_cost"}).AddRow(damageCatalogId, "Ban depan", "MODERATE", 1, 25000)
+	mock.ExpectQuery(query).WithArgs(expectedMotorReturn.ID).WillReturnRows(rows)
+
+	expected := expectedMotorReturn
+	expected.Damages = []motorReturnDto.MotorReturnDamage{{DamageCatalogID: damageCatalogId, Part: "Ban depan", Severity: "MODERATE", Quantity: 1, RepairCost: 25000, Subtotal: 25000}}
+
 	result, err := repository.GetById(expectedMotorReturn.ID)
 
 	assert.Nil(t, err)
-	assert.Equal(t, expectedMotorReturn, result)
+	assert.Equal(t, expected, result)
 
 }
 
@@ -476,9 +736,9 @@ func TestGetById_Fail(t *testing.T) {
 	defer db.Close()
 
 	//initialization repository
-	repository := NewMotorRepository(db)
+	repository := NewMotorRepository(db, "")
 
-	query := "SELECT id, transaction_id, return_date, extra_charge, condition_motor, description, created_at, updated_at FROM motor_return WHERE id = \\$1;"
+	query := "SELECT mr.id, mr.transaction_id, mr.return_date, mr.extra_charge, mr.condition_motor, mr.description, mr.odometer, mr.fuel_level, COALESCE(.+), mr.created_at, mr.updated_at FROM motor_return mr WHERE mr.id = \\$1;"
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
 
@@ -488,3 +748,158 @@ func TestGetById_Fail(t *testing.T) {
 	assert.Empty(t, result)
 
 }
+
+const lockMotorReturnQuery = "SELECT t.user_id, mr.extra_charge, mr.condition_motor, mr.description, mr.voided_at IS NOT NULL FROM motor_return mr JOIN transaction t ON (.+) FOR UPDATE OF mr;"
+
+func expectLockMotorReturn(mock sqlmock.Sqlmock, voided bool) {
+	rows := sqlmock.NewRows([]string{"user_id", "extra_charge", "condition_motor", "description", "voided"}).
+		AddRow("907698c8-ae04-47b2-a7b9-68c46690c3f8", expectedMotorReturn.ExtraCharge, expectedMotorReturn.ConditionMotor, expectedMotorReturn.Descrption, voided)
+	mock.ExpectQuery(lockMotorReturnQuery).WithArgs(expectedMotorReturn.ID).WillReturnRows(rows)
+}
+
+func TestAmend_SuccessLowerCharge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+	extraCharge := 15000
+	request := motorReturnDto.AmendMotorReturnRequest{ExtraCharge: &extraCharge, Reason: "salah ketik biaya", ID: expectedMotorReturn.ID, Actor: "admin"}
+
+	mock.ExpectBegin()
+	expectLockMotorReturn(mock, false)
+	mock.ExpectQuery("SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;").WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(0))
+	mock.ExpectExec("UPDATE balance SET amount = amount \\+ \\$1 WHERE user_id = \\$2;").WithArgs(10000, "907698c8-ae04-47b2-a7b9-68c46690c3f8").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO balance_ledger(.+)'RETURN_ADJUSTMENT'").WithArgs("907698c8-ae04-47b2-a7b9-68c46690c3f8", 10000, expectedMotorReturn.ID, "AMEND: salah ketik biaya").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO motor_return_revision").
+		WithArgs(expectedMotorReturn.ID, "AMEND", expectedMotorReturn.ExtraCharge, expectedMotorReturn.ConditionMotor, expectedMotorReturn.Descrption, 10000, "salah ketik biaya", "admin").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE motor_return SET extra_charge = \\$1").WithArgs(15000, expectedMotorReturn.ConditionMotor, expectedMotorReturn.Descrption, expectedMotorReturn.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repository.Amend(request)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAmend_FailNotEnoughBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+	extraCharge := 40000
+	request := motorReturnDto.AmendMotorReturnRequest{ExtraCharge: &extraCharge, Reason: "kerusakan terlewat", ID: expectedMotorReturn.ID, Actor: "admin"}
+
+	mock.ExpectBegin()
+	expectLockMotorReturn(mock, false)
+	mock.ExpectQuery("SELECT amount FROM balance WHERE user_id = \\$1 FOR UPDATE;").WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(10000))
+	mock.ExpectRollback()
+
+	err = repository.Amend(request)
+	assert.Equal(t, errors.New("1"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAmend_SameChargeDoesNotTouchBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+	extraCharge := expectedMotorReturn.ExtraCharge
+	request := motorReturnDto.AmendMotorReturnRequest{ExtraCharge: &extraCharge, Description: "bocor sebelum pickup", Reason: "keterangan keliru", ID: expectedMotorReturn.ID, Actor: "admin"}
+
+	mock.ExpectBegin()
+	expectLockMotorReturn(mock, false)
+	mock.ExpectExec("INSERT INTO motor_return_revision").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE motor_return SET extra_charge = \\$1").WithArgs(extraCharge, expectedMotorReturn.ConditionMotor, "bocor sebelum pickup", expectedMotorReturn.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repository.Amend(request)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestVoid_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+	request := motorReturnDto.VoidMotorReturnRequest{Reason: "dicatat pada transaksi yang salah", ID: expectedMotorReturn.ID, Actor: "admin"}
+
+	mock.ExpectBegin()
+	expectLockMotorReturn(mock, false)
+	mock.ExpectQuery("SELECT amount FROM balance").WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(0))
+	mock.ExpectExec("UPDATE balance SET amount = amount \\+ \\$1").WithArgs(expectedMotorReturn.ExtraCharge, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO balance_ledger").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO motor_return_revision").WithArgs(expectedMotorReturn.ID, "VOID", expectedMotorReturn.ExtraCharge, sqlmock.AnyArg(), sqlmock.AnyArg(), expectedMotorReturn.ExtraCharge, request.Reason, "admin").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE motor_return SET extra_charge = 0, voided_at = CURRENT_TIMESTAMP").WithArgs(request.Reason, expectedMotorReturn.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repository.Void(request)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestVoid_FailAlreadyVoided(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	expectLockMotorReturn(mock, true)
+	mock.ExpectRollback()
+
+	err = repository.Void(motorReturnDto.VoidMotorReturnRequest{Reason: "dobel", ID: expectedMotorReturn.ID})
+	assert.Equal(t, errors.New("2"), err)
+}
+
+func TestVoid_FailNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockMotorReturnQuery).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err = repository.Void(motorReturnDto.VoidMotorReturnRequest{Reason: "dobel", ID: expectedMotorReturn.ID})
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestGetRevisions_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorRepository(db, "")
+
+	rows := sqlmock.NewRows([]string{"id", "action", "extra_charge", "condition_motor", "description", "adjustment", "reason", "actor", "created_at"}).
+		AddRow("1", "AMEND", 25000, expectedMotorReturn.ConditionMotor, expectedMotorReturn.Descrption, 10000, "salah ketik biaya", "admin", "0000")
+	mock.ExpectQuery("SELECT (.+) FROM motor_return_revision WHERE motor_return_id = \\$1 ORDER BY created_at;").WithArgs(expectedMotorReturn.ID).WillReturnRows(rows)
+
+	revisions, err := repository.GetRevisions(expectedMotorReturn.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []motorReturnDto.MotorReturnRevision{{ID: "1", Action: "AMEND", ExtraCharge: 25000, ConditionMotor: expectedMotorReturn.ConditionMotor, Description: expectedMotorReturn.Descrption, Adjustment: 10000, Reason: "salah ketik biaya", Actor: "admin", CreatedAt: "0000"}}, revisions)
+}
@@ -3,6 +3,7 @@ package motorReturnUsecase
 import (
 	"bike-rent-express/model/dto/motorReturnDto"
 	"bike-rent-express/src/Users"
+	"bike-rent-express/src/motorPickup"
 	"bike-rent-express/src/motorReturn"
 	"bike-rent-express/src/transaction"
 	"database/sql"
@@ -14,13 +15,26 @@ type motorReturnUsecase struct {
 	motorReturnRepo motorReturn.MotorReturnRepository
 	transactionRepo transaction.TransactionRepository
 	userRepo        Users.UsersRepository
+	motorPickupRepo motorPickup.MotorPickupRepository
 }
 
-func NewMotorReturnUseCase(motorReturnRepo motorReturn.MotorReturnRepository, transactionRepo transaction.TransactionRepository, userRepo Users.UsersRepository) motorReturn.MotorReturnUsecase {
-	return &motorReturnUsecase{motorReturnRepo, transactionRepo, userRepo}
+func NewMotorReturnUseCase(motorReturnRepo motorReturn.MotorReturnRepository, transactionRepo transaction.TransactionRepository, userRepo Users.UsersRepository, motorPickupRepo motorPickup.MotorPickupRepository) motorReturn.MotorReturnUsecase {
+	return &motorReturnUsecase{motorReturnRepo, transactionRepo, userRepo, motorPickupRepo}
 }
 
 func (m *motorReturnUsecase) AddMotorReturn(createMotorReturnRequest motorReturnDto.CreateMotorReturnRequest) (motorReturnDto.CreateMotorReturnRequest, error) {
+	// a vehicle cannot come back with fewer kilometres than it was handed over with
+	pickup, err := m.motorPickupRepo.GetByTransactionId(createMotorReturnRequest.TransactionID)
+	if err == nil && createMotorReturnRequest.Odometer < pickup.Odometer {
+		return createMotorReturnRequest, errors.New("4")
+	}
+	if err != nil && err != sql.ErrNoRows {
+		if strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return createMotorReturnRequest, errors.New("3")
+		}
+		return createMotorReturnRequest, err
+	}
+
 	motorReturnCreated, err := m.motorReturnRepo.Add(createMotorReturnRequest)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid input syntax for type uuid") || err == sql.ErrNoRows {
@@ -31,12 +45,12 @@ func (m *motorReturnUsecase) AddMotorReturn(createMotorReturnRequest motorReturn
 
 	return motorReturnCreated, nil
 }
-func (m *motorReturnUsecase) GetMotorReturnById(id string) (motorReturnDto.MotorReturnResponse, error) {
+func (m *motorReturnUsecase) GetMotorReturnById(id string, branchID string) (motorReturnDto.MotorReturnResponse, error) {
 	var motorReturnDetail motorReturnDto.MotorReturnResponse
 
 	motorReturn, err := m.motorReturnRepo.GetById(id)
 	if err != nil {
-		return motorReturnDetail, err
+		return motorReturnDetail, notFound(err)
 	}
 
 	transaction, err := m.transactionRepo.GetById(motorReturn.TrasactionID)
@@ -44,7 +58,11 @@ func (m *motorReturnUsecase) GetMotorReturnById(id string) (motorReturnDto.Motor
 		return motorReturnDetail, err
 	}
 
-	user, err := m.userRepo.GetByID(transaction.UserID)
+	if branchID != "" && motorReturn.BranchID != branchID && transaction.PickupBranchID != branchID {
+		return motorReturnDetail, errors.New("3")
+	}
+
+	user, err := m.userRepo.GetByIDIncludingDeleted(transaction.UserID)
 	if err != nil {
 		return motorReturnDetail, err
 	}
@@ -54,16 +72,31 @@ func (m *motorReturnUsecase) GetMotorReturnById(id string) (motorReturnDto.Motor
 	motorReturnDetail.ExtraCharge = motorReturn.ExtraCharge
 	motorReturnDetail.ConditionMotor = motorReturn.ConditionMotor
 	motorReturnDetail.Descrption = motorReturn.Descrption
+	motorReturnDetail.Odometer = motorReturn.Odometer
+	motorReturnDetail.FuelLevel = motorReturn.FuelLevel
+	motorReturnDetail.BranchID = motorReturn.BranchID
+	motorReturnDetail.Damages = motorReturn.Damages
+	motorReturnDetail.DamageCharge = damageCharge(motorReturn.Damages)
+	motorReturnDetail.OverageKm = motorReturn.OverageKm
+	motorReturnDetail.OverageCharge = motorReturn.OverageCharge
+	motorReturnDetail.OverrideReason = motorReturn.OverrideReason
+	motorReturnDetail.VoidedAt = motorReturn.VoidedAt
+	motorReturnDetail.VoidReason = motorReturn.VoidReason
 	motorReturnDetail.CreatedAt = motorReturn.CreatedAt
 	motorReturnDetail.UpdatedAt = motorReturn.UpdatedAt
 	motorReturnDetail.Customer = user
 
+	motorReturnDetail.Pickup, err = m.comparePickup(motorReturn)
+	if err != nil {
+		return motorReturnDetail, err
+	}
+
 	return motorReturnDetail, nil
 }
-func (m *motorReturnUsecase) GetMotorReturnAll() ([]motorReturnDto.MotorReturnResponse, error) {
+func (m *motorReturnUsecase) GetMotorReturnAll(branchID string) ([]motorReturnDto.MotorReturnResponse, error) {
 	var motorsReturnDetail []motorReturnDto.MotorReturnResponse
 
-	motorsReturn, err := m.motorReturnRepo.GetAll()
+	motorsReturn, err := m.motorReturnRepo.GetAll(branchID)
 	if err != nil {
 		return motorsReturnDetail, err
 	}
@@ -81,7 +114,7 @@ func (m *motorReturnUsecase) GetMotorReturnAll() ([]motorReturnDto.MotorReturnRe
 			return motorsReturnDetail, err
 		}
 
-		user, err := m.userRepo.GetByID(transaction.UserID)
+		user, err := m.userRepo.GetByIDIncludingDeleted(transaction.UserID)
 		if err != nil {
 			return motorsReturnDetail, err
 		}
@@ -90,12 +123,89 @@ func (m *motorReturnUsecase) GetMotorReturnAll() ([]motorReturnDto.MotorReturnRe
 		motorReturnDetail.ExtraCharge = motorReturn.ExtraCharge
 		motorReturnDetail.ConditionMotor = motorReturn.ConditionMotor
 		motorReturnDetail.Descrption = motorReturn.Descrption
+		motorReturnDetail.Odometer = motorReturn.Odometer
+		motorReturnDetail.FuelLevel = motorReturn.FuelLevel
+		motorReturnDetail.BranchID = motorReturn.BranchID
+		motorReturnDetail.Damages = motorReturn.Damages
+		motorReturnDetail.DamageCharge = damageCharge(motorReturn.Damages)
+		motorReturnDetail.OverageKm = motorReturn.OverageKm
+		motorReturnDetail.OverageCharge = motorReturn.OverageCharge
+		motorReturnDetail.OverrideReason = motorReturn.OverrideReason
+		motorReturnDetail.VoidedAt = motorReturn.VoidedAt
+		motorReturnDetail.VoidReason = motorReturn.VoidReason
 		motorReturnDetail.CreatedAt = motorReturn.CreatedAt
 		motorReturnDetail.UpdatedAt = motorReturn.UpdatedAt
 		motorReturnDetail.Customer = user
 
+		motorReturnDetail.Pickup, err = m.comparePickup(motorReturn)
+		if err != nil {
+			return motorsReturnDetail, err
+		}
+
 		motorsReturnDetail = append(motorsReturnDetail, motorReturnDetail)
 	}
 
 	return motorsReturnDetail, nil
 }
+
+func (m *motorReturnUsecase) AmendMotorReturn(amendMotorReturnRequest motorReturnDto.AmendMotorReturnRequest) (motorReturnDto.MotorReturnResponse, error) {
+	if err := m.motorReturnRepo.Amend(amendMotorReturnRequest); err != nil {
+		return motorReturnDto.MotorReturnResponse{}, notFound(err)
+	}
+
+	return m.GetMotorReturnById(amendMotorReturnRequest.ID, "")
+}
+
+func (m *motorReturnUsecase) VoidMotorReturn(voidMotorReturnRequest motorReturnDto.VoidMotorReturnRequest) (motorReturnDto.MotorReturnResponse, error) {
+	if err := m.motorReturnRepo.Void(voidMotorReturnRequest); err != nil {
+		return motorReturnDto.MotorReturnResponse{}, notFound(err)
+	}
+
+	return m.GetMotorReturnById(voidMotorReturnRequest.ID, "")
+}
+
+func (m *motorReturnUsecase) GetMotorReturnRevisions(id string, branchID string) ([]motorReturnDto.MotorReturnRevision, error) {
+	if _, err := m.GetMotorReturnById(id, branchID); err != nil {
+		return nil, err
+	}
+
+	return m.motorReturnRepo.GetRevisions(id)
+}
+
+// notFound maps a missing or malformed motor return id to "3".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+		return errors.New("3")
+	}
+	return err
+}
+
+// comparePickup returns nil for rentals that were handed over before pickups were recorded.
+func (m *motorReturnUsecase) comparePickup(motorReturn motorReturnDto.MotorReturn) (*motorReturnDto.PickupComparison, error) {
+	pickup, err := m.motorPickupRepo.GetByTransactionId(motorReturn.TrasactionID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &motorReturnDto.PickupComparison{
+		MotorPickupID:     pickup.ID,
+		PickupOdometer:    pickup.Odometer,
+		PickupFuelLevel:   pickup.FuelLevel,
+		PickupDamageNotes: pickup.DamageNotes,
+		Distance:          motorReturn.Odometer - pickup.Odometer,
+		FuelUsed:          pickup.FuelLevel - motorReturn.FuelLevel,
+	}, nil
+}
+
+// damageCharge is the extra charge the itemized damages add up to, it differs from the
+// charged amount when the employee overrode it.
+func damageCharge(damages []motorReturnDto.MotorReturnDamage) int {
+	var total int
+	for _, damage := range damages {
+		total += damage.Subtotal
+	}
+	return total
+}
@@ -2,9 +2,11 @@ package motorReturnUsecase
 
 import (
 	"bike-rent-express/model/dto"
+	"bike-rent-express/model/dto/motorPickupDto"
 	"bike-rent-express/model/dto/motorReturnDto"
 	"bike-rent-express/model/dto/transactionDto"
 	"bike-rent-express/src/motorReturn"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -81,11 +83,26 @@ func (m *mockMotorReturnRepository) GetById(id string) (motorReturnDto.MotorRetu
 	return arg.Get(0).(motorReturnDto.MotorReturn), arg.Error(1)
 }
 
-func (m *mockMotorReturnRepository) GetAll() ([]motorReturnDto.MotorReturn, error) {
-	arg := m.Called()
+func (m *mockMotorReturnRepository) GetAll(branchID string) ([]motorReturnDto.MotorReturn, error) {
+	arg := m.Called(branchID)
 	return arg.Get(0).([]motorReturnDto.MotorReturn), arg.Error(1)
 }
 
+func (m *mockMotorReturnRepository) Amend(amendMotorReturnRequest motorReturnDto.AmendMotorReturnRequest) error {
+	arg := m.Called(amendMotorReturnRequest)
+	return arg.Error(0)
+}
+
+func (m *mockMotorReturnRepository) Void(voidMotorReturnRequest motorReturnDto.VoidMotorReturnRequest) error {
+	arg := m.Called(voidMotorReturnRequest)
+	return arg.Error(0)
+}
+
+func (m *mockMotorReturnRepository) GetRevisions(id string) ([]motorReturnDto.MotorReturnRevision, error) {
+	arg := m.Called(id)
+	return arg.Get(0).([]motorReturnDto.MotorReturnRevision), arg.Error(1)
+}
+
 type mockTransactionRepository struct {
 	mock.Mock
 }
@@ -100,8 +117,8 @@ func (m *mockTransactionRepository) GetById(id string) (transactionDto.Transacti
 	return args.Get(0).(transactionDto.Transaction), args.Error(1)
 }
 
-func (m *mockTransactionRepository) GetAll() ([]transactionDto.Transaction, error) {
-	args := m.Called()
+func (m *mockTransactionRepository) GetAll(branchID string) ([]transactionDto.Transaction, error) {
+	args := m.Called(branchID)
 	return args.Get(0).([]transactionDto.Transaction), args.Error(1)
 }
 
@@ -118,6 +135,10 @@ func (m *mockUserRepository) GetByID(id string) (dto.GetUsers, error) {
 	args := m.Called(id)
 	return args.Get(0).(dto.GetUsers), args.Error(1)
 }
+func (m *mockUserRepository) GetByIDIncludingDeleted(id string) (dto.GetUsers, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.GetUsers), args.Error(1)
+}
 func (m *mockUserRepository) GetAll() ([]dto.GetUsers, error) {
 	args := m.Called()
 	return args.Get(0).([]dto.GetUsers), args.Error(1)
@@ -126,6 +147,18 @@ func (m *mockUserRepository) UpdateUsers(usersItem dto.Users) error {
 	args := m.Called(usersItem)
 	return args.Error(0)
 }
+func (m *mockUserRepository) GetRentalEligibility(id string) (dto.RentalEligibility, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.RentalEligibility), args.Error(1)
+}
+func (m *mockUserRepository) DeactivateUsers(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *mockUserRepository) CloseAccount(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
 func (m *mockUserRepository) GetByUsername(username string) (dto.Users, error) {
 	args := m.Called(username)
 	return args.Get(0).(dto.Users), args.Error(1)
@@ -148,11 +181,31 @@ func (m *mockUserRepository) GetBalance(id string) (dto.Balance, error) {
 	return args.Get(0).(dto.Balance), args.Error(1)
 }
 
+type mockMotorPickupRepository struct {
+	mock.Mock
+}
+
+func (m *mockMotorPickupRepository) Add(createMotorPickupRequest motorPickupDto.CreateMotorPickupRequest) (motorPickupDto.CreateMotorPickupRequest, error) {
+	args := m.Called(createMotorPickupRequest)
+	return args.Get(0).(motorPickupDto.CreateMotorPickupRequest), args.Error(1)
+}
+
+func (m *mockMotorPickupRepository) GetById(id string, branchID string) (motorPickupDto.MotorPickup, error) {
+	args := m.Called(id, branchID)
+	return args.Get(0).(motorPickupDto.MotorPickup), args.Error(1)
+}
+
+func (m *mockMotorPickupRepository) GetByTransactionId(transactionId string) (motorPickupDto.MotorPickup, error) {
+	args := m.Called(transactionId)
+	return args.Get(0).(motorPickupDto.MotorPickup), args.Error(1)
+}
+
 type MotorReturnUsecaseTestSuite struct {
 	suite.Suite
 	mockMotorReturnRepository *mockMotorReturnRepository
 	mockTransactionRepository *mockTransactionRepository
 	mockUserRepository        *mockUserRepository
+	mockMotorPickupRepository *mockMotorPickupRepository
 	motorReturnUsecase        motorReturn.MotorReturnUsecase
 }
 
@@ -160,7 +213,8 @@ func (suite *MotorReturnUsecaseTestSuite) SetupTest() {
 	suite.mockMotorReturnRepository = new(mockMotorReturnRepository)
 	suite.mockTransactionRepository = new(mockTransactionRepository)
 	suite.mockUserRepository = new(mockUserRepository)
-	suite.motorReturnUsecase = NewMotorReturnUseCase(suite.mockMotorReturnRepository, suite.mockTransactionRepository, suite.mockUserRepository)
+	suite.mockMotorPickupRepository = new(mockMotorPickupRepository)
+	suite.motorReturnUsecase = NewMotorReturnUseCase(suite.mockMotorReturnRepository, suite.mockTransactionRepository, suite.mockUserRepository, suite.mockMotorPickupRepository)
 }
 
 // test add success
@@ -173,6 +227,7 @@ func (suite *MotorReturnUsecaseTestSuite) TestAddMotorReturn_Success() {
 		Description:    expectedMotorReturn.Descrption,
 	}
 
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
 	suite.mockMotorReturnRepository.On("Add", expectedCreateMotorReturn).Return(expectedCreateMotorReturn, nil)
 
 	actual, err := suite.motorReturnUsecase.AddMotorReturn(expectedCreateMotorReturn)
@@ -192,6 +247,7 @@ func (suite *MotorReturnUsecaseTestSuite) TestAddMotorReturn_Fail() {
 	}
 	expectedError := errors.New("mock error")
 
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
 	suite.mockMotorReturnRepository.On("Add", expectedCreateMotorReturn).Return(expectedCreateMotorReturn, expectedError)
 
 	_, err := suite.motorReturnUsecase.AddMotorReturn(expectedCreateMotorReturn)
@@ -199,19 +255,106 @@ func (suite *MotorReturnUsecaseTestSuite) TestAddMotorReturn_Fail() {
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
 
+// test add fail odometer lower than at pickup
+func (suite *MotorReturnUsecaseTestSuite) TestAddMotorReturn_FailOdometerBelowPickup() {
+	expectedCreateMotorReturn := motorReturnDto.CreateMotorReturnRequest{
+		TransactionID:  expectedMotorReturn.TrasactionID,
+		ExtraCharge:    expectedMotorReturn.ExtraCharge,
+		ConditionMotor: expectedMotorReturn.ConditionMotor,
+		Description:    expectedMotorReturn.Descrption,
+		Odometer:       1000,
+	}
+
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{Odometer: 1200}, nil)
+
+	_, err := suite.motorReturnUsecase.AddMotorReturn(expectedCreateMotorReturn)
+
+	assert.EqualError(suite.T(), err, "4")
+	suite.mockMotorReturnRepository.AssertNotCalled(suite.T(), "Add", expectedCreateMotorReturn)
+}
+
 // test get by id success
 func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_Success() {
 
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
-	suite.mockUserRepository.On("GetByID", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
 
-	actual, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID)
+	actual, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedMotorReturnResponse, actual)
 }
 
+// test get by id from a branch the return does not belong to
+func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_FailOtherBranch() {
+
+	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
+	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
+
+	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "other-branch")
+
+	assert.EqualError(suite.T(), err, "3")
+	suite.mockUserRepository.AssertNotCalled(suite.T(), "GetByIDIncludingDeleted", expectedCustomer.Uuid)
+}
+
+// test get by id success compares the return readings with the pickup
+func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_SuccessWithPickup() {
+	motorReturnWithReadings := expectedMotorReturn
+	motorReturnWithReadings.Odometer = 1250
+	motorReturnWithReadings.FuelLevel = 40
+	pickup := motorPickupDto.MotorPickup{ID: "1", Odometer: 1200, FuelLevel: 100, DamageNotes: "baret di spakbor"}
+
+	expectedResponse := expectedMotorReturnResponse
+	expectedResponse.Odometer = 1250
+	expectedResponse.FuelLevel = 40
+	expectedResponse.Pickup = &motorReturnDto.PickupComparison{
+		MotorPickupID:     "1",
+		PickupOdometer:    1200,
+		PickupFuelLevel:   100,
+		PickupDamageNotes: "baret di spakbor",
+		Distance:          50,
+		FuelUsed:          60,
+	}
+
+	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(motorReturnWithReadings, nil)
+	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(pickup, nil)
+
+	actual, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedResponse, actual)
+}
+
+// test get by id success returns the itemized damages and what they add up to
+func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_SuccessWithDamages() {
+	damages := []motorReturnDto.MotorReturnDamage{
+		{DamageCatalogID: "1", Part: "Ban depan", Severity: "MODERATE", Quantity: 1, RepairCost: 25000, Subtotal: 25000},
+		{DamageCatalogID: "2", Part: "Spion", Severity: "MINOR", Quantity: 2, RepairCost: 5000, Subtotal: 10000},
+	}
+	motorReturnWithDamages := expectedMotorReturn
+	motorReturnWithDamages.Damages = damages
+	motorReturnWithDamages.OverrideReason = "pelanggan tetap"
+
+	expectedResponse := expectedMotorReturnResponse
+	expectedResponse.Damages = damages
+	expectedResponse.DamageCharge = 35000
+	expectedResponse.OverrideReason = "pelanggan tetap"
+
+	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(motorReturnWithDamages, nil)
+	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
+
+	actual, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedResponse, actual)
+}
+
 // test get by id fail mock motor return
 func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_FailMotorReturn() {
 
@@ -219,7 +362,7 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_FailMotorReturn
 
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID)
+	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
@@ -232,7 +375,7 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_FailTransaction
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID)
+	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
@@ -244,9 +387,9 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_FailUser() {
 
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
-	suite.mockUserRepository.On("GetByID", expectedCustomer.Uuid).Return(expectedCustomer, expectedError)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID)
+	_, err := suite.motorReturnUsecase.GetMotorReturnById(expectedMotorReturnResponse.ID, "")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
@@ -254,12 +397,13 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnById_FailUser() {
 // get all success
 func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnAll_Success() {
 
-	suite.mockMotorReturnRepository.On("GetAll").Return(expectedAllMotorReturn, nil)
+	suite.mockMotorReturnRepository.On("GetAll", "").Return(expectedAllMotorReturn, nil)
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
-	suite.mockUserRepository.On("GetByID", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
 
-	actual, err := suite.motorReturnUsecase.GetMotorReturnAll()
+	actual, err := suite.motorReturnUsecase.GetMotorReturnAll("")
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedAllMotorReturnResponse, actual)
@@ -270,9 +414,9 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnAll_fail() {
 
 	expectedError := errors.New("mock error")
 
-	suite.mockMotorReturnRepository.On("GetAll").Return(expectedAllMotorReturn, expectedError)
+	suite.mockMotorReturnRepository.On("GetAll", "").Return(expectedAllMotorReturn, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnAll()
+	_, err := suite.motorReturnUsecase.GetMotorReturnAll("")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
@@ -282,10 +426,10 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnAll_failGetMotorRetu
 
 	expectedError := errors.New("mock error")
 
-	suite.mockMotorReturnRepository.On("GetAll").Return(expectedAllMotorReturn, nil)
+	suite.mockMotorReturnRepository.On("GetAll", "").Return(expectedAllMotorReturn, nil)
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnAll()
+	_, err := suite.motorReturnUsecase.GetMotorReturnAll("")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
@@ -295,11 +439,11 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnAll_failGetTransacti
 
 	expectedError := errors.New("mock error")
 
-	suite.mockMotorReturnRepository.On("GetAll").Return(expectedAllMotorReturn, nil)
+	suite.mockMotorReturnRepository.On("GetAll", "").Return(expectedAllMotorReturn, nil)
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnAll()
+	_, err := suite.motorReturnUsecase.GetMotorReturnAll("")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
@@ -309,16 +453,63 @@ func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnAll_failGetUser() {
 
 	expectedError := errors.New("mock error")
 
-	suite.mockMotorReturnRepository.On("GetAll").Return(expectedAllMotorReturn, nil)
+	suite.mockMotorReturnRepository.On("GetAll", "").Return(expectedAllMotorReturn, nil)
 	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
-	suite.mockUserRepository.On("GetByID", expectedCustomer.Uuid).Return(expectedCustomer, expectedError)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, expectedError)
 
-	_, err := suite.motorReturnUsecase.GetMotorReturnAll()
+	_, err := suite.motorReturnUsecase.GetMotorReturnAll("")
 
 	assert.EqualError(suite.T(), err, expectedError.Error())
 }
 
+// test amend success returns the corrected detail
+func (suite *MotorReturnUsecaseTestSuite) TestAmendMotorReturn_Success() {
+	extraCharge := 15000
+	request := motorReturnDto.AmendMotorReturnRequest{ExtraCharge: &extraCharge, Reason: "salah ketik biaya", ID: expectedMotorReturn.ID, Actor: "admin"}
+
+	suite.mockMotorReturnRepository.On("Amend", request).Return(nil)
+	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(expectedMotorReturn, nil)
+	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectedCustomer.Uuid).Return(expectedCustomer, nil)
+	suite.mockMotorPickupRepository.On("GetByTransactionId", expectedMotorReturn.TrasactionID).Return(motorPickupDto.MotorPickup{}, sql.ErrNoRows)
+
+	actual, err := suite.motorReturnUsecase.AmendMotorReturn(request)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedMotorReturnResponse, actual)
+}
+
+// test void of an unknown motor return
+func (suite *MotorReturnUsecaseTestSuite) TestVoidMotorReturn_FailNotFound() {
+	request := motorReturnDto.VoidMotorReturnRequest{Reason: "dobel", ID: expectedMotorReturn.ID, Actor: "admin"}
+	suite.mockMotorReturnRepository.On("Void", request).Return(sql.ErrNoRows)
+
+	_, err := suite.motorReturnUsecase.VoidMotorReturn(request)
+
+	assert.EqualError(suite.T(), err, "3")
+}
+
+// test void of a voided motor return keeps the repository error
+func (suite *MotorReturnUsecaseTestSuite) TestVoidMotorReturn_FailAlreadyVoided() {
+	request := motorReturnDto.VoidMotorReturnRequest{Reason: "dobel", ID: expectedMotorReturn.ID, Actor: "admin"}
+	suite.mockMotorReturnRepository.On("Void", request).Return(errors.New("2"))
+
+	_, err := suite.motorReturnUsecase.VoidMotorReturn(request)
+
+	assert.EqualError(suite.T(), err, "2")
+	suite.mockMotorReturnRepository.AssertNotCalled(suite.T(), "GetById", expectedMotorReturn.ID)
+}
+
+// test revisions of an unknown motor return
+func (suite *MotorReturnUsecaseTestSuite) TestGetMotorReturnRevisions_FailNotFound() {
+	suite.mockMotorReturnRepository.On("GetById", expectedMotorReturn.ID).Return(motorReturnDto.MotorReturn{}, sql.ErrNoRows)
+
+	_, err := suite.motorReturnUsecase.GetMotorReturnRevisions(expectedMotorReturn.ID, "")
+
+	assert.EqualError(suite.T(), err, "3")
+}
+
 func TestMotorReturnUsecase(t *testing.T) {
 	suite.Run(t, new(MotorReturnUsecaseTestSuite))
 }
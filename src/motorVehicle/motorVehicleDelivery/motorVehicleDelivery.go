@@ -4,10 +4,14 @@ import (
 	"bike-rent-express/model/dto/json"
 	"bike-rent-express/model/dto/motorVehicleDto"
 	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/sheet"
 	"bike-rent-express/pkg/utils"
 	"bike-rent-express/src/motorVehicle"
 	"database/sql"
 	"errors"
+	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,9 +25,13 @@ func NewMotorVehicleDelivery(v1Group *gin.RouterGroup, motorVehicleUC motorVehic
 		motorVehicleUC}
 	motorVehicleGroup := v1Group.Group("/motor-vehicles")
 	{
-		motorVehicleGroup.GET("/", middleware.JWTAuth("ADMIN", "USER"), handler.getAllMotorVehicle)
-		motorVehicleGroup.GET("/:id", middleware.JWTAuth("ADMIN", "USER"), handler.getMotorVehicleById)
+		motorVehicleGroup.GET("/", middleware.JWTAuth("ADMIN", "USER", "BRANCH_MANAGER"), handler.getAllMotorVehicle)
+		motorVehicleGroup.GET("/:id", middleware.JWTAuth("ADMIN", "USER", "BRANCH_MANAGER"), handler.getMotorVehicleById)
+		motorVehicleGroup.GET("/:id/usage", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.getMotorVehicleUsage)
+		motorVehicleGroup.GET("/:id/history", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.getMotorVehicleHistory)
+		motorVehicleGroup.GET("/export", middleware.JWTAuth("ADMIN"), handler.exportMotorVehicle)
 		motorVehicleGroup.POST("/", middleware.JWTAuth("ADMIN"), handler.createMotorVehicle)
+		motorVehicleGroup.POST("/import", middleware.JWTAuth("ADMIN"), handler.importMotorVehicle)
 		motorVehicleGroup.PUT("/:id", middleware.JWTAuth("ADMIN"), handler.updateMotorVehicle)
 		motorVehicleGroup.DELETE("/:id", middleware.JWTAuth("ADMIN"), handler.deleteMotorVehicle)
 	}
@@ -31,24 +39,36 @@ func NewMotorVehicleDelivery(v1Group *gin.RouterGroup, motorVehicleUC motorVehic
 
 func (md motorVehicleDelivery) getAllMotorVehicle(ctx *gin.Context) {
 
-	motor, err := md.motorVehicleUC.GetAllMotorVehicle()
+	// branch scoped callers are held to their own branch
+	branchID := ctx.GetString("branch_id")
+	if branchID == "" {
+		branchID = ctx.Query("branch_id")
+	}
+
+	spec, errs := queryspec.Parse(ctx.Request.URL.Query(), motorVehicle.Query)
+	if errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "01", "01")
+		return
+	}
+
+	motor, page, err := md.motorVehicleUC.GetAllMotorVehicle(branchID, spec)
 	if err != nil {
 		json.NewResponseError(ctx, err.Error(), "01", "01")
 		return
 	}
 
 	if len(motor) == 0 {
-		json.NewResponseSuccess(ctx, nil, "Empty data", "01", "01")
+		json.NewResponseSuccessPaged(ctx, []motorVehicleDto.MotorVehicle{}, page, "Empty data", "01", "01")
 		return
 	}
 
-	json.NewResponseSuccess(ctx, motor, "success", "01", "02")
+	json.NewResponseSuccessPaged(ctx, motor, page, "success", "01", "02")
 }
 
 func (md motorVehicleDelivery) getMotorVehicleById(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	data, err := md.motorVehicleUC.GetMotorVehicleById(id)
+	data, err := md.motorVehicleUC.GetMotorVehicleById(id, ctx.GetString("branch_id"))
 	if err != nil {
 		if err.Error() == "1" || errors.Is(sql.ErrNoRows, err) {
 			json.NewResponseSuccess(ctx, nil, "Data not found", "02", "01")
@@ -76,6 +96,14 @@ func (md motorVehicleDelivery) createMotorVehicle(ctx *gin.Context) {
 			json.NewResponseBadRequest(ctx, nil, "the license plate is already registered to another vehicle", "03", "01")
 			return
 		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "Branch not found", "03", "02")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "Vehicle model not found", "03", "03")
+			return
+		}
 		json.NewResponseError(ctx, err.Error(), "03", "01")
 		return
 	}
@@ -94,6 +122,7 @@ func (md motorVehicleDelivery) updateMotorVehicle(ctx *gin.Context) {
 		json.NewResponseBadRequest(ctx, err, "Bad Request", "04", "01")
 		return
 	}
+	input.Actor = ctx.GetString("username")
 
 	motor, err := md.motorVehicleUC.UpdateMotorVehicle(id, input)
 	if err != nil {
@@ -101,6 +130,14 @@ func (md motorVehicleDelivery) updateMotorVehicle(ctx *gin.Context) {
 			json.NewResponseBadRequest(ctx, nil, "the license plate is already registered to another vehicle", "03", "01")
 			return
 		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "Branch not found", "04", "02")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "Vehicle model not found", "04", "03")
+			return
+		}
 		json.NewResponseError(ctx, err.Error(), "04", "01")
 		return
 	}
@@ -127,3 +164,116 @@ func (md motorVehicleDelivery) deleteMotorVehicle(ctx *gin.Context) {
 
 	json.NewResponseSuccess(ctx, nil, "Sucessfully deleted motor vehicle", "05", "01")
 }
+
+func (md motorVehicleDelivery) getMotorVehicleUsage(ctx *gin.Context) {
+	usage, err := md.motorVehicleUC.GetMotorVehicleUsage(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "06", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "06", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, usage, "success get motor vehicle usage", "06", "02")
+}
+
+func (md motorVehicleDelivery) getMotorVehicleHistory(ctx *gin.Context) {
+	history, err := md.motorVehicleUC.GetMotorVehicleHistory(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "09", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "09", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, history, "success get motor vehicle history", "09", "02")
+}
+
+// importMotorVehicle takes a fleet file as the multipart field file, with dry_run=true the rows
+// are only checked
+func (md motorVehicleDelivery) importMotorVehicle(ctx *gin.Context) {
+	if errs := utils.ValidatedVar("dry_run", ctx.Query("dry_run"), "omitempty,boolean"); errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "07", "01")
+		return
+	}
+	dryRun := ctx.Query("dry_run") == "true"
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		json.NewResponseBadRequest(ctx, []json.ValidationField{{FieldName: "file", Message: "field is required"}}, "Bad Request", "07", "01")
+		return
+	}
+
+	format, err := sheet.FormatOf(fileHeader.Filename)
+	if err != nil {
+		json.NewResponseBadRequest(ctx, nil, "the file must be a .csv or .xlsx file", "07", "02")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "07", "01")
+		return
+	}
+	defer file.Close()
+
+	records, err := sheet.Read(format, file)
+	if err != nil {
+		json.NewResponseBadRequest(ctx, nil, "the file can not be read", "07", "02")
+		return
+	}
+
+	result, err := md.motorVehicleUC.ImportMotorVehicle(records, dryRun)
+	if err != nil {
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(ctx, nil, "the header names a column that is not a motor vehicle field", "07", "03")
+			return
+		}
+		if err.Error() == "5" {
+			json.NewResponseBadRequest(ctx, nil, "the file has no rows", "07", "04")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "07", "01")
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		var errs []json.ValidationField
+		for _, rowError := range result.Errors {
+			errs = append(errs, json.ValidationField{FieldName: "row " + strconv.Itoa(rowError.Row) + " " + rowError.Column, Message: rowError.Message})
+		}
+		json.NewResponseBadRequest(ctx, errs, "nothing was imported, fix the rows and try again", "07", "05")
+		return
+	}
+
+	if dryRun {
+		json.NewResponseSuccess(ctx, result, "the rows are valid, nothing was imported", "07", "02")
+		return
+	}
+
+	json.NewResponseCreated(ctx, result, "motor vehicles imported", "07", "01")
+}
+
+// exportMotorVehicle downloads the fleet as a csv file, or xlsx with format=xlsx
+func (md motorVehicleDelivery) exportMotorVehicle(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", sheet.FormatCSV)
+	if errs := utils.ValidatedVar("format", format, "oneof=csv xlsx"); errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "08", "01")
+		return
+	}
+
+	records, err := md.motorVehicleUC.ExportMotorVehicle()
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "08", "01")
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="motor-vehicles.`+format+`"`)
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Header().Set("Content-Type", sheet.ContentType(format))
+	sheet.Write(format, ctx.Writer, records)
+}
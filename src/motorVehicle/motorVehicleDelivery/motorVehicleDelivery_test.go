@@ -2,11 +2,16 @@ package motorVehicleDelivery
 
 import (
 	"bike-rent-express/model/dto/motorVehicleDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/motorVehicle"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +39,7 @@ var expectedUpdateMotorVehicle = motorVehicleDto.UpdateMotorVehicle{
 	Plat:           "BA1234I",
 	ProductionYear: "2023",
 	Status:         "AVAILABLE",
+	Actor:          "admin",
 }
 
 var expectedCreateMotorVehicle = motorVehicleDto.CreateMotorVehicle{
@@ -51,13 +57,13 @@ type mockMotorVehicleUsecase struct {
 	mock.Mock
 }
 
-func (m *mockMotorVehicleUsecase) GetAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error) {
-	arg := m.Called()
-	return arg.Get(0).([]motorVehicleDto.MotorVehicle), arg.Error(1)
+func (m *mockMotorVehicleUsecase) GetAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error) {
+	arg := m.Called(branchID, spec)
+	return arg.Get(0).([]motorVehicleDto.MotorVehicle), arg.Get(1).(queryspec.Page), arg.Error(2)
 }
 
-func (m *mockMotorVehicleUsecase) GetMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error) {
-	arg := m.Called(id)
+func (m *mockMotorVehicleUsecase) GetMotorVehicleById(id, branchID string) (motorVehicleDto.MotorVehicle, error) {
+	arg := m.Called(id, branchID)
 	return arg.Get(0).(motorVehicleDto.MotorVehicle), arg.Error(1)
 }
 
@@ -76,6 +82,26 @@ func (m *mockMotorVehicleUsecase) DeleteMotorVehicle(id string) error {
 	return arg.Error(0)
 }
 
+func (m *mockMotorVehicleUsecase) GetMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error) {
+	arg := m.Called(id)
+	return arg.Get(0).(motorVehicleDto.MotorVehicleUsage), arg.Error(1)
+}
+
+func (m *mockMotorVehicleUsecase) GetMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error) {
+	arg := m.Called(id)
+	return arg.Get(0).(motorVehicleDto.MotorVehicleHistory), arg.Error(1)
+}
+
+func (m *mockMotorVehicleUsecase) ImportMotorVehicle(records [][]string, dryRun bool) (motorVehicleDto.MotorVehicleImport, error) {
+	arg := m.Called(records, dryRun)
+	return arg.Get(0).(motorVehicleDto.MotorVehicleImport), arg.Error(1)
+}
+
+func (m *mockMotorVehicleUsecase) ExportMotorVehicle() ([][]string, error) {
+	arg := m.Called()
+	return arg.Get(0).([][]string), arg.Error(1)
+}
+
 type MotorVehicleDeliveryTestSuite struct {
 	suite.Suite
 	router  *gin.Engine
@@ -93,9 +119,9 @@ func (suite *MotorVehicleDeliveryTestSuite) SetupTest() {
 
 func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_Success() {
 	expected := []motorVehicleDto.MotorVehicle{expectedMotorVehicleById}
-	expectedResposnse := `{"responseCode":"2000102","responseMessage":"success","data":[{"id":"3a57713c-24d0-41f8-bfa8-f8f721dba9e4","name":"Vario","type":"MATIC","price":50000,"plat":"BA1234I","created_at":"2024-03-07T00:00:00Z","updated_at":"2024-03-07T00:00:00Z","production_year":"2023","status":"AVAILABLE"}]}`
+	expectedResposnse := `{"responseCode":"2000102","responseMessage":"success","data":[{"id":"3a57713c-24d0-41f8-bfa8-f8f721dba9e4","name":"Vario","type":"MATIC","price":50000,"plat":"BA1234I","created_at":"2024-03-07T00:00:00Z","updated_at":"2024-03-07T00:00:00Z","production_year":"2023","status":"AVAILABLE"}],"paging":{"limit":20,"has_more":true,"next_cursor":"next"}}`
 
-	suite.usecase.On("GetAllMotorVehicle").Return(expected, nil)
+	suite.usecase.On("GetAllMotorVehicle", "", mock.Anything).Return(expected, queryspec.Page{Limit: 20, HasMore: true, NextCursor: "next"}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/", nil)
@@ -108,9 +134,9 @@ func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_Success() {
 }
 
 func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_FailedDataEmpty() {
-	expectedResposnse := `{"responseCode":"2000101","responseMessage":"Empty data"}`
+	expectedResposnse := `{"responseCode":"2000101","responseMessage":"Empty data","data":[],"paging":{"limit":20,"has_more":false}}`
 
-	suite.usecase.On("GetAllMotorVehicle").Return([]motorVehicleDto.MotorVehicle{}, nil)
+	suite.usecase.On("GetAllMotorVehicle", "", mock.Anything).Return([]motorVehicleDto.MotorVehicle{}, queryspec.Page{Limit: 20}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/", nil)
@@ -122,12 +148,47 @@ func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_FailedDataEmp
 	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
 }
 
+func (suite *MotorVehicleDeliveryTestSuite) adminToken() string {
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	return "Bearer " + token
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_SuccessWithQuery() {
+	values := url.Values{"type": {"MATIC"}, "price_max": {"60000"}, "sort": {"-price"}, "limit": {"5"}}
+	spec, _ := queryspec.Parse(values, motorVehicle.Query)
+	suite.usecase.On("GetAllMotorVehicle", "", spec).Return([]motorVehicleDto.MotorVehicle{expectedMotorVehicleById}, queryspec.Page{Limit: 5}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/?"+values.Encode(), nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_FailedQuery() {
+	expectedResposnse := `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"price_min","message":"field is not number"},{"field":"available_from,available_to","message":"field is required together with the related field"},{"field":"sort","message":"field is not one of the allowed values"}]}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/?price_min=cheap&available_from=2024-08-13T09:00:00%2B07:00&sort=plat", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+	suite.usecase.AssertNotCalled(suite.T(), "GetAllMotorVehicle", mock.Anything, mock.Anything)
+}
+
 func (suite *MotorVehicleDeliveryTestSuite) TestGetAllMotorVehicle_Fail() {
 	expected := []motorVehicleDto.MotorVehicle{expectedMotorVehicleById}
 	expectedResposnse := `{"responseCode":"5000101","responseMessage":"internal server error","error":"error"}`
 	expectedError := errors.New("error")
 
-	suite.usecase.On("GetAllMotorVehicle").Return(expected, expectedError)
+	suite.usecase.On("GetAllMotorVehicle", "", mock.Anything).Return(expected, queryspec.Page{}, expectedError)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/", nil)
@@ -143,7 +204,7 @@ func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleById_Success() {
 
 	expectedResposnse := `{"responseCode":"2000202","responseMessage":"success get data by id","data":{"id":"3a57713c-24d0-41f8-bfa8-f8f721dba9e4","name":"Vario","type":"MATIC","price":50000,"plat":"BA1234I","created_at":"2024-03-07T00:00:00Z","updated_at":"2024-03-07T00:00:00Z","production_year":"2023","status":"AVAILABLE"}}`
 
-	suite.usecase.On("GetMotorVehicleById", expectedMotorVehicleById.Id).Return(expectedMotorVehicleById, nil)
+	suite.usecase.On("GetMotorVehicleById", expectedMotorVehicleById.Id, "").Return(expectedMotorVehicleById, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id, nil)
@@ -160,7 +221,7 @@ func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleById_Fail() {
 	expectedResposnse := `{"responseCode":"5000201","responseMessage":"internal server error","error":"error"}`
 	expectedError := errors.New("error")
 
-	suite.usecase.On("GetMotorVehicleById", expectedMotorVehicleById.Id).Return(expectedMotorVehicleById, expectedError)
+	suite.usecase.On("GetMotorVehicleById", expectedMotorVehicleById.Id, "").Return(expectedMotorVehicleById, expectedError)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id, nil)
@@ -177,7 +238,7 @@ func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleById_FailDataNotF
 	expectedResposnse := `{"responseCode":"2000201","responseMessage":"Data not found"}`
 	expectedError := errors.New("1")
 
-	suite.usecase.On("GetMotorVehicleById", expectedMotorVehicleById.Id).Return(expectedMotorVehicleById, expectedError)
+	suite.usecase.On("GetMotorVehicleById", expectedMotorVehicleById.Id, "").Return(expectedMotorVehicleById, expectedError)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id, nil)
@@ -217,7 +278,7 @@ func (suite *MotorVehicleDeliveryTestSuite) TestCreateMotorVehicle_FailBadReques
 		Status:         "AVAILABLE",
 	}
 
-	expectedResposnse := `{"responseCode":"4000301","responseMessage":"Bad Request","error_description":[{"field":"Name","message":"field is required"}]}`
+	expectedResposnse := `{"responseCode":"4000301","responseMessage":"Bad Request","error_description":[{"field":"Name","message":"field is required when the related field is empty"}]}`
 
 	suite.usecase.On("CreateMotorVehicle", expectedCreateMotorVehicle).Return(expectedMotorVehicleById, nil)
 
@@ -232,6 +293,22 @@ func (suite *MotorVehicleDeliveryTestSuite) TestCreateMotorVehicle_FailBadReques
 	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
 }
 
+func (suite *MotorVehicleDeliveryTestSuite) TestCreateMotorVehicle_FailedVehicleModelNotFound() {
+	expectedResposnse := `{"responseCode":"4000303","responseMessage":"Vehicle model not found"}`
+
+	suite.usecase.On("CreateMotorVehicle", mock.Anything).Return(motorVehicleDto.MotorVehicle{}, errors.New("3"))
+
+	w := httptest.NewRecorder()
+	requestbody := []byte(`{"vehicle_model_id":"5d6e7f80-1a2b-4c3d-8e9f-0a1b2c3d4e5f","plat":"BA1234I","production_year":"2023","status":"AVAILABLE"}`)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/motor-vehicles/", bytes.NewBuffer(requestbody))
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
 func (suite *MotorVehicleDeliveryTestSuite) TestCreateMotorVehicle_fail() {
 
 	expectedResposnse := `{"responseCode":"5000301","responseMessage":"internal server error","error":"error"}`
@@ -277,9 +354,10 @@ func (suite *MotorVehicleDeliveryTestSuite) TestUpdateMotorVehicle_FailBadReques
 		Plat:           "BA1234I",
 		ProductionYear: "2023",
 		Status:         "AVAILABLE",
+		Actor:          "admin",
 	}
 
-	expectedResposnse := `{"responseCode":"4000401","responseMessage":"Bad Request","error_description":[{"field":"Name","message":"field is required"}]}`
+	expectedResposnse := `{"responseCode":"4000401","responseMessage":"Bad Request","error_description":[{"field":"Name","message":"field is required when the related field is empty"}]}`
 
 	suite.usecase.On("UpdateMotorVehicle", mock.Anything, mock.Anything).Return(expectedMotorVehicleById, nil)
 
@@ -346,6 +424,158 @@ func (suite *MotorVehicleDeliveryTestSuite) TestDeleteMotorVehicle_Fail() {
 	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
 }
 
+func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleUsage_Success() {
+	returnOdometer := 1450
+	usage := motorVehicleDto.MotorVehicleUsage{
+		MotorVehicleID: expectedMotorVehicleById.Id,
+		Odometer:       1450,
+		TotalDistance:  250,
+		Rentals:        []motorVehicleDto.RentalUsage{{TransactionID: "1", PickupDate: "2024-03-07T09:00:00Z", PickupOdometer: 1200, ReturnOdometer: &returnOdometer, Distance: 250}},
+	}
+	expectedResposnse := `{"responseCode":"2000602","responseMessage":"success get motor vehicle usage","data":{"motor_vehicle_id":"3a57713c-24d0-41f8-bfa8-f8f721dba9e4","odometer":1450,"fuel_level":null,"total_distance":250,"rentals":[{"transaction_id":"1","pickup_date":"2024-03-07T09:00:00Z","return_date":null,"pickup_odometer":1200,"return_odometer":1450,"pickup_fuel_level":0,"return_fuel_level":null,"distance":250,"km_limit":null,"overage_km":0,"overage_charge":0}]}}`
+
+	suite.usecase.On("GetMotorVehicleUsage", expectedMotorVehicleById.Id).Return(usage, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id+"/usage", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleUsage_NotFound() {
+	expectedResposnse := `{"responseCode":"2000601","responseMessage":"Data not found"}`
+
+	suite.usecase.On("GetMotorVehicleUsage", expectedMotorVehicleById.Id).Return(motorVehicleDto.MotorVehicleUsage{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id+"/usage", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleHistory_Success() {
+	oldValue, newValue := "AVAILABLE", "NOT_AVAILABLE"
+	history := motorVehicleDto.MotorVehicleHistory{
+		MotorVehicleID: expectedMotorVehicleById.Id,
+		Plat:           "BA 1234 I",
+		Status:         "NOT_AVAILABLE",
+		Events:         []motorVehicleDto.MotorVehicleEvent{{ID: "1", Field: "STATUS", OldValue: &oldValue, NewValue: &newValue, Actor: "customer", Reason: "rented", TransactionID: "7", CreatedAt: "2024-03-07T09:00:00Z"}},
+	}
+	expectedResposnse := `{"responseCode":"2000902","responseMessage":"success get motor vehicle history","data":{"motor_vehicle_id":"3a57713c-24d0-41f8-bfa8-f8f721dba9e4","plat":"BA 1234 I","status":"NOT_AVAILABLE","events":[{"id":"1","field":"STATUS","old_value":"AVAILABLE","new_value":"NOT_AVAILABLE","actor":"customer","reason":"rented","transaction_id":"7","created_at":"2024-03-07T09:00:00Z"}]}}`
+
+	suite.usecase.On("GetMotorVehicleHistory", expectedMotorVehicleById.Id).Return(history, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id+"/history", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestGetMotorVehicleHistory_NotFound() {
+	expectedResposnse := `{"responseCode":"2000901","responseMessage":"Data not found"}`
+
+	suite.usecase.On("GetMotorVehicleHistory", expectedMotorVehicleById.Id).Return(motorVehicleDto.MotorVehicleHistory{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/"+expectedMotorVehicleById.Id+"/history", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) fleetFileRequest(url, fileName, content string) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", fileName)
+	part.Write([]byte(content))
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Add("Authorization", suite.adminToken())
+	return req
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestImportMotorVehicle_DryRunSuccess() {
+	records := [][]string{{"name", "type", "price", "plat", "production_year", "status"}, {"Vario", "MATIC", "50000", "BA1234I", "2023", "AVAILABLE"}}
+	expectedResposnse := `{"responseCode":"2000702","responseMessage":"the rows are valid, nothing was imported","data":{"dry_run":true,"rows":1,"imported":0}}`
+
+	suite.usecase.On("ImportMotorVehicle", records, true).Return(motorVehicleDto.MotorVehicleImport{DryRun: true, Rows: 1}, nil)
+
+	w := httptest.NewRecorder()
+	req := suite.fleetFileRequest("/api/v1/motor-vehicles/import?dry_run=true", "fleet.csv", "name,type,price,plat,production_year,status\nVario,MATIC,50000,BA1234I,2023,AVAILABLE\n")
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestImportMotorVehicle_FailedRows() {
+	expectedResposnse := `{"responseCode":"4000705","responseMessage":"nothing was imported, fix the rows and try again","error_description":[{"field":"row 2 plat","message":"the license plate is already registered to another vehicle"}]}`
+
+	suite.usecase.On("ImportMotorVehicle", mock.Anything, false).Return(motorVehicleDto.MotorVehicleImport{Rows: 1, Errors: []motorVehicleDto.ImportError{{Row: 2, Column: "plat", Message: "the license plate is already registered to another vehicle"}}}, nil)
+
+	w := httptest.NewRecorder()
+	req := suite.fleetFileRequest("/api/v1/motor-vehicles/import", "fleet.csv", "plat\nBA1234I\n")
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestImportMotorVehicle_FailedFormat() {
+	expectedResposnse := `{"responseCode":"4000702","responseMessage":"the file must be a .csv or .xlsx file"}`
+
+	w := httptest.NewRecorder()
+	req := suite.fleetFileRequest("/api/v1/motor-vehicles/import", "fleet.txt", "plat\nBA1234I\n")
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectedResposnse, w.Body.String())
+	suite.usecase.AssertNotCalled(suite.T(), "ImportMotorVehicle", mock.Anything, mock.Anything)
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestExportMotorVehicle_CSV() {
+	suite.usecase.On("ExportMotorVehicle").Return([][]string{{"plat", "status"}, {"BA1234I", "AVAILABLE"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/export", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), `attachment; filename="motor-vehicles.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), "plat,status\nBA1234I,AVAILABLE\n", w.Body.String())
+}
+
+func (suite *MotorVehicleDeliveryTestSuite) TestExportMotorVehicle_FailedFormat() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/motor-vehicles/export?format=pdf", nil)
+
+	req.Header.Add("Authorization", suite.adminToken())
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), 400, w.Code)
+	suite.usecase.AssertNotCalled(suite.T(), "ExportMotorVehicle")
+}
+
 func TestMotorVehicleDelivery(t *testing.T) {
 	suite.Run(t, new(MotorVehicleDeliveryTestSuite))
 }
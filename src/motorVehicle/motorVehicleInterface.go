@@ -2,23 +2,32 @@ package motorVehicle
 
 import (
 	"bike-rent-express/model/dto/motorVehicleDto"
+	"bike-rent-express/pkg/queryspec"
 )
 
 type (
 	MotorVechileRepository interface {
-		RetrieveAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error)
+		RetrieveAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error)
 		RetrieveMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error)
 		InsertMotorVehicle(motor motorVehicleDto.MotorVehicle) (motorVehicleDto.MotorVehicle, error)
-		ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle) (motorVehicleDto.MotorVehicle, error)
+		ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle, actor string, reason string) (motorVehicleDto.MotorVehicle, error)
 		DropMotorVehicle(id string) error
+		RetrieveMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error)
+		RetrieveMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error)
 		CheckPlatMotor(plat string) (bool, error)
+		InsertMotorVehicles(motors []motorVehicleDto.MotorVehicle, dryRun bool) (int, error)
+		RetrieveMotorVehicleExport() ([]motorVehicleDto.MotorVehicle, error)
 	}
 
 	MotorVechileUsecase interface {
-		GetAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error)
-		GetMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error)
+		GetAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error)
+		GetMotorVehicleById(id string, branchID string) (motorVehicleDto.MotorVehicle, error)
 		CreateMotorVehicle(motor motorVehicleDto.CreateMotorVehicle) (motorVehicleDto.MotorVehicle, error)
 		UpdateMotorVehicle(id string, motor motorVehicleDto.UpdateMotorVehicle) (motorVehicleDto.MotorVehicle, error)
 		DeleteMotorVehicle(id string) error
+		GetMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error)
+		GetMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error)
+		ImportMotorVehicle(records [][]string, dryRun bool) (motorVehicleDto.MotorVehicleImport, error)
+		ExportMotorVehicle() ([][]string, error)
 	}
 )
@@ -0,0 +1,29 @@
+package motorVehicle
+
+import "bike-rent-express/pkg/queryspec"
+
+// Query is what GET /motor-vehicles takes besides the branch. A motor is available from
+// available_from to available_to when neither a rental of it that is not returned yet nor
+// a maintenance that is not completed or cancelled overlaps them.
+var Query = queryspec.Schema{
+	Filters: []queryspec.Filter{
+		{Params: []string{"q"}, Condition: "name ILIKE '%' || ?::text || '%'"},
+		{Params: []string{"type"}, Condition: "LOWER(type) = LOWER(?)"},
+		{Params: []string{"vehicle_model_id"}, Condition: "vehicle_model_id = ?::uuid", Validate: "uuid"},
+		{Params: []string{"status"}, Condition: "status = ?", Validate: "status-valid"},
+		{Params: []string{"price_min"}, Condition: "price >= ?", Validate: "number"},
+		{Params: []string{"price_max"}, Condition: "price <= ?", Validate: "number"},
+		{Params: []string{"year_min"}, Condition: "production_year >= ?", Validate: "number,len=4"},
+		{Params: []string{"year_max"}, Condition: "production_year <= ?", Validate: "number,len=4"},
+		{Params: []string{"available_from", "available_to"}, Condition: "NOT EXISTS (SELECT 1 FROM (SELECT t.start_date, t.end_date FROM transaction t WHERE t.motor_vehicle_id = motor_vehicle.id AND t.status <> 'RETURNED' UNION ALL SELECT m.start_date, COALESCE(m.end_date, 'infinity') FROM maintenance m WHERE m.motor_vehicle_id = motor_vehicle.id AND m.status IN ('SCHEDULED', 'IN_PROGRESS')) busy WHERE busy.end_date > ? AND busy.start_date < ?)", Validate: "format-datetime"},
+	},
+	Sorts: map[string]string{
+		"name":            "name",
+		"price":           "price",
+		"hourly_price":    "hourly_price",
+		"production_year": "production_year",
+		"created_at":      "created_at",
+	},
+	DefaultSort: "created_at",
+	IDColumn:    "id",
+}
@@ -2,10 +2,28 @@ package motorVehicleRepository
 
 import (
 	"bike-rent-express/model/dto/motorVehicleDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
 	"bike-rent-express/src/motorVehicle"
 	"database/sql"
 )
 
+// units resolve what a motor vehicle takes from its vehicle model, the name, type and prices
+// of the unit itself only count when it has no model or overrides them
+const units = `(SELECT mv.id, COALESCE(vm.brand || ' ' || vm.model, mv.name) AS name, COALESCE(vm.transmission, mv.type) AS type,
+	COALESCE(mv.price, vm.default_price) AS price, COALESCE(mv.hourly_price, vm.default_hourly_price, 0) AS hourly_price,
+	mv.plat, mv.created_at, mv.updated_at, mv.production_year, mv.status, mv.deleted_at, mv.home_branch_id, mv.current_branch_id,
+	mv.vehicle_model_id, mv.price AS price_override, mv.hourly_price AS hourly_price_override,
+	mv.odometer, mv.fuel_level, mv.daily_km_limit, mv.overage_per_km
+	FROM motor_vehicle mv LEFT JOIN vehicle_model vm ON vm.id = mv.vehicle_model_id) motor_vehicle`
+
+const columns = "id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, COALESCE(home_branch_id::text, ''), COALESCE(current_branch_id::text, ''), COALESCE(vehicle_model_id::text, ''), COALESCE(price_override, 0), COALESCE(hourly_price_override, 0), odometer, fuel_level, COALESCE(daily_km_limit, 0), overage_per_km"
+
+// plateKey is the expression of the unique index on the plate, utils.PlateKey in SQL
+const plateKey = `UPPER(REGEXP_REPLACE(plat, '\s', '', 'g'))`
+
+const insertQuery = "INSERT INTO motor_vehicle (name, type, price, hourly_price, plat, production_year, status, home_branch_id, current_branch_id, vehicle_model_id, odometer, daily_km_limit, overage_per_km) VALUES (NULLIF($1, ''), NULLIF($2, ''), NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, NULLIF($8, '')::uuid, NULLIF($8, '')::uuid, NULLIF($9, '')::uuid, $10, NULLIF($11, 0), $12) RETURNING id;"
+
 type motorVehicleRepository struct {
 	db *sql.DB
 }
@@ -14,45 +32,55 @@ func NewMotorVehicleRepository(db *sql.DB) motorVehicle.MotorVechileRepository {
 	return &motorVehicleRepository{db}
 }
 
-// get all motor vehicle
-func (mr motorVehicleRepository) RetrieveAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error) {
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE deleted_at IS NULL;"
-	rows, err := mr.db.Query(query)
+// get a page of motor vehicles matching the spec, only the ones standing at branchID when it is given
+func (mr motorVehicleRepository) RetrieveAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error) {
+	where, args := spec.Where([]interface{}{branchID})
+	query := "SELECT " + columns + ", " + spec.SortColumn() + " FROM " + units + " WHERE deleted_at IS NULL AND ($1 = '' OR current_branch_id::text = $1) AND " + where + " ORDER BY " + spec.OrderBy() + " LIMIT " + spec.Limit() + ";"
+	rows, err := mr.db.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return nil, queryspec.Page{}, err
 	}
 	defer rows.Close()
 
 	var motors []motorVehicleDto.MotorVehicle
+	var keys []queryspec.Key
 
 	for rows.Next() {
 		motor := motorVehicleDto.MotorVehicle{}
-		err := rows.Scan(&motor.Id, &motor.Name, &motor.Type, &motor.Price, &motor.Plat, &motor.CreatedAt, &motor.UpdatedAt, &motor.ProductionYear, &motor.Status)
+		key := queryspec.Key{}
+		err := rows.Scan(&motor.Id, &motor.Name, &motor.Type, &motor.Price, &motor.HourlyPrice, &motor.Plat, &motor.CreatedAt, &motor.UpdatedAt, &motor.ProductionYear, &motor.Status, &motor.HomeBranchID, &motor.CurrentBranchID, &motor.VehicleModelID, &motor.PriceOverride, &motor.HourlyPriceOverride, &motor.Odometer, &motor.FuelLevel, &motor.DailyKmLimit, &motor.OveragePerKm, &key.Value)
 		if err != nil {
-			return nil, err
+			return nil, queryspec.Page{}, err
 		}
+		key.ID = motor.Id
 		motors = append(motors, motor)
+		keys = append(keys, key)
+	}
+
+	n, page := spec.Paginate(keys)
+	if n < len(motors) {
+		motors = motors[:n]
 	}
-	return motors, nil
+	return motors, page, nil
 }
 
 // get by id
 func (mr *motorVehicleRepository) RetrieveMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error) {
 
 	var motor motorVehicleDto.MotorVehicle
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL"
-	if err := mr.db.QueryRow(query, id).Scan(&motor.Id, &motor.Name, &motor.Type, &motor.Price, &motor.Plat, &motor.CreatedAt, &motor.UpdatedAt, &motor.ProductionYear, &motor.Status); err != nil {
+	query := "SELECT " + columns + " FROM " + units + " WHERE id = $1 AND deleted_at IS NULL"
+	if err := mr.db.QueryRow(query, id).Scan(&motor.Id, &motor.Name, &motor.Type, &motor.Price, &motor.HourlyPrice, &motor.Plat, &motor.CreatedAt, &motor.UpdatedAt, &motor.ProductionYear, &motor.Status, &motor.HomeBranchID, &motor.CurrentBranchID, &motor.VehicleModelID, &motor.PriceOverride, &motor.HourlyPriceOverride, &motor.Odometer, &motor.FuelLevel, &motor.DailyKmLimit, &motor.OveragePerKm); err != nil {
 		return motor, err
 	}
 
 	return motor, nil
 }
 
-// insert
+// insert, the unit stores its own name, type and prices only when they are given, a zero
+// price or hourly price is taken from the vehicle model
 func (mr *motorVehicleRepository) InsertMotorVehicle(motor motorVehicleDto.MotorVehicle) (motorVehicleDto.MotorVehicle, error) {
 
-	query := "INSERT INTO motor_vehicle (name, type, price, plat, production_year, status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;"
-	err := mr.db.QueryRow(query, motor.Name, motor.Type, motor.Price, motor.Plat, motor.ProductionYear, motor.Status).Scan(&motor.Id)
+	err := mr.db.QueryRow(insertQuery, motor.Name, motor.Type, motor.PriceOverride, motor.HourlyPriceOverride, motor.Plat, motor.ProductionYear, motor.Status, motor.HomeBranchID, motor.VehicleModelID, motor.Odometer, motor.DailyKmLimit, motor.OveragePerKm).Scan(&motor.Id)
 	if err != nil {
 		return motor, err
 	}
@@ -60,21 +88,40 @@ func (mr *motorVehicleRepository) InsertMotorVehicle(motor motorVehicleDto.Motor
 	return mr.RetrieveMotorVehicleById(motor.Id)
 }
 
-func (mr *motorVehicleRepository) ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle) (motorVehicleDto.MotorVehicle, error) {
+// ChangeMotorVehicle records the changes of the status, own prices and plate in the history of
+// the vehicle together with the update.
+func (mr *motorVehicleRepository) ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle, actor string, reason string) (motorVehicleDto.MotorVehicle, error) {
+	tx, err := mr.db.Begin()
+	if err != nil {
+		return motor, err
+	}
 
-	// now := time.Now().Truncate(time.Second)
+	query := `INSERT INTO motor_vehicle_event(motor_vehicle_id, field, old_value, new_value, actor, reason)
+		SELECT mv.id, c.field, c.old_value, c.new_value, $2, $3 FROM motor_vehicle mv
+		CROSS JOIN LATERAL (VALUES ('STATUS', mv.status::text, $4::text), ('PRICE', mv.price::text, NULLIF($5::int, 0)::text), ('HOURLY_PRICE', mv.hourly_price::text, NULLIF($6::int, 0)::text), ('PLAT', mv.plat::text, $7::text)) AS c(field, old_value, new_value)
+		WHERE mv.id = $1 AND c.old_value IS DISTINCT FROM c.new_value;`
+	if _, err := tx.Exec(query, id, actor, reason, motor.Status, motor.PriceOverride, motor.HourlyPriceOverride, motor.Plat); err != nil {
+		tx.Rollback()
+		return motor, err
+	}
 
-	query := "UPDATE motor_vehicle SET name = $1, type = $2, price = $3, plat = $4, production_year = $5, status = $6, updated_at = CURRENT_TIMESTAMP WHERE id = $7;"
-	_, err := mr.db.Exec(query, motor.Name, motor.Type, motor.Price, motor.Plat, motor.ProductionYear, motor.Status, id)
-	if err != nil {
+	query = "UPDATE motor_vehicle SET name = NULLIF($1, ''), type = NULLIF($2, ''), price = NULLIF($3, 0), hourly_price = NULLIF($4, 0), plat = $5, production_year = $6, status = $7, home_branch_id = NULLIF($8, '')::uuid, current_branch_id = COALESCE(current_branch_id, NULLIF($8, '')::uuid), vehicle_model_id = NULLIF($9, '')::uuid, daily_km_limit = NULLIF($10, 0), overage_per_km = $11, updated_at = CURRENT_TIMESTAMP WHERE id = $12;"
+	if _, err := tx.Exec(query, motor.Name, motor.Type, motor.PriceOverride, motor.HourlyPriceOverride, motor.Plat, motor.ProductionYear, motor.Status, motor.HomeBranchID, motor.VehicleModelID, motor.DailyKmLimit, motor.OveragePerKm, id); err != nil {
+		tx.Rollback()
+		return motor, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return motor, err
 	}
+
 	return mr.RetrieveMotorVehicleById(motor.Id)
 }
 
+// DropMotorVehicle also cancels the maintenance of the vehicle that is not done yet.
 func (mr *motorVehicleRepository) DropMotorVehicle(id string) error {
 
-	query := "UPDATE motor_vehicle SET deleted_at = CURRENT_DATE WHERE id = $1;"
+	query := "WITH cancelled AS (UPDATE maintenance SET status = 'CANCELLED', updated_at = CURRENT_TIMESTAMP WHERE motor_vehicle_id = $1 AND status IN ('SCHEDULED', 'IN_PROGRESS')) UPDATE motor_vehicle SET deleted_at = CURRENT_DATE WHERE id = $1;"
 	_, err := mr.db.Exec(query, id)
 	if err != nil {
 		return err
@@ -83,10 +130,72 @@ func (mr *motorVehicleRepository) DropMotorVehicle(id string) error {
 	return err
 }
 
+// RetrieveMotorVehicleUsage lists the readings taken over the rentals of the vehicle, the distance
+// of a rental still out is zero.
+func (mr *motorVehicleRepository) RetrieveMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error) {
+	usage := motorVehicleDto.MotorVehicleUsage{MotorVehicleID: id}
+
+	query := "SELECT odometer, fuel_level FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL;"
+	if err := mr.db.QueryRow(query, id).Scan(&usage.Odometer, &usage.FuelLevel); err != nil {
+		return usage, err
+	}
+
+	query = `SELECT t.id, mp.pickup_date, mr.return_date::text, mp.odometer, mr.odometer, mp.fuel_level, mr.fuel_level, GREATEST(COALESCE(mr.odometer - mp.odometer, 0), 0),
+		t.km_limit, COALESCE(mr.overage_km, 0), COALESCE(mr.overage_charge, 0)
+		FROM transaction t JOIN motor_pickup mp ON mp.transaction_id = t.id LEFT JOIN motor_return mr ON mr.transaction_id = t.id
+		WHERE t.motor_vehicle_id = $1 ORDER BY mp.pickup_date DESC;`
+	rows, err := mr.db.Query(query, id)
+	if err != nil {
+		return usage, err
+	}
+	defer rows.Close()
+
+	usage.Rentals = []motorVehicleDto.RentalUsage{}
+	for rows.Next() {
+		var rental motorVehicleDto.RentalUsage
+		if err := rows.Scan(&rental.TransactionID, &rental.PickupDate, &rental.ReturnDate, &rental.PickupOdometer, &rental.ReturnOdometer, &rental.PickupFuelLevel, &rental.ReturnFuelLevel, &rental.Distance, &rental.KmLimit, &rental.OverageKm, &rental.OverageCharge); err != nil {
+			return usage, err
+		}
+		usage.TotalDistance += rental.Distance
+		usage.Rentals = append(usage.Rentals, rental)
+	}
+
+	return usage, rows.Err()
+}
+
+func (mr *motorVehicleRepository) RetrieveMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error) {
+	history := motorVehicleDto.MotorVehicleHistory{MotorVehicleID: id}
+
+	query := "SELECT plat, status FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL;"
+	if err := mr.db.QueryRow(query, id).Scan(&history.Plat, &history.Status); err != nil {
+		return history, err
+	}
+
+	query = "SELECT id, field, old_value, new_value, actor, reason, COALESCE(transaction_id::text, ''), created_at FROM motor_vehicle_event WHERE motor_vehicle_id = $1 ORDER BY created_at, field;"
+	rows, err := mr.db.Query(query, id)
+	if err != nil {
+		return history, err
+	}
+	defer rows.Close()
+
+	history.Events = []motorVehicleDto.MotorVehicleEvent{}
+	for rows.Next() {
+		var event motorVehicleDto.MotorVehicleEvent
+		if err := rows.Scan(&event.ID, &event.Field, &event.OldValue, &event.NewValue, &event.Actor, &event.Reason, &event.TransactionID, &event.CreatedAt); err != nil {
+			return history, err
+		}
+		history.Events = append(history.Events, event)
+	}
+
+	return history, rows.Err()
+}
+
+// CheckPlatMotor tells whether no vehicle that is not deleted has the plate, ignoring case and
+// spaces like the unique index on the plate does
 func (mr *motorVehicleRepository) CheckPlatMotor(plat string) (bool, error) {
-	query := "SELECT COUNT(plat) FROM motor_vehicle WHERE plat = $1"
+	query := "SELECT COUNT(plat) FROM motor_vehicle WHERE " + plateKey + " = $1 AND deleted_at IS NULL"
 	var count int
-	err := mr.db.QueryRow(query, plat).Scan(&count)
+	err := mr.db.QueryRow(query, utils.PlateKey(plat)).Scan(&count)
 
 	if count > 0 {
 		return false, err
@@ -94,3 +203,47 @@ func (mr *motorVehicleRepository) CheckPlatMotor(plat string) (bool, error) {
 		return true, err
 	}
 }
+
+// InsertMotorVehicles adds all the motors or none of them, a dry run rolls back once they were
+// all accepted. On an error it returns the index of the motor the database refused.
+func (mr *motorVehicleRepository) InsertMotorVehicles(motors []motorVehicleDto.MotorVehicle, dryRun bool) (int, error) {
+	tx, err := mr.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, motor := range motors {
+		var id string
+		if err := tx.QueryRow(insertQuery, motor.Name, motor.Type, motor.PriceOverride, motor.HourlyPriceOverride, motor.Plat, motor.ProductionYear, motor.Status, motor.HomeBranchID, motor.VehicleModelID, motor.Odometer, motor.DailyKmLimit, motor.OveragePerKm).Scan(&id); err != nil {
+			tx.Rollback()
+			return i, err
+		}
+	}
+
+	if dryRun {
+		return 0, tx.Rollback()
+	}
+
+	return 0, tx.Commit()
+}
+
+// RetrieveMotorVehicleExport lists every motor vehicle that is not deleted, the oldest first.
+func (mr *motorVehicleRepository) RetrieveMotorVehicleExport() ([]motorVehicleDto.MotorVehicle, error) {
+	query := "SELECT " + columns + " FROM " + units + " WHERE deleted_at IS NULL ORDER BY created_at, id;"
+	rows, err := mr.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var motors []motorVehicleDto.MotorVehicle
+	for rows.Next() {
+		var motor motorVehicleDto.MotorVehicle
+		if err := rows.Scan(&motor.Id, &motor.Name, &motor.Type, &motor.Price, &motor.HourlyPrice, &motor.Plat, &motor.CreatedAt, &motor.UpdatedAt, &motor.ProductionYear, &motor.Status, &motor.HomeBranchID, &motor.CurrentBranchID, &motor.VehicleModelID, &motor.PriceOverride, &motor.HourlyPriceOverride, &motor.Odometer, &motor.FuelLevel, &motor.DailyKmLimit, &motor.OveragePerKm); err != nil {
+			return nil, err
+		}
+		motors = append(motors, motor)
+	}
+
+	return motors, rows.Err()
+}
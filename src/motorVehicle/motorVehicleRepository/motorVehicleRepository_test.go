@@ -2,7 +2,11 @@ package motorVehicleRepository
 
 import (
 	"bike-rent-express/model/dto/motorVehicleDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/motorVehicle"
+	"database/sql"
 	"errors"
+	"net/url"
 	"testing"
 	"time"
 
@@ -16,6 +20,7 @@ var expectedAllMotorVehicle = []motorVehicleDto.MotorVehicle{
 		Name:           "Vario",
 		Type:           "MATIC",
 		Price:          50000,
+		HourlyPrice:    8000,
 		Plat:           "BA1234I",
 		CreatedAt:      "2024-03-07T00:00:00Z",
 		UpdatedAt:      "2024-03-07T00:00:00Z",
@@ -40,6 +45,7 @@ var expectedMotorVehicleById = motorVehicleDto.MotorVehicle{
 	Name:           "Vario",
 	Type:           "MATIC",
 	Price:          50000,
+	HourlyPrice:    8000,
 	Plat:           "BA1234I",
 	CreatedAt:      "2024-03-07T00:00:00Z",
 	UpdatedAt:      "2024-03-07T00:00:00Z",
@@ -60,17 +66,18 @@ func TestRetrieveAllMotorVehicle_Success(t *testing.T) {
 	repository := NewMotorVehicleRepository(db)
 
 	//mock database
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE deleted_at IS NULL;"
-	rows := mock.NewRows([]string{"id", "name", "type", "price", "plat", "created_at", "updated_at", "production_year", "status"}).
-		AddRow(expectedAllMotorVehicle[0].Id, expectedAllMotorVehicle[0].Name, expectedAllMotorVehicle[0].Type, expectedAllMotorVehicle[0].Price, expectedAllMotorVehicle[0].Plat, expectedAllMotorVehicle[0].CreatedAt, expectedAllMotorVehicle[0].UpdatedAt, expectedAllMotorVehicle[0].ProductionYear, expectedAllMotorVehicle[0].Status).
-		AddRow(expectedAllMotorVehicle[1].Id, expectedAllMotorVehicle[1].Name, expectedAllMotorVehicle[1].Type, expectedAllMotorVehicle[1].Price, expectedAllMotorVehicle[1].Plat, expectedAllMotorVehicle[1].CreatedAt, expectedAllMotorVehicle[0].UpdatedAt, expectedAllMotorVehicle[1].ProductionYear, expectedAllMotorVehicle[1].Status)
+	query := "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE deleted_at IS NULL AND (.+);"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km", "sort_key"}).
+		AddRow(expectedAllMotorVehicle[0].Id, expectedAllMotorVehicle[0].Name, expectedAllMotorVehicle[0].Type, expectedAllMotorVehicle[0].Price, expectedAllMotorVehicle[0].HourlyPrice, expectedAllMotorVehicle[0].Plat, expectedAllMotorVehicle[0].CreatedAt, expectedAllMotorVehicle[0].UpdatedAt, expectedAllMotorVehicle[0].ProductionYear, expectedAllMotorVehicle[0].Status, "", "", "", 0, 0, 0, nil, 0, 0, expectedAllMotorVehicle[0].CreatedAt).
+		AddRow(expectedAllMotorVehicle[1].Id, expectedAllMotorVehicle[1].Name, expectedAllMotorVehicle[1].Type, expectedAllMotorVehicle[1].Price, expectedAllMotorVehicle[1].HourlyPrice, expectedAllMotorVehicle[1].Plat, expectedAllMotorVehicle[1].CreatedAt, expectedAllMotorVehicle[0].UpdatedAt, expectedAllMotorVehicle[1].ProductionYear, expectedAllMotorVehicle[1].Status, "", "", "", 0, 0, 0, nil, 0, 0, expectedAllMotorVehicle[1].CreatedAt)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	result, err := repository.RetrieveAllMotorVehicle()
+	result, page, err := repository.RetrieveAllMotorVehicle("", parseSpec(t, ""))
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedAllMotorVehicle, result)
+	assert.Equal(t, queryspec.Page{Limit: 20}, page)
 }
 
 // tes get all (if error when db.Query)
@@ -86,11 +93,11 @@ func TestRetrieveAllMotorVehicle_Fail(t *testing.T) {
 	repository := NewMotorVehicleRepository(db)
 
 	//mock database
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE deleted_at IS NULL;"
+	query := "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE deleted_at IS NULL AND (.+);"
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
 
-	result, err := repository.RetrieveAllMotorVehicle()
+	result, _, err := repository.RetrieveAllMotorVehicle("", parseSpec(t, ""))
 
 	assert.Error(t, err)
 	assert.Empty(t, result)
@@ -109,11 +116,11 @@ func TestRetrieveAllMotorVehicle_Fail2(t *testing.T) {
 	repository := NewMotorVehicleRepository(db)
 
 	//mock database
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE deleted_at IS NULL;"
+	query := "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE deleted_at IS NULL AND (.+);"
 
 	mock.ExpectQuery(query)
 
-	result, err := repository.RetrieveAllMotorVehicle()
+	result, _, err := repository.RetrieveAllMotorVehicle("", parseSpec(t, ""))
 
 	assert.NotNil(t, err)
 	assert.Error(t, err)
@@ -133,9 +140,9 @@ func TestRetrieveMotorVehicleById_Success(t *testing.T) {
 	//initialization repository
 	repository := NewMotorVehicleRepository(db)
 
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
-	rows := mock.NewRows([]string{"id", "name", "type", "price", "plat", "created_at", "updated_at", "production_year", "status"}).
-		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status)
+	query := "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", "", "", 0, 0, 0, nil, 0, 0)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -146,6 +153,32 @@ func TestRetrieveMotorVehicleById_Success(t *testing.T) {
 
 }
 
+func TestRetrieveMotorVehicleById_UnitOfModel(t *testing.T) {
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	unit := expectedMotorVehicleById
+	unit.Name = "Honda Vario 160"
+	unit.VehicleModelID = "5d6e7f80-1a2b-4c3d-8e9f-0a1b2c3d4e5f"
+	unit.PriceOverride = 50000
+
+	query := "SELECT (.+) FROM \\(SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm ON vm.id = mv.vehicle_model_id\\) motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(unit.Id, unit.Name, unit.Type, unit.Price, unit.HourlyPrice, unit.Plat, unit.CreatedAt, unit.UpdatedAt, unit.ProductionYear, unit.Status, "", "", unit.VehicleModelID, unit.PriceOverride, 0, 0, nil, 0, 0)
+	mock.ExpectQuery(query).WithArgs(unit.Id).WillReturnRows(rows)
+
+	result, err := repository.RetrieveMotorVehicleById(unit.Id)
+
+	assert.Nil(t, err)
+	assert.Equal(t, unit, result)
+}
+
 // test get by id fail
 func TestRetrieveMotorVehicleById_Fail(t *testing.T) {
 
@@ -158,7 +191,7 @@ func TestRetrieveMotorVehicleById_Fail(t *testing.T) {
 	//initialization repository
 	repository := NewMotorVehicleRepository(db)
 
-	query := "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL"
+	query := "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = $1 AND deleted_at IS NULL"
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
 
@@ -186,9 +219,9 @@ func TestInsertMotorVehicle_Success(t *testing.T) {
 	//source: https://github.com/DATA-DOG/go-sqlmock/issues/27
 	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedMotorVehicleById.Id))
 
-	query = "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
-	rows := mock.NewRows([]string{"id", "name", "type", "price", "plat", "created_at", "updated_at", "production_year", "status"}).
-		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status)
+	query = "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", "", "", 0, 0, 0, nil, 0, 0)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -214,9 +247,9 @@ func TestInsertMotorVehicle_Fail(t *testing.T) {
 
 	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedMotorVehicleById.Id))
 
-	query = "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
-	rows := mock.NewRows([]string{"id", "name", "type", "price", "plat", "created_at", "updated_at", "production_year", "status"}).
-		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status)
+	query = "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", "", "", 0, 0, 0, nil, 0, 0)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -237,18 +270,26 @@ func TestChangeMotorVehicle_Success(t *testing.T) {
 	//initialization repository
 	repository := NewMotorVehicleRepository(db)
 
-	query := "UPDATE motor_vehicle SET name = \\$1, type = \\$2, price = \\$3, plat = \\$4, production_year = \\$5, status = \\$6, updated_at = CURRENT_TIMESTAMP WHERE id = \\$7;"
+	mock.ExpectBegin()
 
-	mock.ExpectExec(query).WithArgs(expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, expectedMotorVehicleById.Id).
+	// only the fields that change are recorded in the history
+	query := "INSERT INTO motor_vehicle_event(.+) SELECT mv.id, c.field, c.old_value, c.new_value, \\$2, \\$3 FROM motor_vehicle mv (.+) WHERE mv.id = \\$1 AND c.old_value IS DISTINCT FROM c.new_value;"
+	mock.ExpectExec(query).WithArgs(expectedMotorVehicleById.Id, "admin", "repainted", expectedMotorVehicleById.Status, expectedMotorVehicleById.PriceOverride, expectedMotorVehicleById.HourlyPriceOverride, expectedMotorVehicleById.Plat).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
-	rows := mock.NewRows([]string{"id", "name", "type", "price", "plat", "created_at", "updated_at", "production_year", "status"}).
-		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status)
+	query = "UPDATE motor_vehicle SET name = NULLIF\\(\\$1, ''\\), type = NULLIF\\(\\$2, ''\\), price = NULLIF\\(\\$3, 0\\), hourly_price = NULLIF\\(\\$4, 0\\), plat = \\$5, production_year = \\$6, status = \\$7, (.+) WHERE id = \\$12;"
+
+	mock.ExpectExec(query).WithArgs(expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.PriceOverride, expectedMotorVehicleById.HourlyPriceOverride, expectedMotorVehicleById.Plat, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, expectedMotorVehicleById.HomeBranchID, expectedMotorVehicleById.VehicleModelID, expectedMotorVehicleById.DailyKmLimit, expectedMotorVehicleById.OveragePerKm, expectedMotorVehicleById.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	query = "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", "", "", 0, 0, 0, nil, 0, 0)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	result, err := repository.ChangeMotorVehicle(expectedMotorVehicleById.Id, expectedMotorVehicleById)
+	result, err := repository.ChangeMotorVehicle(expectedMotorVehicleById.Id, expectedMotorVehicleById, "admin", "repainted")
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedMotorVehicleById, result)
@@ -269,16 +310,16 @@ func TestChangeMotorVehicle_Fail(t *testing.T) {
 	// wrong query
 	query := "UPDATE motor_vehicle SET name = $1, type = $2, price = $3, plat = $4, production_year = $5, status = $6, updated_at = $7 WHERE id = $8;"
 
-	mock.ExpectExec(query).WithArgs(expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, time.Now(), expectedMotorVehicleById.Id).
+	mock.ExpectExec(query).WithArgs(expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, time.Now(), expectedMotorVehicleById.Id).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	query = "SELECT id, name, type, price, plat, created_at, updated_at, production_year, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
-	rows := mock.NewRows([]string{"id", "name", "type", "price", "plat", "created_at", "updated_at", "production_year", "status"}).
-		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status)
+	query = "SELECT id, name, type, price, hourly_price, plat, created_at, updated_at, production_year, status, (.+) FROM (.+) motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", "", "", 0, 0, 0, nil, 0, 0)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	_, err = repository.ChangeMotorVehicle(expectedMotorVehicleById.Id, expectedMotorVehicleById)
+	_, err = repository.ChangeMotorVehicle(expectedMotorVehicleById.Id, expectedMotorVehicleById, "admin", "repainted")
 
 	assert.Error(t, err)
 }
@@ -335,7 +376,7 @@ func TestDropMotorVehicle_Fail(t *testing.T) {
 
 // 	repository := NewMotorVehicleRepository(db)
 
-// 	query := "SELECT COUNT(.+) FROM motor_vehicle WHERE plat = \\$1;"
+// 	query := "SELECT COUNT(.+) FROM (.+) motor_vehicle WHERE plat = \\$1;"
 // 	rows := sqlmock.NewRows([]string{".+"}).AddRow(0)
 
 // 	mock.ExpectQuery(query).WillReturnRows(rows)
@@ -345,3 +386,247 @@ func TestDropMotorVehicle_Fail(t *testing.T) {
 // 	assert.Nil(t, err)
 // 	assert.Equal(t, true, ready)
 // }
+
+func TestRetrieveAllMotorVehicle_SuccessByBranch(t *testing.T) {
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+	branchId := "2b4c6d8e-0f1a-4b3c-9d5e-7f8a9b0c1d2e"
+
+	query := "SELECT (.+) FROM (.+) motor_vehicle WHERE deleted_at IS NULL AND \\(\\$1 = '' OR current_branch_id::text = \\$1\\) AND TRUE ORDER BY created_at ASC, id::text ASC LIMIT 21;"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km", "sort_key"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", branchId, "", 0, 0, 0, nil, 0, 0, expectedMotorVehicleById.CreatedAt)
+	mock.ExpectQuery(query).WithArgs(branchId).WillReturnRows(rows)
+
+	result, _, err := repository.RetrieveAllMotorVehicle(branchId, parseSpec(t, ""))
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, branchId, result[0].CurrentBranchID)
+}
+
+func TestRetrieveAllMotorVehicle_FilterSortAndCursor(t *testing.T) {
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	query := "SELECT (.+) FROM (.+) motor_vehicle WHERE (.+) AND LOWER\\(type\\) = LOWER\\(\\$2\\) AND price <= \\$3 ORDER BY price DESC, id::text DESC LIMIT 2;"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km", "sort_key"}).
+		AddRow(expectedAllMotorVehicle[0].Id, expectedAllMotorVehicle[0].Name, expectedAllMotorVehicle[0].Type, expectedAllMotorVehicle[0].Price, expectedAllMotorVehicle[0].HourlyPrice, expectedAllMotorVehicle[0].Plat, expectedAllMotorVehicle[0].CreatedAt, expectedAllMotorVehicle[0].UpdatedAt, expectedAllMotorVehicle[0].ProductionYear, expectedAllMotorVehicle[0].Status, "", "", "", 0, 0, 0, nil, 0, 0, "50000").
+		AddRow(expectedAllMotorVehicle[1].Id, expectedAllMotorVehicle[1].Name, expectedAllMotorVehicle[1].Type, expectedAllMotorVehicle[1].Price, expectedAllMotorVehicle[1].HourlyPrice, expectedAllMotorVehicle[1].Plat, expectedAllMotorVehicle[1].CreatedAt, expectedAllMotorVehicle[1].UpdatedAt, expectedAllMotorVehicle[1].ProductionYear, expectedAllMotorVehicle[1].Status, "", "", "", 0, 0, 0, nil, 0, 0, "50000")
+	mock.ExpectQuery(query).WithArgs("", "matic", "60000").WillReturnRows(rows)
+
+	result, page, err := repository.RetrieveAllMotorVehicle("", parseSpec(t, "type=matic&price_max=60000&sort=-price&limit=1"))
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAllMotorVehicle[:1], result)
+	assert.True(t, page.HasMore)
+	assert.NotEmpty(t, page.NextCursor)
+
+	query = "SELECT (.+) AND \\(price, id::text\\) < \\(\\$4, \\$5\\) ORDER BY price DESC, id::text DESC LIMIT 2;"
+	rows = mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km", "sort_key"}).
+		AddRow(expectedAllMotorVehicle[1].Id, expectedAllMotorVehicle[1].Name, expectedAllMotorVehicle[1].Type, expectedAllMotorVehicle[1].Price, expectedAllMotorVehicle[1].HourlyPrice, expectedAllMotorVehicle[1].Plat, expectedAllMotorVehicle[1].CreatedAt, expectedAllMotorVehicle[1].UpdatedAt, expectedAllMotorVehicle[1].ProductionYear, expectedAllMotorVehicle[1].Status, "", "", "", 0, 0, 0, nil, 0, 0, "50000")
+	mock.ExpectQuery(query).WithArgs("", "matic", "60000", "50000", expectedAllMotorVehicle[0].Id).WillReturnRows(rows)
+
+	result, page, err = repository.RetrieveAllMotorVehicle("", parseSpec(t, "type=matic&price_max=60000&sort=-price&limit=1&cursor="+page.NextCursor))
+	assert.Nil(t, err)
+	assert.Equal(t, expectedAllMotorVehicle[1:], result)
+	assert.Equal(t, queryspec.Page{Limit: 1}, page)
+}
+
+func parseSpec(t *testing.T, rawQuery string) queryspec.Spec {
+	values, _ := url.ParseQuery(rawQuery)
+	spec, errs := queryspec.Parse(values, motorVehicle.Query)
+	if errs != nil {
+		t.Fatal("Error parsing query spec: ", errs)
+	}
+	return spec
+}
+
+// test usage sums the distance of the returned rentals, the one still out counts zero
+func TestRetrieveMotorVehicleUsage_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	query := "SELECT odometer, fuel_level FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs(expectedMotorVehicleById.Id).WillReturnRows(sqlmock.NewRows([]string{"odometer", "fuel_level"}).AddRow(1450, 40))
+
+	query = "SELECT t.id, mp.pickup_date, (.+) FROM transaction t JOIN motor_pickup mp (.+) WHERE t.motor_vehicle_id = \\$1 ORDER BY mp.pickup_date DESC;"
+	rows := sqlmock.NewRows([]string{"id", "pickup_date", "return_date", "pickup_odometer", "return_odometer", "pickup_fuel_level", "return_fuel_level", "distance", "km_limit", "overage_km", "overage_charge"}).
+		AddRow("2", "2024-03-09T09:00:00Z", nil, 1450, nil, 40, nil, 0, 300, 0, 0).
+		AddRow("1", "2024-03-07T09:00:00Z", "2024-03-08", 1200, 1450, 100, 40, 250, 150, 100, 10000)
+	mock.ExpectQuery(query).WithArgs(expectedMotorVehicleById.Id).WillReturnRows(rows)
+
+	result, err := repository.RetrieveMotorVehicleUsage(expectedMotorVehicleById.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 1450, result.Odometer)
+	assert.Equal(t, 250, result.TotalDistance)
+	assert.Len(t, result.Rentals, 2)
+	assert.Nil(t, result.Rentals[0].ReturnOdometer)
+	assert.Equal(t, 1450, *result.Rentals[1].ReturnOdometer)
+	assert.Equal(t, 10000, result.Rentals[1].OverageCharge)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+// test usage of a deleted or unknown motor vehicle
+func TestRetrieveMotorVehicleUsage_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	query := "SELECT odometer, fuel_level FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs(expectedMotorVehicleById.Id).WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.RetrieveMotorVehicleUsage(expectedMotorVehicleById.Id)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveMotorVehicleHistory_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	query := "SELECT plat, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs(expectedMotorVehicleById.Id).WillReturnRows(sqlmock.NewRows([]string{"plat", "status"}).AddRow("BA 1234 I", "AVAILABLE"))
+
+	query = "SELECT id, field, old_value, new_value, actor, reason, (.+) FROM motor_vehicle_event WHERE motor_vehicle_id = \\$1 ORDER BY created_at, field;"
+	rows := sqlmock.NewRows([]string{"id", "field", "old_value", "new_value", "actor", "reason", "transaction_id", "created_at"}).
+		AddRow("1", "STATUS", "AVAILABLE", "NOT_AVAILABLE", "customer", "rented", "7", "2024-03-07T09:00:00Z").
+		AddRow("2", "STATUS", "NOT_AVAILABLE", "AVAILABLE", "employee", "returned", "7", "2024-03-08T09:00:00Z").
+		AddRow("3", "PRICE", nil, "60000", "admin", "updated", "", "2024-03-09T09:00:00Z")
+	mock.ExpectQuery(query).WithArgs(expectedMotorVehicleById.Id).WillReturnRows(rows)
+
+	result, err := repository.RetrieveMotorVehicleHistory(expectedMotorVehicleById.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, "AVAILABLE", result.Status)
+	assert.Len(t, result.Events, 3)
+	assert.Equal(t, "7", result.Events[1].TransactionID)
+	assert.Nil(t, result.Events[2].OldValue)
+	assert.Equal(t, "60000", *result.Events[2].NewValue)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+// test history of a deleted or unknown motor vehicle
+func TestRetrieveMotorVehicleHistory_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	query := "SELECT plat, status FROM motor_vehicle WHERE id = \\$1 AND deleted_at IS NULL;"
+	mock.ExpectQuery(query).WithArgs(expectedMotorVehicleById.Id).WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.RetrieveMotorVehicleHistory(expectedMotorVehicleById.Id)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertMotorVehicles_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO motor_vehicle (.+) RETURNING id;").WithArgs("Vario", "MATIC", 50000, 0, "BA1234I", "2023", "AVAILABLE", "", "", 0, 0, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("INSERT INTO motor_vehicle (.+) RETURNING id;").WithArgs("NMAX", "MATIC", 50000, 0, "AB1234I", "2023", "AVAILABLE", "", "", 0, 0, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+	mock.ExpectCommit()
+
+	motors := []motorVehicleDto.MotorVehicle{
+		{Name: "Vario", Type: "MATIC", PriceOverride: 50000, Plat: "BA1234I", ProductionYear: "2023", Status: "AVAILABLE"},
+		{Name: "NMAX", Type: "MATIC", PriceOverride: 50000, Plat: "AB1234I", ProductionYear: "2023", Status: "AVAILABLE"},
+	}
+	_, err = repository.InsertMotorVehicles(motors, false)
+
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertMotorVehicles_DryRunRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO motor_vehicle (.+) RETURNING id;").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectRollback()
+
+	_, err = repository.InsertMotorVehicles([]motorVehicleDto.MotorVehicle{{Name: "Vario", Plat: "BA1234I"}}, true)
+
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertMotorVehicles_FailedRow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO motor_vehicle (.+) RETURNING id;").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("INSERT INTO motor_vehicle (.+) RETURNING id;").WillReturnError(errors.New("pq: violates foreign key constraint"))
+	mock.ExpectRollback()
+
+	failed, err := repository.InsertMotorVehicles([]motorVehicleDto.MotorVehicle{{Plat: "BA1234I"}, {Plat: "AB1234I"}}, false)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, failed)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRetrieveMotorVehicleExport_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewMotorVehicleRepository(db)
+
+	query := "SELECT (.+) FROM (.+) motor_vehicle WHERE deleted_at IS NULL ORDER BY created_at, id;"
+	rows := mock.NewRows([]string{"id", "name", "type", "price", "hourly_price", "plat", "created_at", "updated_at", "production_year", "status", "home_branch_id", "current_branch_id", "vehicle_model_id", "price_override", "hourly_price_override", "odometer", "fuel_level", "daily_km_limit", "overage_per_km"}).
+		AddRow(expectedMotorVehicleById.Id, expectedMotorVehicleById.Name, expectedMotorVehicleById.Type, expectedMotorVehicleById.Price, expectedMotorVehicleById.HourlyPrice, expectedMotorVehicleById.Plat, expectedMotorVehicleById.CreatedAt, expectedMotorVehicleById.UpdatedAt, expectedMotorVehicleById.ProductionYear, expectedMotorVehicleById.Status, "", "", "", 0, 0, 0, nil, 0, 0)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	result, err := repository.RetrieveMotorVehicleExport()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []motorVehicleDto.MotorVehicle{expectedMotorVehicleById}, result)
+}
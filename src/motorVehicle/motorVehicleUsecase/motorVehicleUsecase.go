@@ -2,11 +2,19 @@ package motorVehicleUsecase
 
 import (
 	"bike-rent-express/model/dto/motorVehicleDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
 	"bike-rent-express/src/motorVehicle"
+	"database/sql"
 	"errors"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
+// fleetColumns are the columns of a fleet file, named like the fields of CreateMotorVehicle
+var fleetColumns = []string{"vehicle_model_id", "name", "type", "price", "hourly_price", "plat", "production_year", "status", "home_branch_id", "odometer", "daily_km_limit", "overage_per_km"}
+
 type motorVehicleUsecase struct {
 	motorVehicleRepo motorVehicle.MotorVechileRepository
 }
@@ -15,18 +23,18 @@ func NewMotorVehicleUsecase(motorVehicleRepo motorVehicle.MotorVechileRepository
 	return &motorVehicleUsecase{motorVehicleRepo}
 }
 
-// get all motors
-func (mu motorVehicleUsecase) GetAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error) {
+// get a page of motors
+func (mu motorVehicleUsecase) GetAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error) {
 
-	motors, err := mu.motorVehicleRepo.RetrieveAllMotorVehicle()
+	motors, page, err := mu.motorVehicleRepo.RetrieveAllMotorVehicle(branchID, spec)
 	if err != nil {
-		return motors, err
+		return motors, page, err
 	}
-	return motors, nil
+	return motors, page, nil
 }
 
-// get by id
-func (mu motorVehicleUsecase) GetMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error) {
+// get by id, a caller scoped to branchID only sees the motors standing at that branch
+func (mu motorVehicleUsecase) GetMotorVehicleById(id string, branchID string) (motorVehicleDto.MotorVehicle, error) {
 	motor, err := mu.motorVehicleRepo.RetrieveMotorVehicleById(id)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid input syntax for type uuid") {
@@ -35,10 +43,17 @@ func (mu motorVehicleUsecase) GetMotorVehicleById(id string) (motorVehicleDto.Mo
 		return motor, err
 	}
 
+	if branchID != "" && motor.CurrentBranchID != branchID {
+		return motorVehicleDto.MotorVehicle{}, errors.New("1")
+	}
+
 	return motor, nil
 }
 
+// CreateMotorVehicle stores the plate normalized, "1" means a vehicle already has the plate
 func (mu motorVehicleUsecase) CreateMotorVehicle(motor motorVehicleDto.CreateMotorVehicle) (motorVehicleDto.MotorVehicle, error) {
+	motor.Plat = utils.NormalizePlate(motor.Plat)
+
 	ready, err := mu.motorVehicleRepo.CheckPlatMotor(motor.Plat)
 	if err != nil {
 		return motorVehicleDto.MotorVehicle{}, err
@@ -48,16 +63,18 @@ func (mu motorVehicleUsecase) CreateMotorVehicle(motor motorVehicleDto.CreateMot
 		return motorVehicleDto.MotorVehicle{}, errors.New("1")
 	}
 
-	newMotor, err := mu.motorVehicleRepo.InsertMotorVehicle(motorVehicleDto.MotorVehicle{
-		Name:           motor.Name,
-		Type:           motor.Type,
-		Price:          motor.Price,
-		Plat:           motor.Plat,
-		ProductionYear: motor.ProductionYear,
-		Status:         motor.Status,
-	})
+	newMotor, err := mu.motorVehicleRepo.InsertMotorVehicle(newMotorVehicle(motor))
 
 	if err != nil {
+		if strings.Contains(err.Error(), "motor_vehicle_plat_key_idx") {
+			return newMotor, errors.New("1")
+		}
+		if strings.Contains(err.Error(), "vehicle_model_id_fkey") {
+			return newMotor, errors.New("3")
+		}
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return newMotor, errors.New("2")
+		}
 		return newMotor, err
 	}
 
@@ -70,7 +87,8 @@ func (mu motorVehicleUsecase) UpdateMotorVehicle(id string, input motorVehicleDt
 		return motor, err
 	}
 
-	if input.Plat != motor.Plat {
+	input.Plat = utils.NormalizePlate(input.Plat)
+	if utils.PlateKey(input.Plat) != utils.PlateKey(motor.Plat) {
 		ready, err := mu.motorVehicleRepo.CheckPlatMotor(input.Plat)
 		if err != nil {
 			return motorVehicleDto.MotorVehicle{}, err
@@ -82,6 +100,9 @@ func (mu motorVehicleUsecase) UpdateMotorVehicle(id string, input motorVehicleDt
 
 	}
 
+	if input.VehicleModelID != "" {
+		motor.VehicleModelID = input.VehicleModelID
+	}
 	if input.Name != "" {
 		motor.Name = input.Name
 	}
@@ -89,7 +110,17 @@ func (mu motorVehicleUsecase) UpdateMotorVehicle(id string, input motorVehicleDt
 		motor.Type = input.Type
 	}
 	if input.Price != 0 {
-		motor.Price = input.Price
+		motor.PriceOverride = input.Price
+	}
+	if input.HourlyPrice != nil {
+		motor.HourlyPriceOverride = *input.HourlyPrice
+	}
+	// a unit of a vehicle model is named after the model and follows its prices unless told otherwise
+	if motor.VehicleModelID != "" {
+		motor.Name, motor.Type = "", ""
+		if input.UseModelPrice {
+			motor.PriceOverride, motor.HourlyPriceOverride = 0, 0
+		}
 	}
 	if input.Plat != "" {
 		motor.Plat = input.Plat
@@ -100,9 +131,32 @@ func (mu motorVehicleUsecase) UpdateMotorVehicle(id string, input motorVehicleDt
 	if input.Status != "" {
 		motor.Status = input.Status
 	}
+	if input.HomeBranchID != "" {
+		motor.HomeBranchID = input.HomeBranchID
+	}
+	if input.DailyKmLimit != nil {
+		motor.DailyKmLimit = *input.DailyKmLimit
+	}
+	if input.OveragePerKm != nil {
+		motor.OveragePerKm = *input.OveragePerKm
+	}
+
+	// changes without a reason given are recorded in the history as plain updates
+	if input.Reason == "" {
+		input.Reason = "updated"
+	}
 
-	data, err := mu.motorVehicleRepo.ChangeMotorVehicle(id, motor)
+	data, err := mu.motorVehicleRepo.ChangeMotorVehicle(id, motor, input.Actor, input.Reason)
 	if err != nil {
+		if strings.Contains(err.Error(), "motor_vehicle_plat_key_idx") {
+			return data, errors.New("1")
+		}
+		if strings.Contains(err.Error(), "vehicle_model_id_fkey") {
+			return data, errors.New("3")
+		}
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return data, errors.New("2")
+		}
 		return data, err
 	}
 	return data, nil
@@ -124,3 +178,229 @@ func (mu motorVehicleUsecase) DeleteMotorVehicle(id string) error {
 	return nil
 
 }
+
+
+// GetMotorVehicleUsage returns "1" when the motor vehicle does not exist.
+func (mu motorVehicleUsecase) GetMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error) {
+	usage, err := mu.motorVehicleRepo.RetrieveMotorVehicleUsage(id)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return usage, errors.New("1")
+		}
+		return usage, err
+	}
+
+	return usage, nil
+}
+
+// GetMotorVehicleHistory returns "1" when the motor vehicle does not exist.
+func (mu motorVehicleUsecase) GetMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error) {
+	history, err := mu.motorVehicleRepo.RetrieveMotorVehicleHistory(id)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return history, errors.New("1")
+		}
+		return history, err
+	}
+
+	return history, nil
+}
+
+// ImportMotorVehicle checks every row of a fleet file with the rules of CreateMotorVehicle and
+// adds them all, or none when a row has errors. It returns "4" when the header names a column
+// that is not a fleet column and "5" when the file has no rows.
+func (mu motorVehicleUsecase) ImportMotorVehicle(records [][]string, dryRun bool) (motorVehicleDto.MotorVehicleImport, error) {
+	result := motorVehicleDto.MotorVehicleImport{DryRun: dryRun}
+	if len(records) == 0 {
+		return result, errors.New("5")
+	}
+
+	header := map[string]int{}
+	for i, column := range records[0] {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !contains(fleetColumns, column) {
+			return result, errors.New("4")
+		}
+		header[column] = i
+	}
+
+	var motors []motorVehicleDto.CreateMotorVehicle
+	var rows []int
+	platRows := map[string]int{}
+	for i, record := range records[1:] {
+		row := i + 2
+		if isBlank(record) {
+			continue
+		}
+
+		notNumber := map[string]bool{}
+		cell := func(column string) string {
+			if j, ok := header[column]; ok && j < len(record) {
+				return strings.TrimSpace(record[j])
+			}
+			return ""
+		}
+		number := func(column string) int {
+			value := cell(column)
+			if value == "" {
+				return 0
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				notNumber[column] = true
+				result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: row, Column: column, Message: "field is not number"})
+			}
+			return n
+		}
+
+		motor := motorVehicleDto.CreateMotorVehicle{
+			VehicleModelID: cell("vehicle_model_id"),
+			Name:           cell("name"),
+			Type:           cell("type"),
+			Price:          number("price"),
+			HourlyPrice:    number("hourly_price"),
+			Plat:           cell("plat"),
+			ProductionYear: cell("production_year"),
+			Status:         cell("status"),
+			HomeBranchID:   cell("home_branch_id"),
+			Odometer:       number("odometer"),
+			DailyKmLimit:   number("daily_km_limit"),
+			OveragePerKm:   number("overage_per_km"),
+		}
+
+		for _, field := range utils.Validated(motor) {
+			if column := columnOf(field.FieldName); !notNumber[column] {
+				result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: row, Column: column, Message: field.Message})
+			}
+		}
+
+		motor.Plat = utils.NormalizePlate(motor.Plat)
+		if motor.Plat != "" {
+			if first, ok := platRows[utils.PlateKey(motor.Plat)]; ok {
+				result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: row, Column: "plat", Message: "the license plate is repeated from row " + strconv.Itoa(first)})
+			} else {
+				platRows[utils.PlateKey(motor.Plat)] = row
+				ready, err := mu.motorVehicleRepo.CheckPlatMotor(motor.Plat)
+				if err != nil {
+					return result, err
+				}
+				if !ready {
+					result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: row, Column: "plat", Message: "the license plate is already registered to another vehicle"})
+				}
+			}
+		}
+
+		motors = append(motors, motor)
+		rows = append(rows, row)
+	}
+
+	result.Rows = len(motors)
+	if result.Rows == 0 {
+		return result, errors.New("5")
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	newMotors := make([]motorVehicleDto.MotorVehicle, len(motors))
+	for i, motor := range motors {
+		newMotors[i] = newMotorVehicle(motor)
+	}
+
+	failed, err := mu.motorVehicleRepo.InsertMotorVehicles(newMotors, dryRun)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "motor_vehicle_plat_key_idx"):
+			result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: rows[failed], Column: "plat", Message: "the license plate is already registered to another vehicle"})
+		case strings.Contains(err.Error(), "vehicle_model_id_fkey"):
+			result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: rows[failed], Column: "vehicle_model_id", Message: "Vehicle model not found"})
+		case strings.Contains(err.Error(), "violates foreign key constraint"):
+			result.Errors = append(result.Errors, motorVehicleDto.ImportError{Row: rows[failed], Column: "home_branch_id", Message: "Branch not found"})
+		default:
+			return result, err
+		}
+		return result, nil
+	}
+
+	if !dryRun {
+		result.Imported = result.Rows
+	}
+	return result, nil
+}
+
+// ExportMotorVehicle returns the fleet as the rows of a fleet file, header first. A unit of a
+// vehicle model leaves out the name and type it takes from the model and only carries the
+// prices it overrides, so the file can be imported again as it is.
+func (mu motorVehicleUsecase) ExportMotorVehicle() ([][]string, error) {
+	motors, err := mu.motorVehicleRepo.RetrieveMotorVehicleExport()
+	if err != nil {
+		return nil, err
+	}
+
+	records := [][]string{fleetColumns}
+	for _, motor := range motors {
+		name, motorType := motor.Name, motor.Type
+		if motor.VehicleModelID != "" {
+			name, motorType = "", ""
+		}
+		records = append(records, []string{
+			motor.VehicleModelID,
+			name,
+			motorType,
+			strconv.Itoa(motor.PriceOverride),
+			strconv.Itoa(motor.HourlyPriceOverride),
+			motor.Plat,
+			motor.ProductionYear,
+			motor.Status,
+			motor.HomeBranchID,
+			strconv.Itoa(motor.Odometer),
+			strconv.Itoa(motor.DailyKmLimit),
+			strconv.Itoa(motor.OveragePerKm),
+		})
+	}
+
+	return records, nil
+}
+
+func newMotorVehicle(motor motorVehicleDto.CreateMotorVehicle) motorVehicleDto.MotorVehicle {
+	return motorVehicleDto.MotorVehicle{
+		Name:                motor.Name,
+		Type:                motor.Type,
+		PriceOverride:       motor.Price,
+		HourlyPriceOverride: motor.HourlyPrice,
+		Plat:                motor.Plat,
+		ProductionYear:      motor.ProductionYear,
+		Status:              motor.Status,
+		HomeBranchID:        motor.HomeBranchID,
+		VehicleModelID:      motor.VehicleModelID,
+		Odometer:            motor.Odometer,
+		DailyKmLimit:        motor.DailyKmLimit,
+		OveragePerKm:        motor.OveragePerKm,
+	}
+}
+
+// columnOf names the fleet column of a field of CreateMotorVehicle
+func columnOf(field string) string {
+	if structField, ok := reflect.TypeOf(motorVehicleDto.CreateMotorVehicle{}).FieldByName(field); ok {
+		return structField.Tag.Get("json")
+	}
+	return field
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
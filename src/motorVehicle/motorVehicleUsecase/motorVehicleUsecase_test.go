@@ -2,6 +2,7 @@ package motorVehicleUsecase
 
 import (
 	"bike-rent-express/model/dto/motorVehicleDto"
+	"bike-rent-express/pkg/queryspec"
 	"errors"
 	"testing"
 
@@ -14,7 +15,7 @@ var expectedMotorVehicleById = motorVehicleDto.MotorVehicle{
 	Name:           "Vario",
 	Type:           "MATIC",
 	Price:          50000,
-	Plat:           "BA1234I",
+	Plat:           "BA 1234 I",
 	CreatedAt:      "2024-03-07T00:00:00Z",
 	UpdatedAt:      "2024-03-07T00:00:00Z",
 	ProductionYear: "2023",
@@ -23,11 +24,12 @@ var expectedMotorVehicleById = motorVehicleDto.MotorVehicle{
 
 type mockMotorVehicleRepository struct {
 	mock.Mock
+	changed motorVehicleDto.MotorVehicle
 }
 
-func (m *mockMotorVehicleRepository) RetrieveAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error) {
-	arg := m.Called()
-	return arg.Get(0).([]motorVehicleDto.MotorVehicle), arg.Error(1)
+func (m *mockMotorVehicleRepository) RetrieveAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error) {
+	arg := m.Called(branchID, spec)
+	return arg.Get(0).([]motorVehicleDto.MotorVehicle), arg.Get(1).(queryspec.Page), arg.Error(2)
 }
 
 func (m *mockMotorVehicleRepository) RetrieveMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error) {
@@ -40,8 +42,9 @@ func (m *mockMotorVehicleRepository) InsertMotorVehicle(motor motorVehicleDto.Mo
 	return arg.Get(0).(motorVehicleDto.MotorVehicle), arg.Error(1)
 }
 
-func (m *mockMotorVehicleRepository) ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle) (motorVehicleDto.MotorVehicle, error) {
-	arg := m.Called(id, expectedMotorVehicleById)
+func (m *mockMotorVehicleRepository) ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle, actor string, reason string) (motorVehicleDto.MotorVehicle, error) {
+	m.changed = motor
+	arg := m.Called(id, expectedMotorVehicleById, actor, reason)
 	return arg.Get(0).(motorVehicleDto.MotorVehicle), arg.Error(1)
 }
 
@@ -50,25 +53,48 @@ func (m *mockMotorVehicleRepository) DropMotorVehicle(id string) error {
 	return arg.Error(0)
 }
 
+func (m *mockMotorVehicleRepository) RetrieveMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error) {
+	arg := m.Called(id)
+	return arg.Get(0).(motorVehicleDto.MotorVehicleUsage), arg.Error(1)
+}
+
+func (m *mockMotorVehicleRepository) RetrieveMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error) {
+	arg := m.Called(id)
+	return arg.Get(0).(motorVehicleDto.MotorVehicleHistory), arg.Error(1)
+}
+
 func (m *mockMotorVehicleRepository) CheckPlatMotor(plat string) (bool, error) {
 	args := m.Called(plat)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockMotorVehicleRepository) InsertMotorVehicles(motors []motorVehicleDto.MotorVehicle, dryRun bool) (int, error) {
+	arg := m.Called(motors, dryRun)
+	return arg.Int(0), arg.Error(1)
+}
+
+func (m *mockMotorVehicleRepository) RetrieveMotorVehicleExport() ([]motorVehicleDto.MotorVehicle, error) {
+	arg := m.Called()
+	return arg.Get(0).([]motorVehicleDto.MotorVehicle), arg.Error(1)
+}
+
 func TestGetAllMotorVehicle_Success(t *testing.T) {
 	mockRepo := new(mockMotorVehicleRepository)
 
 	expected := []motorVehicleDto.MotorVehicle{expectedMotorVehicleById}
 
-	mockRepo.On("RetrieveAllMotorVehicle").Return(expected, nil)
+	expectedPage := queryspec.Page{Limit: 20, HasMore: true, NextCursor: "next"}
+
+	mockRepo.On("RetrieveAllMotorVehicle", "", queryspec.Spec{}).Return(expected, expectedPage, nil)
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
-	result, err := usecase.GetAllMotorVehicle()
+	result, page, err := usecase.GetAllMotorVehicle("", queryspec.Spec{})
 
 	mockRepo.AssertExpectations(t)
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
+	assert.Equal(t, expectedPage, page)
 }
 
 func TestGetAllMotorVehicle_Fail(t *testing.T) {
@@ -77,11 +103,11 @@ func TestGetAllMotorVehicle_Fail(t *testing.T) {
 	expected := []motorVehicleDto.MotorVehicle{expectedMotorVehicleById}
 	expectedError := errors.New("mock error")
 
-	mockRepo.On("RetrieveAllMotorVehicle").Return(expected, expectedError)
+	mockRepo.On("RetrieveAllMotorVehicle", "", queryspec.Spec{}).Return(expected, queryspec.Page{}, expectedError)
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
-	_, err := usecase.GetAllMotorVehicle()
+	_, _, err := usecase.GetAllMotorVehicle("", queryspec.Spec{})
 
 	mockRepo.AssertExpectations(t)
 	assert.EqualError(t, err, expectedError.Error())
@@ -94,7 +120,7 @@ func TestGetMotorVehicleById_Success(t *testing.T) {
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
-	result, err := usecase.GetMotorVehicleById(expectedMotorVehicleById.Id)
+	result, err := usecase.GetMotorVehicleById(expectedMotorVehicleById.Id, "")
 
 	mockRepo.AssertExpectations(t)
 	assert.NoError(t, err)
@@ -110,12 +136,25 @@ func TestGetMotorVehicleById_Fail(t *testing.T) {
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
-	_, err := usecase.GetMotorVehicleById(expectedMotorVehicleById.Id)
+	_, err := usecase.GetMotorVehicleById(expectedMotorVehicleById.Id, "")
 
 	mockRepo.AssertExpectations(t)
 	assert.EqualError(t, err, expectedError.Error())
 }
 
+func TestGetMotorVehicleById_FailOtherBranch(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	mockRepo.On("RetrieveMotorVehicleById", expectedMotorVehicleById.Id).Return(expectedMotorVehicleById, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.GetMotorVehicleById(expectedMotorVehicleById.Id, "other-branch")
+
+	mockRepo.AssertExpectations(t)
+	assert.EqualError(t, err, "1")
+}
+
 func TestCreateMotorVehicle_Success(t *testing.T) {
 	mockRepo := new(mockMotorVehicleRepository)
 
@@ -129,7 +168,7 @@ func TestCreateMotorVehicle_Success(t *testing.T) {
 	}
 
 	mockRepo.On("InsertMotorVehicle", expectedMotorVehicleById).Return(expectedMotorVehicleById, nil)
-	mockRepo.On("CheckPlatMotor", expected.Plat).Return(true, nil)
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
@@ -153,7 +192,7 @@ func TestCreateMotorVehicle_Fail(t *testing.T) {
 	}
 	expectedError := errors.New("mock error")
 
-	mockRepo.On("CheckPlatMotor", expected.Plat).Return(true, nil)
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
 	mockRepo.On("InsertMotorVehicle", expectedMotorVehicleById).Return(expectedMotorVehicleById, expectedError)
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
@@ -164,6 +203,54 @@ func TestCreateMotorVehicle_Fail(t *testing.T) {
 	assert.EqualError(t, err, expectedError.Error())
 }
 
+func TestCreateMotorVehicle_NormalizesPlate(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	expected := motorVehicleDto.CreateMotorVehicle{
+		Name:           "Vario",
+		Type:           "MATIC",
+		Price:          50000,
+		Plat:           " ba1234i ",
+		ProductionYear: "2023",
+		Status:         "AVAILABLE",
+	}
+
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
+	mockRepo.On("InsertMotorVehicle", expectedMotorVehicleById).Return(expectedMotorVehicleById, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	result, err := usecase.CreateMotorVehicle(expected)
+
+	mockRepo.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "BA 1234 I", result.Plat)
+}
+
+func TestCreateMotorVehicle_FailPlateTaken(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	expected := motorVehicleDto.CreateMotorVehicle{
+		Name:           "Vario",
+		Type:           "MATIC",
+		Price:          50000,
+		Plat:           "BA 1234 I",
+		ProductionYear: "2023",
+		Status:         "AVAILABLE",
+	}
+	expectedError := errors.New(`pq: duplicate key value violates unique constraint "motor_vehicle_plat_key_idx"`)
+
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
+	mockRepo.On("InsertMotorVehicle", expectedMotorVehicleById).Return(motorVehicleDto.MotorVehicle{}, expectedError)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.CreateMotorVehicle(expected)
+
+	mockRepo.AssertExpectations(t)
+	assert.EqualError(t, err, "1")
+}
+
 func TestUpdateMotorVehicle_Success(t *testing.T) {
 	mockRepo := new(mockMotorVehicleRepository)
 
@@ -178,7 +265,7 @@ func TestUpdateMotorVehicle_Success(t *testing.T) {
 
 	mockRepo.On("RetrieveMotorVehicleById", expectedMotorVehicleById.Id).Return(expectedMotorVehicleById, nil)
 
-	mockRepo.On("ChangeMotorVehicle", expectedMotorVehicleById.Id, expectedMotorVehicleById).Return(expectedMotorVehicleById, nil)
+	mockRepo.On("ChangeMotorVehicle", expectedMotorVehicleById.Id, expectedMotorVehicleById, "", "updated").Return(expectedMotorVehicleById, nil)
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
@@ -189,6 +276,31 @@ func TestUpdateMotorVehicle_Success(t *testing.T) {
 	assert.Equal(t, expectedMotorVehicleById, result)
 }
 
+func TestUpdateMotorVehicle_ClearHourlyPrice(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	current := expectedMotorVehicleById
+	current.HourlyPriceOverride = 8000
+	hourlyPrice := 0
+	input := motorVehicleDto.UpdateMotorVehicle{
+		HourlyPrice:    &hourlyPrice,
+		Plat:           "BA1234I",
+		ProductionYear: "2023",
+		Status:         "AVAILABLE",
+	}
+
+	mockRepo.On("RetrieveMotorVehicleById", expectedMotorVehicleById.Id).Return(current, nil)
+	mockRepo.On("ChangeMotorVehicle", expectedMotorVehicleById.Id, expectedMotorVehicleById, "", "updated").Return(expectedMotorVehicleById, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.UpdateMotorVehicle(expectedMotorVehicleById.Id, input)
+
+	mockRepo.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mockRepo.changed.HourlyPriceOverride)
+}
+
 func TestUpdateMotorVehicle_fail(t *testing.T) {
 	mockRepo := new(mockMotorVehicleRepository)
 
@@ -204,7 +316,7 @@ func TestUpdateMotorVehicle_fail(t *testing.T) {
 
 	mockRepo.On("RetrieveMotorVehicleById", expectedMotorVehicleById.Id).Return(expectedMotorVehicleById, nil)
 
-	mockRepo.On("ChangeMotorVehicle", expectedMotorVehicleById.Id, expectedMotorVehicleById).Return(expectedMotorVehicleById, expectedError)
+	mockRepo.On("ChangeMotorVehicle", expectedMotorVehicleById.Id, expectedMotorVehicleById, "", "updated").Return(expectedMotorVehicleById, expectedError)
 
 	usecase := NewMotorVehicleUsecase(mockRepo)
 
@@ -266,3 +378,155 @@ func TestDeleteMotorVehicle_Fail(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	assert.EqualError(t, err, expectedError.Error())
 }
+
+func TestGetMotorVehicleUsage_Success(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	expected := motorVehicleDto.MotorVehicleUsage{MotorVehicleID: expectedMotorVehicleById.Id, Odometer: 1450, TotalDistance: 250}
+	mockRepo.On("RetrieveMotorVehicleUsage", expectedMotorVehicleById.Id).Return(expected, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	usage, err := usecase.GetMotorVehicleUsage(expectedMotorVehicleById.Id)
+
+	mockRepo.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, usage)
+}
+
+func TestGetMotorVehicleUsage_NotFound(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	mockRepo.On("RetrieveMotorVehicleUsage", "x").Return(motorVehicleDto.MotorVehicleUsage{}, errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.GetMotorVehicleUsage("x")
+
+	mockRepo.AssertExpectations(t)
+	assert.EqualError(t, err, "1")
+}
+
+func TestGetMotorVehicleHistory_NotFound(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	mockRepo.On("RetrieveMotorVehicleHistory", "x").Return(motorVehicleDto.MotorVehicleHistory{}, errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.GetMotorVehicleHistory("x")
+
+	mockRepo.AssertExpectations(t)
+	assert.EqualError(t, err, "1")
+}
+
+var fleetRecords = [][]string{
+	{"name", "type", "price", "plat", "production_year", "status", "odometer"},
+	{"Vario", "MATIC", "50000", "BA1234I", "2023", "AVAILABLE", "1200"},
+	{"", "", "", "", "", "", ""},
+	{"Beat", "MATIC", "45000", "BA5678I", "2022", "AVAILABLE", ""},
+}
+
+func TestImportMotorVehicle_Success(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	expectedMotors := []motorVehicleDto.MotorVehicle{
+		{Name: "Vario", Type: "MATIC", PriceOverride: 50000, Plat: "BA 1234 I", ProductionYear: "2023", Status: "AVAILABLE", Odometer: 1200},
+		{Name: "Beat", Type: "MATIC", PriceOverride: 45000, Plat: "BA 5678 I", ProductionYear: "2022", Status: "AVAILABLE"},
+	}
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
+	mockRepo.On("CheckPlatMotor", "BA 5678 I").Return(true, nil)
+	mockRepo.On("InsertMotorVehicles", expectedMotors, false).Return(0, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	result, err := usecase.ImportMotorVehicle(fleetRecords, false)
+
+	mockRepo.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Equal(t, motorVehicleDto.MotorVehicleImport{Rows: 2, Imported: 2}, result)
+}
+
+func TestImportMotorVehicle_RowErrors(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	records := [][]string{
+		{"name", "type", "price", "plat", "production_year", "status"},
+		{"Vario", "MATIC", "lima puluh", "BA1234I", "2023", "AVAILABLE"},
+		{"Vario", "MATIC", "50000", "BA1234I", "2023", "RUSAK"},
+		{"Beat", "MATIC", "45000", "BA5678I", "2022", "AVAILABLE"},
+	}
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
+	mockRepo.On("CheckPlatMotor", "BA 5678 I").Return(false, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	result, err := usecase.ImportMotorVehicle(records, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Rows)
+	assert.Equal(t, []motorVehicleDto.ImportError{
+		{Row: 2, Column: "price", Message: "field is not number"},
+		{Row: 3, Column: "status", Message: "AVAILABLE, NOT_AVAILABLE or IN_MAINTENANCE status only"},
+		{Row: 3, Column: "plat", Message: "the license plate is repeated from row 2"},
+		{Row: 4, Column: "plat", Message: "the license plate is already registered to another vehicle"},
+	}, result.Errors)
+	mockRepo.AssertNotCalled(t, "InsertMotorVehicles", mock.Anything, mock.Anything)
+}
+
+func TestImportMotorVehicle_DryRunVehicleModelNotFound(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	records := [][]string{
+		{"vehicle_model_id", "plat", "production_year", "status"},
+		{"11111111-1111-1111-1111-111111111111", "BA1234I", "2023", "AVAILABLE"},
+	}
+	mockRepo.On("CheckPlatMotor", "BA 1234 I").Return(true, nil)
+	mockRepo.On("InsertMotorVehicles", mock.Anything, true).Return(0, errors.New(`pq: insert or update on table "motor_vehicle" violates foreign key constraint "motor_vehicle_vehicle_model_id_fkey"`))
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	result, err := usecase.ImportMotorVehicle(records, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []motorVehicleDto.ImportError{{Row: 2, Column: "vehicle_model_id", Message: "Vehicle model not found"}}, result.Errors)
+	assert.Equal(t, 0, result.Imported)
+}
+
+func TestImportMotorVehicle_FailedUnknownColumn(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.ImportMotorVehicle([][]string{{"plat", "colour"}, {"BA1234I", "red"}}, false)
+
+	assert.EqualError(t, err, "4")
+}
+
+func TestImportMotorVehicle_FailedNoRows(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	_, err := usecase.ImportMotorVehicle([][]string{{"plat"}, {""}}, false)
+
+	assert.EqualError(t, err, "5")
+}
+
+func TestExportMotorVehicle_Success(t *testing.T) {
+	mockRepo := new(mockMotorVehicleRepository)
+
+	unit := motorVehicleDto.MotorVehicle{Name: "Honda CT 125", Type: "KOPLING", Price: 150000, Plat: "BA 9999 I", ProductionYear: "2024", Status: "AVAILABLE", VehicleModelID: "11111111-1111-1111-1111-111111111111", HourlyPriceOverride: 20000}
+	mockRepo.On("RetrieveMotorVehicleExport").Return([]motorVehicleDto.MotorVehicle{expectedMotorVehicleById, unit}, nil)
+
+	usecase := NewMotorVehicleUsecase(mockRepo)
+
+	records, err := usecase.ExportMotorVehicle()
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		fleetColumns,
+		{"", "Vario", "MATIC", "0", "0", "BA 1234 I", "2023", "AVAILABLE", "", "0", "0", "0"},
+		{"11111111-1111-1111-1111-111111111111", "", "", "0", "20000", "BA 9999 I", "2024", "AVAILABLE", "", "0", "0", "0"},
+	}, records)
+}
@@ -0,0 +1,19 @@
+package overdue
+
+import (
+	"bike-rent-express/model/dto/overdueDto"
+	"time"
+)
+
+// JobName is the scheduler job marking overdue rentals, at most one replica runs it at a time
+const JobName = "overdue-rental"
+
+type (
+	OverdueRepository interface {
+		MarkOverdue(now time.Time, lateFeePercent int) ([]overdueDto.OverdueTransaction, error)
+	}
+
+	OverdueUsecase interface {
+		ProcessOverdue() ([]overdueDto.OverdueTransaction, error)
+	}
+)
@@ -0,0 +1,107 @@
+package overdueRepository
+
+import (
+	"bike-rent-express/model/dto/overdueDto"
+	"bike-rent-express/pkg/scheduler"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/overdue"
+	"database/sql"
+	"math"
+	"time"
+)
+
+type overdueRepository struct {
+	db *sql.DB
+}
+
+func NewOverdueRepository(db *sql.DB) overdue.OverdueRepository {
+	return &overdueRepository{db}
+}
+
+// MarkOverdue flags active rentals past their end date and recalculates the late fee of
+// every overdue rental. An overdue rental blocks its customer from renting through the
+// rental eligibility check. It returns only the rentals that became overdue in this run, and
+// nothing while another replica runs it.
+func (o *overdueRepository) MarkOverdue(now time.Time, lateFeePercent int) ([]overdueDto.OverdueTransaction, error) {
+	var newlyOverdue []overdueDto.OverdueTransaction
+
+	tx, err := o.db.Begin()
+	if err != nil {
+		return newlyOverdue, err
+	}
+
+	locked, err := scheduler.TryLock(tx, overdue.JobName)
+	if err != nil || !locked {
+		tx.Rollback()
+		return newlyOverdue, err
+	}
+
+	query := "UPDATE transaction SET status = 'OVERDUE', updated_at = CURRENT_TIMESTAMP WHERE status IN ('ACTIVE', 'PICKED_UP') AND end_date < $1 RETURNING id;"
+	rows, err := tx.Query(query, now)
+	if err != nil {
+		tx.Rollback()
+		return newlyOverdue, err
+	}
+
+	marked := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return newlyOverdue, err
+		}
+		marked[id] = true
+	}
+	rows.Close()
+
+	// the late fee is the rental price of the started hours past the end date
+	type overdueRental struct {
+		transaction overdueDto.OverdueTransaction
+		endDate     time.Time
+		dailyPrice  int
+		hourlyPrice int
+	}
+
+	query = "SELECT t.id, t.user_id, t.motor_vehicle_id, t.end_date, " + utils.UnitPrices + " FROM transaction t JOIN " + utils.UnitModelJoin + " ON mv.id = t.motor_vehicle_id WHERE t.status = 'OVERDUE' FOR UPDATE OF t;"
+	rows, err = tx.Query(query)
+	if err != nil {
+		tx.Rollback()
+		return newlyOverdue, err
+	}
+
+	var rentals []overdueRental
+	for rows.Next() {
+		var rental overdueRental
+		if err := rows.Scan(&rental.transaction.ID, &rental.transaction.UserID, &rental.transaction.MotorVehicleId, &rental.endDate, &rental.dailyPrice, &rental.hourlyPrice); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return newlyOverdue, err
+		}
+		rentals = append(rentals, rental)
+	}
+	rows.Close()
+
+	query = "UPDATE transaction SET late_fee = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;"
+	for _, rental := range rentals {
+		hours := int(math.Ceil(now.Sub(rental.endDate).Hours()))
+		transaction := rental.transaction
+		transaction.EndDate = rental.endDate.Format(time.RFC3339Nano)
+		transaction.LateFee = utils.RentalPrice(hours, rental.dailyPrice, rental.hourlyPrice) * lateFeePercent / 100
+
+		if _, err := tx.Exec(query, transaction.LateFee, transaction.ID); err != nil {
+			tx.Rollback()
+			return newlyOverdue, err
+		}
+
+		if marked[transaction.ID] {
+			newlyOverdue = append(newlyOverdue, transaction)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return newlyOverdue, err
+	}
+
+	return newlyOverdue, nil
+}
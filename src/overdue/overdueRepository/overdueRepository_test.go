@@ -0,0 +1,128 @@
+package overdueRepository
+
+import (
+	"bike-rent-express/model/dto/overdueDto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+
+var expectOverdueTransaction = overdueDto.OverdueTransaction{
+	ID:             "621dfcb6-06df-4420-b98e-3ec04def9547",
+	UserID:         "f4884dfc-7ef3-4d84-b77e-4fb930069da5",
+	MotorVehicleId: "907698c8-ae04-47b2-a7b9-68c46690c3f8",
+	EndDate:        "2024-03-08T00:00:00Z",
+	LateFee:        300000,
+}
+
+func TestMarkOverdue_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewOverdueRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\);").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+
+	query := "UPDATE transaction SET status = 'OVERDUE'(.+) RETURNING id;"
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(expectOverdueTransaction.ID)
+	mock.ExpectQuery(query).WithArgs(now).WillReturnRows(rows)
+
+	// the second rental was already overdue before this run, its fee is updated but no event is expected
+	query = "SELECT t.id, (.+) FROM transaction t JOIN motor_vehicle mv (.+) WHERE t.status = 'OVERDUE' FOR UPDATE OF t;"
+	rows = sqlmock.NewRows([]string{"id", "user_id", "motor_vehicle_id", "end_date", "price", "hourly_price"}).
+		AddRow(expectOverdueTransaction.ID, expectOverdueTransaction.UserID, expectOverdueTransaction.MotorVehicleId, time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), 100000, 0).
+		AddRow("1", "2", "3", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 135000, 10000)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	// 56 hours past the end are three started days, 224 hours are nine days and eight hours
+	query = "UPDATE transaction SET late_fee = \\$1, (.+) WHERE id = \\$2;"
+	mock.ExpectExec(query).WithArgs(expectOverdueTransaction.LateFee, expectOverdueTransaction.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(1295000, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	result, err := repository.MarkOverdue(now, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, []overdueDto.OverdueTransaction{expectOverdueTransaction}, result)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkOverdue_FailMarkTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewOverdueRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\);").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+
+	query := "UPDATE transaction SET status = 'OVERDUE'(.+) RETURNING id;"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	mock.ExpectRollback()
+
+	result, err := repository.MarkOverdue(now, 100)
+	assert.Error(t, err)
+	assert.Empty(t, result)
+}
+
+func TestMarkOverdue_FailAccrueLateFee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewOverdueRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\);").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+
+	query := "UPDATE transaction SET status = 'OVERDUE'(.+) RETURNING id;"
+	rows := sqlmock.NewRows([]string{"id"})
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	query = "SELECT t.id, (.+) FROM transaction t JOIN motor_vehicle mv (.+) WHERE t.status = 'OVERDUE' FOR UPDATE OF t;"
+	rows = sqlmock.NewRows([]string{"id", "user_id", "motor_vehicle_id", "end_date", "price", "hourly_price"}).
+		AddRow(expectOverdueTransaction.ID, expectOverdueTransaction.UserID, expectOverdueTransaction.MotorVehicleId, time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), 100000, 0)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	query = "UPDATE transaction SET late_fee = \\$1, (.+) WHERE id = \\$2;"
+	mock.ExpectExec(query).WillReturnError(errors.New("error sql"))
+
+	mock.ExpectRollback()
+
+	_, err = repository.MarkOverdue(now, 100)
+	assert.Error(t, err)
+}
+
+func TestMarkOverdue_LockedByAnotherReplica(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewOverdueRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\);").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	result, err := repository.MarkOverdue(now, 100)
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
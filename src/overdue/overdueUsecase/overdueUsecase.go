@@ -0,0 +1,41 @@
+package overdueUsecase
+
+import (
+	"bike-rent-express/model/dto/overdueDto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/pkg/event"
+	"bike-rent-express/src/overdue"
+)
+
+type overdueUsecase struct {
+	overdueRepository overdue.OverdueRepository
+	publisher         event.Publisher
+	clock             clock.Clock
+	lateFeePercent    int
+}
+
+func NewOverdueUsecase(overdueRepository overdue.OverdueRepository, publisher event.Publisher, clk clock.Clock, lateFeePercent int) overdue.OverdueUsecase {
+	return &overdueUsecase{overdueRepository, publisher, clk, lateFeePercent}
+}
+
+func (o *overdueUsecase) ProcessOverdue() ([]overdueDto.OverdueTransaction, error) {
+	now := o.clock.Now()
+
+	overdueTransactions, err := o.overdueRepository.MarkOverdue(now, o.lateFeePercent)
+	if err != nil {
+		return overdueTransactions, err
+	}
+
+	for _, transaction := range overdueTransactions {
+		err := o.publisher.Publish(event.Event{
+			Name:       event.TransactionOverdue,
+			Payload:    transaction,
+			OccurredAt: now,
+		})
+		if err != nil {
+			return overdueTransactions, err
+		}
+	}
+
+	return overdueTransactions, nil
+}
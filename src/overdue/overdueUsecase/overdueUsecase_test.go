@@ -0,0 +1,111 @@
+package overdueUsecase
+
+import (
+	"bike-rent-express/model/dto/overdueDto"
+	"bike-rent-express/pkg/event"
+	"bike-rent-express/src/overdue"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var now = time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+
+var expectOverdueTransaction = overdueDto.OverdueTransaction{
+	ID:             "621dfcb6-06df-4420-b98e-3ec04def9547",
+	UserID:         "f4884dfc-7ef3-4d84-b77e-4fb930069da5",
+	MotorVehicleId: "907698c8-ae04-47b2-a7b9-68c46690c3f8",
+	EndDate:        "2024-03-08T00:00:00Z",
+	LateFee:        300000,
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f fakeClock) Now() time.Time {
+	return f.now
+}
+
+type mockOverdueRepository struct {
+	mock.Mock
+}
+
+func (m *mockOverdueRepository) MarkOverdue(now time.Time, lateFeePercent int) ([]overdueDto.OverdueTransaction, error) {
+	args := m.Called(now, lateFeePercent)
+	return args.Get(0).([]overdueDto.OverdueTransaction), args.Error(1)
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(e event.Event) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+type OverdueUsecaseTestSuite struct {
+	suite.Suite
+	mockOverdueRepository *mockOverdueRepository
+	mockPublisher         *mockPublisher
+	overdueUsecase        overdue.OverdueUsecase
+}
+
+func (suite *OverdueUsecaseTestSuite) SetupTest() {
+	suite.mockOverdueRepository = new(mockOverdueRepository)
+	suite.mockPublisher = new(mockPublisher)
+	suite.overdueUsecase = NewOverdueUsecase(suite.mockOverdueRepository, suite.mockPublisher, fakeClock{now}, 150)
+}
+
+func (suite *OverdueUsecaseTestSuite) TestProcessOverdue_Success() {
+	overdueTransactions := []overdueDto.OverdueTransaction{expectOverdueTransaction}
+	expectEvent := event.Event{
+		Name:       event.TransactionOverdue,
+		Payload:    expectOverdueTransaction,
+		OccurredAt: now,
+	}
+
+	suite.mockOverdueRepository.On("MarkOverdue", now, 150).Return(overdueTransactions, nil)
+	suite.mockPublisher.On("Publish", expectEvent).Return(nil)
+
+	actual, err := suite.overdueUsecase.ProcessOverdue()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), overdueTransactions, actual)
+	suite.mockPublisher.AssertNumberOfCalls(suite.T(), "Publish", 1)
+}
+
+func (suite *OverdueUsecaseTestSuite) TestProcessOverdue_NothingOverdue() {
+	suite.mockOverdueRepository.On("MarkOverdue", now, 150).Return([]overdueDto.OverdueTransaction{}, nil)
+
+	actual, err := suite.overdueUsecase.ProcessOverdue()
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), actual)
+	suite.mockPublisher.AssertNotCalled(suite.T(), "Publish", mock.Anything)
+}
+
+func (suite *OverdueUsecaseTestSuite) TestProcessOverdue_FailedMarkOverdue() {
+	suite.mockOverdueRepository.On("MarkOverdue", now, 150).Return([]overdueDto.OverdueTransaction{}, errors.New("error"))
+
+	_, err := suite.overdueUsecase.ProcessOverdue()
+	assert.Error(suite.T(), err)
+	suite.mockPublisher.AssertNotCalled(suite.T(), "Publish", mock.Anything)
+}
+
+func (suite *OverdueUsecaseTestSuite) TestProcessOverdue_FailedPublish() {
+	overdueTransactions := []overdueDto.OverdueTransaction{expectOverdueTransaction}
+
+	suite.mockOverdueRepository.On("MarkOverdue", now, 150).Return(overdueTransactions, nil)
+	suite.mockPublisher.On("Publish", mock.Anything).Return(errors.New("error"))
+
+	_, err := suite.overdueUsecase.ProcessOverdue()
+	assert.Error(suite.T(), err)
+}
+
+func TestOverdueUsecase(t *testing.T) {
+	suite.Run(t, new(OverdueUsecaseTestSuite))
+}
@@ -0,0 +1,118 @@
+package rentalTierDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/rentalTierDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/rentalTier"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rentalTierDelivery struct {
+	rentalTierUC rentalTier.RentalTierUsecase
+}
+
+func NewRentalTierDelivery(v1Group *gin.RouterGroup, rentalTierUC rentalTier.RentalTierUsecase) {
+	handler := rentalTierDelivery{rentalTierUC}
+
+	rentalTierGroup := v1Group.Group("/rental-tiers")
+	{
+		rentalTierGroup.POST("", middleware.JWTAuth("ADMIN"), handler.CreateRentalTier)
+		rentalTierGroup.GET("", middleware.JWTAuth("ADMIN"), handler.GetAllRentalTier)
+		rentalTierGroup.PUT("/:id", middleware.JWTAuth("ADMIN"), handler.UpdateRentalTier)
+	}
+
+	v1Group.PUT("/users/:id/rental-limit", middleware.JWTAuth("ADMIN"), handler.UpdateUserRentalLimit)
+}
+
+func (r *rentalTierDelivery) CreateRentalTier(ctx *gin.Context) {
+	var request rentalTierDto.RentalTierRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "01", "01")
+		return
+	}
+
+	tier, err := r.rentalTierUC.CreateRentalTier(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(ctx, nil, "rental tier name already exists", "01", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(ctx, tier, "Rental tier created", "01", "01")
+}
+
+func (r *rentalTierDelivery) GetAllRentalTier(ctx *gin.Context) {
+	tiers, err := r.rentalTierUC.GetAllRentalTier()
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	if len(tiers) == 0 {
+		json.NewResponseSuccess(ctx, nil, "Data empty", "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, tiers, "Success get all rental tier", "02", "02")
+}
+
+func (r *rentalTierDelivery) UpdateRentalTier(ctx *gin.Context) {
+	var request rentalTierDto.RentalTierRequest
+	id := ctx.Param("id")
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "03", "01")
+		return
+	}
+
+	tier, err := r.rentalTierUC.UpdateRentalTier(id, request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "03", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "rental tier name already exists", "03", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, tier, "Rental tier updated", "03", "02")
+}
+
+func (r *rentalTierDelivery) UpdateUserRentalLimit(ctx *gin.Context) {
+	var request rentalTierDto.UserRentalLimitRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "04", "01")
+		return
+	}
+	request.UserID = ctx.Param("id")
+
+	if err := r.rentalTierUC.UpdateUserRentalLimit(request); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "User not found", "04", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "rental tier not found", "04", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "04", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "User rental limit updated", "04", "02")
+}
@@ -0,0 +1,201 @@
+package rentalTierDelivery
+
+import (
+	"bike-rent-express/model/dto/rentalTierDto"
+	"bike-rent-express/pkg/middleware"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectRentalTier = rentalTierDto.RentalTier{
+	ID:               "1",
+	Name:             "gold",
+	MaxActiveRentals: 5,
+	CreatedAt:        "0000",
+	UpdatedAt:        "0000",
+}
+
+var rentalTierRequest = rentalTierDto.RentalTierRequest{
+	Name:             "gold",
+	MaxActiveRentals: 5,
+}
+
+type mockRentalTierUC struct {
+	mock.Mock
+}
+
+func (m *mockRentalTierUC) CreateRentalTier(tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	args := m.Called(tier)
+	return args.Get(0).(rentalTierDto.RentalTier), args.Error(1)
+}
+
+func (m *mockRentalTierUC) GetAllRentalTier() ([]rentalTierDto.RentalTier, error) {
+	args := m.Called()
+	return args.Get(0).([]rentalTierDto.RentalTier), args.Error(1)
+}
+
+func (m *mockRentalTierUC) UpdateRentalTier(id string, tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	args := m.Called(id, tier)
+	return args.Get(0).(rentalTierDto.RentalTier), args.Error(1)
+}
+
+func (m *mockRentalTierUC) UpdateUserRentalLimit(request rentalTierDto.UserRentalLimitRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+type RentalTierDeliveryTestSuite struct {
+	suite.Suite
+	mockRentalTierUC *mockRentalTierUC
+	router           *gin.Engine
+	accessToken      string
+}
+
+func (suite *RentalTierDeliveryTestSuite) SetupTest() {
+	suite.mockRentalTierUC = new(mockRentalTierUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewRentalTierDelivery(v1, suite.mockRentalTierUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.accessToken = "Bearer " + token
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestCreateRentalTier_Success() {
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Rental tier created","data":{"id":"1","name":"gold","max_active_rentals":5,"created_at":"0000","updated_at":"0000"}}`
+	suite.mockRentalTierUC.On("CreateRentalTier", rentalTierRequest).Return(expectRentalTier, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(rentalTierRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/rental-tiers", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestCreateRentalTier_FailedBind() {
+	expectResponse := `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"MaxActiveRentals","message":"field is below the minimum value"}]}`
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(rentalTierDto.RentalTierRequest{Name: "gold", MaxActiveRentals: -1})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/rental-tiers", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestCreateRentalTier_FailedDuplicateName() {
+	expectResponse := `{"responseCode":"4000102","responseMessage":"rental tier name already exists"}`
+	suite.mockRentalTierUC.On("CreateRentalTier", rentalTierRequest).Return(rentalTierDto.RentalTier{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(rentalTierRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/rental-tiers", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestGetAllRentalTier_Success() {
+	expectResponse := `{"responseCode":"2000202","responseMessage":"Success get all rental tier","data":[{"id":"1","name":"gold","max_active_rentals":5,"created_at":"0000","updated_at":"0000"}]}`
+	suite.mockRentalTierUC.On("GetAllRentalTier").Return([]rentalTierDto.RentalTier{expectRentalTier}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/rental-tiers", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestGetAllRentalTier_Failed() {
+	expectResponse := `{"responseCode":"5000201","responseMessage":"internal server error","error":"error"}`
+	suite.mockRentalTierUC.On("GetAllRentalTier").Return([]rentalTierDto.RentalTier{}, errors.New("error"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/rental-tiers", nil)
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 500, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestUpdateRentalTier_Success() {
+	expectResponse := `{"responseCode":"2000302","responseMessage":"Rental tier updated","data":{"id":"1","name":"gold","max_active_rentals":5,"created_at":"0000","updated_at":"0000"}}`
+	suite.mockRentalTierUC.On("UpdateRentalTier", expectRentalTier.ID, rentalTierRequest).Return(expectRentalTier, nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(rentalTierRequest)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/rental-tiers/"+expectRentalTier.ID, bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestUpdateRentalTier_FailedNotFound() {
+	expectResponse := `{"responseCode":"2000301","responseMessage":"Data not found"}`
+	suite.mockRentalTierUC.On("UpdateRentalTier", expectRentalTier.ID, rentalTierRequest).Return(rentalTierDto.RentalTier{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(rentalTierRequest)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/rental-tiers/"+expectRentalTier.ID, bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestUpdateUserRentalLimit_Success() {
+	expectResponse := `{"responseCode":"2000402","responseMessage":"User rental limit updated"}`
+	maxActiveRentals := 2
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1", MaxActiveRentals: &maxActiveRentals}
+	suite.mockRentalTierUC.On("UpdateUserRentalLimit", request).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/1/rental-limit", bytes.NewBufferString(`{"max_active_rentals":2}`))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *RentalTierDeliveryTestSuite) TestUpdateUserRentalLimit_FailedTierNotFound() {
+	expectResponse := `{"responseCode":"4000402","responseMessage":"rental tier not found"}`
+	tierId := "0b6a3e2c-5b7e-4a0e-9d5a-3f1d2c4b5a6e"
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1", TierID: &tierId}
+	suite.mockRentalTierUC.On("UpdateUserRentalLimit", request).Return(errors.New("2"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/1/rental-limit", bytes.NewBufferString(`{"tier_id":"`+tierId+`"}`))
+	req.Header.Add("Authorization", suite.accessToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestRentalTierDelivery(t *testing.T) {
+	suite.Run(t, new(RentalTierDeliveryTestSuite))
+}
@@ -0,0 +1,19 @@
+package rentalTier
+
+import "bike-rent-express/model/dto/rentalTierDto"
+
+type (
+	RentalTierRepository interface {
+		Add(tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error)
+		GetAll() ([]rentalTierDto.RentalTier, error)
+		Update(id string, tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error)
+		UpdateUserRentalLimit(request rentalTierDto.UserRentalLimitRequest) error
+	}
+
+	RentalTierUsecase interface {
+		CreateRentalTier(tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error)
+		GetAllRentalTier() ([]rentalTierDto.RentalTier, error)
+		UpdateRentalTier(id string, tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error)
+		UpdateUserRentalLimit(request rentalTierDto.UserRentalLimitRequest) error
+	}
+)
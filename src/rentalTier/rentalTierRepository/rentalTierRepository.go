@@ -0,0 +1,76 @@
+package rentalTierRepository
+
+import (
+	"bike-rent-express/model/dto/rentalTierDto"
+	"bike-rent-express/src/rentalTier"
+	"database/sql"
+)
+
+type rentalTierRepository struct {
+	db *sql.DB
+}
+
+func NewRentalTierRepository(db *sql.DB) rentalTier.RentalTierRepository {
+	return &rentalTierRepository{db}
+}
+
+func (r *rentalTierRepository) Add(tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	var newTier rentalTierDto.RentalTier
+	query := "INSERT INTO rental_tier(name, max_active_rentals) VALUES ($1, $2) RETURNING id, name, max_active_rentals, created_at, updated_at;"
+	err := r.db.QueryRow(query, tier.Name, tier.MaxActiveRentals).Scan(&newTier.ID, &newTier.Name, &newTier.MaxActiveRentals, &newTier.CreatedAt, &newTier.UpdatedAt)
+	if err != nil {
+		return newTier, err
+	}
+
+	return newTier, nil
+}
+
+func (r *rentalTierRepository) GetAll() ([]rentalTierDto.RentalTier, error) {
+	query := "SELECT id, name, max_active_rentals, created_at, updated_at FROM rental_tier ORDER BY max_active_rentals;"
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tiers []rentalTierDto.RentalTier
+	for rows.Next() {
+		var tier rentalTierDto.RentalTier
+		if err := rows.Scan(&tier.ID, &tier.Name, &tier.MaxActiveRentals, &tier.CreatedAt, &tier.UpdatedAt); err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, nil
+}
+
+func (r *rentalTierRepository) Update(id string, tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	var updatedTier rentalTierDto.RentalTier
+	query := "UPDATE rental_tier SET name = $1, max_active_rentals = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 RETURNING id, name, max_active_rentals, created_at, updated_at;"
+	err := r.db.QueryRow(query, tier.Name, tier.MaxActiveRentals, id).Scan(&updatedTier.ID, &updatedTier.Name, &updatedTier.MaxActiveRentals, &updatedTier.CreatedAt, &updatedTier.UpdatedAt)
+	if err != nil {
+		return updatedTier, err
+	}
+
+	return updatedTier, nil
+}
+
+func (r *rentalTierRepository) UpdateUserRentalLimit(request rentalTierDto.UserRentalLimitRequest) error {
+	query := "UPDATE users SET tier_id = $1, max_active_rentals = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 AND role = 'USER';"
+	result, err := r.db.Exec(query, request.TierID, request.MaxActiveRentals, request.UserID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package rentalTierRepository
+
+import (
+	"bike-rent-express/model/dto/rentalTierDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var expectRentalTier = rentalTierDto.RentalTier{
+	ID:               "1",
+	Name:             "gold",
+	MaxActiveRentals: 5,
+	CreatedAt:        "0000",
+	UpdatedAt:        "0000",
+}
+
+var rentalTierRequest = rentalTierDto.RentalTierRequest{
+	Name:             "gold",
+	MaxActiveRentals: 5,
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+
+	query := "INSERT INTO rental_tier(.+) RETURNING .+;"
+	rows := sqlmock.NewRows([]string{"id", "name", "max_active_rentals", "created_at", "updated_at"}).AddRow(expectRentalTier.ID, expectRentalTier.Name, expectRentalTier.MaxActiveRentals, expectRentalTier.CreatedAt, expectRentalTier.UpdatedAt)
+	mock.ExpectQuery(query).WithArgs(rentalTierRequest.Name, rentalTierRequest.MaxActiveRentals).WillReturnRows(rows)
+
+	actualTier, err := repository.Add(rentalTierRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectRentalTier, actualTier)
+}
+
+func TestAdd_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+
+	query := "INSERT INTO rental_tier(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	_, err = repository.Add(rentalTierRequest)
+	assert.Error(t, err)
+}
+
+func TestGetAll_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+
+	query := "SELECT (.+) FROM rental_tier"
+	rows := sqlmock.NewRows([]string{"id", "name", "max_active_rentals", "created_at", "updated_at"}).AddRow(expectRentalTier.ID, expectRentalTier.Name, expectRentalTier.MaxActiveRentals, expectRentalTier.CreatedAt, expectRentalTier.UpdatedAt)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	actualTiers, err := repository.GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []rentalTierDto.RentalTier{expectRentalTier}, actualTiers)
+}
+
+func TestGetAll_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+
+	query := "SELECT (.+) FROM rental_tier"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	actualTiers, err := repository.GetAll()
+	assert.Error(t, err)
+	assert.Nil(t, actualTiers)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+
+	query := "UPDATE rental_tier SET (.+) WHERE id = \\$3 RETURNING .+;"
+	rows := sqlmock.NewRows([]string{"id", "name", "max_active_rentals", "created_at", "updated_at"}).AddRow(expectRentalTier.ID, expectRentalTier.Name, expectRentalTier.MaxActiveRentals, expectRentalTier.CreatedAt, expectRentalTier.UpdatedAt)
+	mock.ExpectQuery(query).WithArgs(rentalTierRequest.Name, rentalTierRequest.MaxActiveRentals, expectRentalTier.ID).WillReturnRows(rows)
+
+	actualTier, err := repository.Update(expectRentalTier.ID, rentalTierRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectRentalTier, actualTier)
+}
+
+func TestUpdate_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+
+	query := "UPDATE rental_tier SET (.+) WHERE id = \\$3 RETURNING .+;"
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "max_active_rentals", "created_at", "updated_at"}))
+
+	_, err = repository.Update(expectRentalTier.ID, rentalTierRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestUpdateUserRentalLimit_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+	maxActiveRentals := 2
+	request := rentalTierDto.UserRentalLimitRequest{
+		UserID:           "1",
+		TierID:           &expectRentalTier.ID,
+		MaxActiveRentals: &maxActiveRentals,
+	}
+
+	query := "UPDATE users SET tier_id = \\$1, max_active_rentals = \\$2"
+	mock.ExpectExec(query).WithArgs(&expectRentalTier.ID, &maxActiveRentals, request.UserID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.UpdateUserRentalLimit(request)
+	assert.Nil(t, err)
+}
+
+func TestUpdateUserRentalLimit_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1"}
+
+	query := "UPDATE users SET tier_id = \\$1, max_active_rentals = \\$2"
+	mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.UpdateUserRentalLimit(request)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestUpdateUserRentalLimit_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewRentalTierRepository(db)
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1"}
+
+	query := "UPDATE users SET tier_id = \\$1, max_active_rentals = \\$2"
+	mock.ExpectExec(query).WillReturnError(errors.New("error sql"))
+
+	err = repository.UpdateUserRentalLimit(request)
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package rentalTierUsecase
+
+import (
+	"bike-rent-express/model/dto/rentalTierDto"
+	"bike-rent-express/src/rentalTier"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type rentalTierUsecase struct {
+	rentalTierRepository rentalTier.RentalTierRepository
+}
+
+func NewRentalTierUsecase(rentalTierRepository rentalTier.RentalTierRepository) rentalTier.RentalTierUsecase {
+	return &rentalTierUsecase{rentalTierRepository}
+}
+
+func (r *rentalTierUsecase) CreateRentalTier(tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	newTier, err := r.rentalTierRepository.Add(tier)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return newTier, errors.New("1")
+		}
+		return newTier, err
+	}
+
+	return newTier, nil
+}
+
+func (r *rentalTierUsecase) GetAllRentalTier() ([]rentalTierDto.RentalTier, error) {
+	return r.rentalTierRepository.GetAll()
+}
+
+func (r *rentalTierUsecase) UpdateRentalTier(id string, tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	updatedTier, err := r.rentalTierRepository.Update(id, tier)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return updatedTier, errors.New("1")
+		}
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return updatedTier, errors.New("2")
+		}
+		return updatedTier, err
+	}
+
+	return updatedTier, nil
+}
+
+func (r *rentalTierUsecase) UpdateUserRentalLimit(request rentalTierDto.UserRentalLimitRequest) error {
+	err := r.rentalTierRepository.UpdateUserRentalLimit(request)
+	if err != nil {
+		if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+			return errors.New("1")
+		}
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return errors.New("2")
+		}
+		return err
+	}
+
+	return nil
+}
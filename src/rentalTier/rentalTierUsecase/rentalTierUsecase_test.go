@@ -0,0 +1,134 @@
+package rentalTierUsecase
+
+import (
+	"bike-rent-express/model/dto/rentalTierDto"
+	"bike-rent-express/src/rentalTier"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectRentalTier = rentalTierDto.RentalTier{
+	ID:               "1",
+	Name:             "gold",
+	MaxActiveRentals: 5,
+	CreatedAt:        "0000",
+	UpdatedAt:        "0000",
+}
+
+var rentalTierRequest = rentalTierDto.RentalTierRequest{
+	Name:             "gold",
+	MaxActiveRentals: 5,
+}
+
+type mockRentalTierRepository struct {
+	mock.Mock
+}
+
+func (m *mockRentalTierRepository) Add(tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	args := m.Called(tier)
+	return args.Get(0).(rentalTierDto.RentalTier), args.Error(1)
+}
+
+func (m *mockRentalTierRepository) GetAll() ([]rentalTierDto.RentalTier, error) {
+	args := m.Called()
+	return args.Get(0).([]rentalTierDto.RentalTier), args.Error(1)
+}
+
+func (m *mockRentalTierRepository) Update(id string, tier rentalTierDto.RentalTierRequest) (rentalTierDto.RentalTier, error) {
+	args := m.Called(id, tier)
+	return args.Get(0).(rentalTierDto.RentalTier), args.Error(1)
+}
+
+func (m *mockRentalTierRepository) UpdateUserRentalLimit(request rentalTierDto.UserRentalLimitRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+type RentalTierUsecaseTestSuite struct {
+	suite.Suite
+	mockRentalTierRepository *mockRentalTierRepository
+	rentalTierUC             rentalTier.RentalTierUsecase
+}
+
+func (suite *RentalTierUsecaseTestSuite) SetupTest() {
+	suite.mockRentalTierRepository = new(mockRentalTierRepository)
+	suite.rentalTierUC = NewRentalTierUsecase(suite.mockRentalTierRepository)
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestCreateRentalTier_Success() {
+	suite.mockRentalTierRepository.On("Add", rentalTierRequest).Return(expectRentalTier, nil)
+
+	actualTier, err := suite.rentalTierUC.CreateRentalTier(rentalTierRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectRentalTier, actualTier)
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestCreateRentalTier_FailedDuplicateName() {
+	suite.mockRentalTierRepository.On("Add", rentalTierRequest).Return(rentalTierDto.RentalTier{}, errors.New(`pq: duplicate key value violates unique constraint "rental_tier_name_key"`))
+
+	_, err := suite.rentalTierUC.CreateRentalTier(rentalTierRequest)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestGetAllRentalTier_Success() {
+	suite.mockRentalTierRepository.On("GetAll").Return([]rentalTierDto.RentalTier{expectRentalTier}, nil)
+
+	actualTiers, err := suite.rentalTierUC.GetAllRentalTier()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []rentalTierDto.RentalTier{expectRentalTier}, actualTiers)
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestUpdateRentalTier_Success() {
+	suite.mockRentalTierRepository.On("Update", expectRentalTier.ID, rentalTierRequest).Return(expectRentalTier, nil)
+
+	actualTier, err := suite.rentalTierUC.UpdateRentalTier(expectRentalTier.ID, rentalTierRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectRentalTier, actualTier)
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestUpdateRentalTier_FailedNotFound() {
+	suite.mockRentalTierRepository.On("Update", expectRentalTier.ID, rentalTierRequest).Return(rentalTierDto.RentalTier{}, sql.ErrNoRows)
+
+	_, err := suite.rentalTierUC.UpdateRentalTier(expectRentalTier.ID, rentalTierRequest)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestUpdateRentalTier_FailedDuplicateName() {
+	suite.mockRentalTierRepository.On("Update", expectRentalTier.ID, rentalTierRequest).Return(rentalTierDto.RentalTier{}, errors.New(`pq: duplicate key value violates unique constraint "rental_tier_name_key"`))
+
+	_, err := suite.rentalTierUC.UpdateRentalTier(expectRentalTier.ID, rentalTierRequest)
+	assert.Equal(suite.T(), "2", err.Error())
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestUpdateUserRentalLimit_Success() {
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1", TierID: &expectRentalTier.ID}
+	suite.mockRentalTierRepository.On("UpdateUserRentalLimit", request).Return(nil)
+
+	err := suite.rentalTierUC.UpdateUserRentalLimit(request)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestUpdateUserRentalLimit_FailedUserNotFound() {
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1"}
+	suite.mockRentalTierRepository.On("UpdateUserRentalLimit", request).Return(sql.ErrNoRows)
+
+	err := suite.rentalTierUC.UpdateUserRentalLimit(request)
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *RentalTierUsecaseTestSuite) TestUpdateUserRentalLimit_FailedTierNotFound() {
+	request := rentalTierDto.UserRentalLimitRequest{UserID: "1", TierID: &expectRentalTier.ID}
+	suite.mockRentalTierRepository.On("UpdateUserRentalLimit", request).Return(errors.New(`pq: insert or update on table "users" violates foreign key constraint "users_tier_id_fkey"`))
+
+	err := suite.rentalTierUC.UpdateUserRentalLimit(request)
+	assert.Equal(suite.T(), "2", err.Error())
+}
+
+func TestRentalTierUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RentalTierUsecaseTestSuite))
+}
@@ -0,0 +1,85 @@
+package reportDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/reportDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/report"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type reportDelivery struct {
+	reportUC report.ReportUsecase
+}
+
+func NewReportDelivery(v1Group *gin.RouterGroup, reportUC report.ReportUsecase) {
+	handler := reportDelivery{reportUC}
+
+	reportGroup := v1Group.Group("/reports")
+	{
+		reportGroup.GET("/employees", middleware.JWTAuth("ADMIN"), handler.GetEmployeeReport)
+	}
+}
+
+func (r *reportDelivery) GetEmployeeReport(c *gin.Context) {
+	var request reportDto.EmployeeReportRequest
+
+	c.ShouldBindQuery(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
+		return
+	}
+
+	reports, err := r.reportUC.GetEmployeeReport(request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "to must not be before from and at most a year after it", "01", "02")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	if request.Format == reportDto.FormatCSV {
+		writeEmployeeReportCSV(c, "employee-report-"+request.From+"-"+request.To+".csv", reports)
+		return
+	}
+
+	if len(reports) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, reports, "Success get employee report", "01", "02")
+}
+
+func writeEmployeeReportCSV(c *gin.Context, filename string, reports []reportDto.EmployeeReport) {
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv")
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"employee_id", "employee_name", "period_start", "rentals_handled", "returns_processed", "extra_charges", "disputes_lost", "avg_handover_minutes"})
+	for _, employeeReport := range reports {
+		avgHandover := ""
+		if employeeReport.AvgHandoverMinutes != nil {
+			avgHandover = strconv.FormatFloat(*employeeReport.AvgHandoverMinutes, 'f', 1, 64)
+		}
+		writer.Write([]string{
+			employeeReport.EmployeeID,
+			employeeReport.EmployeeName,
+			employeeReport.PeriodStart,
+			strconv.Itoa(employeeReport.RentalsHandled),
+			strconv.Itoa(employeeReport.ReturnsProcessed),
+			strconv.Itoa(employeeReport.ExtraCharges),
+			strconv.Itoa(employeeReport.DisputesLost),
+			avgHandover,
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,107 @@
+package reportDelivery
+
+import (
+	"bike-rent-express/model/dto/reportDto"
+	"bike-rent-express/pkg/middleware"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var handover = 12.5
+
+var expectReports = []reportDto.EmployeeReport{
+	{EmployeeID: "1", EmployeeName: "dino", PeriodStart: "2024-09-01", RentalsHandled: 4, ReturnsProcessed: 3, ExtraCharges: 25000, DisputesLost: 1, AvgHandoverMinutes: &handover},
+	{EmployeeID: "2", EmployeeName: "rina", PeriodStart: "2024-09-01", RentalsHandled: 1},
+}
+
+type mockReportUC struct {
+	mock.Mock
+}
+
+func (m *mockReportUC) GetEmployeeReport(request reportDto.EmployeeReportRequest) ([]reportDto.EmployeeReport, error) {
+	args := m.Called(request)
+	return args.Get(0).([]reportDto.EmployeeReport), args.Error(1)
+}
+
+type ReportDeliveryTestSuite struct {
+	suite.Suite
+	mockReportUC *mockReportUC
+	router       *gin.Engine
+	adminToken   string
+}
+
+func (suite *ReportDeliveryTestSuite) SetupTest() {
+	suite.mockReportUC = new(mockReportUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewReportDelivery(v1, suite.mockReportUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+}
+
+func (suite *ReportDeliveryTestSuite) TestGetEmployeeReport_Success() {
+	expectResponse := `{"responseCode":"2000102","responseMessage":"Success get employee report","data":[{"employee_id":"1","employee_name":"dino","period_start":"2024-09-01","rentals_handled":4,"returns_processed":3,"extra_charges":25000,"disputes_lost":1,"avg_handover_minutes":12.5},{"employee_id":"2","employee_name":"rina","period_start":"2024-09-01","rentals_handled":1,"returns_processed":0,"extra_charges":0,"disputes_lost":0,"avg_handover_minutes":null}]}`
+	suite.mockReportUC.On("GetEmployeeReport", reportDto.EmployeeReportRequest{From: "2024-09-01", To: "2024-09-30"}).Return(expectReports, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/reports/employees?from=2024-09-01&to=2024-09-30", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *ReportDeliveryTestSuite) TestGetEmployeeReport_CSV() {
+	expectBody := "employee_id,employee_name,period_start,rentals_handled,returns_processed,extra_charges,disputes_lost,avg_handover_minutes\n" +
+		"1,dino,2024-09-01,4,3,25000,1,12.5\n" +
+		"2,rina,2024-09-01,1,0,0,0,\n"
+	request := reportDto.EmployeeReportRequest{From: "2024-09-01", To: "2024-09-30", Period: reportDto.PeriodWeek, Format: reportDto.FormatCSV}
+	suite.mockReportUC.On("GetEmployeeReport", request).Return(expectReports, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/reports/employees?from=2024-09-01&to=2024-09-30&period=week&format=csv", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), `attachment; filename="employee-report-2024-09-01-2024-09-30.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), expectBody, w.Body.String())
+}
+
+func (suite *ReportDeliveryTestSuite) TestGetEmployeeReport_FailedValidation() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/reports/employees?from=2024-09-01&period=year", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	suite.mockReportUC.AssertNotCalled(suite.T(), "GetEmployeeReport", mock.Anything)
+}
+
+func (suite *ReportDeliveryTestSuite) TestGetEmployeeReport_FailedRange() {
+	expectResponse := `{"responseCode":"4000102","responseMessage":"to must not be before from and at most a year after it"}`
+	suite.mockReportUC.On("GetEmployeeReport", reportDto.EmployeeReportRequest{From: "2024-09-30", To: "2024-09-01"}).Return([]reportDto.EmployeeReport{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/reports/employees?from=2024-09-30&to=2024-09-01", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestReportDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportDeliveryTestSuite))
+}
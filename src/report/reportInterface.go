@@ -0,0 +1,13 @@
+package report
+
+import "bike-rent-express/model/dto/reportDto"
+
+type (
+	ReportRepository interface {
+		GetEmployeeReport(request reportDto.EmployeeReportRequest) ([]reportDto.EmployeeReport, error)
+	}
+
+	ReportUsecase interface {
+		GetEmployeeReport(request reportDto.EmployeeReportRequest) ([]reportDto.EmployeeReport, error)
+	}
+)
@@ -0,0 +1,55 @@
+package reportRepository
+
+import (
+	"bike-rent-express/model/dto/reportDto"
+	"bike-rent-express/src/report"
+	"database/sql"
+)
+
+type reportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) report.ReportRepository {
+	return &reportRepository{db}
+}
+
+// GetEmployeeReport puts the rentals, returns, lost disputes and handovers of the employees on
+// one timeline and sums them per employee and period. Voided returns are left out.
+func (r *reportRepository) GetEmployeeReport(request reportDto.EmployeeReportRequest) ([]reportDto.EmployeeReport, error) {
+	var reports []reportDto.EmployeeReport
+
+	query := `WITH activity AS (
+		SELECT employee_id, start_date AS at, 1 AS rentals, 0 AS returns, 0 AS extra_charge, 0 AS disputes_lost, NULL::float8 AS handover FROM transaction WHERE employee_id IS NOT NULL
+		UNION ALL
+		SELECT employee_id, return_date::timestamptz, 0, 1, extra_charge, 0, NULL FROM motor_return WHERE employee_id IS NOT NULL AND voided_at IS NULL
+		UNION ALL
+		SELECT mr.employee_id, d.updated_at::timestamptz, 0, 0, 0, 1, NULL FROM dispute d JOIN motor_return mr ON mr.id = d.motor_return_id WHERE mr.employee_id IS NOT NULL AND d.status = 'RESOLVED' AND d.resolution IN ('PARTIAL_REFUND', 'FULL_REFUND')
+		UNION ALL
+		SELECT mp.employee_id, mp.pickup_date, 0, 0, 0, 0, EXTRACT(EPOCH FROM mp.pickup_date - t.start_date) / 60 FROM motor_pickup mp JOIN transaction t ON t.id = mp.transaction_id
+	)
+	SELECT e.id, e.name, to_char(date_trunc($1, a.at), 'YYYY-MM-DD'), SUM(a.rentals), SUM(a.returns), SUM(a.extra_charge), SUM(a.disputes_lost), ROUND(AVG(a.handover)::numeric, 1)
+	FROM activity a JOIN employee e ON e.id = a.employee_id
+	WHERE a.at >= $2::date AND a.at < $3::date + 1 AND ($4 = '' OR e.id::text = $4) AND ($5 = '' OR COALESCE(e.branch_id::text, '') = $5)
+	GROUP BY e.id, e.name, 3
+	ORDER BY 3, e.name;`
+	rows, err := r.db.Query(query, request.Period, request.From, request.To, request.EmployeeID, request.BranchID)
+	if err != nil {
+		return reports, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var employeeReport reportDto.EmployeeReport
+		var avgHandover sql.NullFloat64
+		if err := rows.Scan(&employeeReport.EmployeeID, &employeeReport.EmployeeName, &employeeReport.PeriodStart, &employeeReport.RentalsHandled, &employeeReport.ReturnsProcessed, &employeeReport.ExtraCharges, &employeeReport.DisputesLost, &avgHandover); err != nil {
+			return reports, err
+		}
+		if avgHandover.Valid {
+			employeeReport.AvgHandoverMinutes = &avgHandover.Float64
+		}
+		reports = append(reports, employeeReport)
+	}
+
+	return reports, nil
+}
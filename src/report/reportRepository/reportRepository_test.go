@@ -0,0 +1,56 @@
+package reportRepository
+
+import (
+	"bike-rent-express/model/dto/reportDto"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var reportRequest = reportDto.EmployeeReportRequest{
+	From:   "2024-09-01",
+	To:     "2024-09-30",
+	Period: reportDto.PeriodMonth,
+}
+
+func TestGetEmployeeReport_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewReportRepository(db)
+
+	query := "WITH activity AS (.+) SELECT (.+) FROM activity a JOIN employee e (.+) GROUP BY (.+);"
+	rows := sqlmock.NewRows([]string{"id", "name", "period_start", "rentals", "returns", "extra_charge", "disputes_lost", "handover"}).
+		AddRow("1", "dino", "2024-09-01", 4, 3, 25000, 1, 12.5).
+		AddRow("2", "rina", "2024-09-01", 1, 0, 0, 0, nil)
+	mock.ExpectQuery(query).WithArgs("month", "2024-09-01", "2024-09-30", "", "").WillReturnRows(rows)
+
+	reports, err := repository.GetEmployeeReport(reportRequest)
+	handover := 12.5
+	assert.Nil(t, err)
+	assert.Equal(t, []reportDto.EmployeeReport{
+		{EmployeeID: "1", EmployeeName: "dino", PeriodStart: "2024-09-01", RentalsHandled: 4, ReturnsProcessed: 3, ExtraCharges: 25000, DisputesLost: 1, AvgHandoverMinutes: &handover},
+		{EmployeeID: "2", EmployeeName: "rina", PeriodStart: "2024-09-01", RentalsHandled: 1},
+	}, reports)
+}
+
+func TestGetEmployeeReport_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewReportRepository(db)
+
+	query := "WITH activity AS .+"
+	mock.ExpectQuery(query).WillReturnError(errors.New("error sql"))
+
+	_, err = repository.GetEmployeeReport(reportRequest)
+	assert.Error(t, err)
+}
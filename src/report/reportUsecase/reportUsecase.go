@@ -0,0 +1,37 @@
+package reportUsecase
+
+import (
+	"bike-rent-express/model/dto/reportDto"
+	"bike-rent-express/src/report"
+	"errors"
+	"time"
+)
+
+const maxReportDays = 366
+
+type reportUsecase struct {
+	reportRepository report.ReportRepository
+}
+
+func NewReportUsecase(reportRepository report.ReportRepository) report.ReportUsecase {
+	return &reportUsecase{reportRepository}
+}
+
+// GetEmployeeReport returns "1" when to is before from or the range spans more than a year.
+func (r *reportUsecase) GetEmployeeReport(request reportDto.EmployeeReportRequest) ([]reportDto.EmployeeReport, error) {
+	from, err := time.Parse("2006-01-02", request.From)
+	if err != nil {
+		return nil, errors.New("1")
+	}
+
+	to, err := time.Parse("2006-01-02", request.To)
+	if err != nil || to.Before(from) || to.Sub(from) >= maxReportDays*24*time.Hour {
+		return nil, errors.New("1")
+	}
+
+	if request.Period == "" {
+		request.Period = reportDto.PeriodMonth
+	}
+
+	return r.reportRepository.GetEmployeeReport(request)
+}
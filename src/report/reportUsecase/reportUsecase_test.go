@@ -0,0 +1,54 @@
+package reportUsecase
+
+import (
+	"bike-rent-express/model/dto/reportDto"
+	"bike-rent-express/src/report"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type mockReportRepository struct {
+	mock.Mock
+}
+
+func (m *mockReportRepository) GetEmployeeReport(request reportDto.EmployeeReportRequest) ([]reportDto.EmployeeReport, error) {
+	args := m.Called(request)
+	return args.Get(0).([]reportDto.EmployeeReport), args.Error(1)
+}
+
+type ReportUsecaseTestSuite struct {
+	suite.Suite
+	mockReportRepository *mockReportRepository
+	reportUC             report.ReportUsecase
+}
+
+func (suite *ReportUsecaseTestSuite) SetupTest() {
+	suite.mockReportRepository = new(mockReportRepository)
+	suite.reportUC = NewReportUsecase(suite.mockReportRepository)
+}
+
+func (suite *ReportUsecaseTestSuite) TestGetEmployeeReport_DefaultsToMonth() {
+	expectReports := []reportDto.EmployeeReport{{EmployeeID: "1", EmployeeName: "dino", PeriodStart: "2024-09-01", RentalsHandled: 2}}
+	suite.mockReportRepository.On("GetEmployeeReport", reportDto.EmployeeReportRequest{From: "2024-09-01", To: "2024-09-30", Period: reportDto.PeriodMonth}).Return(expectReports, nil)
+
+	reports, err := suite.reportUC.GetEmployeeReport(reportDto.EmployeeReportRequest{From: "2024-09-01", To: "2024-09-30"})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectReports, reports)
+}
+
+func (suite *ReportUsecaseTestSuite) TestGetEmployeeReport_FailedRange() {
+	_, err := suite.reportUC.GetEmployeeReport(reportDto.EmployeeReportRequest{From: "2024-09-30", To: "2024-09-01"})
+	assert.Equal(suite.T(), errors.New("1"), err)
+
+	_, err = suite.reportUC.GetEmployeeReport(reportDto.EmployeeReportRequest{From: "2023-01-01", To: "2024-09-01"})
+	assert.Equal(suite.T(), errors.New("1"), err)
+	suite.mockReportRepository.AssertNotCalled(suite.T(), "GetEmployeeReport", mock.Anything)
+}
+
+func TestReportUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportUsecaseTestSuite))
+}
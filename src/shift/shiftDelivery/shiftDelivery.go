@@ -0,0 +1,184 @@
+package shiftDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/shiftDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/shift"
+
+	"github.com/gin-gonic/gin"
+)
+
+type shiftDelivery struct {
+	shiftUC shift.ShiftUsecase
+}
+
+func NewShiftDelivery(v1Group *gin.RouterGroup, shiftUC shift.ShiftUsecase) {
+	handler := shiftDelivery{shiftUC}
+
+	shiftGroup := v1Group.Group("/shifts")
+	{
+		shiftGroup.POST("/templates", middleware.JWTAuth("ADMIN"), handler.CreateTemplate)
+		shiftGroup.GET("/templates", middleware.JWTAuth("ADMIN"), handler.GetTemplates)
+		shiftGroup.PUT("/templates/:id", middleware.JWTAuth("ADMIN"), handler.UpdateTemplate)
+		shiftGroup.DELETE("/templates/:id", middleware.JWTAuth("ADMIN"), handler.DeleteTemplate)
+		shiftGroup.POST("/exceptions", middleware.JWTAuth("ADMIN"), handler.CreateException)
+		shiftGroup.GET("/exceptions", middleware.JWTAuth("ADMIN"), handler.GetExceptions)
+		shiftGroup.DELETE("/exceptions/:id", middleware.JWTAuth("ADMIN"), handler.DeleteException)
+	}
+
+	v1Group.GET("/employee/:id/shifts", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER"), handler.GetCalendar)
+}
+
+func (s *shiftDelivery) CreateTemplate(c *gin.Context) {
+	var request shiftDto.ShiftTemplateRequest
+
+	c.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
+		return
+	}
+
+	template, err := s.shiftUC.CreateTemplate(request)
+	if err != nil {
+		if shiftError(c, err, "01") {
+			return
+		}
+		json.NewResponseError(c, err.Error(), "01", "01")
+		return
+	}
+
+	json.NewResponseCreated(c, template, "Shift template created", "01", "01")
+}
+
+func (s *shiftDelivery) GetTemplates(c *gin.Context) {
+	templates, err := s.shiftUC.GetTemplates(c.Query("employee_id"))
+	if err != nil {
+		json.NewResponseError(c, err.Error(), "02", "01")
+		return
+	}
+
+	if len(templates) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, templates, "Success get shift templates", "02", "02")
+}
+
+func (s *shiftDelivery) UpdateTemplate(c *gin.Context) {
+	var request shiftDto.ShiftTemplateRequest
+
+	c.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "03", "01")
+		return
+	}
+	request.ID = c.Param("id")
+
+	template, err := s.shiftUC.UpdateTemplate(request)
+	if err != nil {
+		if shiftError(c, err, "03") {
+			return
+		}
+		json.NewResponseError(c, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, template, "Shift template updated", "03", "01")
+}
+
+func (s *shiftDelivery) DeleteTemplate(c *gin.Context) {
+	if err := s.shiftUC.DeleteTemplate(c.Param("id")); err != nil {
+		if shiftError(c, err, "04") {
+			return
+		}
+		json.NewResponseError(c, err.Error(), "04", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, nil, "Shift template deleted", "04", "01")
+}
+
+func (s *shiftDelivery) CreateException(c *gin.Context) {
+	var request shiftDto.ShiftExceptionRequest
+
+	c.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(c, err, "Bad Request", "05", "01")
+		return
+	}
+
+	exception, err := s.shiftUC.CreateException(request)
+	if err != nil {
+		if shiftError(c, err, "05") {
+			return
+		}
+		json.NewResponseError(c, err.Error(), "05", "01")
+		return
+	}
+
+	json.NewResponseCreated(c, exception, "Shift exception created", "05", "01")
+}
+
+func (s *shiftDelivery) GetExceptions(c *gin.Context) {
+	exceptions, err := s.shiftUC.GetExceptions(c.Query("employee_id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "from and to must be dates, YYYY-MM-DD, with to not before from", "06", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "06", "01")
+		return
+	}
+
+	if len(exceptions) == 0 {
+		json.NewResponseSuccess(c, nil, "Data empty", "06", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, exceptions, "Success get shift exceptions", "06", "02")
+}
+
+func (s *shiftDelivery) DeleteException(c *gin.Context) {
+	if err := s.shiftUC.DeleteException(c.Param("id")); err != nil {
+		if shiftError(c, err, "07") {
+			return
+		}
+		json.NewResponseError(c, err.Error(), "07", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, nil, "Shift exception deleted", "07", "01")
+}
+
+func (s *shiftDelivery) GetCalendar(c *gin.Context) {
+	calendar, err := s.shiftUC.GetCalendar(c.Param("id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseBadRequest(c, nil, "from and to must be dates, YYYY-MM-DD, at most 31 days apart", "08", "01")
+			return
+		}
+		json.NewResponseError(c, err.Error(), "08", "01")
+		return
+	}
+
+	json.NewResponseSuccess(c, calendar, "Success get shift calendar", "08", "01")
+}
+
+// shiftError writes the response of the numbered usecase errors and reports whether it did.
+func shiftError(c *gin.Context, err error, serviceCode string) bool {
+	switch err.Error() {
+	case "1":
+		json.NewResponseBadRequest(c, nil, "Data not found", serviceCode, "02")
+	case "2":
+		json.NewResponseBadRequest(c, nil, "Shift conflicts with another shift of the employee", serviceCode, "03")
+	case "3":
+		json.NewResponseBadRequest(c, nil, "end_time must be after start_time", serviceCode, "04")
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,149 @@
+package shiftDelivery
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/src/shift/shiftMock"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var weekday = 1
+
+var templateRequest = shiftDto.ShiftTemplateRequest{
+	EmployeeID: "1b3e5a8c-0f6d-4c5e-9a7b-2d4f6e8a0c1b",
+	Weekday:    &weekday,
+	StartTime:  "08:00",
+	EndTime:    "16:00",
+}
+
+var expectTemplate = shiftDto.ShiftTemplate{
+	ID:         "1",
+	EmployeeID: "1b3e5a8c-0f6d-4c5e-9a7b-2d4f6e8a0c1b",
+	Weekday:    1,
+	StartTime:  "08:00",
+	EndTime:    "16:00",
+	CreatedAt:  "0000",
+	UpdatedAt:  "0000",
+}
+
+type ShiftDeliveryTestSuite struct {
+	suite.Suite
+	mockShiftUC   *shiftMock.ShiftUsecase
+	router        *gin.Engine
+	adminToken    string
+	employeeToken string
+}
+
+func (suite *ShiftDeliveryTestSuite) SetupTest() {
+	suite.mockShiftUC = new(shiftMock.ShiftUsecase)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewShiftDelivery(v1, suite.mockShiftUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("employee", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.employeeToken = "Bearer " + token
+}
+
+func (suite *ShiftDeliveryTestSuite) TestCreateTemplate_Success() {
+	suite.mockShiftUC.On("CreateTemplate", templateRequest).Return(expectTemplate, nil)
+
+	body := []byte(`{"employee_id":"1b3e5a8c-0f6d-4c5e-9a7b-2d4f6e8a0c1b","weekday":1,"start_time":"08:00","end_time":"16:00"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/shifts/templates", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2010101","responseMessage":"Shift template created"`)
+}
+
+func (suite *ShiftDeliveryTestSuite) TestCreateTemplate_FailedBadTime() {
+	body := []byte(`{"employee_id":"1b3e5a8c-0f6d-4c5e-9a7b-2d4f6e8a0c1b","weekday":1,"start_time":"8am","end_time":"16:00"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/shifts/templates", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	suite.mockShiftUC.AssertNotCalled(suite.T(), "CreateTemplate", mock.Anything)
+}
+
+func (suite *ShiftDeliveryTestSuite) TestCreateTemplate_FailedConflict() {
+	expectResponse := `{"responseCode":"4000103","responseMessage":"Shift conflicts with another shift of the employee"}`
+	suite.mockShiftUC.On("CreateTemplate", templateRequest).Return(shiftDto.ShiftTemplate{}, errors.New("2"))
+
+	body := []byte(`{"employee_id":"1b3e5a8c-0f6d-4c5e-9a7b-2d4f6e8a0c1b","weekday":1,"start_time":"08:00","end_time":"16:00"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/shifts/templates", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *ShiftDeliveryTestSuite) TestCreateTemplate_FailedNotAdmin() {
+	body := []byte(`{"employee_id":"1b3e5a8c-0f6d-4c5e-9a7b-2d4f6e8a0c1b","weekday":1,"start_time":"08:00","end_time":"16:00"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/shifts/templates", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
+func (suite *ShiftDeliveryTestSuite) TestDeleteException_FailedNotFound() {
+	expectResponse := `{"responseCode":"4000702","responseMessage":"Data not found"}`
+	suite.mockShiftUC.On("DeleteException", "1").Return(errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/shifts/exceptions/1", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *ShiftDeliveryTestSuite) TestGetCalendar_Success() {
+	expectResponse := `{"responseCode":"2000801","responseMessage":"Success get shift calendar","data":[{"date":"2024-09-16","on_duty":[{"start_time":"08:00","end_time":"16:00"}]}]}`
+	suite.mockShiftUC.On("GetCalendar", "1", "2024-09-16", "2024-09-16").Return([]shiftDto.CalendarDay{{Date: "2024-09-16", OnDuty: []shiftDto.Window{{StartTime: "08:00", EndTime: "16:00"}}}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee/1/shifts?from=2024-09-16&to=2024-09-16", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *ShiftDeliveryTestSuite) TestGetCalendar_FailedRange() {
+	suite.mockShiftUC.On("GetCalendar", "1", "", "").Return([]shiftDto.CalendarDay{}, errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/employee/1/shifts", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"4000801"`)
+}
+
+func TestShiftDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(ShiftDeliveryTestSuite))
+}
@@ -0,0 +1,31 @@
+package shift
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"time"
+)
+
+type (
+	ShiftRepository interface {
+		AddTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error)
+		GetTemplates(employeeID string) ([]shiftDto.ShiftTemplate, error)
+		GetTemplateById(id string) (shiftDto.ShiftTemplate, error)
+		UpdateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error)
+		DeleteTemplate(id string) error
+		AddException(request shiftDto.ShiftExceptionRequest) (shiftDto.ShiftException, error)
+		GetExceptions(employeeID, from, to string) ([]shiftDto.ShiftException, error)
+		DeleteException(id string) error
+	}
+
+	ShiftUsecase interface {
+		CreateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error)
+		GetTemplates(employeeID string) ([]shiftDto.ShiftTemplate, error)
+		UpdateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error)
+		DeleteTemplate(id string) error
+		CreateException(request shiftDto.ShiftExceptionRequest) (shiftDto.ShiftException, error)
+		GetExceptions(employeeID, from, to string) ([]shiftDto.ShiftException, error)
+		DeleteException(id string) error
+		GetCalendar(employeeID, from, to string) ([]shiftDto.CalendarDay, error)
+		IsOnShift(employeeID string, at time.Time) (bool, error)
+	}
+)
@@ -0,0 +1,59 @@
+// Package shiftMock holds the shift usecase mock shared by the tests of the modules that
+// check the roster.
+package shiftMock
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ShiftUsecase struct {
+	mock.Mock
+}
+
+func (m *ShiftUsecase) CreateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	args := m.Called(request)
+	return args.Get(0).(shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *ShiftUsecase) GetTemplates(employeeID string) ([]shiftDto.ShiftTemplate, error) {
+	args := m.Called(employeeID)
+	return args.Get(0).([]shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *ShiftUsecase) UpdateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	args := m.Called(request)
+	return args.Get(0).(shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *ShiftUsecase) DeleteTemplate(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *ShiftUsecase) CreateException(request shiftDto.ShiftExceptionRequest) (shiftDto.ShiftException, error) {
+	args := m.Called(request)
+	return args.Get(0).(shiftDto.ShiftException), args.Error(1)
+}
+
+func (m *ShiftUsecase) GetExceptions(employeeID, from, to string) ([]shiftDto.ShiftException, error) {
+	args := m.Called(employeeID, from, to)
+	return args.Get(0).([]shiftDto.ShiftException), args.Error(1)
+}
+
+func (m *ShiftUsecase) DeleteException(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *ShiftUsecase) GetCalendar(employeeID, from, to string) ([]shiftDto.CalendarDay, error) {
+	args := m.Called(employeeID, from, to)
+	return args.Get(0).([]shiftDto.CalendarDay), args.Error(1)
+}
+
+func (m *ShiftUsecase) IsOnShift(employeeID string, at time.Time) (bool, error) {
+	args := m.Called(employeeID, at)
+	return args.Bool(0), args.Error(1)
+}
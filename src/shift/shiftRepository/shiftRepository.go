@@ -0,0 +1,144 @@
+package shiftRepository
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"bike-rent-express/src/shift"
+	"database/sql"
+)
+
+const (
+	templateColumns  = "id, employee_id, weekday, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), created_at, updated_at"
+	exceptionColumns = "id, employee_id, to_char(date, 'YYYY-MM-DD'), type, COALESCE(to_char(start_time, 'HH24:MI'), ''), COALESCE(to_char(end_time, 'HH24:MI'), ''), COALESCE(note, ''), created_at"
+)
+
+type shiftRepository struct {
+	db *sql.DB
+}
+
+func NewShiftRepository(db *sql.DB) shift.ShiftRepository {
+	return &shiftRepository{db}
+}
+
+func (s *shiftRepository) AddTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	var template shiftDto.ShiftTemplate
+	query := "INSERT INTO shift_template(employee_id, weekday, start_time, end_time) VALUES ($1, $2, $3, $4) RETURNING " + templateColumns + ";"
+	if err := scanTemplate(s.db.QueryRow(query, request.EmployeeID, *request.Weekday, request.StartTime, request.EndTime), &template); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+// GetTemplates lists the weekly shifts of the employee, of everyone when employeeID is empty.
+func (s *shiftRepository) GetTemplates(employeeID string) ([]shiftDto.ShiftTemplate, error) {
+	var templates []shiftDto.ShiftTemplate
+
+	query := "SELECT " + templateColumns + " FROM shift_template WHERE $1 = '' OR employee_id::text = $1 ORDER BY employee_id, weekday, start_time;"
+	rows, err := s.db.Query(query, employeeID)
+	if err != nil {
+		return templates, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var template shiftDto.ShiftTemplate
+		if err := scanTemplate(rows, &template); err != nil {
+			return templates, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (s *shiftRepository) GetTemplateById(id string) (shiftDto.ShiftTemplate, error) {
+	var template shiftDto.ShiftTemplate
+	query := "SELECT " + templateColumns + " FROM shift_template WHERE id = $1;"
+	if err := scanTemplate(s.db.QueryRow(query, id), &template); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (s *shiftRepository) UpdateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	var template shiftDto.ShiftTemplate
+	query := "UPDATE shift_template SET employee_id = $1, weekday = $2, start_time = $3, end_time = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $5 RETURNING " + templateColumns + ";"
+	if err := scanTemplate(s.db.QueryRow(query, request.EmployeeID, *request.Weekday, request.StartTime, request.EndTime, request.ID), &template); err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (s *shiftRepository) DeleteTemplate(id string) error {
+	query := "DELETE FROM shift_template WHERE id = $1;"
+	return execAffected(s.db, query, id)
+}
+
+func (s *shiftRepository) AddException(request shiftDto.ShiftExceptionRequest) (shiftDto.ShiftException, error) {
+	var exception shiftDto.ShiftException
+	query := "INSERT INTO shift_exception(employee_id, date, type, start_time, end_time, note) VALUES ($1, $2, $3, NULLIF($4, '')::time, NULLIF($5, '')::time, NULLIF($6, '')) RETURNING " + exceptionColumns + ";"
+	if err := scanException(s.db.QueryRow(query, request.EmployeeID, request.Date, request.Type, request.StartTime, request.EndTime, request.Note), &exception); err != nil {
+		return exception, err
+	}
+
+	return exception, nil
+}
+
+// GetExceptions lists the extra shifts and leave between the dates, an empty employeeID means everyone.
+func (s *shiftRepository) GetExceptions(employeeID, from, to string) ([]shiftDto.ShiftException, error) {
+	var exceptions []shiftDto.ShiftException
+
+	query := "SELECT " + exceptionColumns + " FROM shift_exception WHERE ($1 = '' OR employee_id::text = $1) AND date BETWEEN $2 AND $3 ORDER BY date, start_time NULLS FIRST;"
+	rows, err := s.db.Query(query, employeeID, from, to)
+	if err != nil {
+		return exceptions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exception shiftDto.ShiftException
+		if err := scanException(rows, &exception); err != nil {
+			return exceptions, err
+		}
+		exceptions = append(exceptions, exception)
+	}
+
+	return exceptions, nil
+}
+
+func (s *shiftRepository) DeleteException(id string) error {
+	query := "DELETE FROM shift_exception WHERE id = $1;"
+	return execAffected(s.db, query, id)
+}
+
+// execAffected runs the statement and returns sql.ErrNoRows when it touched nothing.
+func execAffected(db *sql.DB, query string, args ...interface{}) error {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTemplate(row scanner, template *shiftDto.ShiftTemplate) error {
+	return row.Scan(&template.ID, &template.EmployeeID, &template.Weekday, &template.StartTime, &template.EndTime, &template.CreatedAt, &template.UpdatedAt)
+}
+
+func scanException(row scanner, exception *shiftDto.ShiftException) error {
+	return row.Scan(&exception.ID, &exception.EmployeeID, &exception.Date, &exception.Type, &exception.StartTime, &exception.EndTime, &exception.Note, &exception.CreatedAt)
+}
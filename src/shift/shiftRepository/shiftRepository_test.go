@@ -0,0 +1,185 @@
+package shiftRepository
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var weekday = 1
+
+var templateRequest = shiftDto.ShiftTemplateRequest{
+	EmployeeID: "1",
+	Weekday:    &weekday,
+	StartTime:  "08:00",
+	EndTime:    "16:00",
+}
+
+var expectTemplate = shiftDto.ShiftTemplate{
+	ID:         "1",
+	EmployeeID: "1",
+	Weekday:    1,
+	StartTime:  "08:00",
+	EndTime:    "16:00",
+	CreatedAt:  "0000",
+	UpdatedAt:  "0000",
+}
+
+var expectException = shiftDto.ShiftException{
+	ID:         "1",
+	EmployeeID: "1",
+	Date:       "2024-09-16",
+	Type:       shiftDto.ExceptionLeave,
+	Note:       "sick",
+	CreatedAt:  "0000",
+}
+
+func templateRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "employee_id", "weekday", "start_time", "end_time", "created_at", "updated_at"}).
+		AddRow(expectTemplate.ID, expectTemplate.EmployeeID, expectTemplate.Weekday, expectTemplate.StartTime, expectTemplate.EndTime, expectTemplate.CreatedAt, expectTemplate.UpdatedAt)
+}
+
+func exceptionRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "employee_id", "date", "type", "start_time", "end_time", "note", "created_at"}).
+		AddRow(expectException.ID, expectException.EmployeeID, expectException.Date, expectException.Type, expectException.StartTime, expectException.EndTime, expectException.Note, expectException.CreatedAt)
+}
+
+func TestAddTemplate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	query := "INSERT INTO shift_template(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs("1", 1, "08:00", "16:00").WillReturnRows(templateRows())
+
+	template, err := repository.AddTemplate(templateRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectTemplate, template)
+}
+
+func TestGetTemplates_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	query := "SELECT (.+) FROM shift_template WHERE (.+) ORDER BY .+;"
+	mock.ExpectQuery(query).WithArgs("1").WillReturnRows(templateRows())
+
+	templates, err := repository.GetTemplates("1")
+	assert.Nil(t, err)
+	assert.Equal(t, []shiftDto.ShiftTemplate{expectTemplate}, templates)
+}
+
+func TestGetTemplates_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	query := "SELECT (.+) FROM shift_template .+"
+	mock.ExpectQuery(query).WithArgs("").WillReturnError(errors.New("error sql"))
+
+	_, err = repository.GetTemplates("")
+	assert.Error(t, err)
+}
+
+func TestUpdateTemplate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	request := templateRequest
+	request.ID = "1"
+	query := "UPDATE shift_template SET (.+) WHERE id = \\$5 RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs("1", 1, "08:00", "16:00", "1").WillReturnRows(templateRows())
+
+	template, err := repository.UpdateTemplate(request)
+	assert.Nil(t, err)
+	assert.Equal(t, expectTemplate, template)
+}
+
+func TestDeleteTemplate_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	query := "DELETE FROM shift_template WHERE id = \\$1;"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.DeleteTemplate("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestAddException_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	request := shiftDto.ShiftExceptionRequest{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave, Note: "sick"}
+	query := "INSERT INTO shift_exception(.+) RETURNING .+;"
+	mock.ExpectQuery(query).WithArgs("1", "2024-09-16", shiftDto.ExceptionLeave, "", "", "sick").WillReturnRows(exceptionRows())
+
+	exception, err := repository.AddException(request)
+	assert.Nil(t, err)
+	assert.Equal(t, expectException, exception)
+}
+
+func TestGetExceptions_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	query := "SELECT (.+) FROM shift_exception WHERE (.+) AND date BETWEEN \\$2 AND \\$3 ORDER BY .+;"
+	mock.ExpectQuery(query).WithArgs("1", "2024-09-16", "2024-09-22").WillReturnRows(exceptionRows())
+
+	exceptions, err := repository.GetExceptions("1", "2024-09-16", "2024-09-22")
+	assert.Nil(t, err)
+	assert.Equal(t, []shiftDto.ShiftException{expectException}, exceptions)
+}
+
+func TestDeleteException_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewShiftRepository(db)
+
+	query := "DELETE FROM shift_exception WHERE id = \\$1;"
+	mock.ExpectExec(query).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.DeleteException("1")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,309 @@
+package shiftUsecase
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"bike-rent-express/src/shift"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	maxCalendarDays = 31
+)
+
+// wholeDay is the window of a leave taken without times.
+var wholeDay = shiftDto.Window{StartTime: "00:00", EndTime: "24:00"}
+
+type shiftUsecase struct {
+	shiftRepository shift.ShiftRepository
+}
+
+func NewShiftUsecase(shiftRepository shift.ShiftRepository) shift.ShiftUsecase {
+	return &shiftUsecase{shiftRepository}
+}
+
+func (s *shiftUsecase) CreateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	if err := s.checkTemplate(request); err != nil {
+		return shiftDto.ShiftTemplate{}, err
+	}
+
+	template, err := s.shiftRepository.AddTemplate(request)
+	if err != nil {
+		return template, notFound(err)
+	}
+
+	return template, nil
+}
+
+func (s *shiftUsecase) GetTemplates(employeeID string) ([]shiftDto.ShiftTemplate, error) {
+	return s.shiftRepository.GetTemplates(employeeID)
+}
+
+func (s *shiftUsecase) UpdateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	if _, err := s.shiftRepository.GetTemplateById(request.ID); err != nil {
+		return shiftDto.ShiftTemplate{}, notFound(err)
+	}
+
+	if err := s.checkTemplate(request); err != nil {
+		return shiftDto.ShiftTemplate{}, err
+	}
+
+	template, err := s.shiftRepository.UpdateTemplate(request)
+	if err != nil {
+		return template, notFound(err)
+	}
+
+	return template, nil
+}
+
+func (s *shiftUsecase) DeleteTemplate(id string) error {
+	if err := s.shiftRepository.DeleteTemplate(id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// CreateException returns "2" when an extra shift overlaps a shift the employee already
+// works that day or a leave overlaps another leave.
+func (s *shiftUsecase) CreateException(request shiftDto.ShiftExceptionRequest) (shiftDto.ShiftException, error) {
+	window := wholeDay
+	if request.StartTime != "" {
+		window = shiftDto.Window{StartTime: request.StartTime, EndTime: request.EndTime}
+		if window.EndTime <= window.StartTime {
+			return shiftDto.ShiftException{}, errors.New("3")
+		}
+	}
+
+	date, _ := time.Parse(dateLayout, request.Date)
+	templates, exceptions, err := s.roster(request.EmployeeID, request.Date, request.Date)
+	if err != nil {
+		return shiftDto.ShiftException{}, err
+	}
+
+	var taken []shiftDto.Window
+	if request.Type == shiftDto.ExceptionShift {
+		taken = shiftWindows(int(date.Weekday()), request.Date, templates, exceptions)
+	} else {
+		taken = leaveWindows(request.Date, exceptions)
+	}
+	if overlapsAny(window, taken) {
+		return shiftDto.ShiftException{}, errors.New("2")
+	}
+
+	exception, err := s.shiftRepository.AddException(request)
+	if err != nil {
+		return exception, notFound(err)
+	}
+
+	return exception, nil
+}
+
+func (s *shiftUsecase) GetExceptions(employeeID, from, to string) ([]shiftDto.ShiftException, error) {
+	if _, _, err := parseRange(from, to, 0); err != nil {
+		return nil, err
+	}
+
+	return s.shiftRepository.GetExceptions(employeeID, from, to)
+}
+
+func (s *shiftUsecase) DeleteException(id string) error {
+	if err := s.shiftRepository.DeleteException(id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// GetCalendar lays the weekly shifts and the exceptions of the employee out day by day,
+// "1" when the range is not valid or longer than a month.
+func (s *shiftUsecase) GetCalendar(employeeID, from, to string) ([]shiftDto.CalendarDay, error) {
+	start, end, err := parseRange(from, to, maxCalendarDays)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, exceptions, err := s.roster(employeeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var calendar []shiftDto.CalendarDay
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		calendar = append(calendar, calendarDay(day, templates, exceptions))
+	}
+
+	return calendar, nil
+}
+
+// IsOnShift tells whether the employee works at the given moment, in local time.
+func (s *shiftUsecase) IsOnShift(employeeID string, at time.Time) (bool, error) {
+	local := at.In(time.Local)
+	date := local.Format(dateLayout)
+
+	templates, exceptions, err := s.roster(employeeID, date, date)
+	if err != nil {
+		return false, err
+	}
+
+	clock := local.Format("15:04")
+	for _, window := range calendarDay(local, templates, exceptions).OnDuty {
+		if window.StartTime <= clock && clock < window.EndTime {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// checkTemplate returns "3" when the shift ends before it starts and "2" when it overlaps
+// another weekly shift of the employee on the same weekday.
+func (s *shiftUsecase) checkTemplate(request shiftDto.ShiftTemplateRequest) error {
+	if request.EndTime <= request.StartTime {
+		return errors.New("3")
+	}
+
+	templates, err := s.shiftRepository.GetTemplates(request.EmployeeID)
+	if err != nil {
+		return notFound(err)
+	}
+
+	window := shiftDto.Window{StartTime: request.StartTime, EndTime: request.EndTime}
+	for _, template := range templates {
+		if template.ID != request.ID && template.Weekday == *request.Weekday && overlaps(window, templateWindow(template)) {
+			return errors.New("2")
+		}
+	}
+
+	return nil
+}
+
+func (s *shiftUsecase) roster(employeeID, from, to string) ([]shiftDto.ShiftTemplate, []shiftDto.ShiftException, error) {
+	templates, err := s.shiftRepository.GetTemplates(employeeID)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+
+	exceptions, err := s.shiftRepository.GetExceptions(employeeID, from, to)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+
+	return templates, exceptions, nil
+}
+
+func calendarDay(day time.Time, templates []shiftDto.ShiftTemplate, exceptions []shiftDto.ShiftException) shiftDto.CalendarDay {
+	date := day.Format(dateLayout)
+	leave := leaveWindows(date, exceptions)
+
+	return shiftDto.CalendarDay{
+		Date:   date,
+		OnDuty: subtract(shiftWindows(int(day.Weekday()), date, templates, exceptions), leave),
+		Leave:  leave,
+	}
+}
+
+func shiftWindows(weekday int, date string, templates []shiftDto.ShiftTemplate, exceptions []shiftDto.ShiftException) []shiftDto.Window {
+	var windows []shiftDto.Window
+	for _, template := range templates {
+		if template.Weekday == weekday {
+			windows = append(windows, templateWindow(template))
+		}
+	}
+	for _, exception := range exceptions {
+		if exception.Date == date && exception.Type == shiftDto.ExceptionShift {
+			windows = append(windows, shiftDto.Window{StartTime: exception.StartTime, EndTime: exception.EndTime})
+		}
+	}
+
+	return windows
+}
+
+func leaveWindows(date string, exceptions []shiftDto.ShiftException) []shiftDto.Window {
+	var windows []shiftDto.Window
+	for _, exception := range exceptions {
+		if exception.Date != date || exception.Type != shiftDto.ExceptionLeave {
+			continue
+		}
+		if exception.StartTime == "" {
+			windows = append(windows, wholeDay)
+			continue
+		}
+		windows = append(windows, shiftDto.Window{StartTime: exception.StartTime, EndTime: exception.EndTime})
+	}
+
+	return windows
+}
+
+// subtract cuts the leave out of the shifts and returns what is left in order.
+func subtract(windows, cuts []shiftDto.Window) []shiftDto.Window {
+	for _, cut := range cuts {
+		var rest []shiftDto.Window
+		for _, window := range windows {
+			if !overlaps(window, cut) {
+				rest = append(rest, window)
+				continue
+			}
+			if window.StartTime < cut.StartTime {
+				rest = append(rest, shiftDto.Window{StartTime: window.StartTime, EndTime: cut.StartTime})
+			}
+			if cut.EndTime < window.EndTime {
+				rest = append(rest, shiftDto.Window{StartTime: cut.EndTime, EndTime: window.EndTime})
+			}
+		}
+		windows = rest
+	}
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i].StartTime < windows[j].StartTime })
+	return append([]shiftDto.Window{}, windows...)
+}
+
+func templateWindow(template shiftDto.ShiftTemplate) shiftDto.Window {
+	return shiftDto.Window{StartTime: template.StartTime, EndTime: template.EndTime}
+}
+
+func overlaps(a, b shiftDto.Window) bool {
+	return a.StartTime < b.EndTime && b.StartTime < a.EndTime
+}
+
+func overlapsAny(window shiftDto.Window, windows []shiftDto.Window) bool {
+	for _, other := range windows {
+		if overlaps(window, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRange returns "1" when from or to is not a date, to is before from or the range
+// spans more than maxDays days, maxDays 0 means no limit.
+func parseRange(from, to string, maxDays int) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(dateLayout, from, time.Local)
+	if err != nil {
+		return start, start, errors.New("1")
+	}
+
+	end, err := time.ParseInLocation(dateLayout, to, time.Local)
+	if err != nil || end.Before(start) {
+		return start, end, errors.New("1")
+	}
+
+	if maxDays > 0 && end.Sub(start) >= time.Duration(maxDays)*24*time.Hour {
+		return start, end, errors.New("1")
+	}
+
+	return start, end, nil
+}
+
+// notFound maps a missing row, a malformed id or an unknown employee to "1".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") || strings.Contains(err.Error(), "violates foreign key constraint") {
+		return errors.New("1")
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+package shiftUsecase
+
+import (
+	"bike-rent-express/model/dto/shiftDto"
+	"bike-rent-express/src/shift"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// 2024-09-16 is a Monday.
+var monday = 1
+
+var templateRequest = shiftDto.ShiftTemplateRequest{
+	EmployeeID: "1",
+	Weekday:    &monday,
+	StartTime:  "08:00",
+	EndTime:    "16:00",
+}
+
+var expectTemplate = shiftDto.ShiftTemplate{
+	ID:         "1",
+	EmployeeID: "1",
+	Weekday:    1,
+	StartTime:  "08:00",
+	EndTime:    "16:00",
+}
+
+type mockShiftRepository struct {
+	mock.Mock
+}
+
+func (m *mockShiftRepository) AddTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	args := m.Called(request)
+	return args.Get(0).(shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *mockShiftRepository) GetTemplates(employeeID string) ([]shiftDto.ShiftTemplate, error) {
+	args := m.Called(employeeID)
+	return args.Get(0).([]shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *mockShiftRepository) GetTemplateById(id string) (shiftDto.ShiftTemplate, error) {
+	args := m.Called(id)
+	return args.Get(0).(shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *mockShiftRepository) UpdateTemplate(request shiftDto.ShiftTemplateRequest) (shiftDto.ShiftTemplate, error) {
+	args := m.Called(request)
+	return args.Get(0).(shiftDto.ShiftTemplate), args.Error(1)
+}
+
+func (m *mockShiftRepository) DeleteTemplate(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockShiftRepository) AddException(request shiftDto.ShiftExceptionRequest) (shiftDto.ShiftException, error) {
+	args := m.Called(request)
+	return args.Get(0).(shiftDto.ShiftException), args.Error(1)
+}
+
+func (m *mockShiftRepository) GetExceptions(employeeID, from, to string) ([]shiftDto.ShiftException, error) {
+	args := m.Called(employeeID, from, to)
+	return args.Get(0).([]shiftDto.ShiftException), args.Error(1)
+}
+
+func (m *mockShiftRepository) DeleteException(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type ShiftUsecaseTestSuite struct {
+	suite.Suite
+	mockShiftRepository *mockShiftRepository
+	shiftUC             shift.ShiftUsecase
+}
+
+func (suite *ShiftUsecaseTestSuite) SetupTest() {
+	suite.mockShiftRepository = new(mockShiftRepository)
+	suite.shiftUC = NewShiftUsecase(suite.mockShiftRepository)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestCreateTemplate_Success() {
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{{ID: "2", EmployeeID: "1", Weekday: 1, StartTime: "16:00", EndTime: "20:00"}}, nil)
+	suite.mockShiftRepository.On("AddTemplate", templateRequest).Return(expectTemplate, nil)
+
+	template, err := suite.shiftUC.CreateTemplate(templateRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectTemplate, template)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestCreateTemplate_FailedConflict() {
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{{ID: "2", EmployeeID: "1", Weekday: 1, StartTime: "12:00", EndTime: "20:00"}}, nil)
+
+	_, err := suite.shiftUC.CreateTemplate(templateRequest)
+	assert.Equal(suite.T(), errors.New("2"), err)
+	suite.mockShiftRepository.AssertNotCalled(suite.T(), "AddTemplate", mock.Anything)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestCreateTemplate_FailedEndBeforeStart() {
+	request := templateRequest
+	request.EndTime = "07:00"
+
+	_, err := suite.shiftUC.CreateTemplate(request)
+	assert.Equal(suite.T(), errors.New("3"), err)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestUpdateTemplate_SkipsItself() {
+	request := templateRequest
+	request.ID = "1"
+	suite.mockShiftRepository.On("GetTemplateById", "1").Return(expectTemplate, nil)
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{expectTemplate}, nil)
+	suite.mockShiftRepository.On("UpdateTemplate", request).Return(expectTemplate, nil)
+
+	_, err := suite.shiftUC.UpdateTemplate(request)
+	assert.Nil(suite.T(), err)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestUpdateTemplate_FailedNotFound() {
+	request := templateRequest
+	request.ID = "x"
+	suite.mockShiftRepository.On("GetTemplateById", "x").Return(shiftDto.ShiftTemplate{}, errors.New("pq: invalid input syntax for type uuid: \"x\""))
+
+	_, err := suite.shiftUC.UpdateTemplate(request)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestDeleteTemplate_FailedNotFound() {
+	suite.mockShiftRepository.On("DeleteTemplate", "1").Return(sql.ErrNoRows)
+
+	err := suite.shiftUC.DeleteTemplate("1")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestCreateException_FailedShiftConflict() {
+	request := shiftDto.ShiftExceptionRequest{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionShift, StartTime: "15:00", EndTime: "18:00"}
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{expectTemplate}, nil)
+	suite.mockShiftRepository.On("GetExceptions", "1", "2024-09-16", "2024-09-16").Return([]shiftDto.ShiftException{}, nil)
+
+	_, err := suite.shiftUC.CreateException(request)
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestCreateException_LeaveOverShift() {
+	request := shiftDto.ShiftExceptionRequest{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave}
+	expectException := shiftDto.ShiftException{ID: "1", EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave}
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{expectTemplate}, nil)
+	suite.mockShiftRepository.On("GetExceptions", "1", "2024-09-16", "2024-09-16").Return([]shiftDto.ShiftException{}, nil)
+	suite.mockShiftRepository.On("AddException", request).Return(expectException, nil)
+
+	exception, err := suite.shiftUC.CreateException(request)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectException, exception)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestCreateException_FailedLeaveConflict() {
+	request := shiftDto.ShiftExceptionRequest{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave, StartTime: "10:00", EndTime: "12:00"}
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{}, nil)
+	suite.mockShiftRepository.On("GetExceptions", "1", "2024-09-16", "2024-09-16").Return([]shiftDto.ShiftException{{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave}}, nil)
+
+	_, err := suite.shiftUC.CreateException(request)
+	assert.Equal(suite.T(), errors.New("2"), err)
+	suite.mockShiftRepository.AssertNotCalled(suite.T(), "AddException", mock.Anything)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestGetCalendar_Success() {
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{expectTemplate}, nil)
+	suite.mockShiftRepository.On("GetExceptions", "1", "2024-09-15", "2024-09-17").Return([]shiftDto.ShiftException{
+		{EmployeeID: "1", Date: "2024-09-15", Type: shiftDto.ExceptionShift, StartTime: "09:00", EndTime: "12:00"},
+		{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave, StartTime: "10:00", EndTime: "12:00"},
+	}, nil)
+
+	calendar, err := suite.shiftUC.GetCalendar("1", "2024-09-15", "2024-09-17")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []shiftDto.CalendarDay{
+		{Date: "2024-09-15", OnDuty: []shiftDto.Window{{StartTime: "09:00", EndTime: "12:00"}}},
+		{Date: "2024-09-16", OnDuty: []shiftDto.Window{{StartTime: "08:00", EndTime: "10:00"}, {StartTime: "12:00", EndTime: "16:00"}}, Leave: []shiftDto.Window{{StartTime: "10:00", EndTime: "12:00"}}},
+		{Date: "2024-09-17", OnDuty: []shiftDto.Window{}},
+	}, calendar)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestGetCalendar_FailedRange() {
+	_, err := suite.shiftUC.GetCalendar("1", "2024-09-01", "2024-10-15")
+	assert.Equal(suite.T(), errors.New("1"), err)
+
+	_, err = suite.shiftUC.GetCalendar("1", "2024-09-17", "2024-09-15")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *ShiftUsecaseTestSuite) TestIsOnShift() {
+	suite.mockShiftRepository.On("GetTemplates", "1").Return([]shiftDto.ShiftTemplate{expectTemplate}, nil)
+	suite.mockShiftRepository.On("GetExceptions", "1", "2024-09-16", "2024-09-16").Return([]shiftDto.ShiftException{
+		{EmployeeID: "1", Date: "2024-09-16", Type: shiftDto.ExceptionLeave, StartTime: "10:00", EndTime: "12:00"},
+	}, nil)
+
+	onShift, err := suite.shiftUC.IsOnShift("1", time.Date(2024, 9, 16, 9, 0, 0, 0, time.Local))
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), onShift)
+
+	onShift, _ = suite.shiftUC.IsOnShift("1", time.Date(2024, 9, 16, 11, 0, 0, 0, time.Local))
+	assert.False(suite.T(), onShift)
+
+	onShift, _ = suite.shiftUC.IsOnShift("1", time.Date(2024, 9, 16, 16, 0, 0, 0, time.Local))
+	assert.False(suite.T(), onShift)
+}
+
+func TestShiftUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ShiftUsecaseTestSuite))
+}
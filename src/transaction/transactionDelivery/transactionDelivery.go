@@ -20,8 +20,8 @@ func NewTransactionDelivery(v1Group *gin.RouterGroup, transactionUC transaction.
 	transactionGroup := v1Group.Group("/users/transaction")
 	{
 		transactionGroup.POST("", middleware.JWTAuth("ADMIN", "USER"), handler.CreateTransaction)
-		transactionGroup.GET("/:id", middleware.JWTAuth("ADMIN", "USER"), handler.GetTransactionById)
-		transactionGroup.GET("", middleware.JWTAuth("ADMIN"), handler.GetTransactionAll)
+		transactionGroup.GET("/:id", middleware.JWTAuth("ADMIN", "USER", "BRANCH_MANAGER"), handler.GetTransactionById)
+		transactionGroup.GET("", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER"), handler.GetTransactionAll)
 	}
 }
 
@@ -34,6 +34,7 @@ func (t *transactionDelivery) CreateTransaction(c *gin.Context) {
 		json.NewResponseBadRequest(c, err, "Bad Request", "01", "01")
 		return
 	}
+	transactionRequest.Actor = c.GetString("username")
 
 	resultTransaction, err := t.transactionUC.AddTransaction(transactionRequest)
 
@@ -48,6 +49,41 @@ func (t *transactionDelivery) CreateTransaction(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(c, nil, "active rental limit reached", "01", "03")
+			return
+		}
+
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(c, nil, "customer has overdue rentals", "01", "04")
+			return
+		}
+
+		if err.Error() == "5" {
+			json.NewResponseBadRequest(c, nil, "customer has unpaid charges", "01", "05")
+			return
+		}
+
+		if err.Error() == "6" {
+			json.NewResponseBadRequest(c, nil, "motor is not at the pickup branch", "01", "06")
+			return
+		}
+
+		if err.Error() == "7" {
+			json.NewResponseBadRequest(c, nil, "one-way rental is not offered between the branches", "01", "07")
+			return
+		}
+
+		if err.Error() == "8" {
+			json.NewResponseBadRequest(c, nil, "motor vehicle documents expire before the end of the rental", "01", "08")
+			return
+		}
+
+		if err.Error() == "9" {
+			json.NewResponseBadRequest(c, nil, "customer account is not active", "01", "09")
+			return
+		}
+
 		json.NewResponseError(c, err.Error(), "01", "01")
 		return
 	}
@@ -58,7 +94,7 @@ func (t *transactionDelivery) CreateTransaction(c *gin.Context) {
 func (t *transactionDelivery) GetTransactionById(c *gin.Context) {
 	id := c.Param("id")
 
-	transactionDetail, err := t.transactionUC.GetTransactionById(id)
+	transactionDetail, err := t.transactionUC.GetTransactionById(id, c.GetString("branch_id"))
 	if err != nil {
 		if err.Error() == "1" {
 			json.NewResponseSuccess(c, nil, "Data not found", "02", "01")
@@ -72,7 +108,7 @@ func (t *transactionDelivery) GetTransactionById(c *gin.Context) {
 }
 
 func (t *transactionDelivery) GetTransactionAll(c *gin.Context) {
-	transactionsDetail, err := t.transactionUC.GetTransactionAll()
+	transactionsDetail, err := t.transactionUC.GetTransactionAll(c.GetString("branch_id"))
 	if err != nil {
 		json.NewResponseError(c, err.Error(), "03", "01")
 		return
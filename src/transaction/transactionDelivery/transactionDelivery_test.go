@@ -5,6 +5,7 @@ import (
 	employeeDto "bike-rent-express/model/dto/employee"
 	"bike-rent-express/model/dto/motorVehicleDto"
 	"bike-rent-express/model/dto/transactionDto"
+	"bike-rent-express/pkg/middleware"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -29,13 +30,13 @@ func (m *mockTransactionUC) AddTransaction(transactionRequest transactionDto.Add
 	return args.Get(0).(transactionDto.Transaction), args.Error(1)
 }
 
-func (m *mockTransactionUC) GetTransactionById(id string) (transactionDto.ResponseTransaction, error) {
-	args := m.Called(id)
+func (m *mockTransactionUC) GetTransactionById(id, branchID string) (transactionDto.ResponseTransaction, error) {
+	args := m.Called(id, branchID)
 	return args.Get(0).(transactionDto.ResponseTransaction), args.Error(1)
 }
 
-func (m *mockTransactionUC) GetTransactionAll() ([]transactionDto.ResponseTransaction, error) {
-	args := m.Called()
+func (m *mockTransactionUC) GetTransactionAll(branchID string) ([]transactionDto.ResponseTransaction, error) {
+	args := m.Called(branchID)
 	return args.Get(0).([]transactionDto.ResponseTransaction), args.Error(1)
 }
 
@@ -73,16 +74,16 @@ var expectCustomer = dto.GetUsers{
 }
 var expectTransaction = transactionDto.Transaction{
 	ID:        "1",
-	StartDate: "13-09-2024",
-	EndDate:   "13-09-2025",
+	StartDate: "2024-09-13T09:00:00+07:00",
+	EndDate:   "2025-09-13T09:00:00+07:00",
 	Price:     20000,
 	CreatedAt: "test",
 	UpdatedAt: "test",
 }
 var expectTransactionResponse = transactionDto.ResponseTransaction{
 	ID:           "1",
-	StartDate:    "13-09-2024",
-	EndDate:      "13-09-2025",
+	StartDate:    "2024-09-13T09:00:00+07:00",
+	EndDate:      "2025-09-13T09:00:00+07:00",
 	Price:        20000,
 	MotorVehicle: expectMotorVehicle,
 	Employee:     expectEmployee,
@@ -110,11 +111,11 @@ func (suite *TestTransactionDelierySuite) TestCreateTransaction_Success() {
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
-		EmployeeId:     "1",
-		StartDate:      "12-09-2024",
-		EndDate:        "10-09-2024",
+		StartDate:      "2024-09-12T09:00:00+07:00",
+		EndDate:        "2024-09-10T09:00:00+07:00",
+		Actor:          "admin",
 	}
-	expectResponse := `{"responseCode":"2010101","responseMessage":"Transaction Created","data":{"id":"1","user_id":"","motor_vehicle_id":"","employee_id":"","start_date":"13-09-2024","end_date":"13-09-2025","price":20000,"created_at":"test","updated_at":"test"}}`
+	expectResponse := `{"responseCode":"2010101","responseMessage":"Transaction Created","data":{"id":"1","user_id":"","motor_vehicle_id":"","employee_id":"","start_date":"2024-09-13T09:00:00+07:00","end_date":"2025-09-13T09:00:00+07:00","price":20000,"status":"","late_fee":0,"created_at":"test","updated_at":"test"}}`
 
 	suite.mockTransactionUC.On("AddTransaction", transactionRequest).Return(expectTransaction, nil)
 
@@ -130,11 +131,11 @@ func (suite *TestTransactionDelierySuite) TestCreateTransaction_Success() {
 
 func (suite *TestTransactionDelierySuite) TestCreateTransaction_FailedBind() {
 	transactionRequest := transactionDto.AddTransactionRequest{
-		ID:         "1",
-		UserID:     "1",
-		EmployeeId: "1",
-		StartDate:  "12-09-2024",
-		EndDate:    "10-09-2024",
+		ID:        "1",
+		UserID:    "1",
+		StartDate: "2024-09-12T09:00:00+07:00",
+		EndDate:   "2024-09-10T09:00:00+07:00",
+		Actor:     "admin",
 	}
 	expectResponse := `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"MotorVehicleId","message":"field is required"}]}`
 
@@ -155,9 +156,9 @@ func (suite *TestTransactionDelierySuite) TestCreateTransaction_Failed() {
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
-		EmployeeId:     "1",
-		StartDate:      "12-09-2024",
-		EndDate:        "10-09-2024",
+		StartDate:      "2024-09-12T09:00:00+07:00",
+		EndDate:        "2024-09-10T09:00:00+07:00",
+		Actor:          "admin",
 	}
 	expectResponse := `{"responseCode":"5000101","responseMessage":"internal server error","error":"error"}`
 
@@ -173,9 +174,85 @@ func (suite *TestTransactionDelierySuite) TestCreateTransaction_Failed() {
 	assert.Equal(suite.T(), expectResponse, w.Body.String())
 }
 
+func (suite *TestTransactionDelierySuite) adminToken() string {
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	return "Bearer " + token
+}
+
+func (suite *TestTransactionDelierySuite) TestCreateTransaction_FailedRentalLimitReached() {
+	transactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "1",
+		UserID:         "1",
+		MotorVehicleId: "1",
+		StartDate:      "2024-09-12T09:00:00+07:00",
+		EndDate:        "2024-09-13T09:00:00+07:00",
+		Actor:          "admin",
+	}
+	expectResponse := `{"responseCode":"4000103","responseMessage":"active rental limit reached"}`
+
+	suite.mockTransactionUC.On("AddTransaction", transactionRequest).Return(expectTransaction, errors.New("3"))
+
+	w := httptest.NewRecorder()
+	json, _ := json.Marshal(transactionRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/transaction", bytes.NewBuffer(json))
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *TestTransactionDelierySuite) TestCreateTransaction_FailedOverdueRentals() {
+	transactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "1",
+		UserID:         "1",
+		MotorVehicleId: "1",
+		StartDate:      "2024-09-12T09:00:00+07:00",
+		EndDate:        "2024-09-13T09:00:00+07:00",
+		Actor:          "admin",
+	}
+	expectResponse := `{"responseCode":"4000104","responseMessage":"customer has overdue rentals"}`
+
+	suite.mockTransactionUC.On("AddTransaction", transactionRequest).Return(expectTransaction, errors.New("4"))
+
+	w := httptest.NewRecorder()
+	json, _ := json.Marshal(transactionRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/transaction", bytes.NewBuffer(json))
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *TestTransactionDelierySuite) TestCreateTransaction_FailedNoOneWayRoute() {
+	transactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "1",
+		UserID:         "1",
+		MotorVehicleId: "1",
+		StartDate:      "2024-09-12T09:00:00+07:00",
+		EndDate:        "2024-09-13T09:00:00+07:00",
+		ReturnBranchID: "6c2a3b4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e",
+		Actor:          "admin",
+	}
+	expectResponse := `{"responseCode":"4000107","responseMessage":"one-way rental is not offered between the branches"}`
+
+	suite.mockTransactionUC.On("AddTransaction", transactionRequest).Return(expectTransaction, errors.New("7"))
+
+	w := httptest.NewRecorder()
+	json, _ := json.Marshal(transactionRequest)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/transaction", bytes.NewBuffer(json))
+	req.Header.Add("Authorization", suite.adminToken())
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
 func (suite *TestTransactionDelierySuite) TestGetTransactionById_Success() {
-	suite.mockTransactionUC.On("GetTransactionById", expectTransaction.ID).Return(expectTransactionResponse, nil)
-	expectResponse := `{"responseCode":"2000202","responseMessage":"Success get transaction by id","data":{"id":"1","start_date":"13-09-2024","end_date":"13-09-2025","price":20000,"motor_vehicle":{"id":"1","name":"test","type":"test","price":2000,"plat":"test","created_at":"test","updated_at":"test","production_year":"2020","status":"AVAILABLE"},"employee":{"id":"1","name":"test","telp":"08123","username":"test","created_at":"test","updated_at":"test"},"customer":{"id":"1","nama":"test","username":"test","alamat":"test","role":"USER","cant_rent":true,"created_at":"test","updated_at":"test","telepon":"0812312"},"created_at":"test","updated_at":"test"}}`
+	suite.mockTransactionUC.On("GetTransactionById", expectTransaction.ID, "").Return(expectTransactionResponse, nil)
+	expectResponse := `{"responseCode":"2000202","responseMessage":"Success get transaction by id","data":{"id":"1","start_date":"2024-09-13T09:00:00+07:00","end_date":"2025-09-13T09:00:00+07:00","price":20000,"status":"","late_fee":0,"motor_vehicle":{"id":"1","name":"test","type":"test","price":2000,"plat":"test","created_at":"test","updated_at":"test","production_year":"2020","status":"AVAILABLE"},"employee":{"id":"1","name":"test","telp":"08123","username":"test","created_at":"test","updated_at":"test"},"customer":{"id":"1","nama":"test","username":"test","alamat":"test","role":"USER","cant_rent":true,"created_at":"test","updated_at":"test","telepon":"0812312"},"created_at":"test","updated_at":"test"}}`
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/transaction/"+expectTransaction.ID, nil)
@@ -187,7 +264,7 @@ func (suite *TestTransactionDelierySuite) TestGetTransactionById_Success() {
 }
 
 func (suite *TestTransactionDelierySuite) TestGetTransactionById_FailedDataNotFound() {
-	suite.mockTransactionUC.On("GetTransactionById", expectTransaction.ID).Return(expectTransactionResponse, errors.New("1"))
+	suite.mockTransactionUC.On("GetTransactionById", expectTransaction.ID, "").Return(expectTransactionResponse, errors.New("1"))
 	expectResponse := `{"responseCode":"2000201","responseMessage":"Data not found"}`
 
 	w := httptest.NewRecorder()
@@ -200,7 +277,7 @@ func (suite *TestTransactionDelierySuite) TestGetTransactionById_FailedDataNotFo
 }
 
 func (suite *TestTransactionDelierySuite) TestGetTransactionById_Failed() {
-	suite.mockTransactionUC.On("GetTransactionById", expectTransaction.ID).Return(expectTransactionResponse, errors.New("error"))
+	suite.mockTransactionUC.On("GetTransactionById", expectTransaction.ID, "").Return(expectTransactionResponse, errors.New("error"))
 	expectResponse := `{"responseCode":"5000201","responseMessage":"internal server error","error":"error"}`
 
 	w := httptest.NewRecorder()
@@ -217,8 +294,8 @@ func (suite *TestTransactionDelierySuite) TestGetTransactionAll_Success() {
 		expectTransactionResponse,
 	}
 
-	suite.mockTransactionUC.On("GetTransactionAll").Return(allResponseTransaction, nil)
-	expectResponse := `{"responseCode":"2000202","responseMessage":"Success get all transaction","data":[{"id":"1","start_date":"13-09-2024","end_date":"13-09-2025","price":20000,"motor_vehicle":{"id":"1","name":"test","type":"test","price":2000,"plat":"test","created_at":"test","updated_at":"test","production_year":"2020","status":"AVAILABLE"},"employee":{"id":"1","name":"test","telp":"08123","username":"test","created_at":"test","updated_at":"test"},"customer":{"id":"1","nama":"test","username":"test","alamat":"test","role":"USER","cant_rent":true,"created_at":"test","updated_at":"test","telepon":"0812312"},"created_at":"test","updated_at":"test"}]}`
+	suite.mockTransactionUC.On("GetTransactionAll", "").Return(allResponseTransaction, nil)
+	expectResponse := `{"responseCode":"2000202","responseMessage":"Success get all transaction","data":[{"id":"1","start_date":"2024-09-13T09:00:00+07:00","end_date":"2025-09-13T09:00:00+07:00","price":20000,"status":"","late_fee":0,"motor_vehicle":{"id":"1","name":"test","type":"test","price":2000,"plat":"test","created_at":"test","updated_at":"test","production_year":"2020","status":"AVAILABLE"},"employee":{"id":"1","name":"test","telp":"08123","username":"test","created_at":"test","updated_at":"test"},"customer":{"id":"1","nama":"test","username":"test","alamat":"test","role":"USER","cant_rent":true,"created_at":"test","updated_at":"test","telepon":"0812312"},"created_at":"test","updated_at":"test"}]}`
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/transaction", nil)
@@ -234,7 +311,7 @@ func (suite *TestTransactionDelierySuite) TestGetTransactionAll_Failed() {
 		expectTransactionResponse,
 	}
 
-	suite.mockTransactionUC.On("GetTransactionAll").Return(allResponseTransaction, errors.New("error"))
+	suite.mockTransactionUC.On("GetTransactionAll", "").Return(allResponseTransaction, errors.New("error"))
 	expectResponse := `{"responseCode":"5000301","responseMessage":"internal server error","error":"error"}`
 
 	w := httptest.NewRecorder()
@@ -250,7 +327,7 @@ func (suite *TestTransactionDelierySuite) TestGetTransactionAll_Failed() {
 func (suite *TestTransactionDelierySuite) TestGetTransactionAll_FailedEmpty() {
 	allResponseTransaction := []transactionDto.ResponseTransaction{}
 
-	suite.mockTransactionUC.On("GetTransactionAll").Return(allResponseTransaction, nil)
+	suite.mockTransactionUC.On("GetTransactionAll", "").Return(allResponseTransaction, nil)
 	expectResponse := `{"responseCode":"2000201","responseMessage":"Data empty"}`
 
 	w := httptest.NewRecorder()
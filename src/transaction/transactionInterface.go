@@ -6,12 +6,12 @@ type (
 	TransactionRepository interface {
 		Add(transactionRequest transactionDto.AddTransactionRequest) (transactionDto.AddTransactionRequest, error)
 		GetById(id string) (transactionDto.Transaction, error)
-		GetAll() ([]transactionDto.Transaction, error)
+		GetAll(branchID string) ([]transactionDto.Transaction, error)
 	}
 
 	TransactionUsecase interface {
 		AddTransaction(transactionRequest transactionDto.AddTransactionRequest) (transactionDto.Transaction, error)
-		GetTransactionById(id string) (transactionDto.ResponseTransaction, error)
-		GetTransactionAll() ([]transactionDto.ResponseTransaction, error)
+		GetTransactionById(id string, branchID string) (transactionDto.ResponseTransaction, error)
+		GetTransactionAll(branchID string) ([]transactionDto.ResponseTransaction, error)
 	}
 )
@@ -2,18 +2,21 @@ package transactionRepository
 
 import (
 	"bike-rent-express/model/dto/transactionDto"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/Users/usersRepository"
+	"bike-rent-express/src/dispatch/dispatchRepository"
 	"bike-rent-express/src/transaction"
 	"database/sql"
 	"errors"
-	"time"
 )
 
 type transactionRepository struct {
-	db *sql.DB
+	db               *sql.DB
+	maxActiveRentals int
 }
 
-func NewTransactionRepository(db *sql.DB) transaction.TransactionRepository {
-	return &transactionRepository{db}
+func NewTransactionRepository(db *sql.DB, maxActiveRentals int) transaction.TransactionRepository {
+	return &transactionRepository{db, maxActiveRentals}
 }
 
 func (t *transactionRepository) Add(transactionRequest transactionDto.AddTransactionRequest) (transactionDto.AddTransactionRequest, error) {
@@ -23,33 +26,57 @@ func (t *transactionRepository) Add(transactionRequest transactionDto.AddTransac
 		return transactionRequest, err
 	}
 
-	startDate, err := time.Parse("02-01-2006", transactionRequest.StartDate)
+	startDate, endDate, hours, err := utils.RentalPeriod(transactionRequest.StartDate, transactionRequest.EndDate)
 	if err != nil {
 		tx.Rollback()
 		return transactionRequest, err
 	}
 
-	endDate, err := time.Parse("02-01-2006", transactionRequest.EndDate)
-	if err != nil {
+	// lock the customer first so concurrent rentals of the same customer are counted one after another,
+	// a deactivated or closed account can not rent
+	var userId string
+	query := "SELECT id FROM users WHERE id = $1 AND role = 'USER' AND deleted_at IS NULL FOR UPDATE;"
+	if err := tx.QueryRow(query, transactionRequest.UserID).Scan(&userId); err != nil {
 		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return transactionRequest, errors.New("9")
+		}
 		return transactionRequest, err
 	}
 
-	difference := endDate.Sub(startDate).Hours() / 24
-	if difference < 1 {
+	if _, err := usersRepository.CheckRentalEligibility(tx, userId, t.maxActiveRentals); err != nil {
 		tx.Rollback()
-		return transactionRequest, errors.New("end date is at least 1 day from the start date")
+		return transactionRequest, err
 	}
 
-	query := "SELECT price FROM motor_vehicle WHERE id = $1 AND status = 'AVAILABLE';"
-	priceMotor := 0
+	query = "SELECT " + utils.UnitPrices + ", COALESCE(mv.current_branch_id::text, '') FROM " + utils.UnitModelJoin + " WHERE mv.id = $1 AND mv.status = 'AVAILABLE' AND " + utils.NoMaintenance("$2", "$3") + " FOR UPDATE OF mv;"
+	dailyPrice, hourlyPrice, currentBranchID := 0, 0, ""
 
-	err = tx.QueryRow(query, transactionRequest.MotorVehicleId).Scan(&priceMotor)
+	err = tx.QueryRow(query, transactionRequest.MotorVehicleId, startDate, endDate).Scan(&dailyPrice, &hourlyPrice, &currentBranchID)
 	if err != nil {
 		tx.Rollback()
 		return transactionRequest, errors.New("1")
 	}
-	priceMotor *= int(difference)
+	// the papers of the vehicle must stay in force until the rental ends
+	var documentsValid bool
+	query = "SELECT " + utils.DocumentsValid("$2") + " FROM motor_vehicle mv WHERE mv.id = $1;"
+	if err := tx.QueryRow(query, transactionRequest.MotorVehicleId, endDate).Scan(&documentsValid); err != nil {
+		tx.Rollback()
+		return transactionRequest, err
+	}
+
+	if !documentsValid {
+		tx.Rollback()
+		return transactionRequest, errors.New("8")
+	}
+	priceMotor := utils.RentalPrice(hours, dailyPrice, hourlyPrice)
+
+	pickupBranchID, returnBranchID, oneWayFee, err := RouteBranches(tx, transactionRequest.PickupBranchID, transactionRequest.ReturnBranchID, currentBranchID)
+	if err != nil {
+		tx.Rollback()
+		return transactionRequest, err
+	}
+	transactionRequest.PickupBranchID, transactionRequest.ReturnBranchID = pickupBranchID, returnBranchID
 
 	query = "SELECT amount FROM balance WHERE user_id = $1;"
 	userBalance := 0
@@ -60,12 +87,12 @@ func (t *transactionRepository) Add(transactionRequest transactionDto.AddTransac
 		return transactionRequest, err
 	}
 
-	if userBalance < priceMotor {
+	if userBalance < priceMotor+oneWayFee {
 		tx.Rollback()
 		return transactionRequest, errors.New("2")
 	}
 
-	result := userBalance - priceMotor
+	result := userBalance - priceMotor - oneWayFee
 
 	query = "UPDATE balance SET amount = $1 WHERE user_id = $2;"
 	_, err = tx.Exec(query, result, transactionRequest.UserID)
@@ -74,23 +101,28 @@ func (t *transactionRepository) Add(transactionRequest transactionDto.AddTransac
 		return transactionRequest, err
 	}
 
-	query = "UPDATE motor_vehicle SET status = 'NOT_AVAILABLE' WHERE id = $1"
-	_, err = tx.Exec(query, transactionRequest.MotorVehicleId)
+	// the mileage allowance and overage rate of the vehicle are fixed when it is rented
+	query = "INSERT INTO transaction(user_id, motor_vehicle_id, start_date, end_date, price, pickup_branch_id, return_branch_id, one_way_fee, km_limit, overage_per_km) VALUES($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, NULLIF($7, '')::uuid, $8, (SELECT " + utils.KmAllowance("$3", "$4") + " FROM motor_vehicle mv WHERE mv.id = $2), (SELECT overage_per_km FROM motor_vehicle WHERE id = $2)) RETURNING id;"
+
+	err = tx.QueryRow(query, transactionRequest.UserID, transactionRequest.MotorVehicleId, startDate, endDate, priceMotor, transactionRequest.PickupBranchID, transactionRequest.ReturnBranchID, oneWayFee).Scan(&transactionRequest.ID)
 	if err != nil {
 		tx.Rollback()
 		return transactionRequest, err
 	}
 
-	query = "UPDATE users SET can_rent = false WHERE id = $1"
-	_, err = tx.Exec(query, transactionRequest.UserID)
-	if err != nil {
+	if err := dispatchRepository.AddTasks(tx, transactionRequest.ID); err != nil {
 		tx.Rollback()
 		return transactionRequest, err
 	}
 
-	query = "INSERT INTO transaction(user_id, motor_vehicle_id, employee_id, start_date, end_date, price ) VALUES($1, $2, $3, $4, $5, $6) RETURNING id;"
+	query = utils.VehicleStatusEvent("NOT_AVAILABLE", "")
+	if _, err := tx.Exec(query, transactionRequest.MotorVehicleId, transactionRequest.Actor, "rented", transactionRequest.ID); err != nil {
+		tx.Rollback()
+		return transactionRequest, err
+	}
 
-	err = tx.QueryRow(query, transactionRequest.UserID, transactionRequest.MotorVehicleId, transactionRequest.EmployeeId, startDate, endDate, priceMotor).Scan(&transactionRequest.ID)
+	query = "UPDATE motor_vehicle SET status = 'NOT_AVAILABLE' WHERE id = $1"
+	_, err = tx.Exec(query, transactionRequest.MotorVehicleId)
 	if err != nil {
 		tx.Rollback()
 		return transactionRequest, err
@@ -100,22 +132,51 @@ func (t *transactionRepository) Add(transactionRequest transactionDto.AddTransac
 	return transactionRequest, nil
 }
 
+// RouteBranches resolves the pickup and return branches of a rental of a vehicle standing
+// at currentBranchID and returns them with the one-way fee between them, bookings and
+// rentals share it. The vehicle must be picked up where it stands ("6") and a return
+// elsewhere needs a route in the one-way fee matrix ("7").
+func RouteBranches(q utils.RowQuerier, pickupBranchID, returnBranchID, currentBranchID string) (string, string, int, error) {
+	if pickupBranchID == "" {
+		pickupBranchID = currentBranchID
+	}
+	if pickupBranchID != currentBranchID {
+		return "", "", 0, errors.New("6")
+	}
+
+	if returnBranchID == "" || returnBranchID == pickupBranchID {
+		return pickupBranchID, pickupBranchID, 0, nil
+	}
+
+	var fee int
+	query := "SELECT fee FROM one_way_fee WHERE from_branch_id::text = $1 AND to_branch_id::text = $2;"
+	if err := q.QueryRow(query, pickupBranchID, returnBranchID).Scan(&fee); err != nil {
+		if err == sql.ErrNoRows {
+			return "", "", 0, errors.New("7")
+		}
+		return "", "", 0, err
+	}
+
+	return pickupBranchID, returnBranchID, fee, nil
+}
+
 func (t *transactionRepository) GetById(id string) (transactionDto.Transaction, error) {
 	var transaction transactionDto.Transaction
-	query := "SELECT id, user_id, motor_vehicle_id, start_date, end_date, price, created_at, updated_at, employee_id FROM transaction WHERE id = $1;"
+	query := "SELECT id, user_id, motor_vehicle_id, start_date, end_date, price, created_at, updated_at, COALESCE(employee_id::text, ''), status, late_fee, COALESCE(pickup_branch_id::text, ''), COALESCE(return_branch_id::text, ''), one_way_fee FROM transaction WHERE id = $1;"
 
-	if err := t.db.QueryRow(query, id).Scan(&transaction.ID, &transaction.UserID, &transaction.MotorVehicleId, &transaction.StartDate, &transaction.EndDate, &transaction.Price, &transaction.CreatedAt, &transaction.UpdatedAt, &transaction.EmployeeId); err != nil {
+	if err := t.db.QueryRow(query, id).Scan(&transaction.ID, &transaction.UserID, &transaction.MotorVehicleId, &transaction.StartDate, &transaction.EndDate, &transaction.Price, &transaction.CreatedAt, &transaction.UpdatedAt, &transaction.EmployeeId, &transaction.Status, &transaction.LateFee, &transaction.PickupBranchID, &transaction.ReturnBranchID, &transaction.OneWayFee); err != nil {
 		return transaction, err
 	}
 	return transaction, nil
 }
 
-func (t *transactionRepository) GetAll() ([]transactionDto.Transaction, error) {
+// GetAll lists the transactions picked up or returned at branchID, all of them when branchID is empty.
+func (t *transactionRepository) GetAll(branchID string) ([]transactionDto.Transaction, error) {
 	var transactions []transactionDto.Transaction
 
-	query := "SELECT id, user_id, motor_vehicle_id, start_date, end_date, price, created_at, updated_at, employee_id FROM transaction;"
+	query := "SELECT id, user_id, motor_vehicle_id, start_date, end_date, price, created_at, updated_at, COALESCE(employee_id::text, ''), status, late_fee, COALESCE(pickup_branch_id::text, ''), COALESCE(return_branch_id::text, ''), one_way_fee FROM transaction WHERE $1 = '' OR pickup_branch_id::text = $1 OR return_branch_id::text = $1;"
 
-	row, err := t.db.Query(query)
+	row, err := t.db.Query(query, branchID)
 	if err != nil {
 		return transactions, err
 	}
@@ -123,7 +184,7 @@ func (t *transactionRepository) GetAll() ([]transactionDto.Transaction, error) {
 
 	for row.Next() {
 		var transaction transactionDto.Transaction
-		if err := row.Scan(&transaction.ID, &transaction.UserID, &transaction.MotorVehicleId, &transaction.StartDate, &transaction.EndDate, &transaction.Price, &transaction.CreatedAt, &transaction.UpdatedAt, &transaction.EmployeeId); err != nil {
+		if err := row.Scan(&transaction.ID, &transaction.UserID, &transaction.MotorVehicleId, &transaction.StartDate, &transaction.EndDate, &transaction.Price, &transaction.CreatedAt, &transaction.UpdatedAt, &transaction.EmployeeId, &transaction.Status, &transaction.LateFee, &transaction.PickupBranchID, &transaction.ReturnBranchID, &transaction.OneWayFee); err != nil {
 			return transactions, err
 		}
 		transactions = append(transactions, transaction)
@@ -2,6 +2,7 @@ package transactionRepository
 
 import (
 	"bike-rent-express/model/dto/transactionDto"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"testing"
@@ -15,13 +16,31 @@ var expectTransaction = transactionDto.Transaction{
 	UserID:         "1",
 	MotorVehicleId: "1",
 	EmployeeId:     "1",
-	StartDate:      "13-09-2024",
-	EndDate:        "14-09-2024",
+	StartDate:      "2024-09-13T09:00:00+07:00",
+	EndDate:        "2024-09-14T09:00:00+07:00",
 	Price:          2000,
+	Status:         "ACTIVE",
+	LateFee:        0,
 	CreatedAt:      "1",
 	UpdatedAt:      "1",
 }
 
+func expectRentalEligibility(mock sqlmock.Sqlmock, userId string, activeRentals, overdueRentals, unpaidCharges int) {
+	query := "SELECT id FROM users WHERE .+ FOR UPDATE;"
+	rows := sqlmock.NewRows([]string{".+"}).AddRow(userId)
+	mock.ExpectQuery(query).WithArgs(userId).WillReturnRows(rows)
+
+	query = "SELECT (.+) FROM users WHERE users.id = \\$1 AND users.role = 'USER';"
+	rows = sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+"}).AddRow(userId, activeRentals, 3, overdueRentals, unpaidCharges)
+	mock.ExpectQuery(query).WithArgs(userId, 3).WillReturnRows(rows)
+}
+
+func expectDocumentsValid(mock sqlmock.Sqlmock, valid bool) {
+	query := "SELECT NOT EXISTS \\(SELECT 1 FROM vehicle_document (.+)\\) FROM motor_vehicle mv WHERE mv.id = \\$1;"
+	rows := sqlmock.NewRows([]string{".+"}).AddRow(valid)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+}
+
 func TestAddTransaction_Success(t *testing.T) {
 	dbMock, mock, err := sqlmock.New()
 	if err != nil {
@@ -33,18 +52,19 @@ func TestAddTransaction_Success(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(10000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(10000, 0, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
@@ -53,15 +73,16 @@ func TestAddTransaction_Success(t *testing.T) {
 	query = "UPDATE balance"
 	mock.ExpectExec(query).WithArgs(20000, expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	query = "UPDATE motor_vehicle"
-	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnResult(sqlmock.NewResult(1, 1))
-
-	query = "UPDATE users"
-	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
-
 	query = "INSERT INTO transaction(.+) RETURNING .+;"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(expectAddTransactionRequest.ID)
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs(expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	query = "INSERT INTO motor_vehicle_event(.+) SELECT (.+) FROM motor_vehicle mv WHERE .+"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId, "", "rented", expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	query = "UPDATE motor_vehicle"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit()
 
@@ -81,16 +102,16 @@ func TestAddTransaction_FailedGetPriceMotorVehicle(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
 	mock.ExpectQuery(query)
 
 	mock.ExpectRollback()
@@ -100,6 +121,38 @@ func TestAddTransaction_FailedGetPriceMotorVehicle(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAddTransaction_FailedDocumentsExpired(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "123",
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
+
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(10000, 0, "")
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, false)
+
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, "8", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestAddTransaction_FailedGetAmountBalance(t *testing.T) {
 	dbMock, mock, err := sqlmock.New()
 	if err != nil {
@@ -111,18 +164,19 @@ func TestAddTransaction_FailedGetAmountBalance(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(10000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(10000, 0, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	mock.ExpectQuery(query)
@@ -144,18 +198,19 @@ func TestAddTransaction_FailedSmallerPriceBalance(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(90000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(90000, 0, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
@@ -179,12 +234,11 @@ func TestAddTransaction_FailedConvertStartDate(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
 		StartDate:      "asdasdas",
-		EndDate:        "14-08-2024",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
 	mock.ExpectRollback()
@@ -205,12 +259,11 @@ func TestAddTransaction_FailedConvertEndDate(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "14-08-2024",
+		StartDate:      "2024-08-14T09:00:00+07:00",
 		EndDate:        "adsasdasd",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
 	mock.ExpectRollback()
@@ -220,7 +273,7 @@ func TestAddTransaction_FailedConvertEndDate(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestAddTransaction_FiledDifference(t *testing.T) {
+func TestAddTransaction_FailedEndBeforeStart(t *testing.T) {
 	dbMock, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error DB:", err.Error())
@@ -231,12 +284,11 @@ func TestAddTransaction_FiledDifference(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "10-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-10T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
 	mock.ExpectRollback()
@@ -257,18 +309,19 @@ func TestAddTransaction_FailedUpdateBalance(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(10000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(10000, 0, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
@@ -294,18 +347,19 @@ func TestAddTransaction_FailedUpdateMotorVehicle(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(10000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(10000, 0, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
@@ -314,6 +368,14 @@ func TestAddTransaction_FailedUpdateMotorVehicle(t *testing.T) {
 	query = "UPDATE balance"
 	mock.ExpectExec(query).WithArgs(20000, expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
 
+	query = "INSERT INTO transaction(.+) RETURNING .+;"
+	rows = sqlmock.NewRows([]string{".+"}).AddRow(expectAddTransactionRequest.ID)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs(expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	query = "INSERT INTO motor_vehicle_event(.+) SELECT (.+) FROM motor_vehicle mv WHERE .+"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId, "", "rented", expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+
 	query = "UPDATE motor_vehicle"
 	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnError(errors.New("error"))
 	mock.ExpectRollback()
@@ -323,7 +385,7 @@ func TestAddTransaction_FailedUpdateMotorVehicle(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestAddTransaction_FailedUpdateUserCanRent(t *testing.T) {
+func TestAddTransaction_Failed(t *testing.T) {
 	dbMock, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error DB:", err.Error())
@@ -334,18 +396,19 @@ func TestAddTransaction_FailedUpdateUserCanRent(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(10000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+"}).AddRow(10000, 0, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
@@ -354,11 +417,9 @@ func TestAddTransaction_FailedUpdateUserCanRent(t *testing.T) {
 	query = "UPDATE balance"
 	mock.ExpectExec(query).WithArgs(20000, expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	query = "UPDATE motor_vehicle"
-	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnResult(sqlmock.NewResult(1, 1))
+	query = "INSERT INTO transaction(.+) RETURNING .+;"
+	mock.ExpectQuery(query)
 
-	query = "UPDATE users"
-	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.UserID).WillReturnError(errors.New("error"))
 	mock.ExpectRollback()
 
 	_, err = transactionRepository.Add(expectAddTransactionRequest)
@@ -366,7 +427,113 @@ func TestAddTransaction_FailedUpdateUserCanRent(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestAddTransaction_Failed(t *testing.T) {
+func TestAddTransaction_FailedLockUser(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "123",
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+
+	// a deactivated or closed customer has deleted_at set
+	query := "SELECT id FROM users WHERE id = \\$1 AND role = 'USER' AND deleted_at IS NULL FOR UPDATE;"
+	mock.ExpectQuery(query).WithArgs(expectAddTransactionRequest.UserID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, "9", err.Error())
+}
+
+func TestAddTransaction_FailedRentalLimitReached(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "123",
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 3, 0, 0)
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, "3", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTransaction_FailedOverdueRentals(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "123",
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 1, 1, 0)
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, "4", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTransaction_FailedUnpaidCharges(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "123",
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 5000)
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, "5", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTransaction_SuccessHourlyPrice(t *testing.T) {
 	dbMock, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatal("Error DB:", err.Error())
@@ -377,40 +544,44 @@ func TestAddTransaction_Failed(t *testing.T) {
 		ID:             "123",
 		UserID:         "123",
 		MotorVehicleId: "123",
-		EmployeeId:     "123",
-		StartDate:      "13-08-2024",
-		EndDate:        "14-08-2024",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-13T11:30:00+07:00",
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
 
-	query := "SELECT (.+) FROM motor_vehicle WHERE .+"
-	rows := sqlmock.NewRows([]string{".+"}).AddRow(10000)
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(10000, 3000, "")
 	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
 
 	query = "SELECT (.+) FROM balance WHERE .+"
 	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
+	// two and a half hours are charged as three started hours
 	query = "UPDATE balance"
-	mock.ExpectExec(query).WithArgs(20000, expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(query).WithArgs(21000, expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	query = "UPDATE motor_vehicle"
-	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnResult(sqlmock.NewResult(1, 1))
+	query = "INSERT INTO transaction(.+) RETURNING .+;"
+	rows = sqlmock.NewRows([]string{".+"}).AddRow(expectAddTransactionRequest.ID)
+	mock.ExpectQuery(query).WithArgs(expectAddTransactionRequest.UserID, expectAddTransactionRequest.MotorVehicleId, sqlmock.AnyArg(), sqlmock.AnyArg(), 9000, "", "", 0).WillReturnRows(rows)
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs(expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(0, 2))
 
-	query = "UPDATE users"
-	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
+	query = "INSERT INTO motor_vehicle_event(.+) SELECT (.+) FROM motor_vehicle mv WHERE .+"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId, "", "rented", expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	query = "INSERT INTO transaction(.+) RETURNING .+;"
-	mock.ExpectQuery(query)
+	query = "UPDATE motor_vehicle"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	mock.ExpectRollback()
+	mock.ExpectCommit()
 
 	_, err = transactionRepository.Add(expectAddTransactionRequest)
-	assert.NotNil(t, err)
-	assert.Error(t, err)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 func TestGetById_Success(t *testing.T) {
@@ -419,10 +590,10 @@ func TestGetById_Success(t *testing.T) {
 		t.Fatal("Error DB:", err.Error())
 	}
 	defer dbMock.Close()
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM transaction WHERE .+"
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectTransaction.ID, expectTransaction.UserID, expectTransaction.MotorVehicleId, expectTransaction.StartDate, expectTransaction.EndDate, expectTransaction.Price, expectTransaction.CreatedAt, expectTransaction.UpdatedAt, expectTransaction.EmployeeId)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRow(expectTransaction.ID, expectTransaction.UserID, expectTransaction.MotorVehicleId, expectTransaction.StartDate, expectTransaction.EndDate, expectTransaction.Price, expectTransaction.CreatedAt, expectTransaction.UpdatedAt, expectTransaction.EmployeeId, expectTransaction.Status, expectTransaction.LateFee, expectTransaction.PickupBranchID, expectTransaction.ReturnBranchID, expectTransaction.OneWayFee)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
 	actualTransaction, err := transactionRepository.GetById(expectTransaction.ID)
@@ -436,10 +607,10 @@ func TestGetById_Failed(t *testing.T) {
 		t.Fatal("Error DB:", err.Error())
 	}
 	defer dbMock.Close()
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM transaction WHERE .+"
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"})
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"})
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
 	actualTransaction, err := transactionRepository.GetById(expectTransaction.ID)
@@ -468,17 +639,22 @@ func TestGetAll_Success(t *testing.T) {
 			expectTransaction.CreatedAt,
 			expectTransaction.UpdatedAt,
 			expectTransaction.EmployeeId,
+			expectTransaction.Status,
+			expectTransaction.LateFee,
+			expectTransaction.PickupBranchID,
+			expectTransaction.ReturnBranchID,
+			expectTransaction.OneWayFee,
 		},
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM transaction"
-	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRows(value...)
+	rows := sqlmock.NewRows([]string{".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+", ".+"}).AddRows(value...)
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	actualGetAllTransaction, err := transactionRepository.GetAll()
+	actualGetAllTransaction, err := transactionRepository.GetAll("")
 	assert.Nil(t, err)
 	assert.Equal(t, expectedGetAllTranasction, actualGetAllTransaction)
 
@@ -494,15 +670,141 @@ func TestGetAll_Failed(t *testing.T) {
 		expectTransaction,
 	}
 
-	transactionRepository := NewTransactionRepository(dbMock)
+	transactionRepository := NewTransactionRepository(dbMock, 3)
 
 	query := "SELECT (.+) FROM transaction"
 
 	mock.ExpectQuery(query)
 
-	actualGetAllTransaction, err := transactionRepository.GetAll()
+	actualGetAllTransaction, err := transactionRepository.GetAll("")
 	assert.NotNil(t, err)
 	assert.Error(t, err)
 	assert.NotEqual(t, expectedGetAllTranasction, actualGetAllTransaction)
 
 }
+
+func TestAddTransaction_SuccessOneWay(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	pickupBranchId := "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+	returnBranchId := "6c2a3b4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e"
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		ID:             "123",
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+		ReturnBranchID: returnBranchId,
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
+
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(10000, 0, pickupBranchId)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
+
+	query = "SELECT fee FROM one_way_fee WHERE .+"
+	rows = sqlmock.NewRows([]string{"fee"}).AddRow(5000)
+	mock.ExpectQuery(query).WithArgs(pickupBranchId, returnBranchId).WillReturnRows(rows)
+
+	query = "SELECT (.+) FROM balance WHERE .+"
+	rows = sqlmock.NewRows([]string{".+"}).AddRow(30000)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	// the one-way fee is charged on top of the rental price
+	query = "UPDATE balance"
+	mock.ExpectExec(query).WithArgs(15000, expectAddTransactionRequest.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	query = "INSERT INTO transaction(.+) RETURNING .+;"
+	rows = sqlmock.NewRows([]string{".+"}).AddRow(expectAddTransactionRequest.ID)
+	mock.ExpectQuery(query).WithArgs(expectAddTransactionRequest.UserID, expectAddTransactionRequest.MotorVehicleId, sqlmock.AnyArg(), sqlmock.AnyArg(), 10000, pickupBranchId, returnBranchId, 5000).WillReturnRows(rows)
+	mock.ExpectExec("INSERT INTO dispatch_task(.+)").WithArgs(expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	query = "INSERT INTO motor_vehicle_event(.+) SELECT (.+) FROM motor_vehicle mv WHERE .+"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId, "", "rented", expectAddTransactionRequest.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	query = "UPDATE motor_vehicle"
+	mock.ExpectExec(query).WithArgs(expectAddTransactionRequest.MotorVehicleId).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectCommit()
+
+	actualAddTransaction, err := transactionRepository.Add(expectAddTransactionRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, pickupBranchId, actualAddTransaction.PickupBranchID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTransaction_FailedNotAtPickupBranch(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+		PickupBranchID: "6c2a3b4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
+
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(10000, 0, "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
+
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, errors.New("6"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTransaction_FailedNoOneWayRoute(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error DB:", err.Error())
+	}
+	defer dbMock.Close()
+
+	expectAddTransactionRequest := transactionDto.AddTransactionRequest{
+		UserID:         "123",
+		MotorVehicleId: "123",
+		StartDate:      "2024-08-13T09:00:00+07:00",
+		EndDate:        "2024-08-14T09:00:00+07:00",
+		ReturnBranchID: "6c2a3b4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e",
+	}
+
+	transactionRepository := NewTransactionRepository(dbMock, 3)
+
+	mock.ExpectBegin()
+	expectRentalEligibility(mock, expectAddTransactionRequest.UserID, 0, 0, 0)
+
+	query := "SELECT (.+) FROM motor_vehicle mv LEFT JOIN vehicle_model vm (.+) WHERE .+ FOR UPDATE OF mv;"
+	rows := sqlmock.NewRows([]string{"price", "hourly_price", "current_branch_id"}).AddRow(10000, 0, "5b1f2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	mock.ExpectQuery(query).WillReturnRows(rows)
+	expectDocumentsValid(mock, true)
+
+	query = "SELECT fee FROM one_way_fee WHERE .+"
+	mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+	mock.ExpectRollback()
+
+	_, err = transactionRepository.Add(expectAddTransactionRequest)
+	assert.Equal(t, errors.New("7"), err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -1,14 +1,19 @@
 package transactionUsecase
 
 import (
+	"bike-rent-express/model/dto/dispatchDto"
+	employeeDto "bike-rent-express/model/dto/employee"
 	"bike-rent-express/model/dto/transactionDto"
 	"bike-rent-express/src/Users"
+	"bike-rent-express/src/dispatch"
 	"bike-rent-express/src/employee"
 	"bike-rent-express/src/motorVehicle"
 	"bike-rent-express/src/transaction"
 	"database/sql"
 	"errors"
 	"strings"
+
+	"github.com/rs/zerolog/log"
 )
 
 type transactionUsecase struct {
@@ -16,10 +21,11 @@ type transactionUsecase struct {
 	userRepository        Users.UsersRepository
 	employeeRepository    employee.EmployeeRepository
 	vehicleRepository     motorVehicle.MotorVechileRepository
+	dispatchUsecase       dispatch.DispatchUsecase
 }
 
-func NewTransactionRepository(transactionRepository transaction.TransactionRepository, userRepository Users.UsersRepository, employeeRepository employee.EmployeeRepository, motorVehicleRepository motorVehicle.MotorVechileRepository) transaction.TransactionUsecase {
-	return &transactionUsecase{transactionRepository, userRepository, employeeRepository, motorVehicleRepository}
+func NewTransactionRepository(transactionRepository transaction.TransactionRepository, userRepository Users.UsersRepository, employeeRepository employee.EmployeeRepository, motorVehicleRepository motorVehicle.MotorVechileRepository, dispatchUsecase dispatch.DispatchUsecase) transaction.TransactionUsecase {
+	return &transactionUsecase{transactionRepository, userRepository, employeeRepository, motorVehicleRepository, dispatchUsecase}
 }
 
 func (t *transactionUsecase) AddTransaction(transactionRequest transactionDto.AddTransactionRequest) (transactionDto.Transaction, error) {
@@ -33,10 +39,23 @@ func (t *transactionUsecase) AddTransaction(transactionRequest transactionDto.Ad
 		return transaction, err
 	}
 
+	// the tasks are stored with the rental, a task nobody could take now is left to the
+	// dispatch job
+	tasks, err := t.dispatchUsecase.AssignPending(transaction.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to assign the tasks of transaction " + transaction.ID)
+	}
+
+	for _, task := range tasks {
+		if task.Type == dispatchDto.TaskDelivery {
+			transaction.EmployeeId = task.EmployeeID
+		}
+	}
+
 	return transaction, nil
 }
 
-func (t *transactionUsecase) GetTransactionById(id string) (transactionDto.ResponseTransaction, error) {
+func (t *transactionUsecase) GetTransactionById(id string, branchID string) (transactionDto.ResponseTransaction, error) {
 	var transactionDetail transactionDto.ResponseTransaction
 
 	transaction, err := t.transactionRepository.GetById(id)
@@ -47,17 +66,26 @@ func (t *transactionUsecase) GetTransactionById(id string) (transactionDto.Respo
 		return transactionDetail, err
 	}
 
+	// a caller scoped to a branch only sees the rentals that start or end there
+	if branchID != "" && transaction.PickupBranchID != branchID && transaction.ReturnBranchID != branchID {
+		return transactionDetail, errors.New("1")
+	}
+
 	motorVehicle, err := t.vehicleRepository.RetrieveMotorVehicleById(transaction.MotorVehicleId)
 	if err != nil {
 		return transactionDetail, err
 	}
 
-	employee, err := t.employeeRepository.GetById(transaction.EmployeeId)
-	if err != nil {
-		return transactionDetail, err
+	// the delivery of a rental nobody could be dispatched to has no employee yet
+	var employee employeeDto.Employee
+	if transaction.EmployeeId != "" {
+		employee, err = t.employeeRepository.GetById(transaction.EmployeeId)
+		if err != nil {
+			return transactionDetail, err
+		}
 	}
 
-	customer, err := t.userRepository.GetByID(transaction.UserID)
+	customer, err := t.userRepository.GetByIDIncludingDeleted(transaction.UserID)
 	if err != nil {
 		return transactionDetail, err
 	}
@@ -66,6 +94,11 @@ func (t *transactionUsecase) GetTransactionById(id string) (transactionDto.Respo
 	transactionDetail.StartDate = transaction.StartDate
 	transactionDetail.EndDate = transaction.EndDate
 	transactionDetail.Price = transaction.Price
+	transactionDetail.Status = transaction.Status
+	transactionDetail.LateFee = transaction.LateFee
+	transactionDetail.PickupBranchID = transaction.PickupBranchID
+	transactionDetail.ReturnBranchID = transaction.ReturnBranchID
+	transactionDetail.OneWayFee = transaction.OneWayFee
 	transactionDetail.MotorVehicle = motorVehicle
 	transactionDetail.Employee = employee
 	transactionDetail.Customer = customer
@@ -75,10 +108,10 @@ func (t *transactionUsecase) GetTransactionById(id string) (transactionDto.Respo
 	return transactionDetail, nil
 }
 
-func (t *transactionUsecase) GetTransactionAll() ([]transactionDto.ResponseTransaction, error) {
+func (t *transactionUsecase) GetTransactionAll(branchID string) ([]transactionDto.ResponseTransaction, error) {
 	var transactionsDetail []transactionDto.ResponseTransaction
 
-	transactions, err := t.transactionRepository.GetAll()
+	transactions, err := t.transactionRepository.GetAll(branchID)
 	if err != nil {
 		return transactionsDetail, err
 	}
@@ -96,12 +129,15 @@ func (t *transactionUsecase) GetTransactionAll() ([]transactionDto.ResponseTrans
 			return transactionsDetail, err
 		}
 
-		employee, err := t.employeeRepository.GetById(transaction.EmployeeId)
-		if err != nil {
-			return transactionsDetail, err
+		var employee employeeDto.Employee
+		if transaction.EmployeeId != "" {
+			employee, err = t.employeeRepository.GetById(transaction.EmployeeId)
+			if err != nil {
+				return transactionsDetail, err
+			}
 		}
 
-		customer, err := t.userRepository.GetByID(transaction.UserID)
+		customer, err := t.userRepository.GetByIDIncludingDeleted(transaction.UserID)
 		if err != nil {
 			return transactionsDetail, err
 		}
@@ -110,6 +146,11 @@ func (t *transactionUsecase) GetTransactionAll() ([]transactionDto.ResponseTrans
 		transactionDetail.StartDate = transaction.StartDate
 		transactionDetail.EndDate = transaction.EndDate
 		transactionDetail.Price = transaction.Price
+		transactionDetail.Status = transaction.Status
+		transactionDetail.LateFee = transaction.LateFee
+		transactionDetail.PickupBranchID = transaction.PickupBranchID
+		transactionDetail.ReturnBranchID = transaction.ReturnBranchID
+		transactionDetail.OneWayFee = transaction.OneWayFee
 		transactionDetail.MotorVehicle = motorVehicle
 		transactionDetail.Employee = employee
 		transactionDetail.Customer = customer
@@ -2,9 +2,12 @@ package transactionUsecase
 
 import (
 	"bike-rent-express/model/dto"
+	"bike-rent-express/model/dto/dispatchDto"
 	employeeDto "bike-rent-express/model/dto/employee"
 	"bike-rent-express/model/dto/motorVehicleDto"
 	"bike-rent-express/model/dto/transactionDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/dispatch/dispatchMock"
 	"bike-rent-express/src/transaction"
 	"database/sql"
 	"errors"
@@ -29,8 +32,8 @@ func (m *mockTransactionRepository) GetById(id string) (transactionDto.Transacti
 	return args.Get(0).(transactionDto.Transaction), args.Error(1)
 }
 
-func (m *mockTransactionRepository) GetAll() ([]transactionDto.Transaction, error) {
-	args := m.Called()
+func (m *mockTransactionRepository) GetAll(branchID string) ([]transactionDto.Transaction, error) {
+	args := m.Called(branchID)
 	return args.Get(0).([]transactionDto.Transaction), args.Error(1)
 }
 
@@ -47,6 +50,10 @@ func (m *mockUserRepository) GetByID(id string) (dto.GetUsers, error) {
 	args := m.Called(id)
 	return args.Get(0).(dto.GetUsers), args.Error(1)
 }
+func (m *mockUserRepository) GetByIDIncludingDeleted(id string) (dto.GetUsers, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.GetUsers), args.Error(1)
+}
 func (m *mockUserRepository) GetAll() ([]dto.GetUsers, error) {
 	args := m.Called()
 	return args.Get(0).([]dto.GetUsers), args.Error(1)
@@ -55,6 +62,18 @@ func (m *mockUserRepository) UpdateUsers(usersItem dto.Users) error {
 	args := m.Called(usersItem)
 	return args.Error(0)
 }
+func (m *mockUserRepository) GetRentalEligibility(id string) (dto.RentalEligibility, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.RentalEligibility), args.Error(1)
+}
+func (m *mockUserRepository) DeactivateUsers(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+func (m *mockUserRepository) CloseAccount(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
 func (m *mockUserRepository) GetByUsername(username string) (dto.Users, error) {
 	args := m.Called(username)
 	return args.Get(0).(dto.Users), args.Error(1)
@@ -85,10 +104,14 @@ func (m *mockEmployeeRepository) Add(employee employeeDto.CreateEmployeeRequest)
 	args := m.Called(employee)
 	return args.Get(0).(employeeDto.CreateEmployeeRequest), args.Error(1)
 }
-func (m *mockEmployeeRepository) Get() ([]employeeDto.Employee, error) {
-	args := m.Called()
+func (m *mockEmployeeRepository) Get(branchID string) ([]employeeDto.Employee, error) {
+	args := m.Called(branchID)
 	return args.Get(0).([]employeeDto.Employee), args.Error(1)
 }
+func (m *mockEmployeeRepository) Assign(employee employeeDto.AssignEmployeeRequest) (employeeDto.Employee, error) {
+	args := m.Called(employee)
+	return args.Get(0).(employeeDto.Employee), args.Error(1)
+}
 func (m *mockEmployeeRepository) UsernameIsReady(username string) (bool, error) {
 	args := m.Called(username)
 	return args.Bool(0), args.Error(1)
@@ -118,9 +141,9 @@ type mockMotorVehicleRepository struct {
 	mock.Mock
 }
 
-func (m *mockMotorVehicleRepository) RetrieveAllMotorVehicle() ([]motorVehicleDto.MotorVehicle, error) {
-	args := m.Called()
-	return args.Get(0).([]motorVehicleDto.MotorVehicle), args.Error(1)
+func (m *mockMotorVehicleRepository) RetrieveAllMotorVehicle(branchID string, spec queryspec.Spec) ([]motorVehicleDto.MotorVehicle, queryspec.Page, error) {
+	args := m.Called(branchID, spec)
+	return args.Get(0).([]motorVehicleDto.MotorVehicle), args.Get(1).(queryspec.Page), args.Error(2)
 }
 func (m *mockMotorVehicleRepository) RetrieveMotorVehicleById(id string) (motorVehicleDto.MotorVehicle, error) {
 	args := m.Called(id)
@@ -130,8 +153,8 @@ func (m *mockMotorVehicleRepository) InsertMotorVehicle(motor motorVehicleDto.Mo
 	args := m.Called(motor)
 	return args.Get(0).(motorVehicleDto.MotorVehicle), args.Error(1)
 }
-func (m *mockMotorVehicleRepository) ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle) (motorVehicleDto.MotorVehicle, error) {
-	args := m.Called(id, motor)
+func (m *mockMotorVehicleRepository) ChangeMotorVehicle(id string, motor motorVehicleDto.MotorVehicle, actor string, reason string) (motorVehicleDto.MotorVehicle, error) {
+	args := m.Called(id, motor, actor, reason)
 	return args.Get(0).(motorVehicleDto.MotorVehicle), args.Error(1)
 }
 func (m *mockMotorVehicleRepository) DropMotorVehicle(id string) error {
@@ -139,11 +162,31 @@ func (m *mockMotorVehicleRepository) DropMotorVehicle(id string) error {
 	return args.Error(0)
 }
 
+func (m *mockMotorVehicleRepository) RetrieveMotorVehicleUsage(id string) (motorVehicleDto.MotorVehicleUsage, error) {
+	args := m.Called(id)
+	return args.Get(0).(motorVehicleDto.MotorVehicleUsage), args.Error(1)
+}
+
+func (m *mockMotorVehicleRepository) RetrieveMotorVehicleHistory(id string) (motorVehicleDto.MotorVehicleHistory, error) {
+	args := m.Called(id)
+	return args.Get(0).(motorVehicleDto.MotorVehicleHistory), args.Error(1)
+}
+
 func (m *mockMotorVehicleRepository) CheckPlatMotor(plat string) (bool, error) {
 	args := m.Called(plat)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockMotorVehicleRepository) InsertMotorVehicles(motors []motorVehicleDto.MotorVehicle, dryRun bool) (int, error) {
+	args := m.Called(motors, dryRun)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockMotorVehicleRepository) RetrieveMotorVehicleExport() ([]motorVehicleDto.MotorVehicle, error) {
+	args := m.Called()
+	return args.Get(0).([]motorVehicleDto.MotorVehicle), args.Error(1)
+}
+
 var expectMotorVehicle = motorVehicleDto.MotorVehicle{
 	Id:             "1",
 	Name:           "test",
@@ -177,17 +220,18 @@ var expectCustomer = dto.GetUsers{
 	Telp:       "0812312",
 }
 var expectTransaction = transactionDto.Transaction{
-	ID:        "1",
-	StartDate: "13-09-2024",
-	EndDate:   "13-09-2025",
-	Price:     20000,
-	CreatedAt: "test",
-	UpdatedAt: "test",
+	ID:         "1",
+	EmployeeId: "1",
+	StartDate:  "2024-09-13T09:00:00+07:00",
+	EndDate:    "2025-09-13T09:00:00+07:00",
+	Price:      20000,
+	CreatedAt:  "test",
+	UpdatedAt:  "test",
 }
 var expectTransactionResponse = transactionDto.ResponseTransaction{
 	ID:           "1",
-	StartDate:    "13-09-2024",
-	EndDate:      "13-09-2025",
+	StartDate:    "2024-09-13T09:00:00+07:00",
+	EndDate:      "2025-09-13T09:00:00+07:00",
 	Price:        20000,
 	MotorVehicle: expectMotorVehicle,
 	Employee:     expectEmployee,
@@ -202,6 +246,7 @@ type TransactionUseCaseSuite struct {
 	mockUserRepository         *mockUserRepository
 	mockEmployeeRepository     *mockEmployeeRepository
 	mockMotorVehicleRepository *mockMotorVehicleRepository
+	mockDispatchUsecase        *dispatchMock.DispatchUsecase
 	transactionUsecase         transaction.TransactionUsecase
 }
 
@@ -210,7 +255,8 @@ func (suite *TransactionUseCaseSuite) SetupTest() {
 	suite.mockUserRepository = new(mockUserRepository)
 	suite.mockEmployeeRepository = new(mockEmployeeRepository)
 	suite.mockMotorVehicleRepository = new(mockMotorVehicleRepository)
-	suite.transactionUsecase = NewTransactionRepository(suite.mockTransactionRepository, suite.mockUserRepository, suite.mockEmployeeRepository, suite.mockMotorVehicleRepository)
+	suite.mockDispatchUsecase = new(dispatchMock.DispatchUsecase)
+	suite.transactionUsecase = NewTransactionRepository(suite.mockTransactionRepository, suite.mockUserRepository, suite.mockEmployeeRepository, suite.mockMotorVehicleRepository, suite.mockDispatchUsecase)
 }
 
 func (suite *TransactionUseCaseSuite) TestAddTransaction_Success() {
@@ -218,46 +264,73 @@ func (suite *TransactionUseCaseSuite) TestAddTransaction_Success() {
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
-		EmployeeId:     "1",
-		StartDate:      "13-09-2023",
-		EndDate:        "15-09-2024",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-15T09:00:00+07:00",
 	}
 	expectTransaction := transactionDto.Transaction{
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
 		EmployeeId:     "1",
-		StartDate:      "13-09-2023",
-		EndDate:        "13-09-2024",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-13T09:00:00+07:00",
 		Price:          2000,
 		CreatedAt:      "123",
 		UpdatedAt:      "123",
 	}
 
+	createdTransaction := expectTransaction
+	createdTransaction.EmployeeId = ""
+	tasks := []dispatchDto.Task{
+		{ID: "1", TransactionID: "1", Type: dispatchDto.TaskDelivery, EmployeeID: "1", Status: dispatchDto.StatusAssigned},
+		{ID: "2", TransactionID: "1", Type: dispatchDto.TaskPickup, EmployeeID: "2", Status: dispatchDto.StatusAssigned},
+	}
+
 	suite.mockTransactionRepository.On("Add", addTransaction).Return(addTransaction, nil)
-	suite.mockTransactionRepository.On("GetById", addTransaction.ID).Return(expectTransaction, nil)
+	suite.mockTransactionRepository.On("GetById", addTransaction.ID).Return(createdTransaction, nil)
+	suite.mockDispatchUsecase.On("AssignPending", addTransaction.ID).Return(tasks, nil)
 
 	actualTransaction, err := suite.transactionUsecase.AddTransaction(addTransaction)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectTransaction, actualTransaction)
 }
 
+func (suite *TransactionUseCaseSuite) TestAddTransaction_SuccessAssignLeftToJob() {
+	addTransaction := transactionDto.AddTransactionRequest{
+		ID:             "1",
+		UserID:         "1",
+		MotorVehicleId: "1",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-15T09:00:00+07:00",
+	}
+	createdTransaction := transactionDto.Transaction{ID: "1", UserID: "1", MotorVehicleId: "1"}
+	expectError := errors.New("error")
+
+	suite.mockTransactionRepository.On("Add", addTransaction).Return(addTransaction, nil)
+	suite.mockTransactionRepository.On("GetById", addTransaction.ID).Return(createdTransaction, nil)
+	suite.mockDispatchUsecase.On("AssignPending", addTransaction.ID).Return([]dispatchDto.Task{}, expectError)
+
+	// the rental and its tasks are already stored, the dispatch job assigns them later
+	actualTransaction, err := suite.transactionUsecase.AddTransaction(addTransaction)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), createdTransaction, actualTransaction)
+}
+
 func (suite *TransactionUseCaseSuite) TestAddTransaction_FailedAdd() {
 	addTransaction := transactionDto.AddTransactionRequest{
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
-		EmployeeId:     "1",
-		StartDate:      "13-09-2023",
-		EndDate:        "15-09-2024",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-15T09:00:00+07:00",
 	}
 	expectTransaction := transactionDto.Transaction{
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
 		EmployeeId:     "1",
-		StartDate:      "13-09-2023",
-		EndDate:        "13-09-2024",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-13T09:00:00+07:00",
 		Price:          2000,
 		CreatedAt:      "123",
 		UpdatedAt:      "123",
@@ -278,17 +351,16 @@ func (suite *TransactionUseCaseSuite) TestAddTransaction_FailedGetById() {
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
-		EmployeeId:     "1",
-		StartDate:      "13-09-2023",
-		EndDate:        "15-09-2024",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-15T09:00:00+07:00",
 	}
 	expectTransaction := transactionDto.Transaction{
 		ID:             "1",
 		UserID:         "1",
 		MotorVehicleId: "1",
 		EmployeeId:     "1",
-		StartDate:      "13-09-2023",
-		EndDate:        "13-09-2024",
+		StartDate:      "2023-09-13T09:00:00+07:00",
+		EndDate:        "2024-09-13T09:00:00+07:00",
 		Price:          2000,
 		CreatedAt:      "123",
 		UpdatedAt:      "123",
@@ -308,17 +380,38 @@ func (suite *TransactionUseCaseSuite) TestGetTransactionById_Success() {
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
 	suite.mockEmployeeRepository.On("GetById", expectTransaction.EmployeeId).Return(expectEmployee, nil)
-	suite.mockUserRepository.On("GetByID", expectTransaction.UserID).Return(expectCustomer, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectTransaction.UserID).Return(expectCustomer, nil)
 
-	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID)
+	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectTransactionResponse, actualExpectTransactionResponse)
 }
 
+func (suite *TransactionUseCaseSuite) TestGetTransactionById_SuccessNotDispatched() {
+	undispatchedTransaction := expectTransaction
+	undispatchedTransaction.EmployeeId = ""
+	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(undispatchedTransaction, nil)
+	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectTransaction.UserID).Return(expectCustomer, nil)
+
+	actualTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), employeeDto.Employee{}, actualTransactionResponse.Employee)
+	suite.mockEmployeeRepository.AssertNotCalled(suite.T(), "GetById", "")
+}
+
+func (suite *TransactionUseCaseSuite) TestGetTransactionById_FailedOtherBranch() {
+	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
+
+	_, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "other-branch")
+	assert.Equal(suite.T(), errors.New("1"), err)
+	suite.mockMotorVehicleRepository.AssertNotCalled(suite.T(), "RetrieveMotorVehicleById", expectTransaction.MotorVehicleId)
+}
+
 func (suite *TransactionUseCaseSuite) TestGetTransactionById_FailedGetByIdInvalidInputOrSqlNoRows() {
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, sql.ErrNoRows)
 
-	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID)
+	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
 	assert.NotNil(suite.T(), err)
 	assert.NotEqual(suite.T(), expectTransactionResponse, actualExpectTransactionResponse)
 }
@@ -327,7 +420,7 @@ func (suite *TransactionUseCaseSuite) TestGetTransactionById_FailedGetById() {
 	expectError := errors.New("error")
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, expectError)
 
-	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID)
+	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionResponse, actualExpectTransactionResponse)
@@ -338,7 +431,7 @@ func (suite *TransactionUseCaseSuite) TestGetTransactionById_FailedGetMotorVehic
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, expectError)
 
-	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID)
+	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionResponse, actualExpectTransactionResponse)
@@ -350,7 +443,7 @@ func (suite *TransactionUseCaseSuite) TestGetTransactionById_FailedGetEmployee()
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
 	suite.mockEmployeeRepository.On("GetById", expectTransaction.EmployeeId).Return(expectEmployee, expectError)
 
-	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID)
+	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionResponse, actualExpectTransactionResponse)
@@ -361,9 +454,9 @@ func (suite *TransactionUseCaseSuite) TestGetTransactionById_FailedGetCustomer()
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
 	suite.mockEmployeeRepository.On("GetById", expectTransaction.EmployeeId).Return(expectEmployee, nil)
-	suite.mockUserRepository.On("GetByID", expectTransaction.UserID).Return(expectCustomer, expectError)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectTransaction.UserID).Return(expectCustomer, expectError)
 
-	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID)
+	actualExpectTransactionResponse, err := suite.transactionUsecase.GetTransactionById(expectTransaction.ID, "")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionResponse, actualExpectTransactionResponse)
@@ -378,13 +471,13 @@ func (suite *TransactionUseCaseSuite) TestGetAllTransaction_Success() {
 		expectTransactionResponse,
 	}
 
-	suite.mockTransactionRepository.On("GetAll").Return(allTransaction, nil)
+	suite.mockTransactionRepository.On("GetAll", "").Return(allTransaction, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
 	suite.mockEmployeeRepository.On("GetById", expectTransaction.EmployeeId).Return(expectEmployee, nil)
-	suite.mockUserRepository.On("GetByID", expectTransaction.UserID).Return(expectCustomer, nil)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectTransaction.UserID).Return(expectCustomer, nil)
 
-	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll()
+	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll("")
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectTransactionGetAllResponse, actualTransactionGetAllResponse)
 }
@@ -398,9 +491,9 @@ func (suite *TransactionUseCaseSuite) TestGetAllTransaction_FailedGetAll() {
 		expectTransactionResponse,
 	}
 	expectError := errors.New("error")
-	suite.mockTransactionRepository.On("GetAll").Return(allTransaction, expectError)
+	suite.mockTransactionRepository.On("GetAll", "").Return(allTransaction, expectError)
 
-	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll()
+	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll("")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionGetAllResponse, actualTransactionGetAllResponse)
@@ -416,10 +509,10 @@ func (suite *TransactionUseCaseSuite) TestGetAllTransaction_FailedTransactionGet
 	}
 
 	expectError := errors.New("error")
-	suite.mockTransactionRepository.On("GetAll").Return(allTransaction, nil)
+	suite.mockTransactionRepository.On("GetAll", "").Return(allTransaction, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, expectError)
 
-	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll()
+	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll("")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionGetAllResponse, actualTransactionGetAllResponse)
@@ -435,11 +528,11 @@ func (suite *TransactionUseCaseSuite) TestGetAllTransaction_FailedGetMotorById()
 	}
 
 	expectError := errors.New("error")
-	suite.mockTransactionRepository.On("GetAll").Return(allTransaction, nil)
+	suite.mockTransactionRepository.On("GetAll", "").Return(allTransaction, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, expectError)
 
-	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll()
+	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll("")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionGetAllResponse, actualTransactionGetAllResponse)
@@ -455,12 +548,12 @@ func (suite *TransactionUseCaseSuite) TestGetAllTransaction_FailedGetEmployeeId(
 	}
 
 	expectError := errors.New("error")
-	suite.mockTransactionRepository.On("GetAll").Return(allTransaction, nil)
+	suite.mockTransactionRepository.On("GetAll", "").Return(allTransaction, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
 	suite.mockEmployeeRepository.On("GetById", expectTransaction.EmployeeId).Return(expectEmployee, expectError)
 
-	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll()
+	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll("")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionGetAllResponse, actualTransactionGetAllResponse)
@@ -476,13 +569,13 @@ func (suite *TransactionUseCaseSuite) TestGetAllTransaction_FailedCustomerGetByI
 	}
 
 	expectError := errors.New("error")
-	suite.mockTransactionRepository.On("GetAll").Return(allTransaction, nil)
+	suite.mockTransactionRepository.On("GetAll", "").Return(allTransaction, nil)
 	suite.mockTransactionRepository.On("GetById", expectTransaction.ID).Return(expectTransaction, nil)
 	suite.mockMotorVehicleRepository.On("RetrieveMotorVehicleById", expectTransaction.MotorVehicleId).Return(expectMotorVehicle, nil)
 	suite.mockEmployeeRepository.On("GetById", expectTransaction.EmployeeId).Return(expectEmployee, nil)
-	suite.mockUserRepository.On("GetByID", expectTransaction.UserID).Return(expectCustomer, expectError)
+	suite.mockUserRepository.On("GetByIDIncludingDeleted", expectTransaction.UserID).Return(expectCustomer, expectError)
 
-	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll()
+	actualTransactionGetAllResponse, err := suite.transactionUsecase.GetTransactionAll("")
 	assert.NotNil(suite.T(), err)
 	assert.ErrorIs(suite.T(), expectError, err)
 	assert.NotEqual(suite.T(), expectTransactionGetAllResponse, actualTransactionGetAllResponse)
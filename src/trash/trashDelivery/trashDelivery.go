@@ -0,0 +1,87 @@
+package trashDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/src/trash"
+
+	"github.com/gin-gonic/gin"
+)
+
+type trashDelivery struct {
+	trashUC trash.TrashUsecase
+}
+
+func NewTrashDelivery(v1Group *gin.RouterGroup, trashUC trash.TrashUsecase) {
+	handler := trashDelivery{trashUC}
+
+	trashGroup := v1Group.Group("/trash")
+	{
+		trashGroup.GET("/:kind", middleware.JWTAuth("ADMIN"), handler.GetAllDeleted)
+		trashGroup.PUT("/:kind/:id/restore", middleware.JWTAuth("ADMIN"), handler.RestoreDeleted)
+		trashGroup.DELETE("/:kind/:id", middleware.JWTAuth("ADMIN"), handler.PurgeDeleted)
+	}
+}
+
+func (t *trashDelivery) GetAllDeleted(ctx *gin.Context) {
+	records, err := t.trashUC.GetAllDeleted(ctx.Param("kind"))
+	if err != nil {
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "kind must be motor-vehicles, users or employees", "01", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	if len(records) == 0 {
+		json.NewResponseSuccess(ctx, []trashDto.DeletedRecord{}, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, records, "Success get all deleted records", "01", "02")
+}
+
+func (t *trashDelivery) RestoreDeleted(ctx *gin.Context) {
+	if err := t.trashUC.RestoreDeleted(ctx.Param("kind"), ctx.Param("id")); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "02", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "kind must be motor-vehicles, users or employees", "02", "02")
+			return
+		}
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(ctx, nil, "the plate or username is used by another record", "02", "03")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Record restored", "02", "02")
+}
+
+func (t *trashDelivery) PurgeDeleted(ctx *gin.Context) {
+	result, err := t.trashUC.PurgeDeleted(ctx.Param("kind"), ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "03", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "kind must be motor-vehicles, users or employees", "03", "02")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "the record is kept until its retention period is over", "03", "03")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, result, "Record purged", "03", "02")
+}
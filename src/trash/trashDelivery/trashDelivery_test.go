@@ -0,0 +1,152 @@
+package trashDelivery
+
+import (
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/pkg/middleware"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var deletedVehicle = trashDto.DeletedRecord{
+	Kind:          "motor-vehicles",
+	ID:            "1",
+	Name:          "Vario",
+	Label:         "BA 1234 I",
+	DeletedAt:     "2024-12-20",
+	PurgeableFrom: "2025-03-20",
+}
+
+type mockTrashUC struct {
+	mock.Mock
+}
+
+func (m *mockTrashUC) GetAllDeleted(kind string) ([]trashDto.DeletedRecord, error) {
+	args := m.Called(kind)
+	return args.Get(0).([]trashDto.DeletedRecord), args.Error(1)
+}
+
+func (m *mockTrashUC) RestoreDeleted(kind string, id string) error {
+	args := m.Called(kind, id)
+	return args.Error(0)
+}
+
+func (m *mockTrashUC) PurgeDeleted(kind string, id string) (trashDto.PurgeResult, error) {
+	args := m.Called(kind, id)
+	return args.Get(0).(trashDto.PurgeResult), args.Error(1)
+}
+
+type TrashDeliveryTestSuite struct {
+	suite.Suite
+	mockTrashUC   *mockTrashUC
+	router        *gin.Engine
+	adminToken    string
+	employeeToken string
+}
+
+func (suite *TrashDeliveryTestSuite) SetupTest() {
+	suite.mockTrashUC = new(mockTrashUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewTrashDelivery(v1, suite.mockTrashUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("employee", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.employeeToken = "Bearer " + token
+}
+
+func (suite *TrashDeliveryTestSuite) TestGetAllDeleted_Success() {
+	expectResponse := `{"responseCode":"2000102","responseMessage":"Success get all deleted records","data":[{"kind":"motor-vehicles","id":"1","name":"Vario","label":"BA 1234 I","deleted_at":"2024-12-20","purgeable_from":"2025-03-20"}]}`
+	suite.mockTrashUC.On("GetAllDeleted", "motor-vehicles").Return([]trashDto.DeletedRecord{deletedVehicle}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/trash/motor-vehicles", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *TrashDeliveryTestSuite) TestGetAllDeleted_FailedUnknownKind() {
+	suite.mockTrashUC.On("GetAllDeleted", "branches").Return([]trashDto.DeletedRecord{}, errors.New("2"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/trash/branches", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+}
+
+func (suite *TrashDeliveryTestSuite) TestGetAllDeleted_FailedNotAdmin() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/trash/users", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+	suite.mockTrashUC.AssertNotCalled(suite.T(), "GetAllDeleted", mock.Anything)
+}
+
+func (suite *TrashDeliveryTestSuite) TestRestoreDeleted_Success() {
+	suite.mockTrashUC.On("RestoreDeleted", "users", "1").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/trash/users/1/restore", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2000202","responseMessage":"Record restored"`)
+}
+
+func (suite *TrashDeliveryTestSuite) TestRestoreDeleted_FailedTaken() {
+	suite.mockTrashUC.On("RestoreDeleted", "employees", "1").Return(errors.New("4"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/trash/employees/1/restore", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"4000203"`)
+}
+
+func (suite *TrashDeliveryTestSuite) TestPurgeDeleted_Success() {
+	suite.mockTrashUC.On("PurgeDeleted", "motor-vehicles", "1").Return(trashDto.PurgeResult{Kind: "motor-vehicles", ID: "1", Outcome: "ANONYMIZED"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/trash/motor-vehicles/1", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"2000302","responseMessage":"Record purged","data":{"kind":"motor-vehicles","id":"1","outcome":"ANONYMIZED"}}`, w.Body.String())
+}
+
+func (suite *TrashDeliveryTestSuite) TestPurgeDeleted_FailedRetentionNotOver() {
+	suite.mockTrashUC.On("PurgeDeleted", "users", "1").Return(trashDto.PurgeResult{}, errors.New("3"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/trash/users/1", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"4000303"`)
+}
+
+func TestTrashDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashDeliveryTestSuite))
+}
@@ -0,0 +1,39 @@
+package trash
+
+import "bike-rent-express/model/dto/trashDto"
+
+// the kinds of deleted records as they are named in the routes
+const (
+	KindMotorVehicles = "motor-vehicles"
+	KindUsers         = "users"
+	KindEmployees     = "employees"
+)
+
+var Kinds = []string{KindMotorVehicles, KindUsers, KindEmployees}
+
+// PurgeJobName is the scheduler job purging expired records, a record is purged only once even
+// when replicas run it together
+const PurgeJobName = "trash-purge"
+
+const (
+	OutcomeDeleted    = "DELETED"
+	OutcomeAnonymized = "ANONYMIZED"
+)
+
+type (
+	TrashRepository interface {
+		GetAll(kind string, deletedBefore string) ([]trashDto.DeletedRecord, error)
+		Restore(kind string, id string) error
+		Purge(kind string, id string, deletedBefore string) (string, error)
+	}
+
+	TrashUsecase interface {
+		GetAllDeleted(kind string) ([]trashDto.DeletedRecord, error)
+		RestoreDeleted(kind string, id string) error
+		PurgeDeleted(kind string, id string) (trashDto.PurgeResult, error)
+	}
+
+	PurgeUsecase interface {
+		ProcessPurge() ([]trashDto.PurgeResult, error)
+	}
+)
@@ -0,0 +1,175 @@
+package trashRepository
+
+import (
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/trash"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// table tells how the deleted records of a kind are listed, restored and purged, the queries
+// of owned delete the rows that only make sense with the record or detach the ones kept without it
+type table struct {
+	name      string
+	nameCol   string
+	labelCol  string
+	taken     string
+	owned     []string
+	anonymize string
+}
+
+var tables = map[string]table{
+	trash.KindMotorVehicles: {
+		name:      "motor_vehicle",
+		nameCol:   "COALESCE(name, '')",
+		labelCol:  "plat",
+		taken:     "SELECT EXISTS(SELECT 1 FROM motor_vehicle WHERE UPPER(REGEXP_REPLACE(plat, '\\s', '', 'g')) = UPPER(REGEXP_REPLACE($1, '\\s', '', 'g')) AND deleted_at IS NULL);",
+		owned:     []string{"UPDATE motor_vehicle_event SET motor_vehicle_id = NULL, old_value = CASE WHEN field = 'PLAT' THEN NULL ELSE old_value END, new_value = CASE WHEN field = 'PLAT' THEN NULL ELSE new_value END WHERE motor_vehicle_id = $1;", "DELETE FROM vehicle_document WHERE motor_vehicle_id = $1;", "DELETE FROM maintenance_schedule WHERE motor_vehicle_id = $1;"},
+		anonymize: "UPDATE motor_vehicle SET plat = 'PURGED', purged_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1;",
+	},
+	trash.KindUsers: {
+		name:      "users",
+		nameCol:   "name",
+		labelCol:  "username",
+		taken:     "SELECT EXISTS(SELECT 1 FROM users WHERE username = $1 AND deleted_at IS NULL);",
+		anonymize: utils.AnonymizeUser,
+	},
+	trash.KindEmployees: {
+		name:      "employee",
+		nameCol:   "name",
+		labelCol:  "username",
+		taken:     "SELECT EXISTS(SELECT 1 FROM employee WHERE username = $1 AND deleted_at IS NULL);",
+		owned:     []string{"DELETE FROM shift_exception WHERE employee_id = $1;", "DELETE FROM shift_template WHERE employee_id = $1;"},
+		anonymize: "UPDATE employee SET name = 'Deleted employee', username = 'deleted-' || id, password = '', telp = '', purged_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1;",
+	},
+}
+
+type trashRepository struct {
+	db *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) trash.TrashRepository {
+	return &trashRepository{db}
+}
+
+// GetAll lists the deleted records of the kind that are not purged yet, only the ones deleted
+// on or before deletedBefore unless it is empty.
+func (t *trashRepository) GetAll(kind string, deletedBefore string) ([]trashDto.DeletedRecord, error) {
+	tbl := tables[kind]
+	query := "SELECT id, " + tbl.nameCol + ", " + tbl.labelCol + ", TO_CHAR(deleted_at, 'YYYY-MM-DD') FROM " + tbl.name + " WHERE deleted_at IS NOT NULL AND purged_at IS NULL AND ($1 = '' OR deleted_at <= $1::date) ORDER BY deleted_at, id;"
+	rows, err := t.db.Query(query, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []trashDto.DeletedRecord
+	for rows.Next() {
+		record := trashDto.DeletedRecord{Kind: kind}
+		if err := rows.Scan(&record.ID, &record.Name, &record.Label, &record.DeletedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// Restore fails with "4" when the plate or username of the record is used by another one that
+// is not deleted.
+func (t *trashRepository) Restore(kind string, id string) error {
+	tbl := tables[kind]
+	tx, err := t.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var label string
+	query := "SELECT " + tbl.labelCol + " FROM " + tbl.name + " WHERE id = $1 AND deleted_at IS NOT NULL AND purged_at IS NULL FOR UPDATE;"
+	if err := tx.QueryRow(query, id).Scan(&label); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var taken bool
+	if err := tx.QueryRow(tbl.taken, label).Scan(&taken); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if taken {
+		tx.Rollback()
+		return errors.New("4")
+	}
+
+	query = "UPDATE " + tbl.name + " SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1;"
+	if _, err := tx.Exec(query, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Purge deletes the record together with the rows it owns, it fails with "3" unless the record
+// was deleted on or before deletedBefore. When rentals or other records still point at it the
+// delete is undone and the record is anonymized instead.
+func (t *trashRepository) Purge(kind string, id string, deletedBefore string) (string, error) {
+	tbl := tables[kind]
+	tx, err := t.db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	var expired bool
+	query := "SELECT deleted_at <= $2::date FROM " + tbl.name + " WHERE id = $1 AND deleted_at IS NOT NULL AND purged_at IS NULL FOR UPDATE;"
+	if err := tx.QueryRow(query, id, deletedBefore).Scan(&expired); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+	if !expired {
+		tx.Rollback()
+		return "", errors.New("3")
+	}
+
+	if _, err := tx.Exec("SAVEPOINT purge;"); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	outcome := trash.OutcomeDeleted
+	if err := deleteRecord(tx, tbl, id); err != nil {
+		if !strings.Contains(err.Error(), "violates foreign key constraint") {
+			tx.Rollback()
+			return "", err
+		}
+
+		if _, err := tx.Exec("ROLLBACK TO SAVEPOINT purge;"); err != nil {
+			tx.Rollback()
+			return "", err
+		}
+		if _, err := tx.Exec(tbl.anonymize, id); err != nil {
+			tx.Rollback()
+			return "", err
+		}
+		outcome = trash.OutcomeAnonymized
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return outcome, nil
+}
+
+func deleteRecord(tx *sql.Tx, tbl table, id string) error {
+	for _, query := range tbl.owned {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec("DELETE FROM "+tbl.name+" WHERE id = $1;", id)
+	return err
+}
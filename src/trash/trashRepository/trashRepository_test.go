@@ -0,0 +1,179 @@
+package trashRepository
+
+import (
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/src/trash"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const vehicleID = "11111111-1111-1111-1111-111111111111"
+
+func TestGetAll_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	query := "SELECT id, COALESCE\\(name, ''\\), plat, (.+) FROM motor_vehicle WHERE deleted_at IS NOT NULL AND purged_at IS NULL AND \\(\\$1 = '' OR deleted_at <= \\$1::date\\) ORDER BY deleted_at, id;"
+	mock.ExpectQuery(query).WithArgs("").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "plat", "deleted_at"}).AddRow(vehicleID, "Vario", "BA 1234 I", "2025-01-01"))
+
+	records, err := repository.GetAll(trash.KindMotorVehicles, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []trashDto.DeletedRecord{{Kind: trash.KindMotorVehicles, ID: vehicleID, Name: "Vario", Label: "BA 1234 I", DeletedAt: "2025-01-01"}}, records)
+}
+
+func TestRestore_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT username FROM employee WHERE id = \\$1 AND deleted_at IS NOT NULL AND purged_at IS NULL FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("dino"))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM employee WHERE username = \\$1 AND deleted_at IS NULL\\);").WithArgs("dino").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("UPDATE employee SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = \\$1;").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repository.Restore(trash.KindEmployees, "1")
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore_FailedUsernameTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT username FROM users WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"username"}).AddRow("budi"))
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM users WHERE username = \\$1 AND deleted_at IS NULL\\);").WithArgs("budi").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	err = repository.Restore(trash.KindUsers, "1")
+	assert.Equal(t, "4", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRestore_FailedNotDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT plat FROM motor_vehicle WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs(vehicleID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err = repository.Restore(trash.KindMotorVehicles, vehicleID)
+	assert.Equal(t, sql.ErrNoRows, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge_Deleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT deleted_at <= \\$2::date FROM employee WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs("1", "2025-01-01").WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(true))
+	mock.ExpectExec("SAVEPOINT purge;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM shift_exception WHERE employee_id = \\$1;").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM shift_template WHERE employee_id = \\$1;").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec("DELETE FROM employee WHERE id = \\$1;").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	outcome, err := repository.Purge(trash.KindEmployees, "1", "2025-01-01")
+	assert.Nil(t, err)
+	assert.Equal(t, trash.OutcomeDeleted, outcome)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge_DeletedVehicleKeepsHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT deleted_at <= \\$2::date FROM motor_vehicle WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs(vehicleID, "2025-01-01").WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(true))
+	mock.ExpectExec("SAVEPOINT purge;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE motor_vehicle_event SET motor_vehicle_id = NULL, (.+) WHERE motor_vehicle_id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM vehicle_document WHERE motor_vehicle_id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM maintenance_schedule WHERE motor_vehicle_id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM motor_vehicle WHERE id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	outcome, err := repository.Purge(trash.KindMotorVehicles, vehicleID, "2025-01-01")
+	assert.Nil(t, err)
+	assert.Equal(t, trash.OutcomeDeleted, outcome)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge_AnonymizedWhenReferenced(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT deleted_at <= \\$2::date FROM motor_vehicle WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs(vehicleID, "2025-01-01").WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(true))
+	mock.ExpectExec("SAVEPOINT purge;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE motor_vehicle_event SET motor_vehicle_id = NULL, (.+) WHERE motor_vehicle_id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM vehicle_document WHERE motor_vehicle_id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM maintenance_schedule WHERE motor_vehicle_id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM motor_vehicle WHERE id = \\$1;").WithArgs(vehicleID).WillReturnError(errors.New(`pq: update or delete on table "motor_vehicle" violates foreign key constraint "transaction_motor_vehicle_id_fkey" on table "transaction"`))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT purge;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE motor_vehicle SET plat = 'PURGED', purged_at = CURRENT_TIMESTAMP, (.+) WHERE id = \\$1;").WithArgs(vehicleID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	outcome, err := repository.Purge(trash.KindMotorVehicles, vehicleID, "2025-01-01")
+	assert.Nil(t, err)
+	assert.Equal(t, trash.OutcomeAnonymized, outcome)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge_FailedRetentionNotOver(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewTrashRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT deleted_at <= \\$2::date FROM users WHERE id = \\$1 (.+) FOR UPDATE;").WithArgs("1", "2025-01-01").WillReturnRows(sqlmock.NewRows([]string{"expired"}).AddRow(false))
+	mock.ExpectRollback()
+
+	_, err = repository.Purge(trash.KindUsers, "1", "2025-01-01")
+	assert.Equal(t, "3", err.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
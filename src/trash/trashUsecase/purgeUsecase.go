@@ -0,0 +1,44 @@
+package trashUsecase
+
+import (
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/src/trash"
+)
+
+type purgeUsecase struct {
+	trashRepository trash.TrashRepository
+	clock           clock.Clock
+	retentionDays   int
+}
+
+func NewPurgeUsecase(trashRepository trash.TrashRepository, clk clock.Clock, retentionDays int) trash.PurgeUsecase {
+	return &purgeUsecase{trashRepository, clk, retentionDays}
+}
+
+// ProcessPurge purges every record of every kind deleted at least the retention days ago, a
+// record restored or purged by an admin in the meantime is skipped.
+func (p *purgeUsecase) ProcessPurge() ([]trashDto.PurgeResult, error) {
+	before := deletedBefore(p.clock.Now(), p.retentionDays)
+
+	var purged []trashDto.PurgeResult
+	for _, kind := range trash.Kinds {
+		records, err := p.trashRepository.GetAll(kind, before)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, record := range records {
+			outcome, err := p.trashRepository.Purge(kind, record.ID, before)
+			if err != nil {
+				if notFound(err).Error() == "1" {
+					continue
+				}
+				return purged, err
+			}
+			purged = append(purged, trashDto.PurgeResult{Kind: kind, ID: record.ID, Outcome: outcome})
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,85 @@
+package trashUsecase
+
+import (
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/src/trash"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+type trashUsecase struct {
+	trashRepository trash.TrashRepository
+	clock           clock.Clock
+	retentionDays   int
+}
+
+func NewTrashUsecase(trashRepository trash.TrashRepository, clk clock.Clock, retentionDays int) trash.TrashUsecase {
+	return &trashUsecase{trashRepository, clk, retentionDays}
+}
+
+func (t *trashUsecase) GetAllDeleted(kind string) ([]trashDto.DeletedRecord, error) {
+	if !slices.Contains(trash.Kinds, kind) {
+		return nil, errors.New("2")
+	}
+
+	records, err := t.trashRepository.GetAll(kind, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		records[i].PurgeableFrom = purgeableFrom(records[i].DeletedAt, t.retentionDays)
+	}
+	return records, nil
+}
+
+func (t *trashUsecase) RestoreDeleted(kind string, id string) error {
+	if !slices.Contains(trash.Kinds, kind) {
+		return errors.New("2")
+	}
+
+	if err := t.trashRepository.Restore(kind, id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted fails with "3" while the record was deleted less than the retention days ago.
+func (t *trashUsecase) PurgeDeleted(kind string, id string) (trashDto.PurgeResult, error) {
+	if !slices.Contains(trash.Kinds, kind) {
+		return trashDto.PurgeResult{}, errors.New("2")
+	}
+
+	outcome, err := t.trashRepository.Purge(kind, id, deletedBefore(t.clock.Now(), t.retentionDays))
+	if err != nil {
+		return trashDto.PurgeResult{}, notFound(err)
+	}
+
+	return trashDto.PurgeResult{Kind: kind, ID: id, Outcome: outcome}, nil
+}
+
+// deletedBefore is the last day a record may have been deleted on to be purged now.
+func deletedBefore(now time.Time, retentionDays int) string {
+	return now.AddDate(0, 0, -retentionDays).Format("2006-01-02")
+}
+
+func purgeableFrom(deletedAt string, retentionDays int) string {
+	day, err := time.Parse("2006-01-02", deletedAt)
+	if err != nil {
+		return ""
+	}
+	return day.AddDate(0, 0, retentionDays).Format("2006-01-02")
+}
+
+// notFound maps a missing, already purged or malformed id to "1".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+		return errors.New("1")
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package trashUsecase
+
+import (
+	"bike-rent-express/model/dto/trashDto"
+	"bike-rent-express/src/trash"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var now = time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC)
+
+var deletedVehicle = trashDto.DeletedRecord{
+	Kind:      trash.KindMotorVehicles,
+	ID:        "11111111-1111-1111-1111-111111111111",
+	Name:      "Vario",
+	Label:     "BA 1234 I",
+	DeletedAt: "2024-12-20",
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f fakeClock) Now() time.Time {
+	return f.now
+}
+
+type mockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *mockTrashRepository) GetAll(kind string, deletedBefore string) ([]trashDto.DeletedRecord, error) {
+	args := m.Called(kind, deletedBefore)
+	return args.Get(0).([]trashDto.DeletedRecord), args.Error(1)
+}
+
+func (m *mockTrashRepository) Restore(kind string, id string) error {
+	args := m.Called(kind, id)
+	return args.Error(0)
+}
+
+func (m *mockTrashRepository) Purge(kind string, id string, deletedBefore string) (string, error) {
+	args := m.Called(kind, id, deletedBefore)
+	return args.String(0), args.Error(1)
+}
+
+type TrashUsecaseTestSuite struct {
+	suite.Suite
+	mockTrashRepository *mockTrashRepository
+	trashUC             trash.TrashUsecase
+	purgeUC             trash.PurgeUsecase
+}
+
+func (suite *TrashUsecaseTestSuite) SetupTest() {
+	suite.mockTrashRepository = new(mockTrashRepository)
+	suite.trashUC = NewTrashUsecase(suite.mockTrashRepository, fakeClock{now}, 90)
+	suite.purgeUC = NewPurgeUsecase(suite.mockTrashRepository, fakeClock{now}, 90)
+}
+
+func (suite *TrashUsecaseTestSuite) TestGetAllDeleted_Success() {
+	suite.mockTrashRepository.On("GetAll", trash.KindMotorVehicles, "").Return([]trashDto.DeletedRecord{deletedVehicle}, nil)
+
+	expect := deletedVehicle
+	expect.PurgeableFrom = "2025-03-20"
+
+	records, err := suite.trashUC.GetAllDeleted(trash.KindMotorVehicles)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []trashDto.DeletedRecord{expect}, records)
+}
+
+func (suite *TrashUsecaseTestSuite) TestGetAllDeleted_FailedUnknownKind() {
+	_, err := suite.trashUC.GetAllDeleted("branches")
+	assert.Equal(suite.T(), "2", err.Error())
+	suite.mockTrashRepository.AssertNotCalled(suite.T(), "GetAll", mock.Anything, mock.Anything)
+}
+
+func (suite *TrashUsecaseTestSuite) TestRestoreDeleted_NotFound() {
+	suite.mockTrashRepository.On("Restore", trash.KindUsers, "x").Return(errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	err := suite.trashUC.RestoreDeleted(trash.KindUsers, "x")
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *TrashUsecaseTestSuite) TestPurgeDeleted_Success() {
+	suite.mockTrashRepository.On("Purge", trash.KindMotorVehicles, deletedVehicle.ID, "2025-01-01").Return(trash.OutcomeAnonymized, nil)
+
+	result, err := suite.trashUC.PurgeDeleted(trash.KindMotorVehicles, deletedVehicle.ID)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), trashDto.PurgeResult{Kind: trash.KindMotorVehicles, ID: deletedVehicle.ID, Outcome: trash.OutcomeAnonymized}, result)
+}
+
+func (suite *TrashUsecaseTestSuite) TestPurgeDeleted_FailedRetentionNotOver() {
+	suite.mockTrashRepository.On("Purge", trash.KindEmployees, "1", "2025-01-01").Return("", errors.New("3"))
+
+	_, err := suite.trashUC.PurgeDeleted(trash.KindEmployees, "1")
+	assert.Equal(suite.T(), "3", err.Error())
+}
+
+func (suite *TrashUsecaseTestSuite) TestProcessPurge_Success() {
+	suite.mockTrashRepository.On("GetAll", trash.KindMotorVehicles, "2025-01-01").Return([]trashDto.DeletedRecord{deletedVehicle}, nil)
+	suite.mockTrashRepository.On("GetAll", trash.KindUsers, "2025-01-01").Return([]trashDto.DeletedRecord{{Kind: trash.KindUsers, ID: "2"}}, nil)
+	suite.mockTrashRepository.On("GetAll", trash.KindEmployees, "2025-01-01").Return([]trashDto.DeletedRecord{}, nil)
+	suite.mockTrashRepository.On("Purge", trash.KindMotorVehicles, deletedVehicle.ID, "2025-01-01").Return(trash.OutcomeDeleted, nil)
+	suite.mockTrashRepository.On("Purge", trash.KindUsers, "2", "2025-01-01").Return("", sql.ErrNoRows)
+
+	purged, err := suite.purgeUC.ProcessPurge()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []trashDto.PurgeResult{{Kind: trash.KindMotorVehicles, ID: deletedVehicle.ID, Outcome: trash.OutcomeDeleted}}, purged)
+}
+
+func (suite *TrashUsecaseTestSuite) TestProcessPurge_FailedGetAll() {
+	suite.mockTrashRepository.On("GetAll", trash.KindMotorVehicles, "2025-01-01").Return([]trashDto.DeletedRecord{}, errors.New("error"))
+
+	_, err := suite.purgeUC.ProcessPurge()
+	assert.Error(suite.T(), err)
+	suite.mockTrashRepository.AssertNotCalled(suite.T(), "Purge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrashUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashUsecaseTestSuite))
+}
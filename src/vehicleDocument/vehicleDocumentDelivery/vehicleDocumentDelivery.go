@@ -0,0 +1,133 @@
+package vehicleDocumentDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/vehicleDocument"
+
+	"github.com/gin-gonic/gin"
+)
+
+type vehicleDocumentDelivery struct {
+	vehicleDocumentUC vehicleDocument.VehicleDocumentUsecase
+}
+
+func NewVehicleDocumentDelivery(v1Group *gin.RouterGroup, vehicleDocumentUC vehicleDocument.VehicleDocumentUsecase) {
+	handler := vehicleDocumentDelivery{vehicleDocumentUC}
+
+	vehicleDocumentGroup := v1Group.Group("/vehicle-documents")
+	{
+		vehicleDocumentGroup.GET("", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.GetAllVehicleDocument)
+		vehicleDocumentGroup.GET("/:id", middleware.JWTAuth("ADMIN", "BRANCH_MANAGER", "EMPLOYEE"), handler.GetVehicleDocumentById)
+		vehicleDocumentGroup.POST("", middleware.JWTAuth("ADMIN"), handler.CreateVehicleDocument)
+		vehicleDocumentGroup.PUT("/:id", middleware.JWTAuth("ADMIN"), handler.UpdateVehicleDocument)
+		vehicleDocumentGroup.DELETE("/:id", middleware.JWTAuth("ADMIN"), handler.DeleteVehicleDocument)
+	}
+}
+
+func (v *vehicleDocumentDelivery) GetAllVehicleDocument(ctx *gin.Context) {
+	spec, errs := queryspec.Parse(ctx.Request.URL.Query(), vehicleDocument.Query)
+	if errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "01", "01")
+		return
+	}
+
+	vehicleDocuments, page, err := v.vehicleDocumentUC.GetAllVehicleDocument(spec)
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	if len(vehicleDocuments) == 0 {
+		json.NewResponseSuccessPaged(ctx, []vehicleDocumentDto.VehicleDocument{}, page, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccessPaged(ctx, vehicleDocuments, page, "Success get all vehicle document", "01", "02")
+}
+
+func (v *vehicleDocumentDelivery) GetVehicleDocumentById(ctx *gin.Context) {
+	foundVehicleDocument, err := v.vehicleDocumentUC.GetVehicleDocumentById(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "02", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, foundVehicleDocument, "Success get vehicle document by id", "02", "02")
+}
+
+func (v *vehicleDocumentDelivery) CreateVehicleDocument(ctx *gin.Context) {
+	var request vehicleDocumentDto.VehicleDocumentRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "03", "01")
+		return
+	}
+
+	newVehicleDocument, err := v.vehicleDocumentUC.CreateVehicleDocument(request)
+	if err != nil {
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "motor vehicle not found", "03", "02")
+			return
+		}
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(ctx, nil, "the document must expire after it was issued", "03", "03")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseCreated(ctx, newVehicleDocument, "Vehicle document created", "03", "01")
+}
+
+func (v *vehicleDocumentDelivery) UpdateVehicleDocument(ctx *gin.Context) {
+	var request vehicleDocumentDto.VehicleDocumentRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "04", "01")
+		return
+	}
+
+	updatedVehicleDocument, err := v.vehicleDocumentUC.UpdateVehicleDocument(ctx.Param("id"), request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "04", "01")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "motor vehicle not found", "04", "02")
+			return
+		}
+		if err.Error() == "4" {
+			json.NewResponseBadRequest(ctx, nil, "the document must expire after it was issued", "04", "03")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "04", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, updatedVehicleDocument, "Vehicle document updated", "04", "02")
+}
+
+func (v *vehicleDocumentDelivery) DeleteVehicleDocument(ctx *gin.Context) {
+	if err := v.vehicleDocumentUC.DeleteVehicleDocument(ctx.Param("id")); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "05", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "05", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Vehicle document deleted", "05", "02")
+}
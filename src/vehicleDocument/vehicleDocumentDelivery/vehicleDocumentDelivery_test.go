@@ -0,0 +1,166 @@
+package vehicleDocumentDelivery
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var documentRequest = vehicleDocumentDto.VehicleDocumentRequest{
+	MotorVehicleID: "11111111-1111-1111-1111-111111111111",
+	Type:           "INSURANCE",
+	Number:         "POL-0001",
+	ExpiresAt:      "2025-01-31",
+}
+
+var expectDocument = vehicleDocumentDto.VehicleDocument{
+	ID:             "1",
+	MotorVehicleID: documentRequest.MotorVehicleID,
+	Plat:           "B 1234 XYZ",
+	Type:           "INSURANCE",
+	Number:         "POL-0001",
+	ExpiresAt:      "2025-01-31",
+	DaysLeft:       12,
+	InForce:        true,
+	CreatedAt:      "0000",
+	UpdatedAt:      "0000",
+}
+
+type mockVehicleDocumentUC struct {
+	mock.Mock
+}
+
+func (m *mockVehicleDocumentUC) CreateVehicleDocument(request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(request)
+	return args.Get(0).(vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+func (m *mockVehicleDocumentUC) GetAllVehicleDocument(spec queryspec.Spec) ([]vehicleDocumentDto.VehicleDocument, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]vehicleDocumentDto.VehicleDocument), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockVehicleDocumentUC) GetVehicleDocumentById(id string) (vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(id)
+	return args.Get(0).(vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+func (m *mockVehicleDocumentUC) UpdateVehicleDocument(id string, request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(id, request)
+	return args.Get(0).(vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+func (m *mockVehicleDocumentUC) DeleteVehicleDocument(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type VehicleDocumentDeliveryTestSuite struct {
+	suite.Suite
+	mockVehicleDocumentUC *mockVehicleDocumentUC
+	router                *gin.Engine
+	adminToken            string
+	employeeToken         string
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) SetupTest() {
+	suite.mockVehicleDocumentUC = new(mockVehicleDocumentUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewVehicleDocumentDelivery(v1, suite.mockVehicleDocumentUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("employee", "EMPLOYEE")
+	suite.Require().Nil(err)
+	suite.employeeToken = "Bearer " + token
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) TestGetAllVehicleDocument_Success() {
+	expectResponse := `{"responseCode":"2000102","responseMessage":"Success get all vehicle document","data":[{"id":"1","motor_vehicle_id":"11111111-1111-1111-1111-111111111111","plat":"B 1234 XYZ","type":"INSURANCE","number":"POL-0001","issued_at":null,"expires_at":"2025-01-31","notes":"","days_left":12,"in_force":true,"created_at":"0000","updated_at":"0000"}],"paging":{"limit":20,"has_more":false}}`
+	suite.mockVehicleDocumentUC.On("GetAllVehicleDocument", mock.Anything).Return([]vehicleDocumentDto.VehicleDocument{expectDocument}, queryspec.Page{Limit: 20}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/vehicle-documents?in_force_only=true&expires_before=2025-02-01", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) TestGetAllVehicleDocument_FailedQuery() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/vehicle-documents?type=PASSPORT", nil)
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	suite.mockVehicleDocumentUC.AssertNotCalled(suite.T(), "GetAllVehicleDocument", mock.Anything)
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) TestCreateVehicleDocument_Success() {
+	suite.mockVehicleDocumentUC.On("CreateVehicleDocument", documentRequest).Return(expectDocument, nil)
+
+	body := []byte(`{"motor_vehicle_id":"11111111-1111-1111-1111-111111111111","type":"INSURANCE","number":"POL-0001","expires_at":"2025-01-31"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/vehicle-documents", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2010301","responseMessage":"Vehicle document created"`)
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) TestCreateVehicleDocument_FailedNotAdmin() {
+	body := []byte(`{"motor_vehicle_id":"11111111-1111-1111-1111-111111111111","type":"INSURANCE","number":"POL-0001","expires_at":"2025-01-31"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/vehicle-documents", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.employeeToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) TestUpdateVehicleDocument_FailedExpiresBeforeIssued() {
+	expectResponse := `{"responseCode":"4000403","responseMessage":"the document must expire after it was issued"}`
+	suite.mockVehicleDocumentUC.On("UpdateVehicleDocument", "1", documentRequest).Return(vehicleDocumentDto.VehicleDocument{}, errors.New("4"))
+
+	body := []byte(`{"motor_vehicle_id":"11111111-1111-1111-1111-111111111111","type":"INSURANCE","number":"POL-0001","expires_at":"2025-01-31"}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/vehicle-documents/1", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *VehicleDocumentDeliveryTestSuite) TestDeleteVehicleDocument_NotFound() {
+	expectResponse := `{"responseCode":"2000501","responseMessage":"Data not found"}`
+	suite.mockVehicleDocumentUC.On("DeleteVehicleDocument", "1").Return(errors.New("1"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/vehicle-documents/1", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestVehicleDocumentDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(VehicleDocumentDeliveryTestSuite))
+}
@@ -0,0 +1,33 @@
+package vehicleDocument
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/queryspec"
+)
+
+// ExpiryJobName is the scheduler job listing expiring documents, at most one replica runs it at
+// a time
+const ExpiryJobName = "vehicle-document-expiry"
+
+type (
+	VehicleDocumentRepository interface {
+		Add(vehicleDocument vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error)
+		GetAll(spec queryspec.Spec) ([]vehicleDocumentDto.VehicleDocument, queryspec.Page, error)
+		GetById(id string) (vehicleDocumentDto.VehicleDocument, error)
+		Update(id string, vehicleDocument vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error)
+		Delete(id string) error
+		GetExpiring(until string) ([]vehicleDocumentDto.VehicleDocument, error)
+	}
+
+	VehicleDocumentUsecase interface {
+		CreateVehicleDocument(vehicleDocument vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error)
+		GetAllVehicleDocument(spec queryspec.Spec) ([]vehicleDocumentDto.VehicleDocument, queryspec.Page, error)
+		GetVehicleDocumentById(id string) (vehicleDocumentDto.VehicleDocument, error)
+		UpdateVehicleDocument(id string, vehicleDocument vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error)
+		DeleteVehicleDocument(id string) error
+	}
+
+	ExpiryUsecase interface {
+		ProcessExpiring() ([]vehicleDocumentDto.VehicleDocument, error)
+	}
+)
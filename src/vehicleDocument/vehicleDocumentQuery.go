@@ -0,0 +1,20 @@
+package vehicleDocument
+
+import "bike-rent-express/pkg/queryspec"
+
+// Query is what GET /vehicle-documents takes, in_force_only leaves out the documents that
+// were replaced by a renewal.
+var Query = queryspec.Schema{
+	Filters: []queryspec.Filter{
+		{Params: []string{"motor_vehicle_id"}, Condition: "motor_vehicle_id = ?::uuid", Validate: "uuid"},
+		{Params: []string{"type"}, Condition: "type = ?", Validate: "oneof=REGISTRATION TAX INSURANCE"},
+		{Params: []string{"expires_before"}, Condition: "expires_at <= ?::date", Validate: "format-date"},
+		{Params: []string{"in_force_only"}, Condition: "in_force = ?::boolean", Validate: "boolean"},
+	},
+	Sorts: map[string]string{
+		"expires_at": "expires_at",
+		"created_at": "created_at",
+	},
+	DefaultSort: "expires_at",
+	IDColumn:    "id",
+}
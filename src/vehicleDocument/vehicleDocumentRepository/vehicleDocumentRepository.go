@@ -0,0 +1,143 @@
+package vehicleDocumentRepository
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/scheduler"
+	"bike-rent-express/src/vehicleDocument"
+	"database/sql"
+)
+
+// documents are the documents of the vehicles that are not deleted, the one of each type that
+// expires last is the one in force, the older ones are kept as history
+const documents = `(SELECT d.id, d.motor_vehicle_id, mv.plat, d.type, d.number, d.issued_at, d.expires_at, d.notes, d.created_at, d.updated_at,
+	d.expires_at - CURRENT_DATE AS days_left,
+	d.expires_at = MAX(d.expires_at) OVER (PARTITION BY d.motor_vehicle_id, d.type) AS in_force
+	FROM vehicle_document d JOIN motor_vehicle mv ON mv.id = d.motor_vehicle_id
+	WHERE d.deleted_at IS NULL AND mv.deleted_at IS NULL) vehicle_document`
+
+const columns = "id, motor_vehicle_id, plat, type, number, TO_CHAR(issued_at, 'YYYY-MM-DD'), TO_CHAR(expires_at, 'YYYY-MM-DD'), notes, days_left, in_force, created_at, updated_at"
+
+type vehicleDocumentRepository struct {
+	db *sql.DB
+}
+
+func NewVehicleDocumentRepository(db *sql.DB) vehicleDocument.VehicleDocumentRepository {
+	return &vehicleDocumentRepository{db}
+}
+
+func (v *vehicleDocumentRepository) Add(request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	var id string
+	query := "INSERT INTO vehicle_document(motor_vehicle_id, type, number, issued_at, expires_at, notes) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;"
+	if err := v.db.QueryRow(query, request.MotorVehicleID, request.Type, request.Number, request.IssuedAt, request.ExpiresAt, request.Notes).Scan(&id); err != nil {
+		return vehicleDocumentDto.VehicleDocument{}, err
+	}
+
+	return v.GetById(id)
+}
+
+func (v *vehicleDocumentRepository) GetAll(spec queryspec.Spec) ([]vehicleDocumentDto.VehicleDocument, queryspec.Page, error) {
+	where, args := spec.Where(nil)
+	query := "SELECT " + columns + ", " + spec.SortColumn() + " FROM " + documents + " WHERE " + where + " ORDER BY " + spec.OrderBy() + " LIMIT " + spec.Limit() + ";"
+	rows, err := v.db.Query(query, args...)
+	if err != nil {
+		return nil, queryspec.Page{}, err
+	}
+	defer rows.Close()
+
+	var vehicleDocuments []vehicleDocumentDto.VehicleDocument
+	var keys []queryspec.Key
+	for rows.Next() {
+		var key queryspec.Key
+		vehicleDocument, err := scan(rows, &key.Value)
+		if err != nil {
+			return nil, queryspec.Page{}, err
+		}
+		key.ID = vehicleDocument.ID
+		vehicleDocuments = append(vehicleDocuments, vehicleDocument)
+		keys = append(keys, key)
+	}
+
+	n, page := spec.Paginate(keys)
+	if n < len(vehicleDocuments) {
+		vehicleDocuments = vehicleDocuments[:n]
+	}
+	return vehicleDocuments, page, nil
+}
+
+func (v *vehicleDocumentRepository) GetById(id string) (vehicleDocumentDto.VehicleDocument, error) {
+	query := "SELECT " + columns + " FROM " + documents + " WHERE id = $1;"
+	return scan(v.db.QueryRow(query, id))
+}
+
+func (v *vehicleDocumentRepository) Update(id string, request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	query := "UPDATE vehicle_document SET motor_vehicle_id = $1, type = $2, number = $3, issued_at = $4, expires_at = $5, notes = $6, updated_at = CURRENT_TIMESTAMP WHERE id = $7 AND deleted_at IS NULL RETURNING id;"
+	if err := v.db.QueryRow(query, request.MotorVehicleID, request.Type, request.Number, request.IssuedAt, request.ExpiresAt, request.Notes, id).Scan(&id); err != nil {
+		return vehicleDocumentDto.VehicleDocument{}, err
+	}
+
+	return v.GetById(id)
+}
+
+func (v *vehicleDocumentRepository) Delete(id string) error {
+	query := "UPDATE vehicle_document SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;"
+	result, err := v.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetExpiring returns the documents in force that expire on or before until, the ones that
+// already expired included, soonest first. It returns none while another replica lists them.
+func (v *vehicleDocumentRepository) GetExpiring(until string) ([]vehicleDocumentDto.VehicleDocument, error) {
+	tx, err := v.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	locked, err := scheduler.TryLock(tx, vehicleDocument.ExpiryJobName)
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	query := "SELECT " + columns + " FROM " + documents + " WHERE in_force AND expires_at <= $1::date ORDER BY expires_at, plat;"
+	rows, err := tx.Query(query, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vehicleDocuments []vehicleDocumentDto.VehicleDocument
+	for rows.Next() {
+		vehicleDocument, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		vehicleDocuments = append(vehicleDocuments, vehicleDocument)
+	}
+
+	return vehicleDocuments, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, extra ...interface{}) (vehicleDocumentDto.VehicleDocument, error) {
+	var vehicleDocument vehicleDocumentDto.VehicleDocument
+	dest := append([]interface{}{&vehicleDocument.ID, &vehicleDocument.MotorVehicleID, &vehicleDocument.Plat, &vehicleDocument.Type, &vehicleDocument.Number, &vehicleDocument.IssuedAt, &vehicleDocument.ExpiresAt, &vehicleDocument.Notes, &vehicleDocument.DaysLeft, &vehicleDocument.InForce, &vehicleDocument.CreatedAt, &vehicleDocument.UpdatedAt}, extra...)
+	err := row.Scan(dest...)
+	return vehicleDocument, err
+}
@@ -0,0 +1,147 @@
+package vehicleDocumentRepository
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleDocument"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var documentRequest = vehicleDocumentDto.VehicleDocumentRequest{
+	MotorVehicleID: "11111111-1111-1111-1111-111111111111",
+	Type:           "INSURANCE",
+	Number:         "POL-0001",
+	ExpiresAt:      "2025-01-31",
+}
+
+var expectDocument = vehicleDocumentDto.VehicleDocument{
+	ID:             "1",
+	MotorVehicleID: documentRequest.MotorVehicleID,
+	Plat:           "B 1234 XYZ",
+	Type:           "INSURANCE",
+	Number:         "POL-0001",
+	ExpiresAt:      "2025-01-31",
+	DaysLeft:       12,
+	InForce:        true,
+	CreatedAt:      "0000",
+	UpdatedAt:      "0000",
+}
+
+var documentColumns = []string{"id", "motor_vehicle_id", "plat", "type", "number", "issued_at", "expires_at", "notes", "days_left", "in_force", "created_at", "updated_at"}
+
+func documentRow() []driver.Value {
+	return []driver.Value{expectDocument.ID, expectDocument.MotorVehicleID, expectDocument.Plat, expectDocument.Type, expectDocument.Number, nil, expectDocument.ExpiresAt, expectDocument.Notes, expectDocument.DaysLeft, expectDocument.InForce, expectDocument.CreatedAt, expectDocument.UpdatedAt}
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleDocumentRepository(db)
+
+	mock.ExpectQuery("INSERT INTO vehicle_document(.+) RETURNING id;").WithArgs(documentRequest.MotorVehicleID, "INSURANCE", "POL-0001", nil, "2025-01-31", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT (.+) FROM (.+) vehicle_document WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(documentColumns).AddRow(documentRow()...))
+
+	document, err := repository.Add(documentRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectDocument, document)
+}
+
+func TestGetAll_InForceOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleDocumentRepository(db)
+	spec, errs := queryspec.Parse(url.Values{"in_force_only": {"true"}, "expires_before": {"2025-02-01"}}, vehicleDocument.Query)
+	assert.Nil(t, errs)
+
+	query := "SELECT (.+) FROM (.+) vehicle_document WHERE expires_at <= \\$1::date AND in_force = \\$2::boolean ORDER BY expires_at ASC, id::text ASC LIMIT 21;"
+	mock.ExpectQuery(query).WithArgs("2025-02-01", "true").WillReturnRows(sqlmock.NewRows(append(documentColumns, "sort_key")).AddRow(append(documentRow(), "2025-01-31")...))
+
+	documents, page, err := repository.GetAll(spec)
+	assert.Nil(t, err)
+	assert.Equal(t, []vehicleDocumentDto.VehicleDocument{expectDocument}, documents)
+	assert.Equal(t, queryspec.Page{Limit: 20}, page)
+}
+
+func TestUpdate_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleDocumentRepository(db)
+
+	mock.ExpectQuery("UPDATE vehicle_document SET (.+) WHERE id = \\$7 AND deleted_at IS NULL RETURNING id;").WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.Update("1", documentRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestDelete_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleDocumentRepository(db)
+
+	mock.ExpectExec("UPDATE vehicle_document SET deleted_at = CURRENT_TIMESTAMP WHERE id = \\$1 .+").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repository.Delete("1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestGetExpiring_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleDocumentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\);").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	query := "SELECT (.+) FROM (.+) vehicle_document WHERE in_force AND expires_at <= \\$1::date ORDER BY expires_at, plat;"
+	mock.ExpectQuery(query).WithArgs("2025-02-01").WillReturnRows(sqlmock.NewRows(documentColumns).AddRow(documentRow()...))
+	mock.ExpectRollback()
+
+	documents, err := repository.GetExpiring("2025-02-01")
+	assert.Nil(t, err)
+	assert.Equal(t, []vehicleDocumentDto.VehicleDocument{expectDocument}, documents)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExpiring_LockedByAnotherReplica(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleDocumentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_try_advisory_xact_lock\\(\\$1\\);").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	documents, err := repository.GetExpiring("2025-02-01")
+	assert.Nil(t, err)
+	assert.Empty(t, documents)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,38 @@
+package vehicleDocumentUsecase
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/clock"
+	"bike-rent-express/pkg/event"
+	"bike-rent-express/src/vehicleDocument"
+)
+
+type expiryUsecase struct {
+	vehicleDocumentRepository vehicleDocument.VehicleDocumentRepository
+	publisher                 event.Publisher
+	clock                     clock.Clock
+	noticeDays                int
+}
+
+func NewExpiryUsecase(vehicleDocumentRepository vehicleDocument.VehicleDocumentRepository, publisher event.Publisher, clk clock.Clock, noticeDays int) vehicleDocument.ExpiryUsecase {
+	return &expiryUsecase{vehicleDocumentRepository, publisher, clk, noticeDays}
+}
+
+// ProcessExpiring publishes the documents in force that expire within the notice days, or
+// already did, as one list for the admins. Nothing is published when there are none.
+func (e *expiryUsecase) ProcessExpiring() ([]vehicleDocumentDto.VehicleDocument, error) {
+	now := e.clock.Now()
+	until := now.AddDate(0, 0, e.noticeDays).Format("2006-01-02")
+
+	expiring, err := e.vehicleDocumentRepository.GetExpiring(until)
+	if err != nil || len(expiring) == 0 {
+		return expiring, err
+	}
+
+	err = e.publisher.Publish(event.Event{
+		Name:       event.VehicleDocumentsExpiring,
+		Payload:    expiring,
+		OccurredAt: now,
+	})
+	return expiring, err
+}
@@ -0,0 +1,77 @@
+package vehicleDocumentUsecase
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleDocument"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type vehicleDocumentUsecase struct {
+	vehicleDocumentRepository vehicleDocument.VehicleDocumentRepository
+}
+
+func NewVehicleDocumentUsecase(vehicleDocumentRepository vehicleDocument.VehicleDocumentRepository) vehicleDocument.VehicleDocumentUsecase {
+	return &vehicleDocumentUsecase{vehicleDocumentRepository}
+}
+
+func (v *vehicleDocumentUsecase) CreateVehicleDocument(request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	newVehicleDocument, err := v.vehicleDocumentRepository.Add(request)
+	if err != nil {
+		return newVehicleDocument, invalid(err)
+	}
+
+	return newVehicleDocument, nil
+}
+
+func (v *vehicleDocumentUsecase) GetAllVehicleDocument(spec queryspec.Spec) ([]vehicleDocumentDto.VehicleDocument, queryspec.Page, error) {
+	return v.vehicleDocumentRepository.GetAll(spec)
+}
+
+func (v *vehicleDocumentUsecase) GetVehicleDocumentById(id string) (vehicleDocumentDto.VehicleDocument, error) {
+	foundVehicleDocument, err := v.vehicleDocumentRepository.GetById(id)
+	if err != nil {
+		return foundVehicleDocument, notFound(err)
+	}
+
+	return foundVehicleDocument, nil
+}
+
+func (v *vehicleDocumentUsecase) UpdateVehicleDocument(id string, request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	updatedVehicleDocument, err := v.vehicleDocumentRepository.Update(id, request)
+	if err != nil {
+		return updatedVehicleDocument, notFound(invalid(err))
+	}
+
+	return updatedVehicleDocument, nil
+}
+
+func (v *vehicleDocumentUsecase) DeleteVehicleDocument(id string) error {
+	if err := v.vehicleDocumentRepository.Delete(id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// invalid maps an unknown motor vehicle to "3" and a document that expires before it was
+// issued to "4".
+func invalid(err error) error {
+	if strings.Contains(err.Error(), "violates foreign key constraint") {
+		return errors.New("3")
+	}
+	if strings.Contains(err.Error(), "violates check constraint") {
+		return errors.New("4")
+	}
+	return err
+}
+
+// notFound maps a missing or malformed vehicle document id to "1".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+		return errors.New("1")
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package vehicleDocumentUsecase
+
+import (
+	"bike-rent-express/model/dto/vehicleDocumentDto"
+	"bike-rent-express/pkg/event"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleDocument"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var now = time.Date(2025, 1, 19, 8, 0, 0, 0, time.UTC)
+
+var documentRequest = vehicleDocumentDto.VehicleDocumentRequest{
+	MotorVehicleID: "11111111-1111-1111-1111-111111111111",
+	Type:           "INSURANCE",
+	Number:         "POL-0001",
+	ExpiresAt:      "2025-01-31",
+}
+
+var expectDocument = vehicleDocumentDto.VehicleDocument{
+	ID:             "1",
+	MotorVehicleID: documentRequest.MotorVehicleID,
+	Plat:           "B 1234 XYZ",
+	Type:           "INSURANCE",
+	Number:         "POL-0001",
+	ExpiresAt:      "2025-01-31",
+	DaysLeft:       12,
+	InForce:        true,
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f fakeClock) Now() time.Time {
+	return f.now
+}
+
+type mockVehicleDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *mockVehicleDocumentRepository) Add(request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(request)
+	return args.Get(0).(vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+func (m *mockVehicleDocumentRepository) GetAll(spec queryspec.Spec) ([]vehicleDocumentDto.VehicleDocument, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]vehicleDocumentDto.VehicleDocument), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockVehicleDocumentRepository) GetById(id string) (vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(id)
+	return args.Get(0).(vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+func (m *mockVehicleDocumentRepository) Update(id string, request vehicleDocumentDto.VehicleDocumentRequest) (vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(id, request)
+	return args.Get(0).(vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+func (m *mockVehicleDocumentRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *mockVehicleDocumentRepository) GetExpiring(until string) ([]vehicleDocumentDto.VehicleDocument, error) {
+	args := m.Called(until)
+	return args.Get(0).([]vehicleDocumentDto.VehicleDocument), args.Error(1)
+}
+
+type mockPublisher struct {
+	mock.Mock
+}
+
+func (m *mockPublisher) Publish(e event.Event) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+type VehicleDocumentUsecaseTestSuite struct {
+	suite.Suite
+	mockVehicleDocumentRepository *mockVehicleDocumentRepository
+	mockPublisher                 *mockPublisher
+	vehicleDocumentUC             vehicleDocument.VehicleDocumentUsecase
+	expiryUC                      vehicleDocument.ExpiryUsecase
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) SetupTest() {
+	suite.mockVehicleDocumentRepository = new(mockVehicleDocumentRepository)
+	suite.mockPublisher = new(mockPublisher)
+	suite.vehicleDocumentUC = NewVehicleDocumentUsecase(suite.mockVehicleDocumentRepository)
+	suite.expiryUC = NewExpiryUsecase(suite.mockVehicleDocumentRepository, suite.mockPublisher, fakeClock{now}, 30)
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestCreateVehicleDocument_Success() {
+	suite.mockVehicleDocumentRepository.On("Add", documentRequest).Return(expectDocument, nil)
+
+	document, err := suite.vehicleDocumentUC.CreateVehicleDocument(documentRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectDocument, document)
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestCreateVehicleDocument_FailedMotorVehicleNotFound() {
+	suite.mockVehicleDocumentRepository.On("Add", documentRequest).Return(vehicleDocumentDto.VehicleDocument{}, errors.New(`pq: insert or update on table "vehicle_document" violates foreign key constraint "vehicle_document_motor_vehicle_id_fkey"`))
+
+	_, err := suite.vehicleDocumentUC.CreateVehicleDocument(documentRequest)
+	assert.Equal(suite.T(), "3", err.Error())
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestUpdateVehicleDocument_FailedExpiresBeforeIssued() {
+	suite.mockVehicleDocumentRepository.On("Update", "1", documentRequest).Return(vehicleDocumentDto.VehicleDocument{}, errors.New(`pq: new row for relation "vehicle_document" violates check constraint "vehicle_document_check"`))
+
+	_, err := suite.vehicleDocumentUC.UpdateVehicleDocument("1", documentRequest)
+	assert.Equal(suite.T(), "4", err.Error())
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestGetVehicleDocumentById_NotFound() {
+	suite.mockVehicleDocumentRepository.On("GetById", "x").Return(vehicleDocumentDto.VehicleDocument{}, errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	_, err := suite.vehicleDocumentUC.GetVehicleDocumentById("x")
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestDeleteVehicleDocument_NotFound() {
+	suite.mockVehicleDocumentRepository.On("Delete", "1").Return(sql.ErrNoRows)
+
+	err := suite.vehicleDocumentUC.DeleteVehicleDocument("1")
+	assert.Equal(suite.T(), "1", err.Error())
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestProcessExpiring_Success() {
+	expiring := []vehicleDocumentDto.VehicleDocument{expectDocument}
+	expectEvent := event.Event{
+		Name:       event.VehicleDocumentsExpiring,
+		Payload:    expiring,
+		OccurredAt: now,
+	}
+
+	suite.mockVehicleDocumentRepository.On("GetExpiring", "2025-02-18").Return(expiring, nil)
+	suite.mockPublisher.On("Publish", expectEvent).Return(nil)
+
+	actual, err := suite.expiryUC.ProcessExpiring()
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expiring, actual)
+	suite.mockPublisher.AssertNumberOfCalls(suite.T(), "Publish", 1)
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestProcessExpiring_NothingExpiring() {
+	suite.mockVehicleDocumentRepository.On("GetExpiring", "2025-02-18").Return([]vehicleDocumentDto.VehicleDocument{}, nil)
+
+	actual, err := suite.expiryUC.ProcessExpiring()
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), actual)
+	suite.mockPublisher.AssertNotCalled(suite.T(), "Publish", mock.Anything)
+}
+
+func (suite *VehicleDocumentUsecaseTestSuite) TestProcessExpiring_FailedGetExpiring() {
+	suite.mockVehicleDocumentRepository.On("GetExpiring", "2025-02-18").Return([]vehicleDocumentDto.VehicleDocument{}, errors.New("error"))
+
+	_, err := suite.expiryUC.ProcessExpiring()
+	assert.Error(suite.T(), err)
+	suite.mockPublisher.AssertNotCalled(suite.T(), "Publish", mock.Anything)
+}
+
+func TestVehicleDocumentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(VehicleDocumentUsecaseTestSuite))
+}
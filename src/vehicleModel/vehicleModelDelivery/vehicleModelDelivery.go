@@ -0,0 +1,129 @@
+package vehicleModelDelivery
+
+import (
+	"bike-rent-express/model/dto/json"
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/pkg/utils"
+	"bike-rent-express/src/vehicleModel"
+
+	"github.com/gin-gonic/gin"
+)
+
+type vehicleModelDelivery struct {
+	vehicleModelUC vehicleModel.VehicleModelUsecase
+}
+
+func NewVehicleModelDelivery(v1Group *gin.RouterGroup, vehicleModelUC vehicleModel.VehicleModelUsecase) {
+	handler := vehicleModelDelivery{vehicleModelUC}
+
+	vehicleModelGroup := v1Group.Group("/vehicle-models")
+	{
+		vehicleModelGroup.GET("", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER", "USER"), handler.GetAllVehicleModel)
+		vehicleModelGroup.GET("/:id", middleware.JWTAuth("ADMIN", "EMPLOYEE", "BRANCH_MANAGER", "USER"), handler.GetVehicleModelById)
+		vehicleModelGroup.POST("", middleware.JWTAuth("ADMIN"), handler.CreateVehicleModel)
+		vehicleModelGroup.PUT("/:id", middleware.JWTAuth("ADMIN"), handler.UpdateVehicleModel)
+		vehicleModelGroup.DELETE("/:id", middleware.JWTAuth("ADMIN"), handler.DeleteVehicleModel)
+	}
+}
+
+func (v *vehicleModelDelivery) GetAllVehicleModel(ctx *gin.Context) {
+	spec, errs := queryspec.Parse(ctx.Request.URL.Query(), vehicleModel.Query)
+	if errs != nil {
+		json.NewResponseBadRequest(ctx, errs, "Bad Request", "01", "01")
+		return
+	}
+
+	vehicleModels, page, err := v.vehicleModelUC.GetAllVehicleModel(spec)
+	if err != nil {
+		json.NewResponseError(ctx, err.Error(), "01", "01")
+		return
+	}
+
+	if len(vehicleModels) == 0 {
+		json.NewResponseSuccessPaged(ctx, []vehicleModelDto.VehicleModel{}, page, "Data empty", "01", "01")
+		return
+	}
+
+	json.NewResponseSuccessPaged(ctx, vehicleModels, page, "Success get all vehicle model", "01", "02")
+}
+
+func (v *vehicleModelDelivery) GetVehicleModelById(ctx *gin.Context) {
+	foundVehicleModel, err := v.vehicleModelUC.GetVehicleModelById(ctx.Param("id"))
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "02", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "02", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, foundVehicleModel, "Success get vehicle model by id", "02", "02")
+}
+
+func (v *vehicleModelDelivery) CreateVehicleModel(ctx *gin.Context) {
+	var request vehicleModelDto.VehicleModelRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "03", "01")
+		return
+	}
+
+	newVehicleModel, err := v.vehicleModelUC.CreateVehicleModel(request)
+	if err != nil {
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "the brand already has a model of that name", "03", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "03", "01")
+		return
+	}
+
+	json.NewResponseCreated(ctx, newVehicleModel, "Vehicle model created", "03", "01")
+}
+
+func (v *vehicleModelDelivery) UpdateVehicleModel(ctx *gin.Context) {
+	var request vehicleModelDto.VehicleModelRequest
+
+	ctx.ShouldBindJSON(&request)
+	if err := utils.Validated(request); err != nil {
+		json.NewResponseBadRequest(ctx, err, "Bad Request", "04", "01")
+		return
+	}
+
+	updatedVehicleModel, err := v.vehicleModelUC.UpdateVehicleModel(ctx.Param("id"), request)
+	if err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "04", "01")
+			return
+		}
+		if err.Error() == "3" {
+			json.NewResponseBadRequest(ctx, nil, "the brand already has a model of that name", "04", "02")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "04", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, updatedVehicleModel, "Vehicle model updated", "04", "02")
+}
+
+func (v *vehicleModelDelivery) DeleteVehicleModel(ctx *gin.Context) {
+	if err := v.vehicleModelUC.DeleteVehicleModel(ctx.Param("id")); err != nil {
+		if err.Error() == "1" {
+			json.NewResponseSuccess(ctx, nil, "Data not found", "05", "01")
+			return
+		}
+		if err.Error() == "2" {
+			json.NewResponseBadRequest(ctx, nil, "the vehicle model still has motor vehicles", "05", "01")
+			return
+		}
+		json.NewResponseError(ctx, err.Error(), "05", "01")
+		return
+	}
+
+	json.NewResponseSuccess(ctx, nil, "Vehicle model deleted", "05", "02")
+}
@@ -0,0 +1,168 @@
+package vehicleModelDelivery
+
+import (
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/middleware"
+	"bike-rent-express/pkg/queryspec"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var modelRequest = vehicleModelDto.VehicleModelRequest{
+	Brand:        "Honda",
+	Model:        "CT 125",
+	Transmission: "KOPLING",
+	EngineCC:     125,
+	DefaultPrice: 150000,
+}
+
+var expectModel = vehicleModelDto.VehicleModel{
+	ID:             "1",
+	Brand:          "Honda",
+	Model:          "CT 125",
+	Transmission:   "KOPLING",
+	EngineCC:       125,
+	DefaultPrice:   150000,
+	Specs:          map[string]string{},
+	AvailableUnits: 2,
+	CreatedAt:      "0000",
+	UpdatedAt:      "0000",
+}
+
+type mockVehicleModelUC struct {
+	mock.Mock
+}
+
+func (m *mockVehicleModelUC) CreateVehicleModel(request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	args := m.Called(request)
+	return args.Get(0).(vehicleModelDto.VehicleModel), args.Error(1)
+}
+
+func (m *mockVehicleModelUC) GetAllVehicleModel(spec queryspec.Spec) ([]vehicleModelDto.VehicleModel, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]vehicleModelDto.VehicleModel), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockVehicleModelUC) GetVehicleModelById(id string) (vehicleModelDto.VehicleModel, error) {
+	args := m.Called(id)
+	return args.Get(0).(vehicleModelDto.VehicleModel), args.Error(1)
+}
+
+func (m *mockVehicleModelUC) UpdateVehicleModel(id string, request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	args := m.Called(id, request)
+	return args.Get(0).(vehicleModelDto.VehicleModel), args.Error(1)
+}
+
+func (m *mockVehicleModelUC) DeleteVehicleModel(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type VehicleModelDeliveryTestSuite struct {
+	suite.Suite
+	mockVehicleModelUC *mockVehicleModelUC
+	router             *gin.Engine
+	adminToken         string
+	customerToken      string
+}
+
+func (suite *VehicleModelDeliveryTestSuite) SetupTest() {
+	suite.mockVehicleModelUC = new(mockVehicleModelUC)
+	suite.router = gin.Default()
+	v1 := suite.router.Group("/api/v1")
+	NewVehicleModelDelivery(v1, suite.mockVehicleModelUC)
+
+	token, err := middleware.GenerateTokenJwt("admin", "ADMIN")
+	suite.Require().Nil(err)
+	suite.adminToken = "Bearer " + token
+
+	token, err = middleware.GenerateTokenJwt("customer", "USER")
+	suite.Require().Nil(err)
+	suite.customerToken = "Bearer " + token
+}
+
+func (suite *VehicleModelDeliveryTestSuite) TestGetAllVehicleModel_Success() {
+	expectResponse := `{"responseCode":"2000102","responseMessage":"Success get all vehicle model","data":[{"id":"1","brand":"Honda","model":"CT 125","transmission":"KOPLING","engine_cc":125,"default_price":150000,"default_hourly_price":0,"specs":{},"available_units":2,"created_at":"0000","updated_at":"0000"}],"paging":{"limit":20,"has_more":false}}`
+	suite.mockVehicleModelUC.On("GetAllVehicleModel", mock.Anything).Return([]vehicleModelDto.VehicleModel{expectModel}, queryspec.Page{Limit: 20}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/vehicle-models?available_only=true", nil)
+	req.Header.Add("Authorization", suite.customerToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 200, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *VehicleModelDeliveryTestSuite) TestGetAllVehicleModel_FailedQuery() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/vehicle-models?available_only=maybe", nil)
+	req.Header.Add("Authorization", suite.customerToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), `{"responseCode":"4000101","responseMessage":"Bad Request","error_description":[{"field":"available_only","message":"field is not true or false"}]}`, w.Body.String())
+	suite.mockVehicleModelUC.AssertNotCalled(suite.T(), "GetAllVehicleModel", mock.Anything)
+}
+
+func (suite *VehicleModelDeliveryTestSuite) TestCreateVehicleModel_Success() {
+	suite.mockVehicleModelUC.On("CreateVehicleModel", modelRequest).Return(expectModel, nil)
+
+	body := []byte(`{"brand":"Honda","model":"CT 125","transmission":"KOPLING","engine_cc":125,"default_price":150000}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/vehicle-models", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 201, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"responseCode":"2010301","responseMessage":"Vehicle model created"`)
+}
+
+func (suite *VehicleModelDeliveryTestSuite) TestCreateVehicleModel_FailedNotAdmin() {
+	body := []byte(`{"brand":"Honda","model":"CT 125","transmission":"KOPLING","engine_cc":125,"default_price":150000}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/vehicle-models", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.customerToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 403, w.Code)
+}
+
+func (suite *VehicleModelDeliveryTestSuite) TestUpdateVehicleModel_FailedDuplicate() {
+	expectResponse := `{"responseCode":"4000402","responseMessage":"the brand already has a model of that name"}`
+	suite.mockVehicleModelUC.On("UpdateVehicleModel", "1", modelRequest).Return(vehicleModelDto.VehicleModel{}, errors.New("3"))
+
+	body := []byte(`{"brand":"Honda","model":"CT 125","transmission":"KOPLING","engine_cc":125,"default_price":150000}`)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/vehicle-models/1", bytes.NewBuffer(body))
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func (suite *VehicleModelDeliveryTestSuite) TestDeleteVehicleModel_FailedInUse() {
+	expectResponse := `{"responseCode":"4000501","responseMessage":"the vehicle model still has motor vehicles"}`
+	suite.mockVehicleModelUC.On("DeleteVehicleModel", "1").Return(errors.New("2"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/vehicle-models/1", nil)
+	req.Header.Add("Authorization", suite.adminToken)
+
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), 400, w.Code)
+	assert.Equal(suite.T(), expectResponse, w.Body.String())
+}
+
+func TestVehicleModelDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(VehicleModelDeliveryTestSuite))
+}
@@ -0,0 +1,24 @@
+package vehicleModel
+
+import (
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/queryspec"
+)
+
+type (
+	VehicleModelRepository interface {
+		Add(vehicleModel vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error)
+		GetAll(spec queryspec.Spec) ([]vehicleModelDto.VehicleModel, queryspec.Page, error)
+		GetById(id string) (vehicleModelDto.VehicleModel, error)
+		Update(id string, vehicleModel vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error)
+		Delete(id string) error
+	}
+
+	VehicleModelUsecase interface {
+		CreateVehicleModel(vehicleModel vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error)
+		GetAllVehicleModel(spec queryspec.Spec) ([]vehicleModelDto.VehicleModel, queryspec.Page, error)
+		GetVehicleModelById(id string) (vehicleModelDto.VehicleModel, error)
+		UpdateVehicleModel(id string, vehicleModel vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error)
+		DeleteVehicleModel(id string) error
+	}
+)
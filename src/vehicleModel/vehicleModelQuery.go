@@ -0,0 +1,24 @@
+package vehicleModel
+
+import "bike-rent-express/pkg/queryspec"
+
+// Query is what GET /vehicle-models takes, available_only leaves out the models without a
+// unit that can be rented right now.
+var Query = queryspec.Schema{
+	Filters: []queryspec.Filter{
+		{Params: []string{"q"}, Condition: "(brand || ' ' || model) ILIKE '%' || ?::text || '%'"},
+		{Params: []string{"brand"}, Condition: "LOWER(brand) = LOWER(?)"},
+		{Params: []string{"transmission"}, Condition: "LOWER(transmission) = LOWER(?)"},
+		{Params: []string{"price_min"}, Condition: "default_price >= ?", Validate: "number"},
+		{Params: []string{"price_max"}, Condition: "default_price <= ?", Validate: "number"},
+		{Params: []string{"available_only"}, Condition: "(available_units > 0) = ?::boolean", Validate: "boolean"},
+	},
+	Sorts: map[string]string{
+		"brand":         "brand",
+		"engine_cc":     "engine_cc",
+		"default_price": "default_price",
+		"created_at":    "created_at",
+	},
+	DefaultSort: "brand",
+	IDColumn:    "id",
+}
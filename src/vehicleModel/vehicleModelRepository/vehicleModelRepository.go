@@ -0,0 +1,148 @@
+package vehicleModelRepository
+
+import (
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleModel"
+	"database/sql"
+	"encoding/json"
+	"errors"
+)
+
+// models are the vehicle models that are not deleted with the count of their units that can
+// be rented right now, so the filters can use it like any other column
+const models = `(SELECT vm.id, vm.brand, vm.model, vm.transmission, vm.engine_cc, vm.default_price, vm.default_hourly_price, vm.specs, vm.created_at, vm.updated_at,
+	(SELECT COUNT(*) FROM motor_vehicle mv WHERE mv.vehicle_model_id = vm.id AND mv.status = 'AVAILABLE' AND mv.deleted_at IS NULL) AS available_units
+	FROM vehicle_model vm WHERE vm.deleted_at IS NULL) vehicle_model`
+
+const columns = "id, brand, model, transmission, engine_cc, default_price, default_hourly_price, specs::text, available_units, created_at, updated_at"
+
+type vehicleModelRepository struct {
+	db *sql.DB
+}
+
+func NewVehicleModelRepository(db *sql.DB) vehicleModel.VehicleModelRepository {
+	return &vehicleModelRepository{db}
+}
+
+func (v *vehicleModelRepository) Add(request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	specs, err := marshalSpecs(request.Specs)
+	if err != nil {
+		return vehicleModelDto.VehicleModel{}, err
+	}
+
+	var id string
+	query := "INSERT INTO vehicle_model(brand, model, transmission, engine_cc, default_price, default_hourly_price, specs) VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb) RETURNING id;"
+	if err := v.db.QueryRow(query, request.Brand, request.Model, request.Transmission, request.EngineCC, request.DefaultPrice, request.DefaultHourlyPrice, specs).Scan(&id); err != nil {
+		return vehicleModelDto.VehicleModel{}, err
+	}
+
+	return v.GetById(id)
+}
+
+func (v *vehicleModelRepository) GetAll(spec queryspec.Spec) ([]vehicleModelDto.VehicleModel, queryspec.Page, error) {
+	where, args := spec.Where(nil)
+	query := "SELECT " + columns + ", " + spec.SortColumn() + " FROM " + models + " WHERE " + where + " ORDER BY " + spec.OrderBy() + " LIMIT " + spec.Limit() + ";"
+	rows, err := v.db.Query(query, args...)
+	if err != nil {
+		return nil, queryspec.Page{}, err
+	}
+	defer rows.Close()
+
+	var vehicleModels []vehicleModelDto.VehicleModel
+	var keys []queryspec.Key
+	for rows.Next() {
+		var key queryspec.Key
+		vehicleModel, err := scan(rows, &key.Value)
+		if err != nil {
+			return nil, queryspec.Page{}, err
+		}
+		key.ID = vehicleModel.ID
+		vehicleModels = append(vehicleModels, vehicleModel)
+		keys = append(keys, key)
+	}
+
+	n, page := spec.Paginate(keys)
+	if n < len(vehicleModels) {
+		vehicleModels = vehicleModels[:n]
+	}
+	return vehicleModels, page, nil
+}
+
+func (v *vehicleModelRepository) GetById(id string) (vehicleModelDto.VehicleModel, error) {
+	query := "SELECT " + columns + " FROM " + models + " WHERE id = $1;"
+	return scan(v.db.QueryRow(query, id))
+}
+
+// Update changes the model of every unit at once, the units that override the price keep theirs.
+func (v *vehicleModelRepository) Update(id string, request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	specs, err := marshalSpecs(request.Specs)
+	if err != nil {
+		return vehicleModelDto.VehicleModel{}, err
+	}
+
+	query := "UPDATE vehicle_model SET brand = $1, model = $2, transmission = $3, engine_cc = $4, default_price = $5, default_hourly_price = $6, specs = $7::jsonb, updated_at = CURRENT_TIMESTAMP WHERE id = $8 AND deleted_at IS NULL RETURNING id;"
+	if err := v.db.QueryRow(query, request.Brand, request.Model, request.Transmission, request.EngineCC, request.DefaultPrice, request.DefaultHourlyPrice, specs, id).Scan(&id); err != nil {
+		return vehicleModelDto.VehicleModel{}, err
+	}
+
+	return v.GetById(id)
+}
+
+// Delete refuses with "2" while a motor vehicle is still a unit of the model.
+func (v *vehicleModelRepository) Delete(id string) error {
+	var inUse bool
+	query := "SELECT EXISTS(SELECT 1 FROM motor_vehicle WHERE vehicle_model_id = $1 AND deleted_at IS NULL);"
+	if err := v.db.QueryRow(query, id).Scan(&inUse); err != nil {
+		return err
+	}
+
+	if inUse {
+		return errors.New("2")
+	}
+
+	query = "UPDATE vehicle_model SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;"
+	result, err := v.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, extra ...interface{}) (vehicleModelDto.VehicleModel, error) {
+	var vehicleModel vehicleModelDto.VehicleModel
+	var specs string
+	dest := append([]interface{}{&vehicleModel.ID, &vehicleModel.Brand, &vehicleModel.Model, &vehicleModel.Transmission, &vehicleModel.EngineCC, &vehicleModel.DefaultPrice, &vehicleModel.DefaultHourlyPrice, &specs, &vehicleModel.AvailableUnits, &vehicleModel.CreatedAt, &vehicleModel.UpdatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return vehicleModel, err
+	}
+
+	if err := json.Unmarshal([]byte(specs), &vehicleModel.Specs); err != nil {
+		return vehicleModel, err
+	}
+
+	return vehicleModel, nil
+}
+
+func marshalSpecs(specs map[string]string) (string, error) {
+	if specs == nil {
+		specs = map[string]string{}
+	}
+
+	data, err := json.Marshal(specs)
+	return string(data), err
+}
@@ -0,0 +1,125 @@
+package vehicleModelRepository
+
+import (
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleModel"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var modelRequest = vehicleModelDto.VehicleModelRequest{
+	Brand:        "Honda",
+	Model:        "CT 125",
+	Transmission: "KOPLING",
+	EngineCC:     125,
+	DefaultPrice: 150000,
+	Specs:        map[string]string{"fuel": "8.5 L"},
+}
+
+var expectModel = vehicleModelDto.VehicleModel{
+	ID:             "1",
+	Brand:          "Honda",
+	Model:          "CT 125",
+	Transmission:   "KOPLING",
+	EngineCC:       125,
+	DefaultPrice:   150000,
+	Specs:          map[string]string{"fuel": "8.5 L"},
+	AvailableUnits: 2,
+	CreatedAt:      "0000",
+	UpdatedAt:      "0000",
+}
+
+var modelColumns = []string{"id", "brand", "model", "transmission", "engine_cc", "default_price", "default_hourly_price", "specs", "available_units", "created_at", "updated_at"}
+
+func modelRow() []driver.Value {
+	return []driver.Value{expectModel.ID, expectModel.Brand, expectModel.Model, expectModel.Transmission, expectModel.EngineCC, expectModel.DefaultPrice, expectModel.DefaultHourlyPrice, `{"fuel": "8.5 L"}`, expectModel.AvailableUnits, expectModel.CreatedAt, expectModel.UpdatedAt}
+}
+
+func TestAdd_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleModelRepository(db)
+
+	mock.ExpectQuery("INSERT INTO vehicle_model(.+) RETURNING id;").WithArgs("Honda", "CT 125", "KOPLING", 125, 150000, 0, `{"fuel":"8.5 L"}`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT (.+) FROM (.+) vehicle_model WHERE id = \\$1;").WithArgs("1").WillReturnRows(sqlmock.NewRows(modelColumns).AddRow(modelRow()...))
+
+	vehicleModel, err := repository.Add(modelRequest)
+	assert.Nil(t, err)
+	assert.Equal(t, expectModel, vehicleModel)
+}
+
+func TestGetAll_AvailableOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleModelRepository(db)
+	spec, errs := queryspec.Parse(url.Values{"available_only": {"true"}, "sort": {"-default_price"}}, vehicleModel.Query)
+	assert.Nil(t, errs)
+
+	query := "SELECT (.+) FROM (.+) vehicle_model WHERE \\(available_units > 0\\) = \\$1::boolean ORDER BY default_price DESC, id::text DESC LIMIT 21;"
+	mock.ExpectQuery(query).WithArgs("true").WillReturnRows(sqlmock.NewRows(append(modelColumns, "sort_key")).AddRow(append(modelRow(), "150000")...))
+
+	vehicleModels, page, err := repository.GetAll(spec)
+	assert.Nil(t, err)
+	assert.Equal(t, []vehicleModelDto.VehicleModel{expectModel}, vehicleModels)
+	assert.Equal(t, queryspec.Page{Limit: 20}, page)
+}
+
+func TestUpdate_FailedNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleModelRepository(db)
+
+	mock.ExpectQuery("UPDATE vehicle_model SET (.+) WHERE id = \\$8 AND deleted_at IS NULL RETURNING id;").WillReturnError(sql.ErrNoRows)
+
+	_, err = repository.Update("1", modelRequest)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
+func TestDelete_FailedInUse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleModelRepository(db)
+
+	mock.ExpectQuery("SELECT EXISTS(.+) FROM motor_vehicle WHERE vehicle_model_id = \\$1 .+").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	err = repository.Delete("1")
+	assert.Equal(t, "2", err.Error())
+}
+
+func TestDelete_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal("Error creating mock database: ", err)
+	}
+	defer db.Close()
+
+	repository := NewVehicleModelRepository(db)
+
+	mock.ExpectQuery("SELECT EXISTS(.+) FROM motor_vehicle WHERE vehicle_model_id = \\$1 .+").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("UPDATE vehicle_model SET deleted_at = CURRENT_TIMESTAMP WHERE id = \\$1 .+").WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.Delete("1")
+	assert.Nil(t, err)
+}
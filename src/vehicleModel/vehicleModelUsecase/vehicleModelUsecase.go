@@ -0,0 +1,72 @@
+package vehicleModelUsecase
+
+import (
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleModel"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+type vehicleModelUsecase struct {
+	vehicleModelRepository vehicleModel.VehicleModelRepository
+}
+
+func NewVehicleModelUsecase(vehicleModelRepository vehicleModel.VehicleModelRepository) vehicleModel.VehicleModelUsecase {
+	return &vehicleModelUsecase{vehicleModelRepository}
+}
+
+// CreateVehicleModel returns "3" when the brand already has a model of that name.
+func (v *vehicleModelUsecase) CreateVehicleModel(request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	newVehicleModel, err := v.vehicleModelRepository.Add(request)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return newVehicleModel, errors.New("3")
+		}
+		return newVehicleModel, err
+	}
+
+	return newVehicleModel, nil
+}
+
+func (v *vehicleModelUsecase) GetAllVehicleModel(spec queryspec.Spec) ([]vehicleModelDto.VehicleModel, queryspec.Page, error) {
+	return v.vehicleModelRepository.GetAll(spec)
+}
+
+func (v *vehicleModelUsecase) GetVehicleModelById(id string) (vehicleModelDto.VehicleModel, error) {
+	foundVehicleModel, err := v.vehicleModelRepository.GetById(id)
+	if err != nil {
+		return foundVehicleModel, notFound(err)
+	}
+
+	return foundVehicleModel, nil
+}
+
+func (v *vehicleModelUsecase) UpdateVehicleModel(id string, request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	updatedVehicleModel, err := v.vehicleModelRepository.Update(id, request)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value") {
+			return updatedVehicleModel, errors.New("3")
+		}
+		return updatedVehicleModel, notFound(err)
+	}
+
+	return updatedVehicleModel, nil
+}
+
+func (v *vehicleModelUsecase) DeleteVehicleModel(id string) error {
+	if err := v.vehicleModelRepository.Delete(id); err != nil {
+		return notFound(err)
+	}
+
+	return nil
+}
+
+// notFound maps a missing or malformed vehicle model id to "1".
+func notFound(err error) error {
+	if err == sql.ErrNoRows || strings.Contains(err.Error(), "invalid input syntax for type uuid") {
+		return errors.New("1")
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package vehicleModelUsecase
+
+import (
+	"bike-rent-express/model/dto/vehicleModelDto"
+	"bike-rent-express/pkg/queryspec"
+	"bike-rent-express/src/vehicleModel"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var modelRequest = vehicleModelDto.VehicleModelRequest{
+	Brand:        "Honda",
+	Model:        "CT 125",
+	Transmission: "KOPLING",
+	EngineCC:     125,
+	DefaultPrice: 150000,
+}
+
+var expectModel = vehicleModelDto.VehicleModel{
+	ID:           "1",
+	Brand:        "Honda",
+	Model:        "CT 125",
+	Transmission: "KOPLING",
+	EngineCC:     125,
+	DefaultPrice: 150000,
+}
+
+type mockVehicleModelRepository struct {
+	mock.Mock
+}
+
+func (m *mockVehicleModelRepository) Add(request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	args := m.Called(request)
+	return args.Get(0).(vehicleModelDto.VehicleModel), args.Error(1)
+}
+
+func (m *mockVehicleModelRepository) GetAll(spec queryspec.Spec) ([]vehicleModelDto.VehicleModel, queryspec.Page, error) {
+	args := m.Called(spec)
+	return args.Get(0).([]vehicleModelDto.VehicleModel), args.Get(1).(queryspec.Page), args.Error(2)
+}
+
+func (m *mockVehicleModelRepository) GetById(id string) (vehicleModelDto.VehicleModel, error) {
+	args := m.Called(id)
+	return args.Get(0).(vehicleModelDto.VehicleModel), args.Error(1)
+}
+
+func (m *mockVehicleModelRepository) Update(id string, request vehicleModelDto.VehicleModelRequest) (vehicleModelDto.VehicleModel, error) {
+	args := m.Called(id, request)
+	return args.Get(0).(vehicleModelDto.VehicleModel), args.Error(1)
+}
+
+func (m *mockVehicleModelRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type VehicleModelUsecaseTestSuite struct {
+	suite.Suite
+	mockVehicleModelRepository *mockVehicleModelRepository
+	vehicleModelUC             vehicleModel.VehicleModelUsecase
+}
+
+func (suite *VehicleModelUsecaseTestSuite) SetupTest() {
+	suite.mockVehicleModelRepository = new(mockVehicleModelRepository)
+	suite.vehicleModelUC = NewVehicleModelUsecase(suite.mockVehicleModelRepository)
+}
+
+func (suite *VehicleModelUsecaseTestSuite) TestCreateVehicleModel_Success() {
+	suite.mockVehicleModelRepository.On("Add", modelRequest).Return(expectModel, nil)
+
+	vehicleModel, err := suite.vehicleModelUC.CreateVehicleModel(modelRequest)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), expectModel, vehicleModel)
+}
+
+func (suite *VehicleModelUsecaseTestSuite) TestCreateVehicleModel_FailedDuplicate() {
+	suite.mockVehicleModelRepository.On("Add", modelRequest).Return(vehicleModelDto.VehicleModel{}, errors.New(`pq: duplicate key value violates unique constraint "vehicle_model_brand_model_idx"`))
+
+	_, err := suite.vehicleModelUC.CreateVehicleModel(modelRequest)
+	assert.Equal(suite.T(), errors.New("3"), err)
+}
+
+func (suite *VehicleModelUsecaseTestSuite) TestGetVehicleModelById_FailedInvalidId() {
+	suite.mockVehicleModelRepository.On("GetById", "x").Return(vehicleModelDto.VehicleModel{}, errors.New(`pq: invalid input syntax for type uuid: "x"`))
+
+	_, err := suite.vehicleModelUC.GetVehicleModelById("x")
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *VehicleModelUsecaseTestSuite) TestUpdateVehicleModel_FailedNotFound() {
+	suite.mockVehicleModelRepository.On("Update", "1", modelRequest).Return(vehicleModelDto.VehicleModel{}, sql.ErrNoRows)
+
+	_, err := suite.vehicleModelUC.UpdateVehicleModel("1", modelRequest)
+	assert.Equal(suite.T(), errors.New("1"), err)
+}
+
+func (suite *VehicleModelUsecaseTestSuite) TestDeleteVehicleModel_FailedInUse() {
+	suite.mockVehicleModelRepository.On("Delete", "1").Return(errors.New("2"))
+
+	err := suite.vehicleModelUC.DeleteVehicleModel("1")
+	assert.Equal(suite.T(), errors.New("2"), err)
+}
+
+func TestVehicleModelUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(VehicleModelUsecaseTestSuite))
+}